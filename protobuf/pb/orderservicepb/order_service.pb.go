@@ -22,26 +22,26 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type GetAllInvoicesRequest struct {
+type StreamAllInvoicesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllInvoicesRequest) Reset() {
-	*x = GetAllInvoicesRequest{}
+func (x *StreamAllInvoicesRequest) Reset() {
+	*x = StreamAllInvoicesRequest{}
 	mi := &file_order_service_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllInvoicesRequest) String() string {
+func (x *StreamAllInvoicesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllInvoicesRequest) ProtoMessage() {}
+func (*StreamAllInvoicesRequest) ProtoMessage() {}
 
-func (x *GetAllInvoicesRequest) ProtoReflect() protoreflect.Message {
+func (x *StreamAllInvoicesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_order_service_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -53,32 +53,33 @@ func (x *GetAllInvoicesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllInvoicesRequest.ProtoReflect.Descriptor instead.
-func (*GetAllInvoicesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamAllInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*StreamAllInvoicesRequest) Descriptor() ([]byte, []int) {
 	return file_order_service_proto_rawDescGZIP(), []int{0}
 }
 
-type GetAllInvoicesResponse struct {
+// One page of invoices with their details, pages are streamed in order of invoice id
+type StreamAllInvoicesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Invoices      []*Invoice             `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllInvoicesResponse) Reset() {
-	*x = GetAllInvoicesResponse{}
+func (x *StreamAllInvoicesResponse) Reset() {
+	*x = StreamAllInvoicesResponse{}
 	mi := &file_order_service_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllInvoicesResponse) String() string {
+func (x *StreamAllInvoicesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllInvoicesResponse) ProtoMessage() {}
+func (*StreamAllInvoicesResponse) ProtoMessage() {}
 
-func (x *GetAllInvoicesResponse) ProtoReflect() protoreflect.Message {
+func (x *StreamAllInvoicesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_order_service_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -90,33 +91,138 @@ func (x *GetAllInvoicesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllInvoicesResponse.ProtoReflect.Descriptor instead.
-func (*GetAllInvoicesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamAllInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*StreamAllInvoicesResponse) Descriptor() ([]byte, []int) {
 	return file_order_service_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetAllInvoicesResponse) GetInvoices() []*Invoice {
+func (x *StreamAllInvoicesResponse) GetInvoices() []*Invoice {
 	if x != nil {
 		return x.Invoices
 	}
 	return nil
 }
 
-type Invoice struct {
+type CheckPurchasedProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPurchasedProductRequest) Reset() {
+	*x = CheckPurchasedProductRequest{}
+	mi := &file_order_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPurchasedProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPurchasedProductRequest) ProtoMessage() {}
+
+func (x *CheckPurchasedProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPurchasedProductRequest.ProtoReflect.Descriptor instead.
+func (*CheckPurchasedProductRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckPurchasedProductRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckPurchasedProductRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type CheckPurchasedProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TotalAmount   int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Purchased     bool                   `protobuf:"varint,1,opt,name=purchased,proto3" json:"purchased,omitempty"`
+	InvoiceId     string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *CheckPurchasedProductResponse) Reset() {
+	*x = CheckPurchasedProductResponse{}
+	mi := &file_order_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPurchasedProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPurchasedProductResponse) ProtoMessage() {}
+
+func (x *CheckPurchasedProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPurchasedProductResponse.ProtoReflect.Descriptor instead.
+func (*CheckPurchasedProductResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckPurchasedProductResponse) GetPurchased() bool {
+	if x != nil {
+		return x.Purchased
+	}
+	return false
+}
+
+func (x *CheckPurchasedProductResponse) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+type Invoice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TotalAmount    int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	InvoiceDetails []*InvoiceDetail       `protobuf:"bytes,7,rep,name=invoice_details,json=invoiceDetails,proto3" json:"invoice_details,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *Invoice) Reset() {
 	*x = Invoice{}
-	mi := &file_order_service_proto_msgTypes[2]
+	mi := &file_order_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -128,7 +234,7 @@ func (x *Invoice) String() string {
 func (*Invoice) ProtoMessage() {}
 
 func (x *Invoice) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_proto_msgTypes[2]
+	mi := &file_order_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -141,7 +247,7 @@ func (x *Invoice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
 func (*Invoice) Descriptor() ([]byte, []int) {
-	return file_order_service_proto_rawDescGZIP(), []int{2}
+	return file_order_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Invoice) GetId() string {
@@ -186,14 +292,185 @@ func (x *Invoice) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Invoice) GetInvoiceDetails() []*InvoiceDetail {
+	if x != nil {
+		return x.InvoiceDetails
+	}
+	return nil
+}
+
+type InvoiceDetail struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InvoiceId           string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	ProductId           string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price               int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	DiscountPercentage  int32                  `protobuf:"varint,5,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
+	Quantity            int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TotalPrice          int64                  `protobuf:"varint,7,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	ProductName         string                 `protobuf:"bytes,8,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ProductCategoryId   string                 `protobuf:"bytes,9,opt,name=product_category_id,json=productCategoryId,proto3" json:"product_category_id,omitempty"`
+	ProductCategoryName string                 `protobuf:"bytes,10,opt,name=product_category_name,json=productCategoryName,proto3" json:"product_category_name,omitempty"`
+	ProductBrandId      string                 `protobuf:"bytes,11,opt,name=product_brand_id,json=productBrandId,proto3" json:"product_brand_id,omitempty"`
+	ProductBrandName    string                 `protobuf:"bytes,12,opt,name=product_brand_name,json=productBrandName,proto3" json:"product_brand_name,omitempty"`
+	WarehouseId         string                 `protobuf:"bytes,13,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	WarehouseName       string                 `protobuf:"bytes,14,opt,name=warehouse_name,json=warehouseName,proto3" json:"warehouse_name,omitempty"`
+	PromotionId         string                 `protobuf:"bytes,15,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InvoiceDetail) Reset() {
+	*x = InvoiceDetail{}
+	mi := &file_order_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceDetail) ProtoMessage() {}
+
+func (x *InvoiceDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceDetail.ProtoReflect.Descriptor instead.
+func (*InvoiceDetail) Descriptor() ([]byte, []int) {
+	return file_order_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvoiceDetail) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetDiscountPercentage() int32 {
+	if x != nil {
+		return x.DiscountPercentage
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductCategoryId() string {
+	if x != nil {
+		return x.ProductCategoryId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductCategoryName() string {
+	if x != nil {
+		return x.ProductCategoryName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductBrandId() string {
+	if x != nil {
+		return x.ProductBrandId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductBrandName() string {
+	if x != nil {
+		return x.ProductBrandName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetWarehouseName() string {
+	if x != nil {
+		return x.WarehouseName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
 var File_order_service_proto protoreflect.FileDescriptor
 
 const file_order_service_proto_rawDesc = "" +
 	"\n" +
-	"\x13order_service.proto\x12\forderservice\x1a\x1fgoogle/protobuf/timestamp.proto\"\x17\n" +
-	"\x15GetAllInvoicesRequest\"K\n" +
-	"\x16GetAllInvoicesResponse\x121\n" +
-	"\binvoices\x18\x01 \x03(\v2\x15.orderservice.InvoiceR\binvoices\"\xe3\x01\n" +
+	"\x13order_service.proto\x12\forderservice\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1a\n" +
+	"\x18StreamAllInvoicesRequest\"N\n" +
+	"\x19StreamAllInvoicesResponse\x121\n" +
+	"\binvoices\x18\x01 \x03(\v2\x15.orderservice.InvoiceR\binvoices\"V\n" +
+	"\x1cCheckPurchasedProductRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"\\\n" +
+	"\x1dCheckPurchasedProductResponse\x12\x1c\n" +
+	"\tpurchased\x18\x01 \x01(\bR\tpurchased\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\"\xa9\x02\n" +
 	"\aInvoice\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
@@ -202,9 +479,31 @@ const file_order_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2o\n" +
-	"\x10OrderServiceGRPC\x12[\n" +
-	"\x0eGetAllInvoices\x12#.orderservice.GetAllInvoicesRequest\x1a$.orderservice.GetAllInvoicesResponseB\x11Z\x0forderservicepb/b\x06proto3"
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12D\n" +
+	"\x0finvoice_details\x18\a \x03(\v2\x1b.orderservice.InvoiceDetailR\x0einvoiceDetails\"\xad\x04\n" +
+	"\rInvoiceDetail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12/\n" +
+	"\x13discount_percentage\x18\x05 \x01(\x05R\x12discountPercentage\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vtotal_price\x18\a \x01(\x03R\n" +
+	"totalPrice\x12!\n" +
+	"\fproduct_name\x18\b \x01(\tR\vproductName\x12.\n" +
+	"\x13product_category_id\x18\t \x01(\tR\x11productCategoryId\x122\n" +
+	"\x15product_category_name\x18\n" +
+	" \x01(\tR\x13productCategoryName\x12(\n" +
+	"\x10product_brand_id\x18\v \x01(\tR\x0eproductBrandId\x12,\n" +
+	"\x12product_brand_name\x18\f \x01(\tR\x10productBrandName\x12!\n" +
+	"\fwarehouse_id\x18\r \x01(\tR\vwarehouseId\x12%\n" +
+	"\x0ewarehouse_name\x18\x0e \x01(\tR\rwarehouseName\x12!\n" +
+	"\fpromotion_id\x18\x0f \x01(\tR\vpromotionId2\xec\x01\n" +
+	"\x10OrderServiceGRPC\x12f\n" +
+	"\x11StreamAllInvoices\x12&.orderservice.StreamAllInvoicesRequest\x1a'.orderservice.StreamAllInvoicesResponse0\x01\x12p\n" +
+	"\x15CheckPurchasedProduct\x12*.orderservice.CheckPurchasedProductRequest\x1a+.orderservice.CheckPurchasedProductResponseB\x11Z\x0forderservicepb/b\x06proto3"
 
 var (
 	file_order_service_proto_rawDescOnce sync.Once
@@ -218,24 +517,30 @@ func file_order_service_proto_rawDescGZIP() []byte {
 	return file_order_service_proto_rawDescData
 }
 
-var file_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_order_service_proto_goTypes = []any{
-	(*GetAllInvoicesRequest)(nil),  // 0: orderservice.GetAllInvoicesRequest
-	(*GetAllInvoicesResponse)(nil), // 1: orderservice.GetAllInvoicesResponse
-	(*Invoice)(nil),                // 2: orderservice.Invoice
-	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+	(*StreamAllInvoicesRequest)(nil),      // 0: orderservice.StreamAllInvoicesRequest
+	(*StreamAllInvoicesResponse)(nil),     // 1: orderservice.StreamAllInvoicesResponse
+	(*CheckPurchasedProductRequest)(nil),  // 2: orderservice.CheckPurchasedProductRequest
+	(*CheckPurchasedProductResponse)(nil), // 3: orderservice.CheckPurchasedProductResponse
+	(*Invoice)(nil),                       // 4: orderservice.Invoice
+	(*InvoiceDetail)(nil),                 // 5: orderservice.InvoiceDetail
+	(*timestamppb.Timestamp)(nil),         // 6: google.protobuf.Timestamp
 }
 var file_order_service_proto_depIdxs = []int32{
-	2, // 0: orderservice.GetAllInvoicesResponse.invoices:type_name -> orderservice.Invoice
-	3, // 1: orderservice.Invoice.created_at:type_name -> google.protobuf.Timestamp
-	3, // 2: orderservice.Invoice.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 3: orderservice.OrderServiceGRPC.GetAllInvoices:input_type -> orderservice.GetAllInvoicesRequest
-	1, // 4: orderservice.OrderServiceGRPC.GetAllInvoices:output_type -> orderservice.GetAllInvoicesResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 0: orderservice.StreamAllInvoicesResponse.invoices:type_name -> orderservice.Invoice
+	6, // 1: orderservice.Invoice.created_at:type_name -> google.protobuf.Timestamp
+	6, // 2: orderservice.Invoice.updated_at:type_name -> google.protobuf.Timestamp
+	5, // 3: orderservice.Invoice.invoice_details:type_name -> orderservice.InvoiceDetail
+	0, // 4: orderservice.OrderServiceGRPC.StreamAllInvoices:input_type -> orderservice.StreamAllInvoicesRequest
+	2, // 5: orderservice.OrderServiceGRPC.CheckPurchasedProduct:input_type -> orderservice.CheckPurchasedProductRequest
+	1, // 6: orderservice.OrderServiceGRPC.StreamAllInvoices:output_type -> orderservice.StreamAllInvoicesResponse
+	3, // 7: orderservice.OrderServiceGRPC.CheckPurchasedProduct:output_type -> orderservice.CheckPurchasedProductResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_order_service_proto_init() }
@@ -249,7 +554,7 @@ func file_order_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_service_proto_rawDesc), len(file_order_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
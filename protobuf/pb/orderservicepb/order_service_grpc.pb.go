@@ -19,14 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderServiceGRPC_GetAllInvoices_FullMethodName = "/orderservice.OrderServiceGRPC/GetAllInvoices"
+	OrderServiceGRPC_StreamAllInvoices_FullMethodName     = "/orderservice.OrderServiceGRPC/StreamAllInvoices"
+	OrderServiceGRPC_CheckPurchasedProduct_FullMethodName = "/orderservice.OrderServiceGRPC/CheckPurchasedProduct"
 )
 
 // OrderServiceGRPCClient is the client API for OrderServiceGRPC service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrderServiceGRPCClient interface {
-	GetAllInvoices(ctx context.Context, in *GetAllInvoicesRequest, opts ...grpc.CallOption) (*GetAllInvoicesResponse, error)
+	StreamAllInvoices(ctx context.Context, in *StreamAllInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllInvoicesResponse], error)
+	CheckPurchasedProduct(ctx context.Context, in *CheckPurchasedProductRequest, opts ...grpc.CallOption) (*CheckPurchasedProductResponse, error)
 }
 
 type orderServiceGRPCClient struct {
@@ -37,10 +39,29 @@ func NewOrderServiceGRPCClient(cc grpc.ClientConnInterface) OrderServiceGRPCClie
 	return &orderServiceGRPCClient{cc}
 }
 
-func (c *orderServiceGRPCClient) GetAllInvoices(ctx context.Context, in *GetAllInvoicesRequest, opts ...grpc.CallOption) (*GetAllInvoicesResponse, error) {
+func (c *orderServiceGRPCClient) StreamAllInvoices(ctx context.Context, in *StreamAllInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllInvoicesResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetAllInvoicesResponse)
-	err := c.cc.Invoke(ctx, OrderServiceGRPC_GetAllInvoices_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &OrderServiceGRPC_ServiceDesc.Streams[0], OrderServiceGRPC_StreamAllInvoices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamAllInvoicesRequest, StreamAllInvoicesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderServiceGRPC_StreamAllInvoicesClient = grpc.ServerStreamingClient[StreamAllInvoicesResponse]
+
+func (c *orderServiceGRPCClient) CheckPurchasedProduct(ctx context.Context, in *CheckPurchasedProductRequest, opts ...grpc.CallOption) (*CheckPurchasedProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPurchasedProductResponse)
+	err := c.cc.Invoke(ctx, OrderServiceGRPC_CheckPurchasedProduct_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +72,8 @@ func (c *orderServiceGRPCClient) GetAllInvoices(ctx context.Context, in *GetAllI
 // All implementations must embed UnimplementedOrderServiceGRPCServer
 // for forward compatibility.
 type OrderServiceGRPCServer interface {
-	GetAllInvoices(context.Context, *GetAllInvoicesRequest) (*GetAllInvoicesResponse, error)
+	StreamAllInvoices(*StreamAllInvoicesRequest, grpc.ServerStreamingServer[StreamAllInvoicesResponse]) error
+	CheckPurchasedProduct(context.Context, *CheckPurchasedProductRequest) (*CheckPurchasedProductResponse, error)
 	mustEmbedUnimplementedOrderServiceGRPCServer()
 }
 
@@ -62,8 +84,11 @@ type OrderServiceGRPCServer interface {
 // pointer dereference when methods are called.
 type UnimplementedOrderServiceGRPCServer struct{}
 
-func (UnimplementedOrderServiceGRPCServer) GetAllInvoices(context.Context, *GetAllInvoicesRequest) (*GetAllInvoicesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllInvoices not implemented")
+func (UnimplementedOrderServiceGRPCServer) StreamAllInvoices(*StreamAllInvoicesRequest, grpc.ServerStreamingServer[StreamAllInvoicesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAllInvoices not implemented")
+}
+func (UnimplementedOrderServiceGRPCServer) CheckPurchasedProduct(context.Context, *CheckPurchasedProductRequest) (*CheckPurchasedProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPurchasedProduct not implemented")
 }
 func (UnimplementedOrderServiceGRPCServer) mustEmbedUnimplementedOrderServiceGRPCServer() {}
 func (UnimplementedOrderServiceGRPCServer) testEmbeddedByValue()                          {}
@@ -86,20 +111,31 @@ func RegisterOrderServiceGRPCServer(s grpc.ServiceRegistrar, srv OrderServiceGRP
 	s.RegisterService(&OrderServiceGRPC_ServiceDesc, srv)
 }
 
-func _OrderServiceGRPC_GetAllInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllInvoicesRequest)
+func _OrderServiceGRPC_StreamAllInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAllInvoicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceGRPCServer).StreamAllInvoices(m, &grpc.GenericServerStream[StreamAllInvoicesRequest, StreamAllInvoicesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderServiceGRPC_StreamAllInvoicesServer = grpc.ServerStreamingServer[StreamAllInvoicesResponse]
+
+func _OrderServiceGRPC_CheckPurchasedProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPurchasedProductRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OrderServiceGRPCServer).GetAllInvoices(ctx, in)
+		return srv.(OrderServiceGRPCServer).CheckPurchasedProduct(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: OrderServiceGRPC_GetAllInvoices_FullMethodName,
+		FullMethod: OrderServiceGRPC_CheckPurchasedProduct_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceGRPCServer).GetAllInvoices(ctx, req.(*GetAllInvoicesRequest))
+		return srv.(OrderServiceGRPCServer).CheckPurchasedProduct(ctx, req.(*CheckPurchasedProductRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -112,10 +148,16 @@ var OrderServiceGRPC_ServiceDesc = grpc.ServiceDesc{
 	HandlerType: (*OrderServiceGRPCServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetAllInvoices",
-			Handler:    _OrderServiceGRPC_GetAllInvoices_Handler,
+			MethodName: "CheckPurchasedProduct",
+			Handler:    _OrderServiceGRPC_CheckPurchasedProduct_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAllInvoices",
+			Handler:       _OrderServiceGRPC_StreamAllInvoices_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "order_service.proto",
 }
@@ -40,10 +40,30 @@ func main() {
 	repository.InitTableCategory()
 	repository.InitTableBrand()
 	repository.InitTableProduct()
+	repository.InitTableProductImage()
+	repository.InitTableReview()
+	repository.InitTableWarehouse()
+	repository.InitTableWarehouseStock()
+	repository.InitTableStockMovement()
+	repository.InitTablePromotion()
+	repository.InitTablePromotionUsage()
+	repository.InitTableProductPriceHistory()
+	repository.InitTableProductImportJob()
+	repository.InitTableSlugRedirect()
+	repository.InitTableAttributeDefinition()
+	repository.InitTableCategoryAttribute()
+	repository.InitTableProductAttributeValue()
+	repository.InitTableCollection()
+	repository.InitTableCollectionProduct()
+	repository.InitTableBackorder()
+	repository.InitTableBundleComponent()
+	repository.InitTableSizeChart()
 	infrastructure.InitRedisClient()
 	defer infrastructure.RedisClient.Close()
+	infrastructure.InitViewCaches()
 	infrastructure.InitAllServiceGRPCClients()
 	defer infrastructure.ServiceGRPCConnectionManager.CloseAll()
+	infrastructure.InitMediaStorage()
 
 	humaCfg := huma.DefaultConfig("Catalog Service", "v1.0.0")
 	humaCfg.DocsPath = ""
@@ -75,6 +95,9 @@ func main() {
 	r.GET("/fashionecom/api-document", func(ctx *gin.Context) {
 		ctx.Data(http.StatusOK, "text/html", []byte(humaDocsEmbedded))
 	})
+	if config.AppConfig.MediaStorage == "local" {
+		r.Static("/media", config.AppConfig.MediaLocalDir)
+	}
 
 	api := humagin.New(r, humaCfg)
 
@@ -83,16 +106,57 @@ func main() {
 	categoryRepository := repository.NewCategoryRepository()
 	brandRepository := repository.NewBrandRepository()
 	productRepository := repository.NewProductRepository()
-
-	categoryService := service.NewCategoryService(categoryRepository)
-	brandService := service.NewBrandService(brandRepository)
-	productService := service.NewProductService(productRepository, categoryRepository, brandRepository)
-
-	grpcimpl.StartGRPCServer(grpcimpl.NewCatalogServiceGRPCImpl(productService))
+	productPriceHistoryRepository := repository.NewProductPriceHistoryRepository()
+	productImageRepository := repository.NewProductImageRepository()
+	reviewRepository := repository.NewReviewRepository()
+	stockMovementRepository := repository.NewStockMovementRepository()
+	warehouseRepository := repository.NewWarehouseRepository()
+	promotionRepository := repository.NewPromotionRepository()
+	productImportJobRepository := repository.NewProductImportJobRepository()
+	slugRedirectRepository := repository.NewSlugRedirectRepository()
+	attributeDefinitionRepository := repository.NewAttributeDefinitionRepository()
+	categoryAttributeRepository := repository.NewCategoryAttributeRepository()
+	productAttributeValueRepository := repository.NewProductAttributeValueRepository()
+	collectionRepository := repository.NewCollectionRepository()
+	backorderRepository := repository.NewBackorderRepository()
+	bundleComponentRepository := repository.NewBundleComponentRepository()
+	sizeChartRepository := repository.NewSizeChartRepository()
+
+	stockAllocationStrategy := service.NewStockAllocationStrategy(config.AppConfig.StockAllocationStrategy)
+
+	categoryService := service.NewCategoryService(categoryRepository, productRepository, slugRedirectRepository)
+	brandService := service.NewBrandService(brandRepository, productRepository, slugRedirectRepository)
+	sizeChartService := service.NewSizeChartService(sizeChartRepository, productRepository, categoryRepository, brandRepository)
+	productService := service.NewProductService(productRepository, productPriceHistoryRepository, categoryRepository, brandRepository, stockMovementRepository, warehouseRepository, promotionRepository, slugRedirectRepository, categoryAttributeRepository, productAttributeValueRepository, bundleComponentRepository, stockAllocationStrategy, sizeChartService)
+	productImageService := service.NewProductImageService(productImageRepository, productRepository)
+	reviewService := service.NewReviewService(reviewRepository, productRepository)
+	stockMovementService := service.NewStockMovementService(stockMovementRepository, productRepository, warehouseRepository, promotionRepository)
+	warehouseService := service.NewWarehouseService(warehouseRepository, productRepository)
+	promotionService := service.NewPromotionService(promotionRepository, productRepository, productPriceHistoryRepository, categoryRepository, brandRepository)
+	productImportService := service.NewProductImportService(productImportJobRepository, productRepository, categoryRepository, brandRepository, productService)
+	attributeService := service.NewAttributeService(attributeDefinitionRepository, categoryAttributeRepository, productAttributeValueRepository, categoryRepository, productRepository)
+	collectionService := service.NewCollectionService(collectionRepository, productRepository, categoryRepository, brandRepository)
+	lowStockService := service.NewLowStockService(productRepository)
+	backorderService := service.NewBackorderService(backorderRepository, productRepository, warehouseRepository, stockAllocationStrategy)
+	cacheService := service.NewCacheService()
+
+	grpcimpl.StartGRPCServer(grpcimpl.NewCatalogServiceGRPCImpl(productService, stockMovementService, backorderService))
 
 	handler.NewCategoryHandler(api, categoryService, jwtAuthMiddleware)
 	handler.NewBrandHandler(api, brandService, jwtAuthMiddleware)
 	handler.NewProductHandler(api, productService, jwtAuthMiddleware)
+	handler.NewProductImageHandler(api, productImageService, jwtAuthMiddleware)
+	handler.NewReviewHandler(api, reviewService, jwtAuthMiddleware)
+	handler.NewStockMovementHandler(api, stockMovementService, jwtAuthMiddleware)
+	handler.NewWarehouseHandler(api, warehouseService, jwtAuthMiddleware)
+	handler.NewPromotionHandler(api, promotionService, jwtAuthMiddleware)
+	handler.NewProductImportHandler(api, productImportService, jwtAuthMiddleware)
+	handler.NewAttributeHandler(api, attributeService, jwtAuthMiddleware)
+	handler.NewCollectionHandler(api, collectionService, jwtAuthMiddleware)
+	handler.NewLowStockHandler(api, lowStockService, jwtAuthMiddleware)
+	handler.NewBackorderHandler(api, backorderService, jwtAuthMiddleware)
+	handler.NewCacheHandler(api, cacheService, jwtAuthMiddleware)
+	handler.NewSizeChartHandler(api, sizeChartService, jwtAuthMiddleware)
 
 	r.Run(":" + config.AppConfig.AppPort)
 
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,8 +24,35 @@ type Config struct {
 
 	CatalogServiceGRPCHost       string
 	CatalogServiceGRPCPort       string
+	UserServiceGRPCHost          string
+	UserServiceGRPCPort          string
+	OrderServiceGRPCHost         string
+	OrderServiceGRPCPort         string
 	ElasticsearchServiceGRPCHost string
 	ElasticsearchServiceGRPCPort string
+
+	MediaStorage       string
+	MediaLocalDir      string
+	MediaPublicURL     string
+	MediaMaxUploadSize string
+	MediaS3Endpoint    string
+	MediaS3Region      string
+	MediaS3Bucket      string
+	MediaS3AccessKey   string
+	MediaS3SecretKey   string
+	MediaS3UseSSL      string
+
+	StockAllocationStrategy string
+
+	PromotionSchedulerInterval string
+
+	ProductImportMaxRows         string
+	ProductImportMaxXLSXPartSize string
+
+	LowStockDefaultThreshold string
+
+	CacheProductTTL       string
+	CacheBrandCategoryTTL string
 }
 
 var AppConfig *Config
@@ -48,8 +77,65 @@ func InitConfig() {
 
 		CatalogServiceGRPCHost:       GetEnv("CATALOG_SERVICE_GRPC_HOST", "localhost"),
 		CatalogServiceGRPCPort:       GetEnv("CATALOG_SERVICE_GRPC_PORT", "50050"),
+		UserServiceGRPCHost:          GetEnv("USER_SERVICE_GRPC_HOST", "localhost"),
+		UserServiceGRPCPort:          GetEnv("USER_SERVICE_GRPC_PORT", "50050"),
+		OrderServiceGRPCHost:         GetEnv("ORDER_SERVICE_GRPC_HOST", "localhost"),
+		OrderServiceGRPCPort:         GetEnv("ORDER_SERVICE_GRPC_PORT", "50050"),
 		ElasticsearchServiceGRPCHost: GetEnv("ELASTICSEARCH_SERVICE_GRPC_HOST", "localhost"),
 		ElasticsearchServiceGRPCPort: GetEnv("ELASTICSEARCH_SERVICE_GRPC_PORT", "50050"),
+
+		MediaStorage:       GetEnv("MEDIA_STORAGE", "local"),
+		MediaLocalDir:      GetEnv("MEDIA_LOCAL_DIR", "./media"),
+		MediaPublicURL:     GetEnv("MEDIA_PUBLIC_URL", "http://localhost:8082/media"),
+		MediaMaxUploadSize: GetEnv("MEDIA_MAX_UPLOAD_SIZE", "10485760"),
+		MediaS3Endpoint:    GetEnv("MEDIA_S3_ENDPOINT", "localhost:9000"),
+		MediaS3Region:      GetEnv("MEDIA_S3_REGION", "us-east-1"),
+		MediaS3Bucket:      GetEnv("MEDIA_S3_BUCKET", "catalog-media"),
+		MediaS3AccessKey:   GetEnv("MEDIA_S3_ACCESS_KEY", ""),
+		MediaS3SecretKey:   GetEnv("MEDIA_S3_SECRET_KEY", ""),
+		MediaS3UseSSL:      GetEnv("MEDIA_S3_USE_SSL", "false"),
+
+		StockAllocationStrategy: GetEnv("STOCK_ALLOCATION_STRATEGY", "priority"),
+
+		PromotionSchedulerInterval: GetEnv("PROMOTION_SCHEDULER_INTERVAL", "30s"),
+
+		ProductImportMaxRows:         GetEnv("PRODUCT_IMPORT_MAX_ROWS", "10000"),
+		ProductImportMaxXLSXPartSize: GetEnv("PRODUCT_IMPORT_MAX_XLSX_PART_SIZE", "52428800"),
+
+		LowStockDefaultThreshold: GetEnv("LOW_STOCK_DEFAULT_THRESHOLD", "0"),
+
+		CacheProductTTL:       GetEnv("CACHE_PRODUCT_TTL", "1m"),
+		CacheBrandCategoryTTL: GetEnv("CACHE_BRAND_CATEGORY_TTL", "10m"),
+	}
+
+	// Validate constraint environment variable value
+	if _, err := strconv.ParseInt(AppConfig.MediaMaxUploadSize, 10, 64); err != nil {
+		log.Fatal("Evironment variable MEDIA_MAX_UPLOAD_SIZE is not valid number (must int64): ", err)
+	}
+	if _, err := strconv.ParseBool(AppConfig.MediaS3UseSSL); err != nil {
+		log.Fatal("Evironment variable MEDIA_S3_USE_SSL is not valid boolean: ", err)
+	}
+	if strategy := AppConfig.StockAllocationStrategy; strategy != "priority" && strategy != "nearest" && strategy != "split" {
+		log.Fatalf("Evironment variable STOCK_ALLOCATION_STRATEGY is not valid (must be priority, nearest or split): %s", strategy)
+	}
+	if interval, err := time.ParseDuration(AppConfig.PromotionSchedulerInterval); err != nil || interval <= 0 {
+		log.Fatalf("Evironment variable PROMOTION_SCHEDULER_INTERVAL is not valid positive duration (e.g. 30s): %s", AppConfig.PromotionSchedulerInterval)
+	}
+
+	if maxRows, err := strconv.Atoi(AppConfig.ProductImportMaxRows); err != nil || maxRows <= 0 {
+		log.Fatalf("Evironment variable PRODUCT_IMPORT_MAX_ROWS is not valid positive number: %s", AppConfig.ProductImportMaxRows)
+	}
+	if maxPartSize, err := strconv.ParseInt(AppConfig.ProductImportMaxXLSXPartSize, 10, 64); err != nil || maxPartSize <= 0 {
+		log.Fatalf("Evironment variable PRODUCT_IMPORT_MAX_XLSX_PART_SIZE is not valid positive number (must int64): %s", AppConfig.ProductImportMaxXLSXPartSize)
+	}
+	if threshold, err := strconv.ParseInt(AppConfig.LowStockDefaultThreshold, 10, 32); err != nil || threshold < 0 {
+		log.Fatalf("Evironment variable LOW_STOCK_DEFAULT_THRESHOLD is not valid non-negative number: %s", AppConfig.LowStockDefaultThreshold)
+	}
+	if ttl, err := time.ParseDuration(AppConfig.CacheProductTTL); err != nil || ttl < 0 {
+		log.Fatalf("Evironment variable CACHE_PRODUCT_TTL is not valid non-negative duration (e.g. 1m): %s", AppConfig.CacheProductTTL)
+	}
+	if ttl, err := time.ParseDuration(AppConfig.CacheBrandCategoryTTL); err != nil || ttl < 0 {
+		log.Fatalf("Evironment variable CACHE_BRAND_CATEGORY_TTL is not valid non-negative duration (e.g. 10m): %s", AppConfig.CacheBrandCategoryTTL)
 	}
 
 	log.Println("Load .env file successful")
@@ -62,3 +148,43 @@ func GetEnv(key string, defaultValue string) string {
 		return defaultValue
 	}
 }
+
+func (config *Config) MediaMaxUploadSizeValue() int64 {
+	mediaMaxUploadSize, _ := strconv.ParseInt(config.MediaMaxUploadSize, 10, 64)
+	return mediaMaxUploadSize
+}
+
+func (config *Config) MediaS3UseSSLValue() bool {
+	mediaS3UseSSL, _ := strconv.ParseBool(config.MediaS3UseSSL)
+	return mediaS3UseSSL
+}
+
+func (config *Config) PromotionSchedulerIntervalValue() time.Duration {
+	promotionSchedulerInterval, _ := time.ParseDuration(config.PromotionSchedulerInterval)
+	return promotionSchedulerInterval
+}
+
+func (config *Config) ProductImportMaxRowsValue() int {
+	productImportMaxRows, _ := strconv.Atoi(config.ProductImportMaxRows)
+	return productImportMaxRows
+}
+
+func (config *Config) ProductImportMaxXLSXPartSizeValue() int64 {
+	productImportMaxXLSXPartSize, _ := strconv.ParseInt(config.ProductImportMaxXLSXPartSize, 10, 64)
+	return productImportMaxXLSXPartSize
+}
+
+func (config *Config) LowStockDefaultThresholdValue() int32 {
+	lowStockDefaultThreshold, _ := strconv.ParseInt(config.LowStockDefaultThreshold, 10, 32)
+	return int32(lowStockDefaultThreshold)
+}
+
+func (config *Config) CacheProductTTLValue() time.Duration {
+	cacheProductTTL, _ := time.ParseDuration(config.CacheProductTTL)
+	return cacheProductTTL
+}
+
+func (config *Config) CacheBrandCategoryTTLValue() time.Duration {
+	cacheBrandCategoryTTL, _ := time.ParseDuration(config.CacheBrandCategoryTTL)
+	return cacheBrandCategoryTTL
+}
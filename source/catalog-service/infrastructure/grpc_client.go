@@ -6,19 +6,27 @@ import (
 	"net"
 	"thanhldt060802/config"
 	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+	"thanhldt060802/internal/grpc/client/orderservicepb"
+	"thanhldt060802/internal/grpc/client/userservicepb"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+var UserServiceGRPCClient userservicepb.UserServiceGRPCClient
+var OrderServiceGRPCClient orderservicepb.OrderServiceGRPCClient
 var ElasticsearchServiceGRPCClient elasticsearchservicepb.ElasticsearchServiceGRPCClient
 
 type serviceGRPCConnectionManager struct {
+	userServiceGRPCConnection          *grpc.ClientConn
+	orderServiceGRPCConnection         *grpc.ClientConn
 	elasticsearchServiceGRPCConnection *grpc.ClientConn
 }
 
 func (serviceGRPCConnectionManager *serviceGRPCConnectionManager) CloseAll() {
+	serviceGRPCConnectionManager.userServiceGRPCConnection.Close()
+	serviceGRPCConnectionManager.orderServiceGRPCConnection.Close()
 	serviceGRPCConnectionManager.elasticsearchServiceGRPCConnection.Close()
 }
 
@@ -27,6 +35,68 @@ var ServiceGRPCConnectionManager *serviceGRPCConnectionManager
 func InitAllServiceGRPCClients() {
 	ServiceGRPCConnectionManager = &serviceGRPCConnectionManager{}
 
+	// Kết nối user-service
+	go func() {
+		userServiceGRPCServerAddress := net.JoinHostPort(config.AppConfig.UserServiceGRPCHost, config.AppConfig.UserServiceGRPCPort)
+		for {
+			testingConn, err := net.DialTimeout("tcp", userServiceGRPCServerAddress, 2*time.Second)
+			if err == nil {
+				testingConn.Close()
+
+				userServiceGRPCServerAddress = fmt.Sprintf(
+					"%s:%s",
+					config.AppConfig.UserServiceGRPCHost,
+					config.AppConfig.UserServiceGRPCPort,
+				)
+
+				conn, err := grpc.NewClient(userServiceGRPCServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if err != nil {
+					log.Fatalf("connect to user-service failed: %s", err.Error())
+				}
+				ServiceGRPCConnectionManager.userServiceGRPCConnection = conn
+				UserServiceGRPCClient = userservicepb.NewUserServiceGRPCClient(conn)
+
+				log.Printf("Connect to user-service successful")
+
+				return
+			}
+
+			log.Printf("Waiting for user-service (%s) to be ready...", userServiceGRPCServerAddress)
+			time.Sleep(1 * time.Second)
+		}
+	}()
+
+	// Kết nối order-service
+	go func() {
+		orderServiceGRPCServerAddress := net.JoinHostPort(config.AppConfig.OrderServiceGRPCHost, config.AppConfig.OrderServiceGRPCPort)
+		for {
+			testingConn, err := net.DialTimeout("tcp", orderServiceGRPCServerAddress, 2*time.Second)
+			if err == nil {
+				testingConn.Close()
+
+				orderServiceGRPCServerAddress = fmt.Sprintf(
+					"%s:%s",
+					config.AppConfig.OrderServiceGRPCHost,
+					config.AppConfig.OrderServiceGRPCPort,
+				)
+
+				conn, err := grpc.NewClient(orderServiceGRPCServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if err != nil {
+					log.Fatalf("connect to order-service failed: %s", err.Error())
+				}
+				ServiceGRPCConnectionManager.orderServiceGRPCConnection = conn
+				OrderServiceGRPCClient = orderservicepb.NewOrderServiceGRPCClient(conn)
+
+				log.Printf("Connect to order-service successful")
+
+				return
+			}
+
+			log.Printf("Waiting for order-service (%s) to be ready...", orderServiceGRPCServerAddress)
+			time.Sleep(1 * time.Second)
+		}
+	}()
+
 	// Kết nối elasticsearch-service
 	go func() {
 		elasticsearchServiceGRPCServerAddress := net.JoinHostPort(config.AppConfig.ElasticsearchServiceGRPCHost, config.AppConfig.ElasticsearchServiceGRPCPort)
@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store media files on local filesystem, they are served by the service itself under /media
+type localMediaStorage struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalMediaStorage(dir string, publicURL string) (MediaStorageBackend, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &localMediaStorage{
+		dir:       dir,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+func (localMediaStorage *localMediaStorage) Put(ctx context.Context, key string, content []byte, contentType string) (string, error) {
+	filePath := filepath.Join(localMediaStorage.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", err
+	}
+
+	return localMediaStorage.publicURL + "/" + key, nil
+}
+
+func (localMediaStorage *localMediaStorage) Delete(ctx context.Context, key string) error {
+	filePath := filepath.Join(localMediaStorage.dir, filepath.FromSlash(key))
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
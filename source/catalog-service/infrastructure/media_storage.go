@@ -0,0 +1,44 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"thanhldt060802/config"
+)
+
+// Backend which stores uploaded media files and exposes them by public URL
+type MediaStorageBackend interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var MediaStorage MediaStorageBackend
+
+func InitMediaStorage() {
+	switch config.AppConfig.MediaStorage {
+	case "local":
+		localMediaStorage, err := NewLocalMediaStorage(config.AppConfig.MediaLocalDir, config.AppConfig.MediaPublicURL)
+		if err != nil {
+			log.Fatal("Init local media storage failed: ", err)
+		}
+		MediaStorage = localMediaStorage
+	case "s3":
+		s3MediaStorage, err := NewS3MediaStorage(
+			config.AppConfig.MediaS3Endpoint,
+			config.AppConfig.MediaS3Region,
+			config.AppConfig.MediaS3Bucket,
+			config.AppConfig.MediaS3AccessKey,
+			config.AppConfig.MediaS3SecretKey,
+			config.AppConfig.MediaS3UseSSLValue(),
+			config.AppConfig.MediaPublicURL,
+		)
+		if err != nil {
+			log.Fatal("Init s3 media storage failed: ", err)
+		}
+		MediaStorage = s3MediaStorage
+	default:
+		log.Fatalf("Media storage %s is not supported (must be local or s3)", config.AppConfig.MediaStorage)
+	}
+
+	log.Printf("Init %s media storage successful", config.AppConfig.MediaStorage)
+}
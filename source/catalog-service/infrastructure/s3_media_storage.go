@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Store media files on S3-compatible object storage (AWS S3, MinIO, ...) using path-style requests signed with AWS Signature V4
+type s3MediaStorage struct {
+	baseURL    string
+	host       string
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+	publicURL  string
+	httpClient *http.Client
+}
+
+func NewS3MediaStorage(endpoint string, region string, bucket string, accessKey string, secretKey string, useSSL bool, publicURL string) (MediaStorageBackend, error) {
+	scheme := "http"
+	if useSSL {
+		scheme = "https"
+	}
+
+	s3MediaStorage := &s3MediaStorage{
+		baseURL:    fmt.Sprintf("%s://%s", scheme, endpoint),
+		host:       endpoint,
+		region:     region,
+		bucket:     bucket,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		publicURL:  strings.TrimRight(publicURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if err := s3MediaStorage.createBucketIfNotExists(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return s3MediaStorage, nil
+}
+
+func (s3MediaStorage *s3MediaStorage) Put(ctx context.Context, key string, content []byte, contentType string) (string, error) {
+	res, err := s3MediaStorage.do(ctx, http.MethodPut, "/"+s3MediaStorage.bucket+"/"+escapeS3Key(key), content, contentType)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return "", fmt.Errorf("put object %s failed with status %d: %s", key, res.StatusCode, string(body))
+	}
+
+	return s3MediaStorage.publicURL + "/" + key, nil
+}
+
+func (s3MediaStorage *s3MediaStorage) Delete(ctx context.Context, key string) error {
+	res, err := s3MediaStorage.do(ctx, http.MethodDelete, "/"+s3MediaStorage.bucket+"/"+escapeS3Key(key), nil, "")
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// Deleting a missing object also responds 204
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("delete object %s failed with status %d: %s", key, res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (s3MediaStorage *s3MediaStorage) createBucketIfNotExists(ctx context.Context) error {
+	headRes, err := s3MediaStorage.do(ctx, http.MethodHead, "/"+s3MediaStorage.bucket, nil, "")
+	if err != nil {
+		return err
+	}
+	headRes.Body.Close()
+
+	if headRes.StatusCode == http.StatusOK {
+		return nil
+	}
+	if headRes.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("check bucket %s failed with status %d", s3MediaStorage.bucket, headRes.StatusCode)
+	}
+
+	res, err := s3MediaStorage.do(ctx, http.MethodPut, "/"+s3MediaStorage.bucket, nil, "")
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("create bucket %s failed with status %d: %s", s3MediaStorage.bucket, res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (s3MediaStorage *s3MediaStorage) do(ctx context.Context, method string, path string, content []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s3MediaStorage.baseURL+path, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	s3MediaStorage.sign(req, path, content, time.Now().UTC())
+
+	return s3MediaStorage.httpClient.Do(req)
+}
+
+// Sign request with AWS Signature V4, headers host, x-amz-content-sha256 and x-amz-date are signed
+func (s3MediaStorage *s3MediaStorage) sign(req *http.Request, path string, content []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(content)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + s3MediaStorage.host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s3MediaStorage.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s3MediaStorage.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s3MediaStorage.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3MediaStorage.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func escapeS3Key(key string) string {
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,193 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"thanhldt060802/config"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Read-through cache of views in Redis. Views are stored as JSON with TTL under keys of current generation, so that every
+// view of a cache is invalidated at once by moving to next generation. Concurrent misses of a key in this instance share
+// one load. Cache is never required, Redis failures fall back to load.
+type ViewCache struct {
+	name string
+	ttl  time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	loads  atomic.Int64
+	errors atomic.Int64
+
+	mu    sync.Mutex
+	calls map[string]*viewCacheCall
+}
+
+type viewCacheCall struct {
+	done    chan struct{}
+	payload []byte
+	err     error
+}
+
+type ViewCacheStats struct {
+	Name   string
+	TTL    time.Duration
+	Hits   int64
+	Misses int64
+	Loads  int64
+	Errors int64
+}
+
+var ProductViewCache *ViewCache
+var BrandViewCache *ViewCache
+var CategoryViewCache *ViewCache
+
+func InitViewCaches() {
+	ProductViewCache = NewViewCache("product", config.AppConfig.CacheProductTTLValue())
+	BrandViewCache = NewViewCache("brand", config.AppConfig.CacheBrandCategoryTTLValue())
+	CategoryViewCache = NewViewCache("category", config.AppConfig.CacheBrandCategoryTTLValue())
+
+	log.Println("Init view caches successful")
+}
+
+// TTL 0 disables cache, every lookup loads
+func NewViewCache(name string, ttl time.Duration) *ViewCache {
+	return &ViewCache{
+		name:  name,
+		ttl:   ttl,
+		calls: map[string]*viewCacheCall{},
+	}
+}
+
+// Look view up in cache, it is loaded and stored on miss. Errors of load (not found included) are returned and not stored.
+func GetOrLoadView[T any](ctx context.Context, viewCache *ViewCache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var view T
+	if viewCache.ttl <= 0 {
+		return load(ctx)
+	}
+
+	generation, err := viewCache.generation(ctx)
+	if err != nil {
+		viewCache.errors.Add(1)
+		viewCache.misses.Add(1)
+		return load(ctx)
+	}
+	redisKey := fmt.Sprintf("catalog-service:cache:%s:%d:%s", viewCache.name, generation, key)
+
+	payload, err := RedisClient.Get(ctx, redisKey).Bytes()
+	if err == nil && json.Unmarshal(payload, &view) == nil {
+		viewCache.hits.Add(1)
+		return view, nil
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		viewCache.errors.Add(1)
+	}
+	viewCache.misses.Add(1)
+
+	payload, err = viewCache.do(redisKey, func() ([]byte, error) {
+		loadedView, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := json.Marshal(loadedView)
+		if err != nil {
+			return nil, err
+		}
+		if err := RedisClient.Set(ctx, redisKey, payload, viewCache.ttl).Err(); err != nil {
+			viewCache.errors.Add(1)
+		}
+		return payload, nil
+	})
+	if err != nil {
+		return view, err
+	}
+
+	// Every caller gets its own copy of shared load
+	if err := json.Unmarshal(payload, &view); err != nil {
+		return view, err
+	}
+
+	return view, nil
+}
+
+// Remove view of key from current generation
+func (viewCache *ViewCache) Invalidate(ctx context.Context, key string) {
+	if viewCache.ttl <= 0 {
+		return
+	}
+
+	generation, err := viewCache.generation(ctx)
+	if err == nil {
+		err = RedisClient.Del(ctx, fmt.Sprintf("catalog-service:cache:%s:%d:%s", viewCache.name, generation, key)).Err()
+	}
+	if err != nil {
+		viewCache.errors.Add(1)
+		log.Printf("Invalidate %s cache of key %s failed: %s", viewCache.name, key, err.Error())
+	}
+}
+
+// Move to next generation, views of previous one are left to expire
+func (viewCache *ViewCache) InvalidateAll(ctx context.Context) {
+	if viewCache.ttl <= 0 {
+		return
+	}
+
+	if err := RedisClient.Incr(ctx, viewCache.generationKey()).Err(); err != nil {
+		viewCache.errors.Add(1)
+		log.Printf("Invalidate %s cache failed: %s", viewCache.name, err.Error())
+	}
+}
+
+func (viewCache *ViewCache) Stats() *ViewCacheStats {
+	return &ViewCacheStats{
+		Name:   viewCache.name,
+		TTL:    viewCache.ttl,
+		Hits:   viewCache.hits.Load(),
+		Misses: viewCache.misses.Load(),
+		Loads:  viewCache.loads.Load(),
+		Errors: viewCache.errors.Load(),
+	}
+}
+
+func (viewCache *ViewCache) generationKey() string {
+	return fmt.Sprintf("catalog-service:cache:%s:generation", viewCache.name)
+}
+
+func (viewCache *ViewCache) generation(ctx context.Context) (int64, error) {
+	generation, err := RedisClient.Get(ctx, viewCache.generationKey()).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return generation, err
+}
+
+// Run load once for concurrent callers of the same key, they all get its result
+func (viewCache *ViewCache) do(key string, load func() ([]byte, error)) ([]byte, error) {
+	viewCache.mu.Lock()
+	if call, ok := viewCache.calls[key]; ok {
+		viewCache.mu.Unlock()
+		<-call.done
+		return call.payload, call.err
+	}
+	call := &viewCacheCall{done: make(chan struct{})}
+	viewCache.calls[key] = call
+	viewCache.mu.Unlock()
+
+	viewCache.loads.Add(1)
+	call.payload, call.err = load()
+	close(call.done)
+
+	viewCache.mu.Lock()
+	delete(viewCache.calls, key)
+	viewCache.mu.Unlock()
+
+	return call.payload, call.err
+}
@@ -0,0 +1,47 @@
+package dto
+
+type GetAllAttributeDefinitionsRequest struct {
+	SortBy string `query:"sort_by" default:"code:asc" pattern:"^(code|name|type|created_at)(:(asc|desc))?(,(code|name|type|created_at)(:(asc|desc))?)*$" example:"type,code" doc:"Sort by one or more fields (code, name, type, created_at) separated by commas."`
+}
+
+type GetAttributeDefinitionByIdRequest struct {
+	Id string `path:"id" doc:"Id of attribute."`
+}
+
+type CreateAttributeDefinitionRequest struct {
+	Body struct {
+		Code    string   `json:"code" required:"true" pattern:"^[a-z][a-z0-9_]*$" maxLength:"50" doc:"Code of attribute (unique), used as filter key of product search."`
+		Name    string   `json:"name" required:"true" minLength:"1" doc:"Name of attribute."`
+		Type    string   `json:"type" required:"true" enum:"ENUM,NUMBER,TEXT" doc:"Type of attribute values."`
+		Options []string `json:"options,omitempty" doc:"Allowed values of ENUM attribute."`
+		Unit    string   `json:"unit,omitempty" doc:"Unit of NUMBER attribute, for example cm."`
+	}
+}
+
+type UpdateAttributeDefinitionByIdRequest struct {
+	Id   string `path:"id" doc:"Id of attribute."`
+	Body struct {
+		Name    *string   `json:"name,omitempty" minLength:"1" doc:"Name of attribute."`
+		Options *[]string `json:"options,omitempty" doc:"Allowed values of ENUM attribute, options still used by products cannot be removed."`
+		Unit    *string   `json:"unit,omitempty" doc:"Unit of NUMBER attribute, for example cm."`
+	}
+}
+
+type DeleteAttributeDefinitionByIdRequest struct {
+	Id string `path:"id" doc:"Id of attribute."`
+}
+
+type GetCategoryAttributesRequest struct {
+	Id string `path:"id" doc:"Id of category."`
+}
+
+type UpdateCategoryAttributesRequest struct {
+	Id   string `path:"id" doc:"Id of category."`
+	Body struct {
+		Attributes []struct {
+			AttributeId string `json:"attribute_id" required:"true" doc:"Id of attribute."`
+			IsRequired  bool   `json:"is_required,omitempty" doc:"Products of category must have a value of attribute."`
+			SortOrder   int32  `json:"sort_order,omitempty" doc:"Order of attribute in attribute set."`
+		} `json:"attributes" required:"true" doc:"Own attribute set of category, it replaces the current one. Attributes of ancestor categories are inherited."`
+	}
+}
@@ -0,0 +1,18 @@
+package dto
+
+type GetBackordersRequest struct {
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:asc" pattern:"^(created_at|updated_at|quantity)(:(asc|desc))?(,(created_at|updated_at|quantity)(:(asc|desc))?)*$" example:"created_at:asc" doc:"Sort by one or more fields (created_at, updated_at, quantity) separated by commas."`
+	// Filter
+	ProductId string `query:"product_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by product id."`
+	Status    string `query:"status" enum:"PENDING,ALLOCATED,CANCELLED" example:"PENDING" doc:"Filter by status."`
+}
+
+type GetUnconfirmedAllocatedBackordersRequest struct {
+	Limit int32
+}
+
+type ConfirmAllocatedBackorderRequest struct {
+	Id string
+}
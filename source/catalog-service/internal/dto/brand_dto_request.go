@@ -4,14 +4,24 @@ type GetAllBrandsRequest struct {
 	SortBy string `query:"sort_by" default:"created_at:asc" example:"created_at:desc,name" doc:"Sort by one or more fields separated by commas. For example: sort_by=created_at:desc,name will sort by created_at in descending order, then by name in ascending order."`
 }
 
+type GetArchivedBrandsRequest struct {
+	SortBy string `query:"sort_by" default:"deleted_at:desc" example:"deleted_at:desc,name" doc:"Sort by one or more fields separated by commas. For example: sort_by=deleted_at:desc,name will sort by deleted_at in descending order, then by name in ascending order."`
+}
+
 type GetBrandByIdRequest struct {
 	Id string `path:"id" doc:"Id of brand."`
 }
 
+type GetBrandBySlugRequest struct {
+	Slug string `path:"slug" doc:"Slug of brand, a former slug is redirected to the current one."`
+}
+
 type CreateBrandRequest struct {
 	Body struct {
 		Name        string `json:"name" required:"true" minLength:"1" doc:"Name of brand (unique)."`
+		Slug        string `json:"slug,omitempty" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Slug of brand (unique), generated from name if empty."`
 		Description string `json:"description" required:"true" minLength:"1" doc:"Description of brand."`
+		Status      string `json:"status,omitempty" default:"PUBLISHED" enum:"DRAFT,PUBLISHED" doc:"Status of brand, only published brands are shown publicly."`
 	}
 }
 
@@ -19,10 +29,16 @@ type UpdateBrandByIdRequest struct {
 	Id   string `path:"id" doc:"Id of brand."`
 	Body struct {
 		Name        *string `json:"name,omitempty" minLength:"1" doc:"Name of brand (unique)."`
+		Slug        *string `json:"slug,omitempty" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Slug of brand (unique), generated from new name if empty when renaming."`
 		Description *string `json:"description,omitempty" minLength:"1" doc:"Description of brand."`
+		Status      *string `json:"status,omitempty" enum:"DRAFT,PUBLISHED,ARCHIVED" doc:"Status of brand, archiving requires no published products of brand."`
 	}
 }
 
 type DeleteBrandByIdRequest struct {
 	Id string `path:"id" doc:"Id of brand."`
 }
+
+type RestoreBrandByIdRequest struct {
+	Id string `path:"id" doc:"Id of brand."`
+}
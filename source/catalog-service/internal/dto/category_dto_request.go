@@ -4,23 +4,52 @@ type GetAllCategoriesRequest struct {
 	SortBy string `query:"sort_by" default:"created_at:asc" example:"created_at:desc,name" doc:"Sort by one or more fields separated by commas. For example: sort_by=created_at:desc,name will sort by created_at in descending order, then by name in ascending order."`
 }
 
+type GetArchivedCategoriesRequest struct {
+	SortBy string `query:"sort_by" default:"deleted_at:desc" example:"deleted_at:desc,name" doc:"Sort by one or more fields separated by commas. For example: sort_by=deleted_at:desc,name will sort by deleted_at in descending order, then by name in ascending order."`
+}
+
 type GetCategoryByIdRequest struct {
 	Id string `path:"id" doc:"Id of category."`
 }
 
+type GetCategoryBySlugRequest struct {
+	Slug string `path:"slug" doc:"Slug of category, a former slug is redirected to the current one."`
+}
+
 type CreateCategoryRequest struct {
 	Body struct {
-		Name string `json:"name" required:"true" minLength:"1" doc:"Name of category (unique)."`
+		Name              string `json:"name" required:"true" minLength:"1" doc:"Name of category (unique)."`
+		Slug              string `json:"slug,omitempty" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Slug of category (unique), generated from name if empty."`
+		ParentId          string `json:"parent_id,omitempty" doc:"Parent id of category, empty for root category."`
+		SortOrder         int32  `json:"sort_order,omitempty" doc:"Order of category among its siblings."`
+		Status            string `json:"status,omitempty" default:"PUBLISHED" enum:"DRAFT,PUBLISHED" doc:"Status of category, only published categories are shown publicly."`
+		LowStockThreshold *int32 `json:"low_stock_threshold,omitempty" minimum:"0" doc:"Default low stock threshold of products in category and its subcategories, empty uses threshold of parent category."`
 	}
 }
 
 type UpdateCategoryByIdRequest struct {
 	Id   string `path:"id" doc:"Id of category."`
 	Body struct {
-		Name *string `json:"name,omitempty" minLength:"1" doc:"Name of category (unique)."`
+		Name              *string `json:"name,omitempty" minLength:"1" doc:"Name of category (unique)."`
+		Slug              *string `json:"slug,omitempty" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Slug of category (unique), generated from new name if empty when renaming."`
+		SortOrder         *int32  `json:"sort_order,omitempty" doc:"Order of category among its siblings."`
+		Status            *string `json:"status,omitempty" enum:"DRAFT,PUBLISHED,ARCHIVED" doc:"Status of category, archiving requires no published products and no unarchived child categories."`
+		LowStockThreshold *int32  `json:"low_stock_threshold,omitempty" minimum:"-1" doc:"Default low stock threshold of products in category and its subcategories, -1 clears it so that threshold of parent category is used."`
+	}
+}
+
+type MoveCategoryByIdRequest struct {
+	Id   string `path:"id" doc:"Id of category."`
+	Body struct {
+		ParentId  string `json:"parent_id" required:"true" doc:"New parent id of category, empty to move category to root."`
+		SortOrder *int32 `json:"sort_order,omitempty" doc:"Order of category among its new siblings."`
 	}
 }
 
 type DeleteCategoryByIdRequest struct {
 	Id string `path:"id" doc:"Id of category."`
 }
+
+type RestoreCategoryByIdRequest struct {
+	Id string `path:"id" doc:"Id of category."`
+}
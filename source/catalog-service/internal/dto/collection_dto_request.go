@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type GetCollectionsRequest struct {
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:desc" pattern:"^(name|start_time|end_time|created_at)(:(asc|desc))?(,(name|start_time|end_time|created_at)(:(asc|desc))?)*$" example:"start_time:desc" doc:"Sort by one or more fields (name, start_time, end_time, created_at) separated by commas."`
+	// Filter
+	Status string `query:"status" enum:"DRAFT,PUBLISHED" example:"PUBLISHED" doc:"Filter by status, only for admin and staff, customers only see live collections."`
+}
+
+type GetCollectionByIdRequest struct {
+	Id string `path:"id" doc:"Id of collection."`
+}
+
+type GetCollectionProductsRequest struct {
+	Id     string `path:"id" doc:"Id of collection."`
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"20" minimum:"1" maximum:"100" example:"20" doc:"Limit item from offset."`
+}
+
+// Same filters as product search, products matching them follow hand-picked products of collection
+type CollectionRuleRequest struct {
+	CategoryId    string `json:"category_id,omitempty" doc:"Products of category and its subcategories."`
+	BrandId       string `json:"brand_id,omitempty" doc:"Products of brand."`
+	Sex           string `json:"sex,omitempty" enum:"MALE,FEMALE,UNISEX" doc:"Products for sex."`
+	FinalPriceGte *int64 `json:"final_price_gte,omitempty" minimum:"0" doc:"Final price (after discount) greater than or equals."`
+	FinalPriceLte *int64 `json:"final_price_lte,omitempty" minimum:"0" doc:"Final price (after discount) less than or equals."`
+	Attributes    string `json:"attributes,omitempty" pattern:"^[a-z][a-z0-9_]*:[^,:]+(,[a-z][a-z0-9_]*:[^,:]+)*$" example:"material:cotton|linen" doc:"Attribute filters, same format as product search."`
+	Tags          string `json:"tags,omitempty" example:"summer,basic" doc:"Tags separated by commas, a product matches any of them."`
+	SortBy        string `json:"sort_by,omitempty" default:"created_at:desc" pattern:"^(name|price|final_price|discount_percentage|stock|average_rating|rating_count|created_at|updated_at)(:(asc|desc))?(,(name|price|final_price|discount_percentage|stock|average_rating|rating_count|created_at|updated_at)(:(asc|desc))?)*$" example:"final_price:asc" doc:"Order of matched products by one or more fields separated by commas."`
+	Limit         int32  `json:"limit,omitempty" default:"20" minimum:"1" maximum:"100" doc:"Max products matched by rule."`
+}
+
+type CreateCollectionRequest struct {
+	Body struct {
+		Name        string                 `json:"name" required:"true" minLength:"1" doc:"Name of collection."`
+		Description string                 `json:"description,omitempty" doc:"Description of collection."`
+		Status      string                 `json:"status,omitempty" default:"DRAFT" enum:"DRAFT,PUBLISHED" doc:"Status of collection, only published collections are public."`
+		StartTime   *time.Time             `json:"start_time,omitempty" doc:"Collection is public from start time, empty is right away."`
+		EndTime     *time.Time             `json:"end_time,omitempty" doc:"Collection is public until end time, empty is forever."`
+		Rule        *CollectionRuleRequest `json:"rule,omitempty" doc:"Rule adding products automatically, empty is hand-picked products only."`
+	}
+}
+
+type UpdateCollectionByIdRequest struct {
+	Id   string `path:"id" doc:"Id of collection."`
+	Body struct {
+		Name           *string                `json:"name,omitempty" minLength:"1" doc:"Name of collection."`
+		Description    *string                `json:"description,omitempty" doc:"Description of collection."`
+		Status         *string                `json:"status,omitempty" enum:"DRAFT,PUBLISHED" doc:"Status of collection, only published collections are public."`
+		StartTime      *time.Time             `json:"start_time,omitempty" doc:"Collection is public from start time."`
+		EndTime        *time.Time             `json:"end_time,omitempty" doc:"Collection is public until end time."`
+		RemoveSchedule bool                   `json:"remove_schedule,omitempty" doc:"Clear start time and end time, collection is public whenever published."`
+		Rule           *CollectionRuleRequest `json:"rule,omitempty" doc:"Rule adding products automatically, it replaces the current one."`
+		RemoveRule     bool                   `json:"remove_rule,omitempty" doc:"Clear rule, collection keeps hand-picked products only."`
+	}
+}
+
+type UpdateCollectionProductsRequest struct {
+	Id   string `path:"id" doc:"Id of collection."`
+	Body struct {
+		ProductIds []string `json:"product_ids" required:"true" maxItems:"500" doc:"Ids of hand-picked products in collection order, it replaces the current ones."`
+	}
+}
+
+type UploadCollectionBannerRequest struct {
+	Id      string `path:"id" doc:"Id of collection."`
+	RawBody huma.MultipartFormFiles[struct {
+		File huma.FormFile `form:"file" contentType:"image/jpeg,image/png,image/gif" required:"true" doc:"Banner image file (JPEG, PNG or GIF)."`
+	}]
+}
+
+type DeleteCollectionBannerRequest struct {
+	Id string `path:"id" doc:"Id of collection."`
+}
+
+type DeleteCollectionByIdRequest struct {
+	Id string `path:"id" doc:"Id of collection."`
+}
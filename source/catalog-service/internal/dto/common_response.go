@@ -14,6 +14,18 @@ type PaginationBodyResponseList[T any] struct {
 	}
 }
 
+// Facets count values of attributes and tags among all matched products, not only the current page
+type SearchPaginationBodyResponseList[T any, F any] struct {
+	SearchId string `header:"X-Search-Id" doc:"Id of search, send it back when clicking a product of result."`
+	Body     struct {
+		Code    string `json:"code" example:"string"`
+		Message string `json:"message" example:"string"`
+		Data    []T    `json:"data"`
+		Total   int    `json:"total" example:"1"`
+		Facets  []F    `json:"facets"`
+	}
+}
+
 type BodyResponse[T any] struct {
 	Body struct {
 		Code    string `json:"code" example:"string"`
@@ -22,6 +34,17 @@ type BodyResponse[T any] struct {
 	}
 }
 
+// Lookup by a former slug is answered with 301 and Location of the current slug, body still carries data
+type SlugBodyResponse[T any] struct {
+	Status   int
+	Location string `header:"Location" doc:"Path of current slug when a former slug is requested."`
+	Body     struct {
+		Code    string `json:"code" example:"string"`
+		Message string `json:"message" example:"string"`
+		Data    T      `json:"data"`
+	}
+}
+
 //
 //
 // Create, Update and Delete response
@@ -0,0 +1,16 @@
+package dto
+
+type GetLowStockProductsRequest struct {
+	Limit int32 `query:"limit" default:"50" minimum:"1" maximum:"200" example:"50" doc:"Limit item of report."`
+	// Filter
+	IncludeAll bool `query:"include_all" default:"false" doc:"Include published products above their threshold too, for reviewing thresholds."`
+}
+
+type GetReorderSuggestionsRequest struct {
+	Days         int32 `query:"days" default:"30" minimum:"1" maximum:"365" example:"30" doc:"Sales velocity is units sold per day over the last days."`
+	LeadTimeDays int32 `query:"lead_time_days" default:"7" minimum:"0" maximum:"365" example:"7" doc:"Days until reordered stock arrives."`
+	CoverDays    int32 `query:"cover_days" default:"30" minimum:"1" maximum:"365" example:"30" doc:"Days reordered stock should last after it arrives."`
+	Limit        int32 `query:"limit" default:"50" minimum:"1" maximum:"200" example:"50" doc:"Limit item of report."`
+	// Filter
+	IncludeAll bool `query:"include_all" default:"false" doc:"Suggest for all published products, not only products at or below their threshold."`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type GetProductsRequest struct {
 	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
 	Limit  int32  `query:"limit" default:"5" minimum:"1" maximum:"10" example:"10" doc:"Limit item from offset."`
@@ -13,62 +15,163 @@ type GetProductsRequest struct {
 	Sex                   string `query:"sex" example:"MALE" enum:"MALE,FEMALE,UNISEX" doc:"Search by sexx."`
 	PriceGTE              string `query:"price_gte" pattern:"^[0-9]+$" example:"100000" doc:"Search by price greater than or equals."`
 	PriceLTE              string `query:"price_lte" pattern:"^[0-9]+$" example:"200000" doc:"Search by price less than or equals."`
+	FinalPriceGTE         string `query:"final_price_gte" pattern:"^[0-9]+$" example:"100000" doc:"Search by final price (after discount) greater than or equals."`
+	FinalPriceLTE         string `query:"final_price_lte" pattern:"^[0-9]+$" example:"200000" doc:"Search by final price (after discount) less than or equals."`
 	DiscountPercentageGTE string `query:"discount_percentage_gte" pattern:"^[0-9]+$" example:"20" doc:"Search by discount percentage greater than or equals."`
 	DiscountPercentageLTE string `query:"discount_percentage_lte" pattern:"^[0-9]+$" example:"30" doc:"Search by discount percentage less than or equals."`
 	StockGTE              string `query:"stock_gte" pattern:"^[0-9]+$" example:"50" doc:"Search by stock greater than or equals."`
 	StockLTE              string `query:"stock_lte" pattern:"^[0-9]+$" example:"100" doc:"Search by stock less than or equals."`
+	AverageRatingGTE      string `query:"average_rating_gte" pattern:"^[0-5](\\.[0-9]+)?$" example:"4" doc:"Search by average rating greater than or equals."`
+	RatingCountGTE        string `query:"rating_count_gte" pattern:"^[0-9]+$" example:"10" doc:"Search by rating count greater than or equals."`
 	CategoryName          string `query:"category_name" example:"Quần" doc:"Search by category name."`
 	BrandName             string `query:"brand_name" example:"Gucci" doc:"Search by brand name."`
 	CreatedAtGTE          string `query:"created_at_gte" example:"2024-01-15T00:00:00" doc:"Search by created_at greater than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
 	CreatedAtLTE          string `query:"created_at_lte" example:"2024-02-05T23:59:59" doc:"Search by created_at less than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+	Attributes            string `query:"attributes" pattern:"^[a-z][a-z0-9_]*:[^,:]+(,[a-z][a-z0-9_]*:[^,:]+)*$" example:"material:cotton|linen,length:60..80" doc:"Filter by attributes separated by commas, a product matches any of values separated by '|' or a number range min..max (either side may be empty)."`
+	Tags                  string `query:"tags" example:"summer,basic" doc:"Filter by tags separated by commas, a product matches any of them."`
+}
+
+type GetTopProductsRequest struct {
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item of leaderboard."`
+	Period string `query:"period" default:"all" enum:"all,7d,30d" example:"7d" doc:"Count units sold all-time or in the last 7/30 days."`
+	// Filter
+	CategoryId string `query:"category_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by category id, cannot be combined with brand id."`
+	BrandId    string `query:"brand_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by brand id, cannot be combined with category id."`
+}
+
+type GetTrendingProductsRequest struct {
+	Limit int32 `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item of leaderboard."`
+	// Filter
+	CategoryId string `query:"category_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by category id, cannot be combined with brand id."`
+	BrandId    string `query:"brand_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by brand id, cannot be combined with category id."`
+}
+
+type GetArchivedProductsRequest struct {
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"deleted_at:desc" pattern:"^(deleted_at|created_at|name|price)(:(asc|desc))?(,(deleted_at|created_at|name|price)(:(asc|desc))?)*$" example:"deleted_at:desc" doc:"Sort by one or more fields (deleted_at, created_at, name, price) separated by commas."`
 }
 
 type GetProductByIdRequest struct {
 	Id string `path:"id" doc:"Id of broduct."`
 }
 
+type GetProductBySlugRequest struct {
+	Slug string `path:"slug" doc:"Slug of product, a former slug is redirected to the current one."`
+}
+
+type ClickProductRequest struct {
+	Id       string `path:"id" doc:"Id of broduct."`
+	SearchId string `query:"search_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Id of search (X-Search-Id header of /products) which product was clicked from."`
+}
+
+type GetSearchReportRequest struct {
+	Type  string
+	Limit int32 `query:"limit" default:"10" minimum:"1" maximum:"100" example:"10" doc:"Limit query of report."`
+	// Search
+	CreatedAtGTE string `query:"created_at_gte" example:"2024-01-15T00:00:00" doc:"Search by created_at greater than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+	CreatedAtLTE string `query:"created_at_lte" example:"2024-02-05T23:59:59" doc:"Search by created_at less than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+}
+
+type GetProductRecommendationsRequest struct {
+	Id    string `path:"id" doc:"Id of broduct."`
+	Type  string `query:"type" default:"all" enum:"all,similar,bought_together" example:"similar" doc:"Kind of recommendations, similar items, frequently bought together or both."`
+	Limit int32  `query:"limit" default:"5" minimum:"1" maximum:"20" example:"5" doc:"Limit item of each kind of recommendations."`
+}
+
+type GetProductPriceHistoryRequest struct {
+	Id     string `path:"id" doc:"Id of broduct."`
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:desc" pattern:"^(created_at|price|final_price)(:(asc|desc))?(,(created_at|price|final_price)(:(asc|desc))?)*$" example:"created_at:desc" doc:"Sort by one or more fields (created_at, price, final_price) separated by commas."`
+}
+
 type CreateProductRequest struct {
 	Body struct {
-		Name               string `json:"name" required:"true" minLength:"1" doc:"Name of product."`
-		Description        string `json:"description" required:"true" minLength:"1" doc:"Description of product."`
-		Sex                string `json:"sex" required:"true" minLength:"1" enum:"MALE,FEMALE,UNISEX" doc:"Sex of product."`
-		Price              int64  `json:"price" required:"true" minimum:"0" doc:"Price of product."`
-		DiscountPercentage int32  `json:"discount_percentage" required:"true" minimum:"0" maximum:"100" doc:"Discount percentage of product."`
-		Stock              int32  `json:"stock" required:"true" minimum:"0" doc:"Stock of product."`
-		ImageURL           string `json:"image_url" required:"true" minLength:"1" doc:"Image URL of product."`
-		CategoryId         string `json:"category_id" required:"true" minLength:"1" doc:"Category id of product."`
-		BrandId            string `json:"brand_id" required:"true" minLength:"1" doc:"Brand id of product."`
+		Sku                string                    `json:"sku,omitempty" pattern:"^[A-Za-z0-9][A-Za-z0-9._-]*$" maxLength:"64" doc:"SKU of product (unique), generated if empty."`
+		Slug               string                    `json:"slug,omitempty" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Slug of product (unique), generated from name if empty."`
+		Name               string                    `json:"name" required:"true" minLength:"1" doc:"Name of product."`
+		Description        string                    `json:"description" required:"true" minLength:"1" doc:"Description of product."`
+		Sex                string                    `json:"sex" required:"true" minLength:"1" enum:"MALE,FEMALE,UNISEX" doc:"Sex of product."`
+		Price              int64                     `json:"price" required:"true" minimum:"0" doc:"Price of product."`
+		DiscountPercentage int32                     `json:"discount_percentage" required:"true" minimum:"0" maximum:"100" doc:"Discount percentage of product."`
+		Stock              int32                     `json:"stock" required:"true" minimum:"0" doc:"Stock of product."`
+		WarehouseId        string                    `json:"warehouse_id,omitempty" doc:"Id of warehouse receiving initial stock, default warehouse if empty."`
+		ImageURL           string                    `json:"image_url,omitempty" doc:"Image URL of product, replaced by primary image once images are uploaded."`
+		CategoryId         string                    `json:"category_id" required:"true" minLength:"1" doc:"Category id of product."`
+		BrandId            string                    `json:"brand_id" required:"true" minLength:"1" doc:"Brand id of product."`
+		Status             string                    `json:"status,omitempty" default:"PUBLISHED" enum:"DRAFT,PUBLISHED" doc:"Status of product, only published products are searchable and purchasable."`
+		Attributes         map[string]string         `json:"attributes,omitempty" doc:"Attribute values of product by attribute code, attributes must be in attribute set of category."`
+		Tags               []string                  `json:"tags,omitempty" maxItems:"20" doc:"Free tags of product."`
+		LowStockThreshold  *int32                    `json:"low_stock_threshold,omitempty" minimum:"0" doc:"Product is low on stock at or below threshold, empty uses threshold of category."`
+		BackorderPolicy    string                    `json:"backorder_policy,omitempty" default:"NONE" enum:"NONE,PRE_ORDER,BACKORDER" doc:"Pre-order and backorder products can be bought beyond stock, units beyond stock are allocated when stock is replenished."`
+		ExpectedShipDate   *time.Time                `json:"expected_ship_date,omitempty" doc:"Expected ship date of units beyond stock, required for pre-order."`
+		BackorderLimit     int32                     `json:"backorder_limit,omitempty" minimum:"0" doc:"Max units beyond stock waiting for allocation, 0 is unlimited."`
+		Type               string                    `json:"type,omitempty" default:"SIMPLE" enum:"SIMPLE,BUNDLE" doc:"Type of product, bundle is sold as a unit of its components and its stock is derived from them."`
+		BundlePricing      string                    `json:"bundle_pricing,omitempty" enum:"FIXED,PERCENTAGE_OFF" doc:"Pricing of bundle, FIXED uses price, PERCENTAGE_OFF takes discount percentage off sum of prices of components (required for bundle)."`
+		BundleComponents   []*BundleComponentRequest `json:"bundle_components,omitempty" maxItems:"20" doc:"Components of bundle (required for bundle)."`
 	}
 }
 
 type UpdateProductByIdRequest struct {
 	Id   string `path:"id" doc:"Id of broduct."`
 	Body struct {
-		Name               *string `json:"name,omitempty" minLength:"1" doc:"Name of broduct."`
-		Description        *string `json:"description,omitempty" minLength:"1" doc:"Description of broduct."`
-		Sex                *string `json:"sex,omitempty" minLength:"1" enum:"MALE,FEMALE,UNISEX" doc:"Sex of product."`
-		Price              *int64  `json:"price,omitempty" minimum:"0" doc:"Price of product."`
-		DiscountPercentage *int32  `json:"discount_percentage,omitempty" minimum:"0" maximum:"100" doc:"Discount percentage of product."`
-		Stock              *int32  `json:"stock,omitempty" minimum:"0" doc:"Stock of product."`
-		ImageURL           *string `json:"image_url,omitempty" minLength:"1" doc:"Image URL of product."`
-		CategoryId         *string `json:"category_id,omitempty" minLength:"1" doc:"Category id of product."`
-		BrandId            *string `json:"brand_id,omitempty" minLength:"1" doc:"Brand id of product."`
+		Sku                *string                    `json:"sku,omitempty" pattern:"^[A-Za-z0-9][A-Za-z0-9._-]*$" maxLength:"64" doc:"SKU of product (unique)."`
+		Slug               *string                    `json:"slug,omitempty" pattern:"^[a-z0-9]+(-[a-z0-9]+)*$" doc:"Slug of product (unique), generated from new name if empty when renaming."`
+		Name               *string                    `json:"name,omitempty" minLength:"1" doc:"Name of broduct."`
+		Description        *string                    `json:"description,omitempty" minLength:"1" doc:"Description of broduct."`
+		Sex                *string                    `json:"sex,omitempty" minLength:"1" enum:"MALE,FEMALE,UNISEX" doc:"Sex of product."`
+		Price              *int64                     `json:"price,omitempty" minimum:"0" doc:"Price of product."`
+		DiscountPercentage *int32                     `json:"discount_percentage,omitempty" minimum:"0" maximum:"100" doc:"Discount percentage of product."`
+		Stock              *int32                     `json:"stock,omitempty" minimum:"0" doc:"Stock of product."`
+		WarehouseId        string                     `json:"warehouse_id,omitempty" doc:"Id of warehouse taking difference of stock, if empty increase goes to default warehouse and decrease is taken from default warehouse first, then other warehouses."`
+		ImageURL           *string                    `json:"image_url,omitempty" minLength:"1" doc:"Image URL of product."`
+		CategoryId         *string                    `json:"category_id,omitempty" minLength:"1" doc:"Category id of product."`
+		BrandId            *string                    `json:"brand_id,omitempty" minLength:"1" doc:"Brand id of product."`
+		Status             *string                    `json:"status,omitempty" enum:"DRAFT,PUBLISHED,ARCHIVED" doc:"Status of product, only published products are searchable and purchasable."`
+		Attributes         *map[string]string         `json:"attributes,omitempty" doc:"Attribute values of product by attribute code, they replace the current ones."`
+		Tags               *[]string                  `json:"tags,omitempty" maxItems:"20" doc:"Free tags of product, they replace the current ones."`
+		LowStockThreshold  *int32                     `json:"low_stock_threshold,omitempty" minimum:"-1" doc:"Product is low on stock at or below threshold, -1 clears it so that threshold of category is used."`
+		BackorderPolicy    *string                    `json:"backorder_policy,omitempty" enum:"NONE,PRE_ORDER,BACKORDER" doc:"Pre-order and backorder products can be bought beyond stock, units already waiting are still allocated after switching to NONE."`
+		ExpectedShipDate   *time.Time                 `json:"expected_ship_date,omitempty" doc:"Expected ship date of units beyond stock, required for pre-order."`
+		BackorderLimit     *int32                     `json:"backorder_limit,omitempty" minimum:"0" doc:"Max units beyond stock waiting for allocation, 0 is unlimited."`
+		BundlePricing      *string                    `json:"bundle_pricing,omitempty" enum:"FIXED,PERCENTAGE_OFF" doc:"Pricing of bundle, FIXED uses price, PERCENTAGE_OFF takes discount percentage off sum of prices of components."`
+		BundleComponents   *[]*BundleComponentRequest `json:"bundle_components,omitempty" maxItems:"20" doc:"Components of bundle, they replace the current ones."`
 	}
 }
 
+type BundleComponentRequest struct {
+	ProductId string `json:"product_id" required:"true" minLength:"1" doc:"Id of component product."`
+	Quantity  int32  `json:"quantity" required:"true" minimum:"1" doc:"Quantity of component in one unit of bundle."`
+}
+
 type DeleteProductByIdRequest struct {
 	Id string `path:"id" doc:"Id of broduct."`
 }
 
+type RestoreProductByIdRequest struct {
+	Id string `path:"id" doc:"Id of broduct."`
+}
+
 type GetProductsByListIdRequest struct {
 	Ids []string
 }
 
 type UpdateProductStocksByListInvoiceDetailRequest struct {
-	InvoiceDetails []InvoiceDetail
+	InvoiceDetails   []InvoiceDetail
+	InvoiceId        string
+	ActorId          string
+	ShippingLocation *Location
 }
 
 type InvoiceDetail struct {
-	ProductId string
-	Quantity  int32
+	ProductId   string
+	Quantity    int32
+	WarehouseId string
+}
+
+// Shipping location of invoice, used by nearest stock allocation strategy
+type Location struct {
+	Latitude  float64
+	Longitude float64
 }
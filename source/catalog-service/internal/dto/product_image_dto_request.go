@@ -0,0 +1,35 @@
+package dto
+
+import "github.com/danielgtaylor/huma/v2"
+
+type GetProductImagesRequest struct {
+	Id string `path:"id" doc:"Id of broduct."`
+	// Filter
+	Variant string `query:"variant" example:"black" doc:"Filter by variant of image."`
+}
+
+type UploadProductImageRequest struct {
+	Id      string `path:"id" doc:"Id of broduct."`
+	RawBody huma.MultipartFormFiles[struct {
+		File      huma.FormFile `form:"file" contentType:"image/jpeg,image/png,image/gif" required:"true" doc:"Image file (JPEG, PNG or GIF)."`
+		Variant   string        `form:"variant" doc:"Variant which image belongs to (color, style, ...), empty for product itself."`
+		IsPrimary bool          `form:"is_primary" doc:"Mark image as primary image of product."`
+	}]
+}
+
+type ReorderProductImagesRequest struct {
+	Id   string `path:"id" doc:"Id of broduct."`
+	Body struct {
+		ImageIds []string `json:"image_ids" required:"true" minItems:"1" doc:"Ids of all images of product in new gallery order."`
+	}
+}
+
+type SetPrimaryProductImageRequest struct {
+	Id      string `path:"id" doc:"Id of broduct."`
+	ImageId string `path:"image_id" doc:"Id of image."`
+}
+
+type DeleteProductImageRequest struct {
+	Id      string `path:"id" doc:"Id of broduct."`
+	ImageId string `path:"image_id" doc:"Id of image."`
+}
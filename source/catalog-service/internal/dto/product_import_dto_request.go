@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/danielgtaylor/huma/v2"
+
+type CreateProductImportJobRequest struct {
+	RawBody huma.MultipartFormFiles[struct {
+		File   huma.FormFile `form:"file" contentType:"text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/octet-stream" required:"true" doc:"CSV or XLSX file, first row is header with columns sku, name, description, sex, price, discount_percentage, stock, image_url, category, brand, status."`
+		DryRun bool          `form:"dry_run" doc:"Only validate rows and report what would be created or updated."`
+	}]
+}
+
+type GetProductImportJobsRequest struct {
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:desc" pattern:"^(created_at|finished_at)(:(asc|desc))?(,(created_at|finished_at)(:(asc|desc))?)*$" example:"created_at:desc" doc:"Sort by one or more fields (created_at, finished_at) separated by commas."`
+}
+
+type GetProductImportJobByIdRequest struct {
+	Id string `path:"id" doc:"Id of product import job."`
+}
+
+type ExportProductsRequest struct {
+	Format string `query:"format" default:"csv" enum:"csv,xlsx" example:"xlsx" doc:"Format of exported file."`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+type GetPromotionsRequest struct {
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"start_time:desc" pattern:"^(start_time|end_time|discount_percentage|created_at)(:(asc|desc))?(,(start_time|end_time|discount_percentage|created_at)(:(asc|desc))?)*$" example:"start_time:desc" doc:"Sort by one or more fields (start_time, end_time, discount_percentage, created_at) separated by commas."`
+	// Filter
+	Status string `query:"status" enum:"SCHEDULED,ACTIVE,EXPIRED,CANCELLED" example:"ACTIVE" doc:"Filter by status."`
+}
+
+type GetPromotionByIdRequest struct {
+	Id string `path:"id" doc:"Id of promotion."`
+}
+
+type CreatePromotionRequest struct {
+	Body struct {
+		Name               string    `json:"name" required:"true" minLength:"1" doc:"Name of promotion."`
+		Description        string    `json:"description,omitempty" doc:"Description of promotion."`
+		DiscountPercentage int32     `json:"discount_percentage" required:"true" minimum:"1" maximum:"100" doc:"Discount percentage of promotion, it applies only when greater than discount of product."`
+		TargetType         string    `json:"target_type" required:"true" enum:"PRODUCT,CATEGORY,BRAND" doc:"Type of promotion targets, category target includes its subcategories."`
+		TargetIds          []string  `json:"target_ids" required:"true" minItems:"1" doc:"Ids of products, categories or brands discounted by promotion."`
+		StartTime          time.Time `json:"start_time" required:"true" doc:"Start time of promotion."`
+		EndTime            time.Time `json:"end_time" required:"true" doc:"End time of promotion."`
+		PerUserLimit       int32     `json:"per_user_limit,omitempty" minimum:"0" doc:"Max units each user buys under promotion, 0 is unlimited."`
+		StockAllotment     int32     `json:"stock_allotment,omitempty" minimum:"0" doc:"Max units sold under promotion (flash sale), 0 is unlimited."`
+	}
+}
+
+type UpdatePromotionByIdRequest struct {
+	Id   string `path:"id" doc:"Id of promotion."`
+	Body struct {
+		Name               *string    `json:"name,omitempty" minLength:"1" doc:"Name of promotion."`
+		Description        *string    `json:"description,omitempty" doc:"Description of promotion."`
+		DiscountPercentage *int32     `json:"discount_percentage,omitempty" minimum:"1" maximum:"100" doc:"Discount percentage of promotion, only before it starts."`
+		TargetType         *string    `json:"target_type,omitempty" enum:"PRODUCT,CATEGORY,BRAND" doc:"Type of promotion targets, only before it starts."`
+		TargetIds          []string   `json:"target_ids,omitempty" minItems:"1" doc:"Ids of products, categories or brands discounted by promotion, only before it starts."`
+		StartTime          *time.Time `json:"start_time,omitempty" doc:"Start time of promotion, only before it starts."`
+		EndTime            *time.Time `json:"end_time,omitempty" doc:"End time of promotion."`
+		PerUserLimit       *int32     `json:"per_user_limit,omitempty" minimum:"0" doc:"Max units each user buys under promotion, 0 is unlimited."`
+		StockAllotment     *int32     `json:"stock_allotment,omitempty" minimum:"0" doc:"Max units sold under promotion (flash sale), 0 is unlimited."`
+	}
+}
+
+type CancelPromotionByIdRequest struct {
+	Id string `path:"id" doc:"Id of promotion."`
+}
+
+type DeletePromotionByIdRequest struct {
+	Id string `path:"id" doc:"Id of promotion."`
+}
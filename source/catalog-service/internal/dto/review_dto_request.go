@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/danielgtaylor/huma/v2"
+
+type GetProductReviewsRequest struct {
+	Id     string `path:"id" doc:"Id of broduct."`
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"5" minimum:"1" maximum:"20" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:desc" pattern:"^(created_at|rating)(:(asc|desc))?(,(created_at|rating)(:(asc|desc))?)*$" example:"rating:desc,created_at:desc" doc:"Sort by one or more fields (created_at, rating) separated by commas."`
+	// Filter
+	Rating       string `query:"rating" enum:"1,2,3,4,5" example:"5" doc:"Filter by rating."`
+	VerifiedOnly bool   `query:"verified_only" example:"true" doc:"Only get reviews of verified purchases."`
+}
+
+type CreateReviewRequest struct {
+	Id      string `path:"id" doc:"Id of broduct."`
+	RawBody huma.MultipartFormFiles[struct {
+		Rating int32           `form:"rating" required:"true" minimum:"1" maximum:"5" doc:"Rating of product from 1 to 5 stars."`
+		Title  string          `form:"title" required:"true" minLength:"1" maxLength:"200" doc:"Title of review."`
+		Body   string          `form:"body" maxLength:"5000" doc:"Body of review."`
+		Photos []huma.FormFile `form:"photos" contentType:"image/jpeg,image/png,image/gif" doc:"Optional photos of review (at most 5)."`
+	}]
+}
+
+type DeleteReviewByIdRequest struct {
+	Id string `path:"id" doc:"Id of review."`
+}
+
+type GetReviewsRequest struct {
+	Offset int32  `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int32  `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:asc" pattern:"^(created_at|rating)(:(asc|desc))?(,(created_at|rating)(:(asc|desc))?)*$" example:"created_at:asc" doc:"Sort by one or more fields (created_at, rating) separated by commas."`
+	// Filter
+	Status    string `query:"status" default:"PENDING" enum:"PENDING,APPROVED,REJECTED" example:"PENDING" doc:"Filter by moderation status."`
+	ProductId string `query:"product_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by product id."`
+	UserId    string `query:"user_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by user id."`
+}
+
+type ModerateReviewByIdRequest struct {
+	Id   string `path:"id" doc:"Id of review."`
+	Body struct {
+		Status string `json:"status" required:"true" enum:"APPROVED,REJECTED" doc:"New moderation status of review."`
+		Note   string `json:"note,omitempty" maxLength:"500" doc:"Moderation note, e.g. reason of rejection."`
+	}
+}
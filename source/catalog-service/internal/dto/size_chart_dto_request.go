@@ -0,0 +1,52 @@
+package dto
+
+type GetSizeChartsRequest struct {
+	// Filter
+	BrandId    string `query:"brand_id" doc:"Filter by brand."`
+	CategoryId string `query:"category_id" doc:"Filter by category, charts of its ancestors are not included."`
+}
+
+type GetSizeChartByIdRequest struct {
+	Id string `path:"id" doc:"Id of size chart."`
+}
+
+type SizeChartSizeRequest struct {
+	Label        string                         `json:"label" required:"true" minLength:"1" maxLength:"20" example:"M" doc:"Label of size, unique in chart."`
+	Measurements []*SizeChartMeasurementRequest `json:"measurements" required:"true" minItems:"1" doc:"Ranges of body measurements size fits, one range per measurement."`
+}
+
+type SizeChartMeasurementRequest struct {
+	Measurement string  `json:"measurement" required:"true" enum:"HEIGHT,CHEST,WAIST,HIP,INSEAM,FOOT_LENGTH" doc:"Body measurement."`
+	Min         float64 `json:"min" required:"true" exclusiveMinimum:"0" maximum:"300" example:"92" doc:"Min of body measurement in cm."`
+	Max         float64 `json:"max" required:"true" exclusiveMinimum:"0" maximum:"300" example:"100" doc:"Max of body measurement in cm."`
+}
+
+type CreateSizeChartRequest struct {
+	Body struct {
+		BrandId    string                  `json:"brand_id" required:"true" doc:"Brand of chart."`
+		CategoryId string                  `json:"category_id" required:"true" doc:"Category of chart, it sizes subcategories without chart of their own too."`
+		Name       string                  `json:"name" required:"true" minLength:"1" doc:"Name of chart."`
+		Sizes      []*SizeChartSizeRequest `json:"sizes" required:"true" minItems:"1" maxItems:"30" doc:"Sizes from smallest to largest."`
+	}
+}
+
+type UpdateSizeChartByIdRequest struct {
+	Id   string `path:"id" doc:"Id of size chart."`
+	Body struct {
+		Name  *string                 `json:"name,omitempty" minLength:"1" doc:"Name of chart."`
+		Sizes []*SizeChartSizeRequest `json:"sizes,omitempty" minItems:"1" maxItems:"30" doc:"Sizes from smallest to largest, they replace the current ones."`
+	}
+}
+
+type DeleteSizeChartByIdRequest struct {
+	Id string `path:"id" doc:"Id of size chart."`
+}
+
+type GetProductSizeChartRequest struct {
+	Id string `path:"id" doc:"Id of product."`
+}
+
+type GetProductFitRecommendationRequest struct {
+	Id     string `path:"id" doc:"Id of product."`
+	UserId string `query:"user_id" doc:"Recommend for user, only for admin and staff, others get recommendation for themselves."`
+}
@@ -0,0 +1,33 @@
+package dto
+
+type GetStockMovementsRequest struct {
+	Id     string `path:"id" doc:"Id of broduct."`
+	Offset int    `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" example:"20" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:desc" pattern:"^(created_at|quantity)(:(asc|desc))?(,(created_at|quantity)(:(asc|desc))?)*$" example:"created_at:desc" doc:"Sort by one or more fields (created_at, quantity) separated by commas."`
+	// Filter
+	Reason      string `query:"reason" enum:"PURCHASE,SALE,CANCEL_RESTORE,ADJUSTMENT,RETURN" example:"SALE" doc:"Filter by reason of stock movement."`
+	WarehouseId string `query:"warehouse_id" doc:"Filter by id of warehouse."`
+}
+
+type CreateStockMovementRequest struct {
+	Id   string `path:"id" doc:"Id of broduct."`
+	Body struct {
+		Quantity    int32  `json:"quantity" required:"true" doc:"Signed quantity added to stock, negative to remove stock (only for ADJUSTMENT)."`
+		Reason      string `json:"reason" required:"true" enum:"PURCHASE,ADJUSTMENT,RETURN" doc:"Reason of stock movement."`
+		WarehouseId string `json:"warehouse_id,omitempty" doc:"Id of warehouse, default warehouse if empty."`
+		InvoiceId   string `json:"invoice_id,omitempty" doc:"Id of returned invoice (for RETURN)."`
+		Note        string `json:"note,omitempty" maxLength:"500" doc:"Note of stock movement."`
+	}
+}
+
+type ReconcileStockMovementsRequest struct {
+	DryRun bool `query:"dry_run" example:"true" doc:"Only report warehouse stocks and products whose stock differs from ledger."`
+}
+
+type RestoreProductStocksByListInvoiceDetailRequest struct {
+	InvoiceDetails []InvoiceDetail
+	InvoiceId      string
+	ActorId        string
+	Reason         string
+}
@@ -0,0 +1,41 @@
+package dto
+
+type GetAllWarehousesRequest struct {
+	SortBy string `query:"sort_by" default:"priority:desc" pattern:"^(code|name|priority|created_at)(:(asc|desc))?(,(code|name|priority|created_at)(:(asc|desc))?)*$" example:"priority:desc,code" doc:"Sort by one or more fields (code, name, priority, created_at) separated by commas."`
+}
+
+type GetWarehouseByIdRequest struct {
+	Id string `path:"id" doc:"Id of warehouse."`
+}
+
+type CreateWarehouseRequest struct {
+	Body struct {
+		Code      string  `json:"code" required:"true" minLength:"1" maxLength:"20" doc:"Code of warehouse (unique)."`
+		Name      string  `json:"name" required:"true" minLength:"1" doc:"Name of warehouse."`
+		Address   string  `json:"address" required:"true" minLength:"1" doc:"Address of warehouse."`
+		Latitude  float64 `json:"latitude" required:"true" minimum:"-90" maximum:"90" doc:"Latitude of warehouse."`
+		Longitude float64 `json:"longitude" required:"true" minimum:"-180" maximum:"180" doc:"Longitude of warehouse."`
+		Priority  int32   `json:"priority" required:"true" minimum:"0" doc:"Priority of warehouse, higher is preferred when allocating stock."`
+	}
+}
+
+type UpdateWarehouseByIdRequest struct {
+	Id   string `path:"id" doc:"Id of warehouse."`
+	Body struct {
+		Code      *string  `json:"code,omitempty" minLength:"1" maxLength:"20" doc:"Code of warehouse (unique)."`
+		Name      *string  `json:"name,omitempty" minLength:"1" doc:"Name of warehouse."`
+		Address   *string  `json:"address,omitempty" minLength:"1" doc:"Address of warehouse."`
+		Latitude  *float64 `json:"latitude,omitempty" minimum:"-90" maximum:"90" doc:"Latitude of warehouse."`
+		Longitude *float64 `json:"longitude,omitempty" minimum:"-180" maximum:"180" doc:"Longitude of warehouse."`
+		Priority  *int32   `json:"priority,omitempty" minimum:"0" doc:"Priority of warehouse, higher is preferred when allocating stock."`
+		IsActive  *bool    `json:"is_active,omitempty" doc:"Inactive warehouse is not allocated for new invoices."`
+	}
+}
+
+type DeleteWarehouseByIdRequest struct {
+	Id string `path:"id" doc:"Id of warehouse."`
+}
+
+type GetWarehouseStocksByProductIdRequest struct {
+	Id string `path:"id" doc:"Id of broduct."`
+}
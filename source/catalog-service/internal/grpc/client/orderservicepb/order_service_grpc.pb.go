@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: order_service.proto
+
+package orderservicepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OrderServiceGRPC_StreamAllInvoices_FullMethodName     = "/orderservice.OrderServiceGRPC/StreamAllInvoices"
+	OrderServiceGRPC_CheckPurchasedProduct_FullMethodName = "/orderservice.OrderServiceGRPC/CheckPurchasedProduct"
+)
+
+// OrderServiceGRPCClient is the client API for OrderServiceGRPC service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrderServiceGRPCClient interface {
+	StreamAllInvoices(ctx context.Context, in *StreamAllInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllInvoicesResponse], error)
+	CheckPurchasedProduct(ctx context.Context, in *CheckPurchasedProductRequest, opts ...grpc.CallOption) (*CheckPurchasedProductResponse, error)
+}
+
+type orderServiceGRPCClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderServiceGRPCClient(cc grpc.ClientConnInterface) OrderServiceGRPCClient {
+	return &orderServiceGRPCClient{cc}
+}
+
+func (c *orderServiceGRPCClient) StreamAllInvoices(ctx context.Context, in *StreamAllInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllInvoicesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderServiceGRPC_ServiceDesc.Streams[0], OrderServiceGRPC_StreamAllInvoices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamAllInvoicesRequest, StreamAllInvoicesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderServiceGRPC_StreamAllInvoicesClient = grpc.ServerStreamingClient[StreamAllInvoicesResponse]
+
+func (c *orderServiceGRPCClient) CheckPurchasedProduct(ctx context.Context, in *CheckPurchasedProductRequest, opts ...grpc.CallOption) (*CheckPurchasedProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPurchasedProductResponse)
+	err := c.cc.Invoke(ctx, OrderServiceGRPC_CheckPurchasedProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderServiceGRPCServer is the server API for OrderServiceGRPC service.
+// All implementations must embed UnimplementedOrderServiceGRPCServer
+// for forward compatibility.
+type OrderServiceGRPCServer interface {
+	StreamAllInvoices(*StreamAllInvoicesRequest, grpc.ServerStreamingServer[StreamAllInvoicesResponse]) error
+	CheckPurchasedProduct(context.Context, *CheckPurchasedProductRequest) (*CheckPurchasedProductResponse, error)
+	mustEmbedUnimplementedOrderServiceGRPCServer()
+}
+
+// UnimplementedOrderServiceGRPCServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrderServiceGRPCServer struct{}
+
+func (UnimplementedOrderServiceGRPCServer) StreamAllInvoices(*StreamAllInvoicesRequest, grpc.ServerStreamingServer[StreamAllInvoicesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAllInvoices not implemented")
+}
+func (UnimplementedOrderServiceGRPCServer) CheckPurchasedProduct(context.Context, *CheckPurchasedProductRequest) (*CheckPurchasedProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPurchasedProduct not implemented")
+}
+func (UnimplementedOrderServiceGRPCServer) mustEmbedUnimplementedOrderServiceGRPCServer() {}
+func (UnimplementedOrderServiceGRPCServer) testEmbeddedByValue()                          {}
+
+// UnsafeOrderServiceGRPCServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderServiceGRPCServer will
+// result in compilation errors.
+type UnsafeOrderServiceGRPCServer interface {
+	mustEmbedUnimplementedOrderServiceGRPCServer()
+}
+
+func RegisterOrderServiceGRPCServer(s grpc.ServiceRegistrar, srv OrderServiceGRPCServer) {
+	// If the following call pancis, it indicates UnimplementedOrderServiceGRPCServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrderServiceGRPC_ServiceDesc, srv)
+}
+
+func _OrderServiceGRPC_StreamAllInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAllInvoicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceGRPCServer).StreamAllInvoices(m, &grpc.GenericServerStream[StreamAllInvoicesRequest, StreamAllInvoicesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderServiceGRPC_StreamAllInvoicesServer = grpc.ServerStreamingServer[StreamAllInvoicesResponse]
+
+func _OrderServiceGRPC_CheckPurchasedProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPurchasedProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceGRPCServer).CheckPurchasedProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderServiceGRPC_CheckPurchasedProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceGRPCServer).CheckPurchasedProduct(ctx, req.(*CheckPurchasedProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderServiceGRPC_ServiceDesc is the grpc.ServiceDesc for OrderServiceGRPC service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderServiceGRPC_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orderservice.OrderServiceGRPC",
+	HandlerType: (*OrderServiceGRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckPurchasedProduct",
+			Handler:    _OrderServiceGRPC_CheckPurchasedProduct_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAllInvoices",
+			Handler:       _OrderServiceGRPC_StreamAllInvoices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "order_service.proto",
+}
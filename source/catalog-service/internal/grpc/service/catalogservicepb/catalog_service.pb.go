@@ -22,26 +22,26 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type GetAllProductsRequest struct {
+type StreamAllProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllProductsRequest) Reset() {
-	*x = GetAllProductsRequest{}
+func (x *StreamAllProductsRequest) Reset() {
+	*x = StreamAllProductsRequest{}
 	mi := &file_catalog_service_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllProductsRequest) String() string {
+func (x *StreamAllProductsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllProductsRequest) ProtoMessage() {}
+func (*StreamAllProductsRequest) ProtoMessage() {}
 
-func (x *GetAllProductsRequest) ProtoReflect() protoreflect.Message {
+func (x *StreamAllProductsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_catalog_service_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -53,8 +53,8 @@ func (x *GetAllProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllProductsRequest.ProtoReflect.Descriptor instead.
-func (*GetAllProductsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamAllProductsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAllProductsRequest) Descriptor() ([]byte, []int) {
 	return file_catalog_service_proto_rawDescGZIP(), []int{0}
 }
 
@@ -102,28 +102,816 @@ func (x *GetProductByIdRequest) GetId() string {
 	return ""
 }
 
+type GetProductsByIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIdsRequest) Reset() {
+	*x = GetProductsByIdsRequest{}
+	mi := &file_catalog_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIdsRequest) ProtoMessage() {}
+
+func (x *GetProductsByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProductsByIdsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 type UpdateProductStocksByListInvoiceDetailRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceDetails   []*InvoiceDetail       `protobuf:"bytes,1,rep,name=invoice_details,json=invoiceDetails,proto3" json:"invoice_details,omitempty"`
+	InvoiceId        string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	ActorId          string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ShippingLocation *Location              `protobuf:"bytes,4,opt,name=shipping_location,json=shippingLocation,proto3" json:"shipping_location,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) Reset() {
+	*x = UpdateProductStocksByListInvoiceDetailRequest{}
+	mi := &file_catalog_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductStocksByListInvoiceDetailRequest) ProtoMessage() {}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductStocksByListInvoiceDetailRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductStocksByListInvoiceDetailRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) GetInvoiceDetails() []*InvoiceDetail {
+	if x != nil {
+		return x.InvoiceDetails
+	}
+	return nil
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailRequest) GetShippingLocation() *Location {
+	if x != nil {
+		return x.ShippingLocation
+	}
+	return nil
+}
+
+type RestoreProductStocksByListInvoiceDetailRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	InvoiceDetails []*InvoiceDetail       `protobuf:"bytes,1,rep,name=invoice_details,json=invoiceDetails,proto3" json:"invoice_details,omitempty"`
+	InvoiceId      string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	ActorId        string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UpdateProductStocksByListInvoiceDetailRequest) Reset() {
-	*x = UpdateProductStocksByListInvoiceDetailRequest{}
-	mi := &file_catalog_service_proto_msgTypes[2]
+func (x *RestoreProductStocksByListInvoiceDetailRequest) Reset() {
+	*x = RestoreProductStocksByListInvoiceDetailRequest{}
+	mi := &file_catalog_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductStocksByListInvoiceDetailRequest) ProtoMessage() {}
+
+func (x *RestoreProductStocksByListInvoiceDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductStocksByListInvoiceDetailRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductStocksByListInvoiceDetailRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailRequest) GetInvoiceDetails() []*InvoiceDetail {
+	if x != nil {
+		return x.InvoiceDetails
+	}
+	return nil
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetUnconfirmedAllocatedBackordersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnconfirmedAllocatedBackordersRequest) Reset() {
+	*x = GetUnconfirmedAllocatedBackordersRequest{}
+	mi := &file_catalog_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnconfirmedAllocatedBackordersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnconfirmedAllocatedBackordersRequest) ProtoMessage() {}
+
+func (x *GetUnconfirmedAllocatedBackordersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnconfirmedAllocatedBackordersRequest.ProtoReflect.Descriptor instead.
+func (*GetUnconfirmedAllocatedBackordersRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUnconfirmedAllocatedBackordersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ConfirmAllocatedBackorderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmAllocatedBackorderRequest) Reset() {
+	*x = ConfirmAllocatedBackorderRequest{}
+	mi := &file_catalog_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmAllocatedBackorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmAllocatedBackorderRequest) ProtoMessage() {}
+
+func (x *ConfirmAllocatedBackorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmAllocatedBackorderRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmAllocatedBackorderRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfirmAllocatedBackorderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// One page of products, pages are streamed in order of product id
+type StreamAllProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAllProductsResponse) Reset() {
+	*x = StreamAllProductsResponse{}
+	mi := &file_catalog_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAllProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAllProductsResponse) ProtoMessage() {}
+
+func (x *StreamAllProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAllProductsResponse.ProtoReflect.Descriptor instead.
+func (*StreamAllProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamAllProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type GetProductByIdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByIdResponse) Reset() {
+	*x = GetProductByIdResponse{}
+	mi := &file_catalog_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByIdResponse) ProtoMessage() {}
+
+func (x *GetProductByIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByIdResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByIdResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetProductByIdResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Products in order of requested ids, ids of unknown products are left out
+type GetProductsByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIdsResponse) Reset() {
+	*x = GetProductsByIdsResponse{}
+	mi := &file_catalog_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIdsResponse) ProtoMessage() {}
+
+func (x *GetProductsByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetProductsByIdsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type UpdateProductStocksByListInvoiceDetailResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StockAllocations []*StockAllocation     `protobuf:"bytes,1,rep,name=stock_allocations,json=stockAllocations,proto3" json:"stock_allocations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailResponse) Reset() {
+	*x = UpdateProductStocksByListInvoiceDetailResponse{}
+	mi := &file_catalog_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductStocksByListInvoiceDetailResponse) ProtoMessage() {}
+
+func (x *UpdateProductStocksByListInvoiceDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductStocksByListInvoiceDetailResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductStocksByListInvoiceDetailResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateProductStocksByListInvoiceDetailResponse) GetStockAllocations() []*StockAllocation {
+	if x != nil {
+		return x.StockAllocations
+	}
+	return nil
+}
+
+type RestoreProductStocksByListInvoiceDetailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailResponse) Reset() {
+	*x = RestoreProductStocksByListInvoiceDetailResponse{}
+	mi := &file_catalog_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductStocksByListInvoiceDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductStocksByListInvoiceDetailResponse) ProtoMessage() {}
+
+func (x *RestoreProductStocksByListInvoiceDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductStocksByListInvoiceDetailResponse.ProtoReflect.Descriptor instead.
+func (*RestoreProductStocksByListInvoiceDetailResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{11}
+}
+
+// Allocated backorders not yet applied to their invoice by order-service, oldest first
+type GetUnconfirmedAllocatedBackordersResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AllocatedBackorders []*AllocatedBackorder  `protobuf:"bytes,1,rep,name=allocated_backorders,json=allocatedBackorders,proto3" json:"allocated_backorders,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetUnconfirmedAllocatedBackordersResponse) Reset() {
+	*x = GetUnconfirmedAllocatedBackordersResponse{}
+	mi := &file_catalog_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnconfirmedAllocatedBackordersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnconfirmedAllocatedBackordersResponse) ProtoMessage() {}
+
+func (x *GetUnconfirmedAllocatedBackordersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnconfirmedAllocatedBackordersResponse.ProtoReflect.Descriptor instead.
+func (*GetUnconfirmedAllocatedBackordersResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUnconfirmedAllocatedBackordersResponse) GetAllocatedBackorders() []*AllocatedBackorder {
+	if x != nil {
+		return x.AllocatedBackorders
+	}
+	return nil
+}
+
+type ConfirmAllocatedBackorderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmAllocatedBackorderResponse) Reset() {
+	*x = ConfirmAllocatedBackorderResponse{}
+	mi := &file_catalog_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmAllocatedBackorderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmAllocatedBackorderResponse) ProtoMessage() {}
+
+func (x *ConfirmAllocatedBackorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmAllocatedBackorderResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmAllocatedBackorderResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{13}
+}
+
+type Product struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Sex                string                 `protobuf:"bytes,4,opt,name=sex,proto3" json:"sex,omitempty"`
+	Price              int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	DiscountPercentage int32                  `protobuf:"varint,6,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
+	Stock              int32                  `protobuf:"varint,7,opt,name=stock,proto3" json:"stock,omitempty"`
+	ImageUrl           string                 `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	CategoryId         string                 `protobuf:"bytes,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CategoryName       string                 `protobuf:"bytes,10,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	BrandId            string                 `protobuf:"bytes,11,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	BrandName          string                 `protobuf:"bytes,12,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CategoryBreadcrumb []*CategoryBreadcrumb  `protobuf:"bytes,15,rep,name=category_breadcrumb,json=categoryBreadcrumb,proto3" json:"category_breadcrumb,omitempty"`
+	AverageRating      float64                `protobuf:"fixed64,16,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	RatingCount        int32                  `protobuf:"varint,17,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	FinalPrice         int64                  `protobuf:"varint,18,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
+	LowestPrice_30D    int64                  `protobuf:"varint,19,opt,name=lowest_price_30d,json=lowestPrice30d,proto3" json:"lowest_price_30d,omitempty"`
+	Status             string                 `protobuf:"bytes,20,opt,name=status,proto3" json:"status,omitempty"`
+	Slug               string                 `protobuf:"bytes,21,opt,name=slug,proto3" json:"slug,omitempty"`
+	Attributes         []*ProductAttribute    `protobuf:"bytes,22,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Tags               []string               `protobuf:"bytes,23,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_catalog_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetSex() string {
+	if x != nil {
+		return x.Sex
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetDiscountPercentage() int32 {
+	if x != nil {
+		return x.DiscountPercentage
+	}
+	return 0
+}
+
+func (x *Product) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Product) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Product) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Product) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+func (x *Product) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *Product) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetCategoryBreadcrumb() []*CategoryBreadcrumb {
+	if x != nil {
+		return x.CategoryBreadcrumb
+	}
+	return nil
+}
+
+func (x *Product) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *Product) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Product) GetFinalPrice() int64 {
+	if x != nil {
+		return x.FinalPrice
+	}
+	return 0
+}
+
+func (x *Product) GetLowestPrice_30D() int64 {
+	if x != nil {
+		return x.LowestPrice_30D
+	}
+	return 0
+}
+
+func (x *Product) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Product) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Product) GetAttributes() []*ProductAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Product) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ProductAttribute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	NumberValue   float64                `protobuf:"fixed64,6,opt,name=number_value,json=numberValue,proto3" json:"number_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAttribute) Reset() {
+	*x = ProductAttribute{}
+	mi := &file_catalog_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductStocksByListInvoiceDetailRequest) String() string {
+func (x *ProductAttribute) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductStocksByListInvoiceDetailRequest) ProtoMessage() {}
+func (*ProductAttribute) ProtoMessage() {}
 
-func (x *UpdateProductStocksByListInvoiceDetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_service_proto_msgTypes[2]
+func (x *ProductAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -134,40 +922,77 @@ func (x *UpdateProductStocksByListInvoiceDetailRequest) ProtoReflect() protorefl
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductStocksByListInvoiceDetailRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductStocksByListInvoiceDetailRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_service_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ProductAttribute.ProtoReflect.Descriptor instead.
+func (*ProductAttribute) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *UpdateProductStocksByListInvoiceDetailRequest) GetInvoiceDetails() []*InvoiceDetail {
+func (x *ProductAttribute) GetCode() string {
 	if x != nil {
-		return x.InvoiceDetails
+		return x.Code
 	}
-	return nil
+	return ""
+}
+
+func (x *ProductAttribute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
 }
 
-type GetAllProductsResponse struct {
+func (x *ProductAttribute) GetNumberValue() float64 {
+	if x != nil {
+		return x.NumberValue
+	}
+	return 0
+}
+
+type CategoryBreadcrumb struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllProductsResponse) Reset() {
-	*x = GetAllProductsResponse{}
-	mi := &file_catalog_service_proto_msgTypes[3]
+func (x *CategoryBreadcrumb) Reset() {
+	*x = CategoryBreadcrumb{}
+	mi := &file_catalog_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllProductsResponse) String() string {
+func (x *CategoryBreadcrumb) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllProductsResponse) ProtoMessage() {}
+func (*CategoryBreadcrumb) ProtoMessage() {}
 
-func (x *GetAllProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_service_proto_msgTypes[3]
+func (x *CategoryBreadcrumb) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -178,40 +1003,56 @@ func (x *GetAllProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllProductsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllProductsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CategoryBreadcrumb.ProtoReflect.Descriptor instead.
+func (*CategoryBreadcrumb) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetAllProductsResponse) GetProducts() []*Product {
+func (x *CategoryBreadcrumb) GetId() string {
 	if x != nil {
-		return x.Products
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type GetProductByIdResponse struct {
+func (x *CategoryBreadcrumb) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryBreadcrumb) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type InvoiceDetail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductByIdResponse) Reset() {
-	*x = GetProductByIdResponse{}
-	mi := &file_catalog_service_proto_msgTypes[4]
+func (x *InvoiceDetail) Reset() {
+	*x = InvoiceDetail{}
+	mi := &file_catalog_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductByIdResponse) String() string {
+func (x *InvoiceDetail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductByIdResponse) ProtoMessage() {}
+func (*InvoiceDetail) ProtoMessage() {}
 
-func (x *GetProductByIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_service_proto_msgTypes[4]
+func (x *InvoiceDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,39 +1063,55 @@ func (x *GetProductByIdResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductByIdResponse.ProtoReflect.Descriptor instead.
-func (*GetProductByIdResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use InvoiceDetail.ProtoReflect.Descriptor instead.
+func (*InvoiceDetail) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetProductByIdResponse) GetProduct() *Product {
+func (x *InvoiceDetail) GetProductId() string {
 	if x != nil {
-		return x.Product
+		return x.ProductId
 	}
-	return nil
+	return ""
 }
 
-type UpdateProductStocksByListInvoiceDetailResponse struct {
+func (x *InvoiceDetail) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+type Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductStocksByListInvoiceDetailResponse) Reset() {
-	*x = UpdateProductStocksByListInvoiceDetailResponse{}
-	mi := &file_catalog_service_proto_msgTypes[5]
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_catalog_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductStocksByListInvoiceDetailResponse) String() string {
+func (x *Location) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductStocksByListInvoiceDetailResponse) ProtoMessage() {}
+func (*Location) ProtoMessage() {}
 
-func (x *UpdateProductStocksByListInvoiceDetailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_service_proto_msgTypes[5]
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -265,46 +1122,55 @@ func (x *UpdateProductStocksByListInvoiceDetailResponse) ProtoReflect() protoref
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductStocksByListInvoiceDetailResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductStocksByListInvoiceDetailResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{18}
 }
 
-type Product struct {
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type StockAllocation struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Sex                string                 `protobuf:"bytes,4,opt,name=sex,proto3" json:"sex,omitempty"`
-	Price              int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
-	DiscountPercentage int32                  `protobuf:"varint,6,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
-	Stock              int32                  `protobuf:"varint,7,opt,name=stock,proto3" json:"stock,omitempty"`
-	ImageUrl           string                 `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	CategoryId         string                 `protobuf:"bytes,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	CategoryName       string                 `protobuf:"bytes,10,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
-	BrandId            string                 `protobuf:"bytes,11,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
-	BrandName          string                 `protobuf:"bytes,12,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
-	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ProductId          string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId        string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity           int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price              int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	DiscountPercentage int32                  `protobuf:"varint,5,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
+	PromotionId        string                 `protobuf:"bytes,6,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Pending            bool                   `protobuf:"varint,7,opt,name=pending,proto3" json:"pending,omitempty"`
+	Bundle             bool                   `protobuf:"varint,8,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	BundleId           string                 `protobuf:"bytes,9,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
 
-func (x *Product) Reset() {
-	*x = Product{}
-	mi := &file_catalog_service_proto_msgTypes[6]
+func (x *StockAllocation) Reset() {
+	*x = StockAllocation{}
+	mi := &file_catalog_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Product) String() string {
+func (x *StockAllocation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Product) ProtoMessage() {}
+func (*StockAllocation) ProtoMessage() {}
 
-func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_service_proto_msgTypes[6]
+func (x *StockAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,132 +1181,174 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Product.ProtoReflect.Descriptor instead.
-func (*Product) Descriptor() ([]byte, []int) {
-	return file_catalog_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use StockAllocation.ProtoReflect.Descriptor instead.
+func (*StockAllocation) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Product) GetId() string {
+func (x *StockAllocation) GetProductId() string {
 	if x != nil {
-		return x.Id
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Product) GetName() string {
+func (x *StockAllocation) GetWarehouseId() string {
 	if x != nil {
-		return x.Name
+		return x.WarehouseId
 	}
 	return ""
 }
 
-func (x *Product) GetDescription() string {
+func (x *StockAllocation) GetQuantity() int32 {
 	if x != nil {
-		return x.Description
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *Product) GetSex() string {
+func (x *StockAllocation) GetPrice() int64 {
 	if x != nil {
-		return x.Sex
+		return x.Price
 	}
-	return ""
+	return 0
 }
 
-func (x *Product) GetPrice() int64 {
+func (x *StockAllocation) GetDiscountPercentage() int32 {
 	if x != nil {
-		return x.Price
+		return x.DiscountPercentage
 	}
 	return 0
 }
 
-func (x *Product) GetDiscountPercentage() int32 {
+func (x *StockAllocation) GetPromotionId() string {
 	if x != nil {
-		return x.DiscountPercentage
+		return x.PromotionId
 	}
-	return 0
+	return ""
 }
 
-func (x *Product) GetStock() int32 {
+func (x *StockAllocation) GetPending() bool {
 	if x != nil {
-		return x.Stock
+		return x.Pending
 	}
-	return 0
+	return false
 }
 
-func (x *Product) GetImageUrl() string {
+func (x *StockAllocation) GetBundle() bool {
 	if x != nil {
-		return x.ImageUrl
+		return x.Bundle
 	}
-	return ""
+	return false
 }
 
-func (x *Product) GetCategoryId() string {
+func (x *StockAllocation) GetBundleId() string {
 	if x != nil {
-		return x.CategoryId
+		return x.BundleId
 	}
 	return ""
 }
 
-func (x *Product) GetCategoryName() string {
+// Stock taken for backorder, by fulfilling warehouse
+type AllocatedBackorder struct {
+	state            protoimpl.MessageState     `protogen:"open.v1"`
+	Id               string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InvoiceId        string                     `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	ProductId        string                     `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity         int32                      `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	StockAllocations []*AllocatedBackorderStock `protobuf:"bytes,5,rep,name=stock_allocations,json=stockAllocations,proto3" json:"stock_allocations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AllocatedBackorder) Reset() {
+	*x = AllocatedBackorder{}
+	mi := &file_catalog_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocatedBackorder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocatedBackorder) ProtoMessage() {}
+
+func (x *AllocatedBackorder) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[20]
 	if x != nil {
-		return x.CategoryName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocatedBackorder.ProtoReflect.Descriptor instead.
+func (*AllocatedBackorder) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AllocatedBackorder) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *Product) GetBrandId() string {
+func (x *AllocatedBackorder) GetInvoiceId() string {
 	if x != nil {
-		return x.BrandId
+		return x.InvoiceId
 	}
 	return ""
 }
 
-func (x *Product) GetBrandName() string {
+func (x *AllocatedBackorder) GetProductId() string {
 	if x != nil {
-		return x.BrandName
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+func (x *AllocatedBackorder) GetQuantity() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Quantity
 	}
-	return nil
+	return 0
 }
 
-func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *AllocatedBackorder) GetStockAllocations() []*AllocatedBackorderStock {
 	if x != nil {
-		return x.UpdatedAt
+		return x.StockAllocations
 	}
 	return nil
 }
 
-type InvoiceDetail struct {
+type AllocatedBackorderStock struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InvoiceDetail) Reset() {
-	*x = InvoiceDetail{}
-	mi := &file_catalog_service_proto_msgTypes[7]
+func (x *AllocatedBackorderStock) Reset() {
+	*x = AllocatedBackorderStock{}
+	mi := &file_catalog_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InvoiceDetail) String() string {
+func (x *AllocatedBackorderStock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceDetail) ProtoMessage() {}
+func (*AllocatedBackorderStock) ProtoMessage() {}
 
-func (x *InvoiceDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_service_proto_msgTypes[7]
+func (x *AllocatedBackorderStock) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,19 +1359,19 @@ func (x *InvoiceDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceDetail.ProtoReflect.Descriptor instead.
-func (*InvoiceDetail) Descriptor() ([]byte, []int) {
-	return file_catalog_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AllocatedBackorderStock.ProtoReflect.Descriptor instead.
+func (*AllocatedBackorderStock) Descriptor() ([]byte, []int) {
+	return file_catalog_service_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *InvoiceDetail) GetProductId() string {
+func (x *AllocatedBackorderStock) GetWarehouseId() string {
 	if x != nil {
-		return x.ProductId
+		return x.WarehouseId
 	}
 	return ""
 }
 
-func (x *InvoiceDetail) GetQuantity() int32 {
+func (x *AllocatedBackorderStock) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
@@ -474,17 +1382,40 @@ var File_catalog_service_proto protoreflect.FileDescriptor
 
 const file_catalog_service_proto_rawDesc = "" +
 	"\n" +
-	"\x15catalog_service.proto\x12\x0ecatalogservice\x1a\x1fgoogle/protobuf/timestamp.proto\"\x17\n" +
-	"\x15GetAllProductsRequest\"'\n" +
+	"\x15catalog_service.proto\x12\x0ecatalogservice\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1a\n" +
+	"\x18StreamAllProductsRequest\"'\n" +
 	"\x15GetProductByIdRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"w\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x17GetProductsByIdsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\xf8\x01\n" +
 	"-UpdateProductStocksByListInvoiceDetailRequest\x12F\n" +
-	"\x0finvoice_details\x18\x01 \x03(\v2\x1d.catalogservice.InvoiceDetailR\x0einvoiceDetails\"M\n" +
-	"\x16GetAllProductsResponse\x123\n" +
+	"\x0finvoice_details\x18\x01 \x03(\v2\x1d.catalogservice.InvoiceDetailR\x0einvoiceDetails\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12E\n" +
+	"\x11shipping_location\x18\x04 \x01(\v2\x18.catalogservice.LocationR\x10shippingLocation\"\xca\x01\n" +
+	".RestoreProductStocksByListInvoiceDetailRequest\x12F\n" +
+	"\x0finvoice_details\x18\x01 \x03(\v2\x1d.catalogservice.InvoiceDetailR\x0einvoiceDetails\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"@\n" +
+	"(GetUnconfirmedAllocatedBackordersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"2\n" +
+	" ConfirmAllocatedBackorderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"P\n" +
+	"\x19StreamAllProductsResponse\x123\n" +
 	"\bproducts\x18\x01 \x03(\v2\x17.catalogservice.ProductR\bproducts\"K\n" +
 	"\x16GetProductByIdResponse\x121\n" +
-	"\aproduct\x18\x01 \x01(\v2\x17.catalogservice.ProductR\aproduct\"0\n" +
-	".UpdateProductStocksByListInvoiceDetailResponse\"\xd1\x03\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.catalogservice.ProductR\aproduct\"O\n" +
+	"\x18GetProductsByIdsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.catalogservice.ProductR\bproducts\"~\n" +
+	".UpdateProductStocksByListInvoiceDetailResponse\x12L\n" +
+	"\x11stock_allocations\x18\x01 \x03(\v2\x1f.catalogservice.StockAllocationR\x10stockAllocations\"1\n" +
+	"/RestoreProductStocksByListInvoiceDetailResponse\"\x82\x01\n" +
+	")GetUnconfirmedAllocatedBackordersResponse\x12U\n" +
+	"\x14allocated_backorders\x18\x01 \x03(\v2\".catalogservice.AllocatedBackorderR\x13allocatedBackorders\"#\n" +
+	"!ConfirmAllocatedBackorderResponse\"\xbd\x06\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -504,15 +1435,68 @@ const file_catalog_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"J\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12S\n" +
+	"\x13category_breadcrumb\x18\x0f \x03(\v2\".catalogservice.CategoryBreadcrumbR\x12categoryBreadcrumb\x12%\n" +
+	"\x0eaverage_rating\x18\x10 \x01(\x01R\raverageRating\x12!\n" +
+	"\frating_count\x18\x11 \x01(\x05R\vratingCount\x12\x1f\n" +
+	"\vfinal_price\x18\x12 \x01(\x03R\n" +
+	"finalPrice\x12(\n" +
+	"\x10lowest_price_30d\x18\x13 \x01(\x03R\x0elowestPrice30d\x12\x16\n" +
+	"\x06status\x18\x14 \x01(\tR\x06status\x12\x12\n" +
+	"\x04slug\x18\x15 \x01(\tR\x04slug\x12@\n" +
+	"\n" +
+	"attributes\x18\x16 \x03(\v2 .catalogservice.ProductAttributeR\n" +
+	"attributes\x12\x12\n" +
+	"\x04tags\x18\x17 \x03(\tR\x04tags\"\x9b\x01\n" +
+	"\x10ProductAttribute\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12!\n" +
+	"\fnumber_value\x18\x06 \x01(\x01R\vnumberValue\"L\n" +
+	"\x12CategoryBreadcrumb\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\"m\n" +
 	"\rInvoiceDetail\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity2\x80\x03\n" +
-	"\x12CatalogServiceGRPC\x12_\n" +
-	"\x0eGetAllProducts\x12%.catalogservice.GetAllProductsRequest\x1a&.catalogservice.GetAllProductsResponse\x12_\n" +
-	"\x0eGetProductById\x12%.catalogservice.GetProductByIdRequest\x1a&.catalogservice.GetProductByIdResponse\x12\xa7\x01\n" +
-	"&UpdateProductStocksByListInvoiceDetail\x12=.catalogservice.UpdateProductStocksByListInvoiceDetailRequest\x1a>.catalogservice.UpdateProductStocksByListInvoiceDetailResponseB\x13Z\x11catalogservicepb/b\x06proto3"
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12!\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\"D\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xa8\x02\n" +
+	"\x0fStockAllocation\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12/\n" +
+	"\x13discount_percentage\x18\x05 \x01(\x05R\x12discountPercentage\x12!\n" +
+	"\fpromotion_id\x18\x06 \x01(\tR\vpromotionId\x12\x18\n" +
+	"\apending\x18\a \x01(\bR\apending\x12\x16\n" +
+	"\x06bundle\x18\b \x01(\bR\x06bundle\x12\x1b\n" +
+	"\tbundle_id\x18\t \x01(\tR\bbundleId\"\xd4\x01\n" +
+	"\x12AllocatedBackorder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12T\n" +
+	"\x11stock_allocations\x18\x05 \x03(\v2'.catalogservice.AllocatedBackorderStockR\x10stockAllocations\"X\n" +
+	"\x17AllocatedBackorderStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity2\xbd\a\n" +
+	"\x12CatalogServiceGRPC\x12j\n" +
+	"\x11StreamAllProducts\x12(.catalogservice.StreamAllProductsRequest\x1a).catalogservice.StreamAllProductsResponse0\x01\x12_\n" +
+	"\x0eGetProductById\x12%.catalogservice.GetProductByIdRequest\x1a&.catalogservice.GetProductByIdResponse\x12e\n" +
+	"\x10GetProductsByIds\x12'.catalogservice.GetProductsByIdsRequest\x1a(.catalogservice.GetProductsByIdsResponse\x12\xa7\x01\n" +
+	"&UpdateProductStocksByListInvoiceDetail\x12=.catalogservice.UpdateProductStocksByListInvoiceDetailRequest\x1a>.catalogservice.UpdateProductStocksByListInvoiceDetailResponse\x12\xaa\x01\n" +
+	"'RestoreProductStocksByListInvoiceDetail\x12>.catalogservice.RestoreProductStocksByListInvoiceDetailRequest\x1a?.catalogservice.RestoreProductStocksByListInvoiceDetailResponse\x12\x98\x01\n" +
+	"!GetUnconfirmedAllocatedBackorders\x128.catalogservice.GetUnconfirmedAllocatedBackordersRequest\x1a9.catalogservice.GetUnconfirmedAllocatedBackordersResponse\x12\x80\x01\n" +
+	"\x19ConfirmAllocatedBackorder\x120.catalogservice.ConfirmAllocatedBackorderRequest\x1a1.catalogservice.ConfirmAllocatedBackorderResponseB\x13Z\x11catalogservicepb/b\x06proto3"
 
 var (
 	file_catalog_service_proto_rawDescOnce sync.Once
@@ -526,35 +1510,65 @@ func file_catalog_service_proto_rawDescGZIP() []byte {
 	return file_catalog_service_proto_rawDescData
 }
 
-var file_catalog_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_catalog_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_catalog_service_proto_goTypes = []any{
-	(*GetAllProductsRequest)(nil),                          // 0: catalogservice.GetAllProductsRequest
-	(*GetProductByIdRequest)(nil),                          // 1: catalogservice.GetProductByIdRequest
-	(*UpdateProductStocksByListInvoiceDetailRequest)(nil),  // 2: catalogservice.UpdateProductStocksByListInvoiceDetailRequest
-	(*GetAllProductsResponse)(nil),                         // 3: catalogservice.GetAllProductsResponse
-	(*GetProductByIdResponse)(nil),                         // 4: catalogservice.GetProductByIdResponse
-	(*UpdateProductStocksByListInvoiceDetailResponse)(nil), // 5: catalogservice.UpdateProductStocksByListInvoiceDetailResponse
-	(*Product)(nil),                                        // 6: catalogservice.Product
-	(*InvoiceDetail)(nil),                                  // 7: catalogservice.InvoiceDetail
-	(*timestamppb.Timestamp)(nil),                          // 8: google.protobuf.Timestamp
+	(*StreamAllProductsRequest)(nil),                        // 0: catalogservice.StreamAllProductsRequest
+	(*GetProductByIdRequest)(nil),                           // 1: catalogservice.GetProductByIdRequest
+	(*GetProductsByIdsRequest)(nil),                         // 2: catalogservice.GetProductsByIdsRequest
+	(*UpdateProductStocksByListInvoiceDetailRequest)(nil),   // 3: catalogservice.UpdateProductStocksByListInvoiceDetailRequest
+	(*RestoreProductStocksByListInvoiceDetailRequest)(nil),  // 4: catalogservice.RestoreProductStocksByListInvoiceDetailRequest
+	(*GetUnconfirmedAllocatedBackordersRequest)(nil),        // 5: catalogservice.GetUnconfirmedAllocatedBackordersRequest
+	(*ConfirmAllocatedBackorderRequest)(nil),                // 6: catalogservice.ConfirmAllocatedBackorderRequest
+	(*StreamAllProductsResponse)(nil),                       // 7: catalogservice.StreamAllProductsResponse
+	(*GetProductByIdResponse)(nil),                          // 8: catalogservice.GetProductByIdResponse
+	(*GetProductsByIdsResponse)(nil),                        // 9: catalogservice.GetProductsByIdsResponse
+	(*UpdateProductStocksByListInvoiceDetailResponse)(nil),  // 10: catalogservice.UpdateProductStocksByListInvoiceDetailResponse
+	(*RestoreProductStocksByListInvoiceDetailResponse)(nil), // 11: catalogservice.RestoreProductStocksByListInvoiceDetailResponse
+	(*GetUnconfirmedAllocatedBackordersResponse)(nil),       // 12: catalogservice.GetUnconfirmedAllocatedBackordersResponse
+	(*ConfirmAllocatedBackorderResponse)(nil),               // 13: catalogservice.ConfirmAllocatedBackorderResponse
+	(*Product)(nil),                 // 14: catalogservice.Product
+	(*ProductAttribute)(nil),        // 15: catalogservice.ProductAttribute
+	(*CategoryBreadcrumb)(nil),      // 16: catalogservice.CategoryBreadcrumb
+	(*InvoiceDetail)(nil),           // 17: catalogservice.InvoiceDetail
+	(*Location)(nil),                // 18: catalogservice.Location
+	(*StockAllocation)(nil),         // 19: catalogservice.StockAllocation
+	(*AllocatedBackorder)(nil),      // 20: catalogservice.AllocatedBackorder
+	(*AllocatedBackorderStock)(nil), // 21: catalogservice.AllocatedBackorderStock
+	(*timestamppb.Timestamp)(nil),   // 22: google.protobuf.Timestamp
 }
 var file_catalog_service_proto_depIdxs = []int32{
-	7, // 0: catalogservice.UpdateProductStocksByListInvoiceDetailRequest.invoice_details:type_name -> catalogservice.InvoiceDetail
-	6, // 1: catalogservice.GetAllProductsResponse.products:type_name -> catalogservice.Product
-	6, // 2: catalogservice.GetProductByIdResponse.product:type_name -> catalogservice.Product
-	8, // 3: catalogservice.Product.created_at:type_name -> google.protobuf.Timestamp
-	8, // 4: catalogservice.Product.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 5: catalogservice.CatalogServiceGRPC.GetAllProducts:input_type -> catalogservice.GetAllProductsRequest
-	1, // 6: catalogservice.CatalogServiceGRPC.GetProductById:input_type -> catalogservice.GetProductByIdRequest
-	2, // 7: catalogservice.CatalogServiceGRPC.UpdateProductStocksByListInvoiceDetail:input_type -> catalogservice.UpdateProductStocksByListInvoiceDetailRequest
-	3, // 8: catalogservice.CatalogServiceGRPC.GetAllProducts:output_type -> catalogservice.GetAllProductsResponse
-	4, // 9: catalogservice.CatalogServiceGRPC.GetProductById:output_type -> catalogservice.GetProductByIdResponse
-	5, // 10: catalogservice.CatalogServiceGRPC.UpdateProductStocksByListInvoiceDetail:output_type -> catalogservice.UpdateProductStocksByListInvoiceDetailResponse
-	8, // [8:11] is the sub-list for method output_type
-	5, // [5:8] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	17, // 0: catalogservice.UpdateProductStocksByListInvoiceDetailRequest.invoice_details:type_name -> catalogservice.InvoiceDetail
+	18, // 1: catalogservice.UpdateProductStocksByListInvoiceDetailRequest.shipping_location:type_name -> catalogservice.Location
+	17, // 2: catalogservice.RestoreProductStocksByListInvoiceDetailRequest.invoice_details:type_name -> catalogservice.InvoiceDetail
+	14, // 3: catalogservice.StreamAllProductsResponse.products:type_name -> catalogservice.Product
+	14, // 4: catalogservice.GetProductByIdResponse.product:type_name -> catalogservice.Product
+	14, // 5: catalogservice.GetProductsByIdsResponse.products:type_name -> catalogservice.Product
+	19, // 6: catalogservice.UpdateProductStocksByListInvoiceDetailResponse.stock_allocations:type_name -> catalogservice.StockAllocation
+	20, // 7: catalogservice.GetUnconfirmedAllocatedBackordersResponse.allocated_backorders:type_name -> catalogservice.AllocatedBackorder
+	22, // 8: catalogservice.Product.created_at:type_name -> google.protobuf.Timestamp
+	22, // 9: catalogservice.Product.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 10: catalogservice.Product.category_breadcrumb:type_name -> catalogservice.CategoryBreadcrumb
+	15, // 11: catalogservice.Product.attributes:type_name -> catalogservice.ProductAttribute
+	21, // 12: catalogservice.AllocatedBackorder.stock_allocations:type_name -> catalogservice.AllocatedBackorderStock
+	0,  // 13: catalogservice.CatalogServiceGRPC.StreamAllProducts:input_type -> catalogservice.StreamAllProductsRequest
+	1,  // 14: catalogservice.CatalogServiceGRPC.GetProductById:input_type -> catalogservice.GetProductByIdRequest
+	2,  // 15: catalogservice.CatalogServiceGRPC.GetProductsByIds:input_type -> catalogservice.GetProductsByIdsRequest
+	3,  // 16: catalogservice.CatalogServiceGRPC.UpdateProductStocksByListInvoiceDetail:input_type -> catalogservice.UpdateProductStocksByListInvoiceDetailRequest
+	4,  // 17: catalogservice.CatalogServiceGRPC.RestoreProductStocksByListInvoiceDetail:input_type -> catalogservice.RestoreProductStocksByListInvoiceDetailRequest
+	5,  // 18: catalogservice.CatalogServiceGRPC.GetUnconfirmedAllocatedBackorders:input_type -> catalogservice.GetUnconfirmedAllocatedBackordersRequest
+	6,  // 19: catalogservice.CatalogServiceGRPC.ConfirmAllocatedBackorder:input_type -> catalogservice.ConfirmAllocatedBackorderRequest
+	7,  // 20: catalogservice.CatalogServiceGRPC.StreamAllProducts:output_type -> catalogservice.StreamAllProductsResponse
+	8,  // 21: catalogservice.CatalogServiceGRPC.GetProductById:output_type -> catalogservice.GetProductByIdResponse
+	9,  // 22: catalogservice.CatalogServiceGRPC.GetProductsByIds:output_type -> catalogservice.GetProductsByIdsResponse
+	10, // 23: catalogservice.CatalogServiceGRPC.UpdateProductStocksByListInvoiceDetail:output_type -> catalogservice.UpdateProductStocksByListInvoiceDetailResponse
+	11, // 24: catalogservice.CatalogServiceGRPC.RestoreProductStocksByListInvoiceDetail:output_type -> catalogservice.RestoreProductStocksByListInvoiceDetailResponse
+	12, // 25: catalogservice.CatalogServiceGRPC.GetUnconfirmedAllocatedBackorders:output_type -> catalogservice.GetUnconfirmedAllocatedBackordersResponse
+	13, // 26: catalogservice.CatalogServiceGRPC.ConfirmAllocatedBackorder:output_type -> catalogservice.ConfirmAllocatedBackorderResponse
+	20, // [20:27] is the sub-list for method output_type
+	13, // [13:20] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_catalog_service_proto_init() }
@@ -568,7 +1582,7 @@ func file_catalog_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_service_proto_rawDesc), len(file_catalog_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -6,26 +6,27 @@ import (
 	"thanhldt060802/internal/grpc/service/catalogservicepb"
 	"thanhldt060802/internal/model"
 	"thanhldt060802/internal/service"
+
+	"google.golang.org/grpc"
 )
 
 type CatalogServiceGRPCImpl struct {
 	catalogservicepb.UnimplementedCatalogServiceGRPCServer
-	productService service.ProductService
+	productService       service.ProductService
+	stockMovementService service.StockMovementService
+	backorderService     service.BackorderService
 }
 
-func NewCatalogServiceGRPCImpl(productService service.ProductService) *CatalogServiceGRPCImpl {
-	return &CatalogServiceGRPCImpl{productService: productService}
+func NewCatalogServiceGRPCImpl(productService service.ProductService, stockMovementService service.StockMovementService, backorderService service.BackorderService) *CatalogServiceGRPCImpl {
+	return &CatalogServiceGRPCImpl{productService: productService, stockMovementService: stockMovementService, backorderService: backorderService}
 }
 
-func (catalogServiceGRPC *CatalogServiceGRPCImpl) GetAllProducts(ctx context.Context, req *catalogservicepb.GetAllProductsRequest) (*catalogservicepb.GetAllProductsResponse, error) {
-	products, err := catalogServiceGRPC.productService.GetAllProducts(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res := &catalogservicepb.GetAllProductsResponse{}
-	res.Products = model.FromListProductViewToListProductProto(products)
-	return res, nil
+func (catalogServiceGRPC *CatalogServiceGRPCImpl) StreamAllProducts(req *catalogservicepb.StreamAllProductsRequest, stream grpc.ServerStreamingServer[catalogservicepb.StreamAllProductsResponse]) error {
+	return catalogServiceGRPC.productService.StreamAllProducts(stream.Context(), func(products []*model.ProductView) error {
+		res := &catalogservicepb.StreamAllProductsResponse{}
+		res.Products = model.FromListProductViewToListProductProto(products)
+		return stream.Send(res)
+	})
 }
 
 func (catalogServiceGRPC *CatalogServiceGRPCImpl) GetProductById(ctx context.Context, req *catalogservicepb.GetProductByIdRequest) (*catalogservicepb.GetProductByIdResponse, error) {
@@ -42,20 +43,105 @@ func (catalogServiceGRPC *CatalogServiceGRPCImpl) GetProductById(ctx context.Con
 	return res, nil
 }
 
+func (catalogServiceGRPC *CatalogServiceGRPCImpl) GetProductsByIds(ctx context.Context, req *catalogservicepb.GetProductsByIdsRequest) (*catalogservicepb.GetProductsByIdsResponse, error) {
+	convertReqDTO := &dto.GetProductsByListIdRequest{}
+	convertReqDTO.Ids = req.Ids
+
+	products, err := catalogServiceGRPC.productService.GetProductsByListId(ctx, convertReqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &catalogservicepb.GetProductsByIdsResponse{}
+	res.Products = model.FromListProductViewToListProductProto(products)
+	return res, nil
+}
+
 func (catalogServiceGRPC *CatalogServiceGRPCImpl) UpdateProductStocksByListInvoiceDetail(ctx context.Context, req *catalogservicepb.UpdateProductStocksByListInvoiceDetailRequest) (*catalogservicepb.UpdateProductStocksByListInvoiceDetailResponse, error) {
 	convertReqDTO := &dto.UpdateProductStocksByListInvoiceDetailRequest{}
 	convertReqDTO.InvoiceDetails = make([]dto.InvoiceDetail, len(req.InvoiceDetails))
-	for _, invoiceDetailProto := range req.InvoiceDetails {
-		convertReqDTO.InvoiceDetails = append(convertReqDTO.InvoiceDetails, dto.InvoiceDetail{
+	for i, invoiceDetailProto := range req.InvoiceDetails {
+		convertReqDTO.InvoiceDetails[i] = dto.InvoiceDetail{
 			ProductId: invoiceDetailProto.ProductId,
 			Quantity:  invoiceDetailProto.Quantity,
-		})
+		}
+	}
+	convertReqDTO.InvoiceId = req.InvoiceId
+	convertReqDTO.ActorId = req.ActorId
+	if req.ShippingLocation != nil {
+		convertReqDTO.ShippingLocation = &dto.Location{
+			Latitude:  req.ShippingLocation.Latitude,
+			Longitude: req.ShippingLocation.Longitude,
+		}
 	}
 
-	if err := catalogServiceGRPC.productService.UpdateProductStocksByListInvoiceDetail(ctx, convertReqDTO); err != nil {
+	stockAllocations, err := catalogServiceGRPC.productService.UpdateProductStocksByListInvoiceDetail(ctx, convertReqDTO)
+	if err != nil {
 		return nil, err
 	}
 
 	res := &catalogservicepb.UpdateProductStocksByListInvoiceDetailResponse{}
+	res.StockAllocations = make([]*catalogservicepb.StockAllocation, len(stockAllocations))
+	for i, stockAllocation := range stockAllocations {
+		res.StockAllocations[i] = &catalogservicepb.StockAllocation{
+			ProductId:          stockAllocation.ProductId,
+			WarehouseId:        stockAllocation.WarehouseId,
+			Quantity:           stockAllocation.Quantity,
+			Price:              stockAllocation.Price,
+			DiscountPercentage: stockAllocation.DiscountPercentage,
+			PromotionId:        stockAllocation.PromotionId,
+			Pending:            stockAllocation.Pending,
+			Bundle:             stockAllocation.Bundle,
+			BundleId:           stockAllocation.BundleId,
+		}
+	}
+	return res, nil
+}
+
+func (catalogServiceGRPC *CatalogServiceGRPCImpl) RestoreProductStocksByListInvoiceDetail(ctx context.Context, req *catalogservicepb.RestoreProductStocksByListInvoiceDetailRequest) (*catalogservicepb.RestoreProductStocksByListInvoiceDetailResponse, error) {
+	convertReqDTO := &dto.RestoreProductStocksByListInvoiceDetailRequest{}
+	convertReqDTO.InvoiceDetails = make([]dto.InvoiceDetail, len(req.InvoiceDetails))
+	for i, invoiceDetailProto := range req.InvoiceDetails {
+		convertReqDTO.InvoiceDetails[i] = dto.InvoiceDetail{
+			ProductId:   invoiceDetailProto.ProductId,
+			Quantity:    invoiceDetailProto.Quantity,
+			WarehouseId: invoiceDetailProto.WarehouseId,
+		}
+	}
+	convertReqDTO.InvoiceId = req.InvoiceId
+	convertReqDTO.ActorId = req.ActorId
+	convertReqDTO.Reason = req.Reason
+
+	if err := catalogServiceGRPC.stockMovementService.RestoreProductStocksByListInvoiceDetail(ctx, convertReqDTO); err != nil {
+		return nil, err
+	}
+
+	res := &catalogservicepb.RestoreProductStocksByListInvoiceDetailResponse{}
+	return res, nil
+}
+
+func (catalogServiceGRPC *CatalogServiceGRPCImpl) GetUnconfirmedAllocatedBackorders(ctx context.Context, req *catalogservicepb.GetUnconfirmedAllocatedBackordersRequest) (*catalogservicepb.GetUnconfirmedAllocatedBackordersResponse, error) {
+	convertReqDTO := &dto.GetUnconfirmedAllocatedBackordersRequest{}
+	convertReqDTO.Limit = req.Limit
+
+	allocatedBackorders, err := catalogServiceGRPC.backorderService.GetUnconfirmedAllocatedBackorders(ctx, convertReqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &catalogservicepb.GetUnconfirmedAllocatedBackordersResponse{}
+	res.AllocatedBackorders = model.FromListAllocatedBackorderViewToListAllocatedBackorderProto(allocatedBackorders)
+	return res, nil
+}
+
+func (catalogServiceGRPC *CatalogServiceGRPCImpl) ConfirmAllocatedBackorder(ctx context.Context, req *catalogservicepb.ConfirmAllocatedBackorderRequest) (*catalogservicepb.ConfirmAllocatedBackorderResponse, error) {
+	convertReqDTO := &dto.ConfirmAllocatedBackorderRequest{}
+	convertReqDTO.Id = req.Id
+
+	if err := catalogServiceGRPC.backorderService.ConfirmAllocatedBackorder(ctx, convertReqDTO); err != nil {
+		return nil, err
+	}
+
+	res := &catalogservicepb.ConfirmAllocatedBackorderResponse{}
 	return res, nil
 }
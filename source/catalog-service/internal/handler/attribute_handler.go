@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type AttributeHandler struct {
+	attributeService  service.AttributeService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewAttributeHandler(api huma.API, attributeService service.AttributeService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *AttributeHandler {
+	attributeHandler := &AttributeHandler{
+		attributeService:  attributeService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get all attributes
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/attributes/all",
+		Summary:     "/attributes/all",
+		Description: "Get all attributes.",
+		Tags:        []string{"Attribute"},
+	}, attributeHandler.GetAllAttributeDefinitions)
+
+	// Get attribute by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/attributes/id/{id}",
+		Summary:     "/attributes/id/{id}",
+		Description: "Get attribute by id.",
+		Tags:        []string{"Attribute"},
+	}, attributeHandler.GetAttributeDefinitionById)
+
+	// Create attribute
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/attributes",
+		Summary:     "/attributes",
+		Description: "Create attribute.",
+		Tags:        []string{"Attribute"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, attributeHandler.CreateAttributeDefinition)
+
+	// Update attribute by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/attributes/id/{id}",
+		Summary:     "/attributes/id/{id}",
+		Description: "Update attribute by id, code and type cannot be changed.",
+		Tags:        []string{"Attribute"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, attributeHandler.UpdateAttributeDefinitionById)
+
+	// Delete attribute by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/attributes/id/{id}",
+		Summary:     "/attributes/id/{id}",
+		Description: "Delete attribute by id, only when no category and no product uses it.",
+		Tags:        []string{"Attribute"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, attributeHandler.DeleteAttributeDefinitionById)
+
+	// Get category attributes
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/categories/id/{id}/attributes",
+		Summary:     "/categories/id/{id}/attributes",
+		Description: "Get attribute set of category, including attributes inherited from its ancestors.",
+		Tags:        []string{"Attribute"},
+	}, attributeHandler.GetCategoryAttributes)
+
+	// Update category attributes
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/categories/id/{id}/attributes",
+		Summary:     "/categories/id/{id}/attributes",
+		Description: "Replace own attribute set of category.",
+		Tags:        []string{"Attribute"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, attributeHandler.UpdateCategoryAttributes)
+
+	return attributeHandler
+}
+
+func (attributeHandler *AttributeHandler) GetAllAttributeDefinitions(ctx context.Context, reqDTO *dto.GetAllAttributeDefinitionsRequest) (*dto.PaginationBodyResponseList[*model.AttributeDefinitionView], error) {
+	attributeDefinitions, err := attributeHandler.attributeService.GetAllAttributeDefinitions(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get all attributes failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.AttributeDefinitionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get all attributes successful"
+	res.Body.Data = attributeDefinitions
+	res.Body.Total = len(attributeDefinitions)
+	return res, nil
+}
+
+func (attributeHandler *AttributeHandler) GetAttributeDefinitionById(ctx context.Context, reqDTO *dto.GetAttributeDefinitionByIdRequest) (*dto.BodyResponse[*model.AttributeDefinitionView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get attribute by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundAttributeDefinition, err := attributeHandler.attributeService.GetAttributeDefinitionById(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get attribute by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.AttributeDefinitionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get attribute by id successful"
+	res.Body.Data = foundAttributeDefinition
+	return res, nil
+}
+
+func (attributeHandler *AttributeHandler) CreateAttributeDefinition(ctx context.Context, reqDTO *dto.CreateAttributeDefinitionRequest) (*dto.SuccessResponse, error) {
+	if err := attributeHandler.attributeService.CreateAttributeDefinition(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create attribute failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create attribute successful"
+	return res, nil
+}
+
+func (attributeHandler *AttributeHandler) UpdateAttributeDefinitionById(ctx context.Context, reqDTO *dto.UpdateAttributeDefinitionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update attribute by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := attributeHandler.attributeService.UpdateAttributeDefinitionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update attribute by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update attribute by id successful"
+	return res, nil
+}
+
+func (attributeHandler *AttributeHandler) DeleteAttributeDefinitionById(ctx context.Context, reqDTO *dto.DeleteAttributeDefinitionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete attribute by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := attributeHandler.attributeService.DeleteAttributeDefinitionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete attribute by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete attribute by id successful"
+	return res, nil
+}
+
+func (attributeHandler *AttributeHandler) GetCategoryAttributes(ctx context.Context, reqDTO *dto.GetCategoryAttributesRequest) (*dto.PaginationBodyResponseList[*model.CategoryAttributeView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get category attributes failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	categoryAttributes, err := attributeHandler.attributeService.GetCategoryAttributes(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get category attributes failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.CategoryAttributeView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get category attributes successful"
+	res.Body.Data = categoryAttributes
+	res.Body.Total = len(categoryAttributes)
+	return res, nil
+}
+
+func (attributeHandler *AttributeHandler) UpdateCategoryAttributes(ctx context.Context, reqDTO *dto.UpdateCategoryAttributesRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update category attributes failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := attributeHandler.attributeService.UpdateCategoryAttributes(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update category attributes failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update category attributes successful"
+	return res, nil
+}
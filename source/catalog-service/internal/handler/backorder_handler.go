@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type BackorderHandler struct {
+	backorderService  service.BackorderService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewBackorderHandler(api huma.API, backorderService service.BackorderService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *BackorderHandler {
+	backorderHandler := &BackorderHandler{
+		backorderService:  backorderService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get backorders
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/backorders",
+		Summary:     "/backorders",
+		Description: "Get units of pre-order and backorder products sold beyond stock, pending ones are allocated first in first out when stock is replenished.",
+		Tags:        []string{"Backorder"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, backorderHandler.GetBackorders)
+
+	return backorderHandler
+}
+
+func (backorderHandler *BackorderHandler) GetBackorders(ctx context.Context, reqDTO *dto.GetBackordersRequest) (*dto.PaginationBodyResponseList[*model.BackorderView], error) {
+	backorders, err := backorderHandler.backorderService.GetBackorders(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get backorders failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.BackorderView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get backorders successful"
+	res.Body.Data = backorders
+	res.Body.Total = len(backorders)
+	return res, nil
+}
@@ -31,6 +31,16 @@ func NewBrandHandler(api huma.API, brandService service.BrandService, jwtAuthMid
 		Tags:        []string{"Brand"},
 	}, brandHandler.GetAllBrands)
 
+	// Get archived brands
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/brands/archived",
+		Summary:     "/brands/archived",
+		Description: "Get archived brands.",
+		Tags:        []string{"Brand"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, brandHandler.GetArchivedBrands)
+
 	// Get brand by id
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodGet,
@@ -38,8 +48,19 @@ func NewBrandHandler(api huma.API, brandService service.BrandService, jwtAuthMid
 		Summary:     "/brands/id/{id}",
 		Description: "Get brand by id.",
 		Tags:        []string{"Brand"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
 	}, brandHandler.GetBrandById)
 
+	// Get brand by slug
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/brands/slug/{slug}",
+		Summary:     "/brands/slug/{slug}",
+		Description: "Get brand by slug, a former slug is answered with 301 redirect to the current one.",
+		Tags:        []string{"Brand"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, brandHandler.GetBrandBySlug)
+
 	// Create brand
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -70,6 +91,16 @@ func NewBrandHandler(api huma.API, brandService service.BrandService, jwtAuthMid
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
 	}, brandHandler.DeleteBrandById)
 
+	// Restore brand by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/brands/id/{id}/restore",
+		Summary:     "/brands/id/{id}/restore",
+		Description: "Restore archived brand by id as draft.",
+		Tags:        []string{"Brand"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, brandHandler.RestoreBrandById)
+
 	return brandHandler
 }
 
@@ -92,6 +123,25 @@ func (brandHandler *BrandHandler) GetAllBrands(ctx context.Context, reqDTO *dto.
 	return res, nil
 }
 
+func (brandHandler *BrandHandler) GetArchivedBrands(ctx context.Context, reqDTO *dto.GetArchivedBrandsRequest) (*dto.PaginationBodyResponseList[*model.BrandView], error) {
+	brands, err := brandHandler.brandService.GetArchivedBrands(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get archived brands failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.BrandView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get archived brands successful"
+	res.Body.Data = brands
+	res.Body.Total = len(brands)
+	return res, nil
+}
+
 func (brandHandler *BrandHandler) GetBrandById(ctx context.Context, reqDTO *dto.GetBrandByIdRequest) (*dto.BodyResponse[*model.BrandView], error) {
 	if reqDTO.Id == "{id}" {
 		res := &dto.ErrorResponse{}
@@ -119,6 +169,38 @@ func (brandHandler *BrandHandler) GetBrandById(ctx context.Context, reqDTO *dto.
 	return res, nil
 }
 
+func (brandHandler *BrandHandler) GetBrandBySlug(ctx context.Context, reqDTO *dto.GetBrandBySlugRequest) (*dto.SlugBodyResponse[*model.BrandView], error) {
+	if reqDTO.Slug == "{slug}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get brand by slug failed"
+		res.Details = []string{"missing path parameters: slug"}
+		return nil, res
+	}
+
+	foundBrand, err := brandHandler.brandService.GetBrandBySlug(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get brand by slug failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SlugBodyResponse[*model.BrandView]{}
+	res.Status = http.StatusOK
+	if foundBrand.Slug != reqDTO.Slug {
+		res.Status = http.StatusMovedPermanently
+		res.Location = "/brands/slug/" + foundBrand.Slug
+	}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get brand by slug successful"
+	res.Body.Data = foundBrand
+	return res, nil
+}
+
 func (brandHandler *BrandHandler) CreateBrand(ctx context.Context, reqDTO *dto.CreateBrandRequest) (*dto.SuccessResponse, error) {
 	if err := brandHandler.brandService.CreateBrand(ctx, reqDTO); err != nil {
 		res := &dto.ErrorResponse{}
@@ -184,3 +266,28 @@ func (brandHandler *BrandHandler) DeleteBrandById(ctx context.Context, reqDTO *d
 	res.Body.Message = "Delete brand by id successful"
 	return res, nil
 }
+
+func (brandHandler *BrandHandler) RestoreBrandById(ctx context.Context, reqDTO *dto.RestoreBrandByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Restore brand by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := brandHandler.brandService.RestoreBrandById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Restore brand by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Restore brand by id successful"
+	return res, nil
+}
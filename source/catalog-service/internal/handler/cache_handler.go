@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type CacheHandler struct {
+	cacheService      service.CacheService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewCacheHandler(api huma.API, cacheService service.CacheService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *CacheHandler {
+	cacheHandler := &CacheHandler{
+		cacheService:      cacheService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get cache stats
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/cache/stats",
+		Summary:     "/cache/stats",
+		Description: "Get hits, misses and loads of product, brand and category view caches since this instance started.",
+		Tags:        []string{"Cache"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, cacheHandler.GetCacheStats)
+
+	return cacheHandler
+}
+
+func (cacheHandler *CacheHandler) GetCacheStats(ctx context.Context, _ *struct{}) (*dto.PaginationBodyResponseList[*model.CacheStatsView], error) {
+	cacheStats, err := cacheHandler.cacheService.GetCacheStats(ctx)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get cache stats failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.CacheStatsView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get cache stats successful"
+	res.Body.Data = cacheStats
+	res.Body.Total = len(cacheStats)
+	return res, nil
+}
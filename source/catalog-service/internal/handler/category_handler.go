@@ -31,6 +31,25 @@ func NewCategoryHandler(api huma.API, categoryService service.CategoryService, j
 		Tags:        []string{"Category"},
 	}, categoryHandler.GetAllCategories)
 
+	// Get category tree
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/categories/tree",
+		Summary:     "/categories/tree",
+		Description: "Get all categories as tree.",
+		Tags:        []string{"Category"},
+	}, categoryHandler.GetCategoryTree)
+
+	// Get archived categories
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/categories/archived",
+		Summary:     "/categories/archived",
+		Description: "Get archived categories.",
+		Tags:        []string{"Category"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, categoryHandler.GetArchivedCategories)
+
 	// Get category by id
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodGet,
@@ -38,8 +57,19 @@ func NewCategoryHandler(api huma.API, categoryService service.CategoryService, j
 		Summary:     "/categories/id/{id}",
 		Description: "Get category by id.",
 		Tags:        []string{"Category"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
 	}, categoryHandler.GetCategoryById)
 
+	// Get category by slug
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/categories/slug/{slug}",
+		Summary:     "/categories/slug/{slug}",
+		Description: "Get category by slug, a former slug is answered with 301 redirect to the current one.",
+		Tags:        []string{"Category"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, categoryHandler.GetCategoryBySlug)
+
 	// Create category
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -60,6 +90,16 @@ func NewCategoryHandler(api huma.API, categoryService service.CategoryService, j
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
 	}, categoryHandler.UpdateCategoryById)
 
+	// Move category by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/categories/id/{id}/move",
+		Summary:     "/categories/id/{id}/move",
+		Description: "Move category and its subtree under another parent category.",
+		Tags:        []string{"Category"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, categoryHandler.MoveCategoryById)
+
 	// Delete category by id
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodDelete,
@@ -70,6 +110,16 @@ func NewCategoryHandler(api huma.API, categoryService service.CategoryService, j
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
 	}, categoryHandler.DeleteCategoryById)
 
+	// Restore category by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/categories/id/{id}/restore",
+		Summary:     "/categories/id/{id}/restore",
+		Description: "Restore archived category by id as draft.",
+		Tags:        []string{"Category"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, categoryHandler.RestoreCategoryById)
+
 	return categoryHandler
 }
 
@@ -92,6 +142,44 @@ func (categoryHandler *CategoryHandler) GetAllCategories(ctx context.Context, re
 	return res, nil
 }
 
+func (categoryHandler *CategoryHandler) GetCategoryTree(ctx context.Context, _ *struct{}) (*dto.PaginationBodyResponseList[*model.CategoryTreeView], error) {
+	categoryTree, err := categoryHandler.categoryService.GetCategoryTree(ctx)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get category tree failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.CategoryTreeView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get category tree successful"
+	res.Body.Data = categoryTree
+	res.Body.Total = len(categoryTree)
+	return res, nil
+}
+
+func (categoryHandler *CategoryHandler) GetArchivedCategories(ctx context.Context, reqDTO *dto.GetArchivedCategoriesRequest) (*dto.PaginationBodyResponseList[*model.CategoryView], error) {
+	categories, err := categoryHandler.categoryService.GetArchivedCategories(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get archived categories failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.CategoryView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get archived categories successful"
+	res.Body.Data = categories
+	res.Body.Total = len(categories)
+	return res, nil
+}
+
 func (categoryHandler *CategoryHandler) GetCategoryById(ctx context.Context, reqDTO *dto.GetCategoryByIdRequest) (*dto.BodyResponse[*model.CategoryView], error) {
 	if reqDTO.Id == "{id}" {
 		res := &dto.ErrorResponse{}
@@ -119,6 +207,38 @@ func (categoryHandler *CategoryHandler) GetCategoryById(ctx context.Context, req
 	return res, nil
 }
 
+func (categoryHandler *CategoryHandler) GetCategoryBySlug(ctx context.Context, reqDTO *dto.GetCategoryBySlugRequest) (*dto.SlugBodyResponse[*model.CategoryView], error) {
+	if reqDTO.Slug == "{slug}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get category by slug failed"
+		res.Details = []string{"missing path parameters: slug"}
+		return nil, res
+	}
+
+	foundCategory, err := categoryHandler.categoryService.GetCategoryBySlug(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get category by slug failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SlugBodyResponse[*model.CategoryView]{}
+	res.Status = http.StatusOK
+	if foundCategory.Slug != reqDTO.Slug {
+		res.Status = http.StatusMovedPermanently
+		res.Location = "/categories/slug/" + foundCategory.Slug
+	}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get category by slug successful"
+	res.Body.Data = foundCategory
+	return res, nil
+}
+
 func (categoryHandler *CategoryHandler) CreateCategory(ctx context.Context, reqDTO *dto.CreateCategoryRequest) (*dto.SuccessResponse, error) {
 	if err := categoryHandler.categoryService.CreateCategory(ctx, reqDTO); err != nil {
 		res := &dto.ErrorResponse{}
@@ -160,6 +280,31 @@ func (categoryHandler *CategoryHandler) UpdateCategoryById(ctx context.Context,
 	return res, nil
 }
 
+func (categoryHandler *CategoryHandler) MoveCategoryById(ctx context.Context, reqDTO *dto.MoveCategoryByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Move category by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := categoryHandler.categoryService.MoveCategoryById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Move category by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Move category by id successful"
+	return res, nil
+}
+
 func (categoryHandler *CategoryHandler) DeleteCategoryById(ctx context.Context, reqDTO *dto.DeleteCategoryByIdRequest) (*dto.SuccessResponse, error) {
 	if reqDTO.Id == "{id}" {
 		res := &dto.ErrorResponse{}
@@ -184,3 +329,28 @@ func (categoryHandler *CategoryHandler) DeleteCategoryById(ctx context.Context,
 	res.Body.Message = "Delete category by id successful"
 	return res, nil
 }
+
+func (categoryHandler *CategoryHandler) RestoreCategoryById(ctx context.Context, reqDTO *dto.RestoreCategoryByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Restore category by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := categoryHandler.categoryService.RestoreCategoryById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Restore category by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Restore category by id successful"
+	return res, nil
+}
@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/config"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type CollectionHandler struct {
+	collectionService service.CollectionService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewCollectionHandler(api huma.API, collectionService service.CollectionService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *CollectionHandler {
+	collectionHandler := &CollectionHandler{
+		collectionService: collectionService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get collections
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/collections",
+		Summary:     "/collections",
+		Description: "Get collections, customers only see published collections inside their schedule.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, collectionHandler.GetCollections)
+
+	// Get collection by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/collections/id/{id}",
+		Summary:     "/collections/id/{id}",
+		Description: "Get collection by id.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, collectionHandler.GetCollectionById)
+
+	// Get collection products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/collections/id/{id}/products",
+		Summary:     "/collections/id/{id}/products",
+		Description: "Get products of collection with live stock and price, hand-picked products first then products matching rule.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, collectionHandler.GetCollectionProducts)
+
+	// Create collection
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/collections",
+		Summary:     "/collections",
+		Description: "Create collection.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, collectionHandler.CreateCollection)
+
+	// Update collection by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/collections/id/{id}",
+		Summary:     "/collections/id/{id}",
+		Description: "Update collection by id.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, collectionHandler.UpdateCollectionById)
+
+	// Update collection products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/collections/id/{id}/products",
+		Summary:     "/collections/id/{id}/products",
+		Description: "Replace hand-picked products of collection, order of ids is order of collection.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, collectionHandler.UpdateCollectionProducts)
+
+	// Upload collection banner
+	huma.Register(api, huma.Operation{
+		Method:       http.MethodPut,
+		Path:         "/collections/id/{id}/banner",
+		Summary:      "/collections/id/{id}/banner",
+		Description:  "Upload banner image of collection, it replaces the current banner.",
+		Tags:         []string{"Collection"},
+		MaxBodyBytes: config.AppConfig.MediaMaxUploadSizeValue() + 1<<20,
+		Middlewares:  huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, collectionHandler.UploadCollectionBanner)
+
+	// Delete collection banner
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/collections/id/{id}/banner",
+		Summary:     "/collections/id/{id}/banner",
+		Description: "Delete banner image of collection.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, collectionHandler.DeleteCollectionBanner)
+
+	// Delete collection by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/collections/id/{id}",
+		Summary:     "/collections/id/{id}",
+		Description: "Delete collection by id.",
+		Tags:        []string{"Collection"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, collectionHandler.DeleteCollectionById)
+
+	return collectionHandler
+}
+
+func (collectionHandler *CollectionHandler) GetCollections(ctx context.Context, reqDTO *dto.GetCollectionsRequest) (*dto.PaginationBodyResponseList[*model.CollectionView], error) {
+	collections, err := collectionHandler.collectionService.GetCollections(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get collections failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.CollectionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get collections successful"
+	res.Body.Data = collections
+	res.Body.Total = len(collections)
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) GetCollectionById(ctx context.Context, reqDTO *dto.GetCollectionByIdRequest) (*dto.BodyResponse[*model.CollectionView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get collection by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundCollection, err := collectionHandler.collectionService.GetCollectionById(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get collection by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.CollectionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get collection by id successful"
+	res.Body.Data = foundCollection
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) GetCollectionProducts(ctx context.Context, reqDTO *dto.GetCollectionProductsRequest) (*dto.PaginationBodyResponseList[*model.ProductView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get collection products failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	products, err := collectionHandler.collectionService.GetCollectionProducts(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get collection products failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ProductView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get collection products successful"
+	res.Body.Data = products
+	res.Body.Total = len(products)
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) CreateCollection(ctx context.Context, reqDTO *dto.CreateCollectionRequest) (*dto.BodyResponse[*model.CollectionView], error) {
+	newCollection, err := collectionHandler.collectionService.CreateCollection(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create collection failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.CollectionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create collection successful"
+	res.Body.Data = newCollection
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) UpdateCollectionById(ctx context.Context, reqDTO *dto.UpdateCollectionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update collection by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := collectionHandler.collectionService.UpdateCollectionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update collection by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update collection by id successful"
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) UpdateCollectionProducts(ctx context.Context, reqDTO *dto.UpdateCollectionProductsRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update collection products failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := collectionHandler.collectionService.UpdateCollectionProducts(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update collection products failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update collection products successful"
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) UploadCollectionBanner(ctx context.Context, reqDTO *dto.UploadCollectionBannerRequest) (*dto.BodyResponse[*model.CollectionView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Upload collection banner failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	updatedCollection, err := collectionHandler.collectionService.UploadCollectionBanner(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Upload collection banner failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.CollectionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Upload collection banner successful"
+	res.Body.Data = updatedCollection
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) DeleteCollectionBanner(ctx context.Context, reqDTO *dto.DeleteCollectionBannerRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete collection banner failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := collectionHandler.collectionService.DeleteCollectionBanner(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete collection banner failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete collection banner successful"
+	return res, nil
+}
+
+func (collectionHandler *CollectionHandler) DeleteCollectionById(ctx context.Context, reqDTO *dto.DeleteCollectionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete collection by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := collectionHandler.collectionService.DeleteCollectionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete collection by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete collection by id successful"
+	return res, nil
+}
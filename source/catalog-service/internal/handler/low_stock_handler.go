@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type LowStockHandler struct {
+	lowStockService   service.LowStockService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewLowStockHandler(api huma.API, lowStockService service.LowStockService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *LowStockHandler {
+	lowStockHandler := &LowStockHandler{
+		lowStockService:   lowStockService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get low stock products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/low-stock",
+		Summary:     "/products/low-stock",
+		Description: "Get published products at or below their low stock threshold (threshold of product, else of nearest category, else default), lowest stock against threshold first.",
+		Tags:        []string{"Low Stock"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, lowStockHandler.GetLowStockProducts)
+
+	// Get reorder suggestions
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/reorder-suggestions",
+		Summary:     "/products/reorder-suggestions",
+		Description: "Get reorder suggestions from sales velocity, suggested quantity covers lead time and cover days on top of low stock threshold, products running out soonest first.",
+		Tags:        []string{"Low Stock"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, lowStockHandler.GetReorderSuggestions)
+
+	return lowStockHandler
+}
+
+func (lowStockHandler *LowStockHandler) GetLowStockProducts(ctx context.Context, reqDTO *dto.GetLowStockProductsRequest) (*dto.PaginationBodyResponseList[*model.LowStockProductView], error) {
+	products, err := lowStockHandler.lowStockService.GetLowStockProducts(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get low stock products failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.LowStockProductView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get low stock products successful"
+	res.Body.Data = products
+	res.Body.Total = len(products)
+	return res, nil
+}
+
+func (lowStockHandler *LowStockHandler) GetReorderSuggestions(ctx context.Context, reqDTO *dto.GetReorderSuggestionsRequest) (*dto.PaginationBodyResponseList[*model.ReorderSuggestionView], error) {
+	suggestions, err := lowStockHandler.lowStockService.GetReorderSuggestions(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get reorder suggestions failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ReorderSuggestionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get reorder suggestions successful"
+	res.Body.Data = suggestions
+	res.Body.Total = len(suggestions)
+	return res, nil
+}
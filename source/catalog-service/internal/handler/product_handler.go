@@ -29,17 +29,115 @@ func NewProductHandler(api huma.API, productService service.ProductService, jwtA
 		Summary:     "/products",
 		Description: "Get products.",
 		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
 	}, productHandler.GetProducts)
 
+	// Get top products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/top",
+		Summary:     "/products/top",
+		Description: "Get top-selling products.",
+		Tags:        []string{"Product"},
+	}, productHandler.GetTopProducts)
+
+	// Get trending products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/trending",
+		Summary:     "/products/trending",
+		Description: "Get trending products.",
+		Tags:        []string{"Product"},
+	}, productHandler.GetTrendingProducts)
+
+	// Get archived products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/archived",
+		Summary:     "/products/archived",
+		Description: "Get archived products.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productHandler.GetArchivedProducts)
+
 	// Get product by id
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodGet,
 		Path:        "/products/id/{id}",
 		Summary:     "/products/id/{id}",
-		Description: "Get product by id.",
+		Description: "Get product by id with its size chart, and fit recommendation when logged in.",
 		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
 	}, productHandler.GetProductById)
 
+	// Get product by slug
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/slug/{slug}",
+		Summary:     "/products/slug/{slug}",
+		Description: "Get product by slug, a former slug is answered with 301 redirect to the current one.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, productHandler.GetProductBySlug)
+
+	// Get product recommendations
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/recommendations",
+		Summary:     "/products/id/{id}/recommendations",
+		Description: "Get similar and frequently bought together products of product.",
+		Tags:        []string{"Product"},
+	}, productHandler.GetProductRecommendations)
+
+	// Get product price history
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/price-history",
+		Summary:     "/products/id/{id}/price-history",
+		Description: "Get history of price customers pay for product, promotions included.",
+		Tags:        []string{"Product"},
+	}, productHandler.GetProductPriceHistory)
+
+	// Click product
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/products/id/{id}/click",
+		Summary:     "/products/id/{id}/click",
+		Description: "Record click on product from search result.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, productHandler.ClickProduct)
+
+	// Get top search queries report
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/search-reports/top-queries",
+		Summary:     "/products/search-reports/top-queries",
+		Description: "Get most searched queries.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productHandler.GetTopQueriesReport)
+
+	// Get zero-result search queries report
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/search-reports/zero-result-queries",
+		Summary:     "/products/search-reports/zero-result-queries",
+		Description: "Get most searched queries which returned no product.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productHandler.GetZeroResultQueriesReport)
+
+	// Get click-through rate report
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/search-reports/click-through-rate",
+		Summary:     "/products/search-reports/click-through-rate",
+		Description: "Get click-through rate of most searched queries, lowest rate first.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productHandler.GetClickThroughRateReport)
+
 	// Create product
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -70,11 +168,21 @@ func NewProductHandler(api huma.API, productService service.ProductService, jwtA
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
 	}, productHandler.DeleteProductById)
 
+	// Restore product by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/products/id/{id}/restore",
+		Summary:     "/products/id/{id}/restore",
+		Description: "Restore archived product by id as draft.",
+		Tags:        []string{"Product"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productHandler.RestoreProductById)
+
 	return productHandler
 }
 
-func (productHandler *ProductHandler) GetProducts(ctx context.Context, reqDTO *dto.GetProductsRequest) (*dto.PaginationBodyResponseList[*model.ProductView], error) {
-	products, err := productHandler.productService.GetProducts(ctx, reqDTO)
+func (productHandler *ProductHandler) GetProducts(ctx context.Context, reqDTO *dto.GetProductsRequest) (*dto.SearchPaginationBodyResponseList[*model.ProductView, *model.ProductFacetView], error) {
+	products, facets, searchId, err := productHandler.productService.GetProducts(ctx, reqDTO)
 	if err != nil {
 		res := &dto.ErrorResponse{}
 		res.Status = http.StatusInternalServerError
@@ -84,15 +192,74 @@ func (productHandler *ProductHandler) GetProducts(ctx context.Context, reqDTO *d
 		return nil, res
 	}
 
-	res := &dto.PaginationBodyResponseList[*model.ProductView]{}
+	res := &dto.SearchPaginationBodyResponseList[*model.ProductView, *model.ProductFacetView]{}
+	res.SearchId = searchId
 	res.Body.Code = "OK"
 	res.Body.Message = "Get products successful"
 	res.Body.Data = products
 	res.Body.Total = len(products)
+	res.Body.Facets = facets
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetTopProducts(ctx context.Context, reqDTO *dto.GetTopProductsRequest) (*dto.PaginationBodyResponseList[*model.RankedProductView], error) {
+	rankedProducts, err := productHandler.productService.GetTopProducts(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get top products failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.RankedProductView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get top products successful"
+	res.Body.Data = rankedProducts
+	res.Body.Total = len(rankedProducts)
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetTrendingProducts(ctx context.Context, reqDTO *dto.GetTrendingProductsRequest) (*dto.PaginationBodyResponseList[*model.RankedProductView], error) {
+	rankedProducts, err := productHandler.productService.GetTrendingProducts(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get trending products failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.RankedProductView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get trending products successful"
+	res.Body.Data = rankedProducts
+	res.Body.Total = len(rankedProducts)
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetArchivedProducts(ctx context.Context, reqDTO *dto.GetArchivedProductsRequest) (*dto.PaginationBodyResponseList[*model.ProductView], error) {
+	products, err := productHandler.productService.GetArchivedProducts(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get archived products failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ProductView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get archived products successful"
+	res.Body.Data = products
+	res.Body.Total = len(products)
 	return res, nil
 }
 
-func (productHandler *ProductHandler) GetProductById(ctx context.Context, reqDTO *dto.GetProductByIdRequest) (*dto.BodyResponse[*model.ProductView], error) {
+func (productHandler *ProductHandler) GetProductById(ctx context.Context, reqDTO *dto.GetProductByIdRequest) (*dto.BodyResponse[*model.ProductDetailView], error) {
 	if reqDTO.Id == "{id}" {
 		res := &dto.ErrorResponse{}
 		res.Status = http.StatusBadRequest
@@ -102,7 +269,7 @@ func (productHandler *ProductHandler) GetProductById(ctx context.Context, reqDTO
 		return nil, res
 	}
 
-	foundProduct, err := productHandler.productService.GetProductById(ctx, reqDTO)
+	foundProduct, err := productHandler.productService.GetProductDetailById(ctx, reqDTO)
 	if err != nil {
 		res := &dto.ErrorResponse{}
 		res.Status = http.StatusBadRequest
@@ -112,13 +279,185 @@ func (productHandler *ProductHandler) GetProductById(ctx context.Context, reqDTO
 		return nil, res
 	}
 
-	res := &dto.BodyResponse[*model.ProductView]{}
+	res := &dto.BodyResponse[*model.ProductDetailView]{}
 	res.Body.Code = "OK"
 	res.Body.Message = "Get product by id successful"
 	res.Body.Data = foundProduct
 	return res, nil
 }
 
+func (productHandler *ProductHandler) GetProductBySlug(ctx context.Context, reqDTO *dto.GetProductBySlugRequest) (*dto.SlugBodyResponse[*model.ProductView], error) {
+	if reqDTO.Slug == "{slug}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product by slug failed"
+		res.Details = []string{"missing path parameters: slug"}
+		return nil, res
+	}
+
+	foundProduct, err := productHandler.productService.GetProductBySlug(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product by slug failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SlugBodyResponse[*model.ProductView]{}
+	res.Status = http.StatusOK
+	if foundProduct.Slug != reqDTO.Slug {
+		res.Status = http.StatusMovedPermanently
+		res.Location = "/products/slug/" + foundProduct.Slug
+	}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product by slug successful"
+	res.Body.Data = foundProduct
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetProductRecommendations(ctx context.Context, reqDTO *dto.GetProductRecommendationsRequest) (*dto.BodyResponse[*model.ProductRecommendationsView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product recommendations failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	recommendations, err := productHandler.productService.GetProductRecommendations(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product recommendations failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.ProductRecommendationsView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product recommendations successful"
+	res.Body.Data = recommendations
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetProductPriceHistory(ctx context.Context, reqDTO *dto.GetProductPriceHistoryRequest) (*dto.PaginationBodyResponseList[*model.ProductPriceHistoryView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product price history failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	productPriceHistories, err := productHandler.productService.GetProductPriceHistory(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product price history failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ProductPriceHistoryView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product price history successful"
+	res.Body.Data = productPriceHistories
+	res.Body.Total = len(productPriceHistories)
+	return res, nil
+}
+
+func (productHandler *ProductHandler) ClickProduct(ctx context.Context, reqDTO *dto.ClickProductRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Click product failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := productHandler.productService.ClickProduct(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Click product failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Click product successful"
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetTopQueriesReport(ctx context.Context, reqDTO *dto.GetSearchReportRequest) (*dto.BodyResponse[*model.SearchReportView], error) {
+	reqDTO.Type = "top"
+
+	searchReport, err := productHandler.productService.GetSearchReport(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get top queries report failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SearchReportView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get top queries report successful"
+	res.Body.Data = searchReport
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetZeroResultQueriesReport(ctx context.Context, reqDTO *dto.GetSearchReportRequest) (*dto.BodyResponse[*model.SearchReportView], error) {
+	reqDTO.Type = "zero_result"
+
+	searchReport, err := productHandler.productService.GetSearchReport(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get zero-result queries report failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SearchReportView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get zero-result queries report successful"
+	res.Body.Data = searchReport
+	return res, nil
+}
+
+func (productHandler *ProductHandler) GetClickThroughRateReport(ctx context.Context, reqDTO *dto.GetSearchReportRequest) (*dto.BodyResponse[*model.SearchReportView], error) {
+	reqDTO.Type = "click_through"
+
+	searchReport, err := productHandler.productService.GetSearchReport(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get click-through rate report failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SearchReportView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get click-through rate report successful"
+	res.Body.Data = searchReport
+	return res, nil
+}
+
 func (productHandler *ProductHandler) CreateProduct(ctx context.Context, reqDTO *dto.CreateProductRequest) (*dto.SuccessResponse, error) {
 	if err := productHandler.productService.CreateProduct(ctx, reqDTO); err != nil {
 		res := &dto.ErrorResponse{}
@@ -184,3 +523,28 @@ func (productHandler *ProductHandler) DeleteProductById(ctx context.Context, req
 	res.Body.Message = "Delete product by id successful"
 	return res, nil
 }
+
+func (productHandler *ProductHandler) RestoreProductById(ctx context.Context, reqDTO *dto.RestoreProductByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Restore product by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := productHandler.productService.RestoreProductById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Restore product by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Restore product by id successful"
+	return res, nil
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/config"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ProductImageHandler struct {
+	productImageService service.ProductImageService
+	jwtAuthMiddleware   *middleware.JWTAuthMiddleware
+}
+
+func NewProductImageHandler(api huma.API, productImageService service.ProductImageService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *ProductImageHandler {
+	productImageHandler := &ProductImageHandler{
+		productImageService: productImageService,
+		jwtAuthMiddleware:   jwtAuthMiddleware,
+	}
+
+	// Get product images
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/images",
+		Summary:     "/products/id/{id}/images",
+		Description: "Get gallery images of product.",
+		Tags:        []string{"Product Image"},
+	}, productImageHandler.GetProductImages)
+
+	// Upload product image
+	huma.Register(api, huma.Operation{
+		Method:       http.MethodPost,
+		Path:         "/products/id/{id}/images",
+		Summary:      "/products/id/{id}/images",
+		Description:  "Upload image to gallery of product, thumbnails are generated automatically.",
+		Tags:         []string{"Product Image"},
+		MaxBodyBytes: config.AppConfig.MediaMaxUploadSizeValue() + 1<<20,
+		Middlewares:  huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImageHandler.UploadProductImage)
+
+	// Reorder product images
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/products/id/{id}/images/order",
+		Summary:     "/products/id/{id}/images/order",
+		Description: "Reorder gallery images of product.",
+		Tags:        []string{"Product Image"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImageHandler.ReorderProductImages)
+
+	// Set primary product image
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/products/id/{id}/images/id/{image_id}/primary",
+		Summary:     "/products/id/{id}/images/id/{image_id}/primary",
+		Description: "Mark image as primary image of product.",
+		Tags:        []string{"Product Image"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImageHandler.SetPrimaryProductImage)
+
+	// Delete product image
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/products/id/{id}/images/id/{image_id}",
+		Summary:     "/products/id/{id}/images/id/{image_id}",
+		Description: "Delete image from gallery of product.",
+		Tags:        []string{"Product Image"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImageHandler.DeleteProductImage)
+
+	return productImageHandler
+}
+
+func (productImageHandler *ProductImageHandler) GetProductImages(ctx context.Context, reqDTO *dto.GetProductImagesRequest) (*dto.PaginationBodyResponseList[*model.ProductImageView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product images failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	productImages, err := productImageHandler.productImageService.GetProductImages(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product images failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ProductImageView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product images successful"
+	res.Body.Data = productImages
+	res.Body.Total = len(productImages)
+	return res, nil
+}
+
+func (productImageHandler *ProductImageHandler) UploadProductImage(ctx context.Context, reqDTO *dto.UploadProductImageRequest) (*dto.BodyResponse[*model.ProductImageView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Upload product image failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	newProductImage, err := productImageHandler.productImageService.UploadProductImage(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Upload product image failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.ProductImageView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Upload product image successful"
+	res.Body.Data = newProductImage
+	return res, nil
+}
+
+func (productImageHandler *ProductImageHandler) ReorderProductImages(ctx context.Context, reqDTO *dto.ReorderProductImagesRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Reorder product images failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := productImageHandler.productImageService.ReorderProductImages(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Reorder product images failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Reorder product images successful"
+	return res, nil
+}
+
+func (productImageHandler *ProductImageHandler) SetPrimaryProductImage(ctx context.Context, reqDTO *dto.SetPrimaryProductImageRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" || reqDTO.ImageId == "{image_id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Set primary product image failed"
+		res.Details = []string{"missing path parameters: id, image_id"}
+		return nil, res
+	}
+
+	if err := productImageHandler.productImageService.SetPrimaryProductImage(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Set primary product image failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Set primary product image successful"
+	return res, nil
+}
+
+func (productImageHandler *ProductImageHandler) DeleteProductImage(ctx context.Context, reqDTO *dto.DeleteProductImageRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" || reqDTO.ImageId == "{image_id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete product image failed"
+		res.Details = []string{"missing path parameters: id, image_id"}
+		return nil, res
+	}
+
+	if err := productImageHandler.productImageService.DeleteProductImage(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete product image failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete product image successful"
+	return res, nil
+}
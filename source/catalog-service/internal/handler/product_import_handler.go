@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"thanhldt060802/config"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ProductImportHandler struct {
+	productImportService service.ProductImportService
+	jwtAuthMiddleware    *middleware.JWTAuthMiddleware
+}
+
+func NewProductImportHandler(api huma.API, productImportService service.ProductImportService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *ProductImportHandler {
+	productImportHandler := &ProductImportHandler{
+		productImportService: productImportService,
+		jwtAuthMiddleware:    jwtAuthMiddleware,
+	}
+
+	// Create product import job
+	huma.Register(api, huma.Operation{
+		Method:       http.MethodPost,
+		Path:         "/products/imports",
+		Summary:      "/products/imports",
+		Description:  "Upload CSV/XLSX file of products, they are upserted by sku in background.",
+		Tags:         []string{"Product Import"},
+		MaxBodyBytes: config.AppConfig.MediaMaxUploadSizeValue() + 1<<20,
+		Middlewares:  huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImportHandler.CreateProductImportJob)
+
+	// Get product import jobs
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/imports",
+		Summary:     "/products/imports",
+		Description: "Get product import jobs.",
+		Tags:        []string{"Product Import"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImportHandler.GetProductImportJobs)
+
+	// Get product import job by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/imports/id/{id}",
+		Summary:     "/products/imports/id/{id}",
+		Description: "Get progress and row errors of product import job by id.",
+		Tags:        []string{"Product Import"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImportHandler.GetProductImportJobById)
+
+	// Export products
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/export",
+		Summary:     "/products/export",
+		Description: "Export all products as CSV/XLSX file with the columns of product import.",
+		Tags:        []string{"Product Import"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, productImportHandler.ExportProducts)
+
+	return productImportHandler
+}
+
+func (productImportHandler *ProductImportHandler) CreateProductImportJob(ctx context.Context, reqDTO *dto.CreateProductImportJobRequest) (*dto.BodyResponse[*model.ProductImportJobView], error) {
+	newProductImportJob, err := productImportHandler.productImportService.CreateProductImportJob(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create product import job failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.ProductImportJobView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create product import job successful"
+	res.Body.Data = newProductImportJob
+	return res, nil
+}
+
+func (productImportHandler *ProductImportHandler) GetProductImportJobs(ctx context.Context, reqDTO *dto.GetProductImportJobsRequest) (*dto.PaginationBodyResponseList[*model.ProductImportJobView], error) {
+	productImportJobs, err := productImportHandler.productImportService.GetProductImportJobs(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get product import jobs failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ProductImportJobView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product import jobs successful"
+	res.Body.Data = productImportJobs
+	res.Body.Total = len(productImportJobs)
+	return res, nil
+}
+
+func (productImportHandler *ProductImportHandler) GetProductImportJobById(ctx context.Context, reqDTO *dto.GetProductImportJobByIdRequest) (*dto.BodyResponse[*model.ProductImportJobView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product import job by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundProductImportJob, err := productImportHandler.productImportService.GetProductImportJobById(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product import job by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.ProductImportJobView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product import job by id successful"
+	res.Body.Data = foundProductImportJob
+	return res, nil
+}
+
+// File is streamed as products are read, an error after the first bytes are sent can only be logged
+func (productImportHandler *ProductImportHandler) ExportProducts(ctx context.Context, reqDTO *dto.ExportProductsRequest) (*huma.StreamResponse, error) {
+	contentType := "text/csv"
+	if reqDTO.Format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) {
+			humaCtx.SetHeader("Content-Type", contentType)
+			humaCtx.SetHeader("Content-Disposition", `attachment; filename="products.`+reqDTO.Format+`"`)
+
+			if err := productImportHandler.productImportService.ExportProducts(ctx, reqDTO, humaCtx.BodyWriter()); err != nil {
+				log.Printf("Export products failed: %s", err.Error())
+			}
+		},
+	}, nil
+}
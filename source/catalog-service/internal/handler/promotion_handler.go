@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type PromotionHandler struct {
+	promotionService  service.PromotionService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewPromotionHandler(api huma.API, promotionService service.PromotionService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *PromotionHandler {
+	promotionHandler := &PromotionHandler{
+		promotionService:  promotionService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get promotions
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/promotions",
+		Summary:     "/promotions",
+		Description: "Get promotions.",
+		Tags:        []string{"Promotion"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, promotionHandler.GetPromotions)
+
+	// Get promotion by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/promotions/id/{id}",
+		Summary:     "/promotions/id/{id}",
+		Description: "Get promotion by id.",
+		Tags:        []string{"Promotion"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, promotionHandler.GetPromotionById)
+
+	// Create promotion
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/promotions",
+		Summary:     "/promotions",
+		Description: "Create promotion, it is activated at start time and expired at end time or when its allotment is sold out.",
+		Tags:        []string{"Promotion"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, promotionHandler.CreatePromotion)
+
+	// Update promotion by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/promotions/id/{id}",
+		Summary:     "/promotions/id/{id}",
+		Description: "Update promotion by id, discount, targets and start time only before it starts.",
+		Tags:        []string{"Promotion"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, promotionHandler.UpdatePromotionById)
+
+	// Cancel promotion by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/promotions/id/{id}/cancel",
+		Summary:     "/promotions/id/{id}/cancel",
+		Description: "Cancel promotion by id, products are no longer discounted by it.",
+		Tags:        []string{"Promotion"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, promotionHandler.CancelPromotionById)
+
+	// Delete promotion by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/promotions/id/{id}",
+		Summary:     "/promotions/id/{id}",
+		Description: "Delete promotion by id, only when no invoice used it.",
+		Tags:        []string{"Promotion"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, promotionHandler.DeletePromotionById)
+
+	return promotionHandler
+}
+
+func (promotionHandler *PromotionHandler) GetPromotions(ctx context.Context, reqDTO *dto.GetPromotionsRequest) (*dto.PaginationBodyResponseList[*model.PromotionView], error) {
+	promotions, err := promotionHandler.promotionService.GetPromotions(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get promotions failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.PromotionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get promotions successful"
+	res.Body.Data = promotions
+	res.Body.Total = len(promotions)
+	return res, nil
+}
+
+func (promotionHandler *PromotionHandler) GetPromotionById(ctx context.Context, reqDTO *dto.GetPromotionByIdRequest) (*dto.BodyResponse[*model.PromotionView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get promotion by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundPromotion, err := promotionHandler.promotionService.GetPromotionById(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get promotion by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.PromotionView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get promotion by id successful"
+	res.Body.Data = foundPromotion
+	return res, nil
+}
+
+func (promotionHandler *PromotionHandler) CreatePromotion(ctx context.Context, reqDTO *dto.CreatePromotionRequest) (*dto.SuccessResponse, error) {
+	if err := promotionHandler.promotionService.CreatePromotion(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create promotion failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create promotion successful"
+	return res, nil
+}
+
+func (promotionHandler *PromotionHandler) UpdatePromotionById(ctx context.Context, reqDTO *dto.UpdatePromotionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update promotion by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := promotionHandler.promotionService.UpdatePromotionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update promotion by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update promotion by id successful"
+	return res, nil
+}
+
+func (promotionHandler *PromotionHandler) CancelPromotionById(ctx context.Context, reqDTO *dto.CancelPromotionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Cancel promotion by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := promotionHandler.promotionService.CancelPromotionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Cancel promotion by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Cancel promotion by id successful"
+	return res, nil
+}
+
+func (promotionHandler *PromotionHandler) DeletePromotionById(ctx context.Context, reqDTO *dto.DeletePromotionByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete promotion by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := promotionHandler.promotionService.DeletePromotionById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete promotion by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete promotion by id successful"
+	return res, nil
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/config"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ReviewHandler struct {
+	reviewService     service.ReviewService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewReviewHandler(api huma.API, reviewService service.ReviewService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *ReviewHandler {
+	reviewHandler := &ReviewHandler{
+		reviewService:     reviewService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get product reviews
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/reviews",
+		Summary:     "/products/id/{id}/reviews",
+		Description: "Get approved reviews of product.",
+		Tags:        []string{"Review"},
+	}, reviewHandler.GetProductReviews)
+
+	// Create review
+	huma.Register(api, huma.Operation{
+		Method:       http.MethodPost,
+		Path:         "/products/id/{id}/reviews",
+		Summary:      "/products/id/{id}/reviews",
+		Description:  "Create review of product, it is shown after being approved.",
+		Tags:         []string{"Review"},
+		MaxBodyBytes: 5*config.AppConfig.MediaMaxUploadSizeValue() + 1<<20,
+		Middlewares:  huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, reviewHandler.CreateReview)
+
+	// Delete review by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/reviews/id/{id}",
+		Summary:     "/reviews/id/{id}",
+		Description: "Delete own review, ADMIN and STAFF can delete any review.",
+		Tags:        []string{"Review"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, reviewHandler.DeleteReviewById)
+
+	// Get reviews
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/reviews",
+		Summary:     "/reviews",
+		Description: "Get reviews for moderation.",
+		Tags:        []string{"Review"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, reviewHandler.GetReviews)
+
+	// Moderate review by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/reviews/id/{id}/moderate",
+		Summary:     "/reviews/id/{id}/moderate",
+		Description: "Approve or reject review.",
+		Tags:        []string{"Review"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, reviewHandler.ModerateReviewById)
+
+	return reviewHandler
+}
+
+func (reviewHandler *ReviewHandler) GetProductReviews(ctx context.Context, reqDTO *dto.GetProductReviewsRequest) (*dto.PaginationBodyResponseList[*model.ReviewView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product reviews failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	reviews, err := reviewHandler.reviewService.GetProductReviews(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get product reviews failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ReviewView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get product reviews successful"
+	res.Body.Data = reviews
+	res.Body.Total = len(reviews)
+	return res, nil
+}
+
+func (reviewHandler *ReviewHandler) CreateReview(ctx context.Context, reqDTO *dto.CreateReviewRequest) (*dto.BodyResponse[*model.ReviewView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create review failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	newReview, err := reviewHandler.reviewService.CreateReview(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create review failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.ReviewView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create review successful"
+	res.Body.Data = newReview
+	return res, nil
+}
+
+func (reviewHandler *ReviewHandler) DeleteReviewById(ctx context.Context, reqDTO *dto.DeleteReviewByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete review by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := reviewHandler.reviewService.DeleteReviewById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete review by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete review by id successful"
+	return res, nil
+}
+
+func (reviewHandler *ReviewHandler) GetReviews(ctx context.Context, reqDTO *dto.GetReviewsRequest) (*dto.PaginationBodyResponseList[*model.ReviewView], error) {
+	reviews, err := reviewHandler.reviewService.GetReviews(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get reviews failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.ReviewView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get reviews successful"
+	res.Body.Data = reviews
+	res.Body.Total = len(reviews)
+	return res, nil
+}
+
+func (reviewHandler *ReviewHandler) ModerateReviewById(ctx context.Context, reqDTO *dto.ModerateReviewByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Moderate review by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := reviewHandler.reviewService.ModerateReviewById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Moderate review by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Moderate review by id successful"
+	return res, nil
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SizeChartHandler struct {
+	sizeChartService  service.SizeChartService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewSizeChartHandler(api huma.API, sizeChartService service.SizeChartService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *SizeChartHandler {
+	sizeChartHandler := &SizeChartHandler{
+		sizeChartService:  sizeChartService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get size charts
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/size-charts",
+		Summary:     "/size-charts",
+		Description: "Get size charts, optionally filtered by brand and category.",
+		Tags:        []string{"Size Chart"},
+	}, sizeChartHandler.GetSizeCharts)
+
+	// Get size chart by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/size-charts/id/{id}",
+		Summary:     "/size-charts/id/{id}",
+		Description: "Get size chart by id.",
+		Tags:        []string{"Size Chart"},
+	}, sizeChartHandler.GetSizeChartById)
+
+	// Create size chart
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/size-charts",
+		Summary:     "/size-charts",
+		Description: "Create size chart of brand for category, ranges of measurements are in cm.",
+		Tags:        []string{"Size Chart"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, sizeChartHandler.CreateSizeChart)
+
+	// Update size chart by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/size-charts/id/{id}",
+		Summary:     "/size-charts/id/{id}",
+		Description: "Update size chart by id, sizes given replace all current sizes.",
+		Tags:        []string{"Size Chart"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, sizeChartHandler.UpdateSizeChartById)
+
+	// Delete size chart by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/size-charts/id/{id}",
+		Summary:     "/size-charts/id/{id}",
+		Description: "Delete size chart by id.",
+		Tags:        []string{"Size Chart"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, sizeChartHandler.DeleteSizeChartById)
+
+	// Get size chart of product
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/size-chart",
+		Summary:     "/products/id/{id}/size-chart",
+		Description: "Get size chart of product, resolved by brand of product and the nearest category up the tree of category of product.",
+		Tags:        []string{"Size Chart"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.OptionalAuthentication},
+	}, sizeChartHandler.GetProductSizeChart)
+
+	// Get fit recommendation of product
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/fit-recommendation",
+		Summary:     "/products/id/{id}/fit-recommendation",
+		Description: "Get size of product recommended for body measurements of current user, admin or staff can ask for other user by user_id.",
+		Tags:        []string{"Size Chart"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, sizeChartHandler.GetProductFitRecommendation)
+
+	return sizeChartHandler
+}
+
+func (sizeChartHandler *SizeChartHandler) GetSizeCharts(ctx context.Context, reqDTO *dto.GetSizeChartsRequest) (*dto.PaginationBodyResponseList[*model.SizeChartView], error) {
+	sizeCharts, err := sizeChartHandler.sizeChartService.GetSizeCharts(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get size charts failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.SizeChartView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get size charts successful"
+	res.Body.Data = sizeCharts
+	res.Body.Total = len(sizeCharts)
+	return res, nil
+}
+
+func (sizeChartHandler *SizeChartHandler) GetSizeChartById(ctx context.Context, reqDTO *dto.GetSizeChartByIdRequest) (*dto.BodyResponse[*model.SizeChartView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get size chart by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundSizeChart, err := sizeChartHandler.sizeChartService.GetSizeChartById(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get size chart by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SizeChartView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get size chart by id successful"
+	res.Body.Data = foundSizeChart
+	return res, nil
+}
+
+func (sizeChartHandler *SizeChartHandler) CreateSizeChart(ctx context.Context, reqDTO *dto.CreateSizeChartRequest) (*dto.BodyResponse[*model.SizeChartView], error) {
+	newSizeChart, err := sizeChartHandler.sizeChartService.CreateSizeChart(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create size chart failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SizeChartView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create size chart successful"
+	res.Body.Data = newSizeChart
+	return res, nil
+}
+
+func (sizeChartHandler *SizeChartHandler) UpdateSizeChartById(ctx context.Context, reqDTO *dto.UpdateSizeChartByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update size chart by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := sizeChartHandler.sizeChartService.UpdateSizeChartById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update size chart by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update size chart by id successful"
+	return res, nil
+}
+
+func (sizeChartHandler *SizeChartHandler) DeleteSizeChartById(ctx context.Context, reqDTO *dto.DeleteSizeChartByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete size chart by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := sizeChartHandler.sizeChartService.DeleteSizeChartById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete size chart by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete size chart by id successful"
+	return res, nil
+}
+
+func (sizeChartHandler *SizeChartHandler) GetProductSizeChart(ctx context.Context, reqDTO *dto.GetProductSizeChartRequest) (*dto.BodyResponse[*model.SizeChartView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get size chart of product failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundSizeChart, err := sizeChartHandler.sizeChartService.GetProductSizeChart(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get size chart of product failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SizeChartView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get size chart of product successful"
+	res.Body.Data = foundSizeChart
+	return res, nil
+}
+
+func (sizeChartHandler *SizeChartHandler) GetProductFitRecommendation(ctx context.Context, reqDTO *dto.GetProductFitRecommendationRequest) (*dto.BodyResponse[*model.FitRecommendationView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get fit recommendation of product failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	fitRecommendation, err := sizeChartHandler.sizeChartService.GetProductFitRecommendation(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get fit recommendation of product failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.FitRecommendationView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get fit recommendation of product successful"
+	res.Body.Data = fitRecommendation
+	return res, nil
+}
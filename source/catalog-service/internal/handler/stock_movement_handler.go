@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type StockMovementHandler struct {
+	stockMovementService service.StockMovementService
+	jwtAuthMiddleware    *middleware.JWTAuthMiddleware
+}
+
+func NewStockMovementHandler(api huma.API, stockMovementService service.StockMovementService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *StockMovementHandler {
+	stockMovementHandler := &StockMovementHandler{
+		stockMovementService: stockMovementService,
+		jwtAuthMiddleware:    jwtAuthMiddleware,
+	}
+
+	// Get stock movements
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/stock-movements",
+		Summary:     "/products/id/{id}/stock-movements",
+		Description: "Get stock movement history of product with its stock and ledger stock.",
+		Tags:        []string{"Stock Movement"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, stockMovementHandler.GetStockMovements)
+
+	// Create stock movement
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/products/id/{id}/stock-movements",
+		Summary:     "/products/id/{id}/stock-movements",
+		Description: "Record purchase, adjustment or return of product stock.",
+		Tags:        []string{"Stock Movement"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, stockMovementHandler.CreateStockMovement)
+
+	// Reconcile stock movements
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/products/stock-movements/reconcile",
+		Summary:     "/products/stock-movements/reconcile",
+		Description: "Reset stock of products which differs from their stock movement ledger.",
+		Tags:        []string{"Stock Movement"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, stockMovementHandler.ReconcileStockMovements)
+
+	return stockMovementHandler
+}
+
+func (stockMovementHandler *StockMovementHandler) GetStockMovements(ctx context.Context, reqDTO *dto.GetStockMovementsRequest) (*dto.BodyResponse[*model.StockMovementHistoryView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get stock movements failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	stockMovementHistory, err := stockMovementHandler.stockMovementService.GetStockMovements(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get stock movements failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.StockMovementHistoryView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get stock movements successful"
+	res.Body.Data = stockMovementHistory
+	return res, nil
+}
+
+func (stockMovementHandler *StockMovementHandler) CreateStockMovement(ctx context.Context, reqDTO *dto.CreateStockMovementRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create stock movement failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := stockMovementHandler.stockMovementService.CreateStockMovement(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create stock movement failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create stock movement successful"
+	return res, nil
+}
+
+func (stockMovementHandler *StockMovementHandler) ReconcileStockMovements(ctx context.Context, reqDTO *dto.ReconcileStockMovementsRequest) (*dto.PaginationBodyResponseList[*model.StockReconciliationView], error) {
+	reconciliations, err := stockMovementHandler.stockMovementService.ReconcileStockMovements(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Reconcile stock movements failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.StockReconciliationView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Reconcile stock movements successful"
+	res.Body.Data = reconciliations
+	res.Body.Total = len(reconciliations)
+	return res, nil
+}
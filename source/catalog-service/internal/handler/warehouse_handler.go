@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type WarehouseHandler struct {
+	warehouseService  service.WarehouseService
+	jwtAuthMiddleware *middleware.JWTAuthMiddleware
+}
+
+func NewWarehouseHandler(api huma.API, warehouseService service.WarehouseService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *WarehouseHandler {
+	warehouseHandler := &WarehouseHandler{
+		warehouseService:  warehouseService,
+		jwtAuthMiddleware: jwtAuthMiddleware,
+	}
+
+	// Get all warehouses
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/warehouses/all",
+		Summary:     "/warehouses/all",
+		Description: "Get all warehouses.",
+		Tags:        []string{"Warehouse"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, warehouseHandler.GetAllWarehouses)
+
+	// Get warehouse by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/warehouses/id/{id}",
+		Summary:     "/warehouses/id/{id}",
+		Description: "Get warehouse by id.",
+		Tags:        []string{"Warehouse"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, warehouseHandler.GetWarehouseById)
+
+	// Create warehouse
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/warehouses",
+		Summary:     "/warehouses",
+		Description: "Create warehouse.",
+		Tags:        []string{"Warehouse"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, warehouseHandler.CreateWarehouse)
+
+	// Update warehouse by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		Path:        "/warehouses/id/{id}",
+		Summary:     "/warehouses/id/{id}",
+		Description: "Update warehouse by id.",
+		Tags:        []string{"Warehouse"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, warehouseHandler.UpdateWarehouseById)
+
+	// Delete warehouse by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/warehouses/id/{id}",
+		Summary:     "/warehouses/id/{id}",
+		Description: "Delete warehouse by id, only when it has no stock.",
+		Tags:        []string{"Warehouse"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, warehouseHandler.DeleteWarehouseById)
+
+	// Get warehouse stocks by product id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/products/id/{id}/warehouse-stocks",
+		Summary:     "/products/id/{id}/warehouse-stocks",
+		Description: "Get stock of product in each warehouse.",
+		Tags:        []string{"Warehouse"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdminOrStaff},
+	}, warehouseHandler.GetWarehouseStocksByProductId)
+
+	return warehouseHandler
+}
+
+func (warehouseHandler *WarehouseHandler) GetAllWarehouses(ctx context.Context, reqDTO *dto.GetAllWarehousesRequest) (*dto.PaginationBodyResponseList[*model.WarehouseView], error) {
+	warehouses, err := warehouseHandler.warehouseService.GetAllWarehouses(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get all warehouses failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.WarehouseView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get all warehouses successful"
+	res.Body.Data = warehouses
+	res.Body.Total = len(warehouses)
+	return res, nil
+}
+
+func (warehouseHandler *WarehouseHandler) GetWarehouseById(ctx context.Context, reqDTO *dto.GetWarehouseByIdRequest) (*dto.BodyResponse[*model.WarehouseView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get warehouse by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	foundWarehouse, err := warehouseHandler.warehouseService.GetWarehouseById(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get warehouse by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.WarehouseView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get warehouse by id successful"
+	res.Body.Data = foundWarehouse
+	return res, nil
+}
+
+func (warehouseHandler *WarehouseHandler) CreateWarehouse(ctx context.Context, reqDTO *dto.CreateWarehouseRequest) (*dto.SuccessResponse, error) {
+	if err := warehouseHandler.warehouseService.CreateWarehouse(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create warehouse failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create warehouse successful"
+	return res, nil
+}
+
+func (warehouseHandler *WarehouseHandler) UpdateWarehouseById(ctx context.Context, reqDTO *dto.UpdateWarehouseByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update warehouse by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := warehouseHandler.warehouseService.UpdateWarehouseById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Update warehouse by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Update warehouse by id successful"
+	return res, nil
+}
+
+func (warehouseHandler *WarehouseHandler) DeleteWarehouseById(ctx context.Context, reqDTO *dto.DeleteWarehouseByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete warehouse by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := warehouseHandler.warehouseService.DeleteWarehouseById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete warehouse by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete warehouse by id successful"
+	return res, nil
+}
+
+func (warehouseHandler *WarehouseHandler) GetWarehouseStocksByProductId(ctx context.Context, reqDTO *dto.GetWarehouseStocksByProductIdRequest) (*dto.PaginationBodyResponseList[*model.WarehouseStockView], error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get warehouse stocks by product id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	warehouseStocks, err := warehouseHandler.warehouseService.GetWarehouseStocksByProductId(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get warehouse stocks by product id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.WarehouseStockView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get warehouse stocks by product id successful"
+	res.Body.Data = warehouseStocks
+	res.Body.Total = len(warehouseStocks)
+	return res, nil
+}
@@ -48,6 +48,34 @@ func (jwtAuthMiddleware *JWTAuthMiddleware) Authentication(ctx huma.Context, nex
 	next(ctx)
 }
 
+// Same as Authentication but let anonymous requests through, user_id is only set when a valid token is sent
+func (jwtAuthMiddleware *JWTAuthMiddleware) OptionalAuthentication(ctx huma.Context, next func(huma.Context)) {
+	authHeader := ctx.Header("Authorization")
+	if authHeader == "" {
+		next(ctx)
+		return
+	}
+
+	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
+	redisKey := fmt.Sprintf("token:%s", tokenStr)
+	userDataJson, err := infrastructure.RedisClient.Get(ctx.Context(), redisKey).Result()
+	if err != nil {
+		next(ctx)
+		return
+	}
+
+	var userData struct {
+		UserId   string `json:"user_id"`
+		RoleName string `json:"role_name"`
+	}
+	json.Unmarshal([]byte(userDataJson), &userData)
+
+	ctx = huma.WithValue(ctx, "user_id", userData.UserId)
+	ctx = huma.WithValue(ctx, "role_name", userData.RoleName)
+
+	next(ctx)
+}
+
 func (jwtAuthMiddleware *JWTAuthMiddleware) RequireAdmin(ctx huma.Context, next func(huma.Context)) {
 	if roleName, _ := ctx.Context().Value("role_name").(string); roleName != "ADMIN" {
 		CustomHumaWriteErr(ctx, http.StatusForbidden, "ERR_FORBIDDEN", "Access denied", []string{"no permission"})
@@ -56,3 +84,12 @@ func (jwtAuthMiddleware *JWTAuthMiddleware) RequireAdmin(ctx huma.Context, next
 
 	next(ctx)
 }
+
+func (jwtAuthMiddleware *JWTAuthMiddleware) RequireAdminOrStaff(ctx huma.Context, next func(huma.Context)) {
+	if roleName, _ := ctx.Context().Value("role_name").(string); roleName != "ADMIN" && roleName != "STAFF" {
+		CustomHumaWriteErr(ctx, http.StatusForbidden, "ERR_FORBIDDEN", "Access denied", []string{"no permission"})
+		return
+	}
+
+	next(ctx)
+}
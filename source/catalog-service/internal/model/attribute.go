@@ -0,0 +1,154 @@
+package model
+
+import (
+	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+	"thanhldt060802/internal/grpc/service/catalogservicepb"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Typed product attribute such as material, fit or season. Values of ENUM attribute are limited to its options,
+// values of NUMBER attribute are numbers (in unit) and values of TEXT attribute are free text.
+type AttributeDefinition struct {
+	bun.BaseModel `bun:"tb_attribute_definition"`
+
+	Id        string     `bun:"id,pk"`
+	Code      string     `bun:"code,notnull,unique"`
+	Name      string     `bun:"name,notnull"`
+	Type      string     `bun:"type,notnull"`
+	Options   []string   `bun:"options,type:jsonb,notnull"`
+	Unit      string     `bun:"unit,notnull,default:''"`
+	CreatedAt *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+type AttributeDefinitionView struct {
+	bun.BaseModel `bun:"tb_attribute_definition,alias:_attribute_definition"`
+
+	Id        string    `json:"id" bun:"id,pk"`
+	Code      string    `json:"code" bun:"code"`
+	Name      string    `json:"name" bun:"name"`
+	Type      string    `json:"type" bun:"type"`
+	Options   []string  `json:"options" bun:"options,type:jsonb"`
+	Unit      string    `json:"unit" bun:"unit"`
+	CreatedAt time.Time `json:"created_at" bun:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bun:"updated_at"`
+}
+
+// Attribute of attribute set of category, the set applies to products of category and of its subcategories
+type CategoryAttribute struct {
+	bun.BaseModel `bun:"tb_category_attribute"`
+
+	CategoryId            string `bun:"category_id,pk"`
+	AttributeDefinitionId string `bun:"attribute_definition_id,pk"`
+	IsRequired            bool   `bun:"is_required,notnull,default:false"`
+	SortOrder             int32  `bun:"sort_order,notnull,default:0"`
+}
+
+type CategoryAttributeView struct {
+	bun.BaseModel `bun:"tb_category_attribute,alias:_category_attribute"`
+
+	CategoryId            string `json:"category_id" bun:"category_id"`
+	AttributeDefinitionId string `json:"attribute_definition_id" bun:"attribute_definition_id"`
+	IsRequired            bool   `json:"is_required" bun:"is_required"`
+	SortOrder             int32  `json:"sort_order" bun:"sort_order"`
+
+	Code    string   `json:"code" bun:"code"`
+	Name    string   `json:"name" bun:"name"`
+	Type    string   `json:"type" bun:"type"`
+	Options []string `json:"options" bun:"options,type:jsonb"`
+	Unit    string   `json:"unit" bun:"unit"`
+
+	// Attribute set of category includes attributes of its ancestors
+	Inherited bool `json:"inherited" bun:"inherited"`
+}
+
+type ProductAttributeValue struct {
+	bun.BaseModel `bun:"tb_product_attribute_value"`
+
+	ProductId             string `bun:"product_id,pk"`
+	AttributeDefinitionId string `bun:"attribute_definition_id,pk"`
+	Value                 string `bun:"value,notnull"`
+}
+
+// Number value is only set for NUMBER attribute so that search can filter it by range
+type ProductAttributeView struct {
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Value       string   `json:"value"`
+	Unit        string   `json:"unit,omitempty"`
+	NumberValue *float64 `json:"number_value,omitempty"`
+}
+
+type ProductFacetView struct {
+	Code    string                    `json:"code"`
+	Name    string                    `json:"name"`
+	Buckets []*ProductFacetBucketView `json:"buckets"`
+}
+
+type ProductFacetBucketView struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// View -> Proto
+
+func FromListProductAttributeViewToListProductAttributeProto(productAttributeViews []*ProductAttributeView) []*catalogservicepb.ProductAttribute {
+	productAttributeProtos := make([]*catalogservicepb.ProductAttribute, len(productAttributeViews))
+	for i, productAttributeView := range productAttributeViews {
+		productAttributeProtos[i] = &catalogservicepb.ProductAttribute{
+			Code:  productAttributeView.Code,
+			Name:  productAttributeView.Name,
+			Type:  productAttributeView.Type,
+			Value: productAttributeView.Value,
+			Unit:  productAttributeView.Unit,
+		}
+		if productAttributeView.NumberValue != nil {
+			productAttributeProtos[i].NumberValue = *productAttributeView.NumberValue
+		}
+	}
+
+	return productAttributeProtos
+}
+
+// Proto -> View
+
+func FromListProductAttributeProtoToListProductAttributeView(productAttributeProtos []*elasticsearchservicepb.ProductAttribute) []*ProductAttributeView {
+	productAttributeViews := make([]*ProductAttributeView, len(productAttributeProtos))
+	for i, productAttributeProto := range productAttributeProtos {
+		productAttributeViews[i] = &ProductAttributeView{
+			Code:  productAttributeProto.Code,
+			Name:  productAttributeProto.Name,
+			Type:  productAttributeProto.Type,
+			Value: productAttributeProto.Value,
+			Unit:  productAttributeProto.Unit,
+		}
+		if productAttributeProto.Type == "NUMBER" {
+			numberValue := productAttributeProto.NumberValue
+			productAttributeViews[i].NumberValue = &numberValue
+		}
+	}
+
+	return productAttributeViews
+}
+
+func FromListProductFacetProtoToListProductFacetView(productFacetProtos []*elasticsearchservicepb.ProductFacet) []*ProductFacetView {
+	productFacetViews := make([]*ProductFacetView, len(productFacetProtos))
+	for i, productFacetProto := range productFacetProtos {
+		productFacetViews[i] = &ProductFacetView{
+			Code:    productFacetProto.Code,
+			Name:    productFacetProto.Name,
+			Buckets: make([]*ProductFacetBucketView, len(productFacetProto.Buckets)),
+		}
+		for j, bucketProto := range productFacetProto.Buckets {
+			productFacetViews[i].Buckets[j] = &ProductFacetBucketView{
+				Value: bucketProto.Value,
+				Count: bucketProto.Count,
+			}
+		}
+	}
+
+	return productFacetViews
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"thanhldt060802/internal/grpc/service/catalogservicepb"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Quantity of product in an invoice sold beyond stock (pre-order or backorder), it is allocated first in first out when
+// stock is replenished
+type Backorder struct {
+	bun.BaseModel `bun:"tb_backorder"`
+
+	Id                string                     `bun:"id,pk"`
+	InvoiceId         string                     `bun:"invoice_id,notnull"`
+	ProductId         string                     `bun:"product_id,notnull"`
+	Quantity          int32                      `bun:"quantity,notnull"`
+	ActorId           *string                    `bun:"actor_id"`
+	ShippingLatitude  *float64                   `bun:"shipping_latitude"`
+	ShippingLongitude *float64                   `bun:"shipping_longitude"`
+	Status            string                     `bun:"status,notnull,default:'PENDING'"`      // PENDING, ALLOCATED or CANCELLED
+	StockAllocations  []*AllocatedBackorderStock `bun:"stock_allocations,type:jsonb,nullzero"` // Warehouses stock was taken from once allocated
+	ConfirmedAt       *time.Time                 `bun:"confirmed_at"`                          // When order-service applied the allocation to the invoice
+	CreatedAt         *time.Time                 `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt         *time.Time                 `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+type BackorderView struct {
+	bun.BaseModel `bun:"tb_backorder,alias:_backorder"`
+
+	Id          string     `json:"id" bun:"id,pk"`
+	InvoiceId   string     `json:"invoice_id" bun:"invoice_id"`
+	ProductId   string     `json:"product_id" bun:"product_id"`
+	Quantity    int32      `json:"quantity" bun:"quantity"`
+	Status      string     `json:"status" bun:"status"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty" bun:"confirmed_at"`
+	CreatedAt   time.Time  `json:"created_at" bun:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bun:"updated_at"`
+
+	ProductSku       string     `json:"product_sku" bun:"product_sku"`
+	ProductName      string     `json:"product_name" bun:"product_name"`
+	BackorderPolicy  string     `json:"backorder_policy" bun:"backorder_policy"`
+	ExpectedShipDate *time.Time `json:"expected_ship_date,omitempty" bun:"expected_ship_date"`
+}
+
+// Payload of event catalog-service.allocated-backorder, order-service moves pending invoice details of product to warehouses
+type AllocatedBackorderView struct {
+	Id               string                     `json:"id"`
+	InvoiceId        string                     `json:"invoice_id"`
+	ProductId        string                     `json:"product_id"`
+	Quantity         int32                      `json:"quantity"`
+	StockAllocations []*AllocatedBackorderStock `json:"stock_allocations"`
+}
+
+type AllocatedBackorderStock struct {
+	WarehouseId string `json:"warehouse_id"`
+	Quantity    int32  `json:"quantity"`
+}
+
+func FromAllocatedBackorderViewToAllocatedBackorderProto(allocatedBackorderView *AllocatedBackorderView) *catalogservicepb.AllocatedBackorder {
+	stockAllocationProtos := make([]*catalogservicepb.AllocatedBackorderStock, len(allocatedBackorderView.StockAllocations))
+	for i, stockAllocation := range allocatedBackorderView.StockAllocations {
+		stockAllocationProtos[i] = &catalogservicepb.AllocatedBackorderStock{
+			WarehouseId: stockAllocation.WarehouseId,
+			Quantity:    stockAllocation.Quantity,
+		}
+	}
+
+	return &catalogservicepb.AllocatedBackorder{
+		Id:               allocatedBackorderView.Id,
+		InvoiceId:        allocatedBackorderView.InvoiceId,
+		ProductId:        allocatedBackorderView.ProductId,
+		Quantity:         allocatedBackorderView.Quantity,
+		StockAllocations: stockAllocationProtos,
+	}
+}
+
+func FromListAllocatedBackorderViewToListAllocatedBackorderProto(allocatedBackorderViews []*AllocatedBackorderView) []*catalogservicepb.AllocatedBackorder {
+	allocatedBackorderProtos := make([]*catalogservicepb.AllocatedBackorder, len(allocatedBackorderViews))
+	for i, allocatedBackorderView := range allocatedBackorderViews {
+		allocatedBackorderProtos[i] = FromAllocatedBackorderViewToAllocatedBackorderProto(allocatedBackorderView)
+	}
+
+	return allocatedBackorderProtos
+}
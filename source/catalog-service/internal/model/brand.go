@@ -11,17 +11,23 @@ type Brand struct {
 
 	Id          string     `bun:"id,pk"`
 	Name        string     `bun:"name,notnull"`
+	Slug        string     `bun:"slug,notnull,unique"`
 	Description string     `bun:"description,notnull"`
+	Status      string     `bun:"status,notnull,default:'PUBLISHED'"`
 	CreatedAt   *time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt   *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	DeletedAt   *time.Time `bun:"deleted_at"`
 }
 
 type BrandView struct {
 	bun.BaseModel `bun:"tb_brand,alias:_brand"`
 
-	Id          string    `json:"id" bun:"id,pk"`
-	Name        string    `json:"name" bun:"name"`
-	Description string    `json:"description" bun:"description"`
-	CreatedAt   time.Time `json:"created_at" bun:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" bun:"updated_at"`
+	Id          string     `json:"id" bun:"id,pk"`
+	Name        string     `json:"name" bun:"name"`
+	Slug        string     `json:"slug" bun:"slug"`
+	Description string     `json:"description" bun:"description"`
+	Status      string     `json:"status" bun:"status"`
+	CreatedAt   time.Time  `json:"created_at" bun:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bun:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" bun:"deleted_at"`
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Component product of a bundle with the quantity of it in one unit of the bundle
+type BundleComponent struct {
+	bun.BaseModel `bun:"tb_bundle_component"`
+
+	BundleId    string     `bun:"bundle_id,pk"`
+	ComponentId string     `bun:"component_id,pk"`
+	Quantity    int32      `bun:"quantity,notnull"`
+	CreatedAt   *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+type BundleComponentView struct {
+	ProductId string `json:"product_id"`
+	Sku       string `json:"sku"`
+	Name      string `json:"name"`
+	Quantity  int32  `json:"quantity"`
+	Price     int64  `json:"price"`
+	Stock     int32  `json:"stock"`
+	Status    string `json:"status"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// Counters of a view cache since this instance started
+type CacheStatsView struct {
+	Name       string  `json:"name"`
+	TTLSeconds int64   `json:"ttl_seconds"` // 0 when cache is disabled
+	Hits       int64   `json:"hits"`
+	Misses     int64   `json:"misses"`
+	Loads      int64   `json:"loads"` // Misses of the same key at once share one load
+	Errors     int64   `json:"errors"`
+	HitRatio   float64 `json:"hit_ratio"`
+}
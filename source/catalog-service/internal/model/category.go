@@ -1,6 +1,8 @@
 package model
 
 import (
+	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+	"thanhldt060802/internal/grpc/service/catalogservicepb"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -9,17 +11,74 @@ import (
 type Category struct {
 	bun.BaseModel `bun:"tb_category"`
 
-	Id        string     `bun:"id,pk"`
-	Name      string     `bun:"name,notnull"`
-	CreatedAt *time.Time `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	Id                string     `bun:"id,pk"`
+	Name              string     `bun:"name,notnull"`
+	Slug              string     `bun:"slug,notnull,unique"`
+	ParentId          *string    `bun:"parent_id"`
+	Path              string     `bun:"path,notnull"` // Materialized path of ids from root to this category, for example /root-id/parent-id/id/
+	Depth             int32      `bun:"depth,notnull,default:0"`
+	SortOrder         int32      `bun:"sort_order,notnull,default:0"`
+	Status            string     `bun:"status,notnull,default:'PUBLISHED'"`
+	LowStockThreshold *int32     `bun:"low_stock_threshold"` // Default threshold of products in category and subcategories, nil falls back to parent
+	CreatedAt         *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt         *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	DeletedAt         *time.Time `bun:"deleted_at"`
 }
 
 type CategoryView struct {
 	bun.BaseModel `bun:"tb_category,alias:_category"`
 
-	Id        string    `json:"id" bun:"id,pk"`
-	Name      string    `json:"name" bun:"name"`
-	CreatedAt time.Time `json:"created_at" bun:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" bun:"updated_at"`
+	Id                string     `json:"id" bun:"id,pk"`
+	Name              string     `json:"name" bun:"name"`
+	Slug              string     `json:"slug" bun:"slug"`
+	ParentId          *string    `json:"parent_id" bun:"parent_id"`
+	Path              string     `json:"path" bun:"path"`
+	Depth             int32      `json:"depth" bun:"depth"`
+	SortOrder         int32      `json:"sort_order" bun:"sort_order"`
+	Status            string     `json:"status" bun:"status"`
+	LowStockThreshold *int32     `json:"low_stock_threshold,omitempty" bun:"low_stock_threshold"`
+	CreatedAt         time.Time  `json:"created_at" bun:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" bun:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" bun:"deleted_at"`
+}
+
+type CategoryTreeView struct {
+	CategoryView
+	Children []*CategoryTreeView `json:"children"`
+}
+
+type CategoryBreadcrumbView struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// View -> Proto
+
+func FromListCategoryBreadcrumbViewToListCategoryBreadcrumbProto(categoryBreadcrumbViews []*CategoryBreadcrumbView) []*catalogservicepb.CategoryBreadcrumb {
+	categoryBreadcrumbProtos := make([]*catalogservicepb.CategoryBreadcrumb, len(categoryBreadcrumbViews))
+	for i, categoryBreadcrumbView := range categoryBreadcrumbViews {
+		categoryBreadcrumbProtos[i] = &catalogservicepb.CategoryBreadcrumb{
+			Id:   categoryBreadcrumbView.Id,
+			Name: categoryBreadcrumbView.Name,
+			Slug: categoryBreadcrumbView.Slug,
+		}
+	}
+
+	return categoryBreadcrumbProtos
+}
+
+// Proto -> View
+
+func FromListCategoryBreadcrumbProtoToListCategoryBreadcrumbView(categoryBreadcrumbProtos []*elasticsearchservicepb.CategoryBreadcrumb) []*CategoryBreadcrumbView {
+	categoryBreadcrumbViews := make([]*CategoryBreadcrumbView, len(categoryBreadcrumbProtos))
+	for i, categoryBreadcrumbProto := range categoryBreadcrumbProtos {
+		categoryBreadcrumbViews[i] = &CategoryBreadcrumbView{
+			Id:   categoryBreadcrumbProto.Id,
+			Name: categoryBreadcrumbProto.Name,
+			Slug: categoryBreadcrumbProto.Slug,
+		}
+	}
+
+	return categoryBreadcrumbViews
 }
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Curated group of products (lookbook, seasonal selection, ...). Products are hand-picked in order and optionally
+// completed by products matching rule, collection is only public while published and inside its schedule.
+type Collection struct {
+	bun.BaseModel `bun:"tb_collection"`
+
+	Id             string          `bun:"id,pk"`
+	Name           string          `bun:"name,notnull"`
+	Description    string          `bun:"description,notnull,default:''"`
+	BannerImageKey string          `bun:"banner_image_key,notnull,default:''"`
+	BannerImageURL string          `bun:"banner_image_url,notnull,default:''"`
+	Rule           *CollectionRule `bun:"rule,type:jsonb,nullzero"`
+	StartTime      *time.Time      `bun:"start_time,nullzero"`
+	EndTime        *time.Time      `bun:"end_time,nullzero"`
+	Status         string          `bun:"status,notnull"`
+	CreatedAt      *time.Time      `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt      *time.Time      `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+// Product search filters evaluated against elasticsearch-service, matched products follow hand-picked ones
+type CollectionRule struct {
+	CategoryId    string `json:"category_id,omitempty"`
+	BrandId       string `json:"brand_id,omitempty"`
+	Sex           string `json:"sex,omitempty"`
+	FinalPriceGte *int64 `json:"final_price_gte,omitempty"`
+	FinalPriceLte *int64 `json:"final_price_lte,omitempty"`
+	Attributes    string `json:"attributes,omitempty"`
+	Tags          string `json:"tags,omitempty"`
+	SortBy        string `json:"sort_by"`
+	Limit         int32  `json:"limit"`
+}
+
+type CollectionView struct {
+	bun.BaseModel `bun:"tb_collection,alias:_collection"`
+
+	Id             string          `json:"id" bun:"id,pk"`
+	Name           string          `json:"name" bun:"name"`
+	Description    string          `json:"description" bun:"description"`
+	BannerImageURL string          `json:"banner_image_url" bun:"banner_image_url"`
+	Rule           *CollectionRule `json:"rule" bun:"rule,type:jsonb"`
+	StartTime      *time.Time      `json:"start_time" bun:"start_time"`
+	EndTime        *time.Time      `json:"end_time" bun:"end_time"`
+	Status         string          `json:"status" bun:"status"`
+	CreatedAt      time.Time       `json:"created_at" bun:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" bun:"updated_at"`
+
+	// Published and inside schedule
+	IsLive bool `json:"is_live" bun:"is_live"`
+	// Hand-picked products, products matching rule are not counted
+	ProductCount int32 `json:"product_count" bun:"product_count"`
+}
+
+type CollectionProduct struct {
+	bun.BaseModel `bun:"tb_collection_product"`
+
+	CollectionId string     `bun:"collection_id,pk"`
+	ProductId    string     `bun:"product_id,pk"`
+	SortOrder    int32      `bun:"sort_order,notnull,default:0"`
+	CreatedAt    *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
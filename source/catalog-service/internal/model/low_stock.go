@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Product with its effective low stock threshold (product, nearest category setting one, or default of config)
+type LowStockProductView struct {
+	bun.BaseModel `bun:"tb_product,alias:_product"`
+
+	Id                string     `json:"id" bun:"id,pk"`
+	Sku               string     `json:"sku" bun:"sku"`
+	Name              string     `json:"name" bun:"name"`
+	Stock             int32      `json:"stock" bun:"stock"`
+	Status            string     `json:"status" bun:"status"`
+	CategoryId        string     `json:"category_id" bun:"category_id"`
+	CategoryName      string     `json:"category_name" bun:"category_name"`
+	BrandId           string     `json:"brand_id" bun:"brand_id"`
+	BrandName         string     `json:"brand_name" bun:"brand_name"`
+	LowStockThreshold int32      `json:"low_stock_threshold" bun:"low_stock_threshold"`
+	LowStockAlertedAt *time.Time `json:"low_stock_alerted_at,omitempty" bun:"low_stock_alerted_at"`
+}
+
+// Quantity to reorder so that stock covers lead time and cover days at current sales velocity, on top of threshold
+type ReorderSuggestionView struct {
+	*LowStockProductView
+	UnitsSold         int64    `json:"units_sold"`
+	DailyUnits        float64  `json:"daily_units"`
+	DaysOfStock       *float64 `json:"days_of_stock"` // Nil when product has no sales in period
+	SuggestedQuantity int64    `json:"suggested_quantity"`
+}
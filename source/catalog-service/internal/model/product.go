@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
 	"thanhldt060802/internal/grpc/service/catalogservicepb"
 	"time"
@@ -13,6 +14,8 @@ type Product struct {
 	bun.BaseModel `bun:"tb_product"`
 
 	Id                 string     `bun:"id,pk"`
+	Sku                string     `bun:"sku,notnull,unique"`
+	Slug               string     `bun:"slug,notnull,unique"`
 	Name               string     `bun:"name,notnull"`
 	Description        string     `bun:"description,notnull"`
 	Sex                string     `bun:"sex,notnull"`
@@ -22,27 +25,100 @@ type Product struct {
 	ImageURL           string     `bun:"image_url,notnull"`
 	CategoryId         string     `bun:"category_id,notnull"`
 	BrandId            string     `bun:"brand_id,notnull"`
+	AverageRating      float64    `bun:"average_rating,notnull,default:0"`
+	RatingCount        int32      `bun:"rating_count,notnull,default:0"`
+	Tags               []string   `bun:"tags,type:jsonb,notnull,default:'[]'"`
+	Status             string     `bun:"status,notnull,default:'PUBLISHED'"`
+	Type               string     `bun:"type,notnull,default:'SIMPLE'"`           // BUNDLE is sold as a unit of its components, its stock is derived from them
+	BundlePricing      string     `bun:"bundle_pricing,nullzero"`                 // FIXED price, or PERCENTAGE_OFF discount percentage off price of its components
+	LowStockThreshold  *int32     `bun:"low_stock_threshold"`                     // Nil falls back to threshold of category
+	LowStockAlertedAt  *time.Time `bun:"low_stock_alerted_at"`                    // Set while product stays at or below threshold, so it is alerted once
+	BackorderPolicy    string     `bun:"backorder_policy,notnull,default:'NONE'"` // PRE_ORDER and BACKORDER accept purchases beyond stock
+	ExpectedShipDate   *time.Time `bun:"expected_ship_date"`
+	BackorderLimit     int32      `bun:"backorder_limit,notnull,default:0"`    // Max units pending allocation, 0 is unlimited
+	BackorderQuantity  int32      `bun:"backorder_quantity,notnull,default:0"` // Units sold beyond stock and pending allocation
 	CreatedAt          *time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt          *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	DeletedAt          *time.Time `bun:"deleted_at"`
+}
+
+// SKU given to product created without one, derived from its id so that it is unique
+func GenerateSku(productId string) string {
+	return "SKU-" + strings.ToUpper(strings.ReplaceAll(productId, "-", "")[:12])
 }
 
 type ProductView struct {
 	bun.BaseModel `bun:"tb_product,alias:_product"`
 
-	Id                 string    `json:"id" bun:"id,pk"`
-	Name               string    `json:"name" bun:"name"`
-	Description        string    `json:"description" bun:"description"`
-	Sex                string    `json:"sex" bun:"sex"`
-	Price              int64     `json:"price" bun:"price"`
-	DiscountPercentage int32     `json:"discount_percentage" bun:"discount_percentage"`
-	Stock              int32     `json:"stock" bun:"stock"`
-	ImageURL           string    `json:"image_url" bun:"image_url"`
-	CategoryId         string    `json:"category_id" bun:"category_id"`
-	CategoryName       string    `json:"category_name" bun:"category_name"`
-	BrandId            string    `json:"brand_id" bun:"brand_id"`
-	BrandName          string    `json:"brand_name" bun:"brand_name"`
-	CreatedAt          time.Time `json:"created_at" bun:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at" bun:"updated_at"`
+	Id                 string     `json:"id" bun:"id,pk"`
+	Sku                string     `json:"sku" bun:"sku"`
+	Slug               string     `json:"slug" bun:"slug"`
+	Name               string     `json:"name" bun:"name"`
+	Description        string     `json:"description" bun:"description"`
+	Sex                string     `json:"sex" bun:"sex"`
+	Price              int64      `json:"price" bun:"price"`
+	DiscountPercentage int32      `json:"discount_percentage" bun:"discount_percentage"`
+	Stock              int32      `json:"stock" bun:"stock"`
+	ImageURL           string     `json:"image_url" bun:"image_url"`
+	CategoryId         string     `json:"category_id" bun:"category_id"`
+	CategoryName       string     `json:"category_name" bun:"category_name"`
+	BrandId            string     `json:"brand_id" bun:"brand_id"`
+	BrandName          string     `json:"brand_name" bun:"brand_name"`
+	AverageRating      float64    `json:"average_rating" bun:"average_rating"`
+	RatingCount        int32      `json:"rating_count" bun:"rating_count"`
+	Tags               []string   `json:"tags" bun:"tags,type:jsonb"`
+	Status             string     `json:"status" bun:"status"`
+	Type               string     `json:"type" bun:"type"`
+	BundlePricing      string     `json:"bundle_pricing,omitempty" bun:"bundle_pricing"`
+	LowStockThreshold  *int32     `json:"low_stock_threshold,omitempty" bun:"low_stock_threshold"`
+	LowStockAlertedAt  *time.Time `json:"low_stock_alerted_at,omitempty" bun:"low_stock_alerted_at"`
+	BackorderPolicy    string     `json:"backorder_policy" bun:"backorder_policy"`
+	ExpectedShipDate   *time.Time `json:"expected_ship_date,omitempty" bun:"expected_ship_date"`
+	BackorderLimit     int32      `json:"backorder_limit" bun:"backorder_limit"`
+	BackorderQuantity  int32      `json:"backorder_quantity" bun:"backorder_quantity"`
+	CreatedAt          time.Time  `json:"created_at" bun:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" bun:"updated_at"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty" bun:"deleted_at"`
+
+	CategoryBreadcrumb []*CategoryBreadcrumbView `json:"category_breadcrumb" bun:"category_breadcrumb,type:jsonb"`
+	Attributes         []*ProductAttributeView   `json:"attributes" bun:"attributes,type:jsonb"`
+	BundleComponents   []*BundleComponentView    `json:"bundle_components,omitempty" bun:"bundle_components,type:jsonb"`
+
+	// Discount percentage above includes active promotion, base discount percentage is the one of product itself
+	BaseDiscountPercentage int32                `json:"base_discount_percentage" bun:"base_discount_percentage"`
+	ActivePromotion        *ActivePromotionView `json:"active_promotion,omitempty" bun:"active_promotion,type:jsonb"`
+
+	// Price customers pay now and lowest price customers paid in the last 30 days, promotions included
+	FinalPrice     int64 `json:"final_price" bun:"final_price"`
+	LowestPrice30d int64 `json:"lowest_price_30d" bun:"lowest_price_30d"`
+}
+
+// Product of product page, size chart is left out when product has none and fit recommendation when caller is not logged
+// in or has no body measurements
+type ProductDetailView struct {
+	*ProductView
+
+	SizeChart         *SizeChartView         `json:"size_chart,omitempty"`
+	FitRecommendation *FitRecommendationView `json:"fit_recommendation,omitempty"`
+}
+
+type ProductClickView struct {
+	ProductId string    `json:"product_id"`
+	SearchId  string    `json:"search_id,omitempty"`
+	UserId    string    `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RankedProductView struct {
+	Product   *ProductView `json:"product"`
+	Rank      int32        `json:"rank"`
+	UnitsSold int64        `json:"units_sold"`
+	Score     float64      `json:"score"`
+}
+
+type ProductRecommendationsView struct {
+	SimilarProducts          []*ProductView `json:"similar_products"`
+	FrequentlyBoughtTogether []*ProductView `json:"frequently_bought_together"`
 }
 
 // View -> Proto
@@ -61,8 +137,17 @@ func FromProductViewToProductProto(productView *ProductView) *catalogservicepb.P
 		CategoryName:       productView.CategoryName,
 		BrandId:            productView.BrandId,
 		BrandName:          productView.BrandName,
+		AverageRating:      productView.AverageRating,
+		RatingCount:        productView.RatingCount,
+		FinalPrice:         productView.FinalPrice,
+		LowestPrice_30D:    productView.LowestPrice30d,
+		Status:             productView.Status,
+		Slug:               productView.Slug,
+		Tags:               productView.Tags,
 		CreatedAt:          timestamppb.New(productView.CreatedAt),
 		UpdatedAt:          timestamppb.New(productView.UpdatedAt),
+		CategoryBreadcrumb: FromListCategoryBreadcrumbViewToListCategoryBreadcrumbProto(productView.CategoryBreadcrumb),
+		Attributes:         FromListProductAttributeViewToListProductAttributeProto(productView.Attributes),
 	}
 }
 
@@ -91,8 +176,17 @@ func FromProductProtoToProductView(productProto *elasticsearchservicepb.Product)
 		CategoryName:       productProto.CategoryName,
 		BrandId:            productProto.BrandId,
 		BrandName:          productProto.BrandName,
+		AverageRating:      productProto.AverageRating,
+		RatingCount:        productProto.RatingCount,
+		FinalPrice:         productProto.FinalPrice,
+		LowestPrice30d:     productProto.LowestPrice_30D,
+		Status:             productProto.Status,
+		Slug:               productProto.Slug,
+		Tags:               productProto.Tags,
 		CreatedAt:          productProto.CreatedAt.AsTime(),
 		UpdatedAt:          productProto.UpdatedAt.AsTime(),
+		CategoryBreadcrumb: FromListCategoryBreadcrumbProtoToListCategoryBreadcrumbView(productProto.CategoryBreadcrumb),
+		Attributes:         FromListProductAttributeProtoToListProductAttributeView(productProto.Attributes),
 	}
 }
 
@@ -104,3 +198,24 @@ func FromListProductProtoToListProductView(productProtos []*elasticsearchservice
 
 	return productViews
 }
+
+func FromListRankedProductProtoToListRankedProductView(rankedProductProtos []*elasticsearchservicepb.RankedProduct) []*RankedProductView {
+	rankedProductViews := make([]*RankedProductView, len(rankedProductProtos))
+	for i, rankedProductProto := range rankedProductProtos {
+		rankedProductViews[i] = &RankedProductView{
+			Product:   FromProductProtoToProductView(rankedProductProto.Product),
+			Rank:      rankedProductProto.Rank,
+			UnitsSold: rankedProductProto.UnitsSold,
+			Score:     rankedProductProto.Score,
+		}
+	}
+
+	return rankedProductViews
+}
+
+func FromProductRecommendationsProtoToProductRecommendationsView(recommendationsProto *elasticsearchservicepb.GetProductRecommendationsResponse) *ProductRecommendationsView {
+	return &ProductRecommendationsView{
+		SimilarProducts:          FromListProductProtoToListProductView(recommendationsProto.SimilarProducts),
+		FrequentlyBoughtTogether: FromListProductProtoToListProductView(recommendationsProto.FrequentlyBoughtTogether),
+	}
+}
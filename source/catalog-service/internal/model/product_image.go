@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type ProductImage struct {
+	bun.BaseModel `bun:"tb_product_image"`
+
+	Id          string            `bun:"id,pk"`
+	ProductId   string            `bun:"product_id,notnull"`
+	Variant     string            `bun:"variant,notnull,default:''"`
+	StorageKey  string            `bun:"storage_key,notnull"`
+	URL         string            `bun:"url,notnull"`
+	Thumbnails  map[string]string `bun:"thumbnails,type:jsonb,notnull"`
+	ContentType string            `bun:"content_type,notnull"`
+	Width       int32             `bun:"width,notnull"`
+	Height      int32             `bun:"height,notnull"`
+	Size        int64             `bun:"size,notnull"`
+	SortOrder   int32             `bun:"sort_order,notnull,default:0"`
+	IsPrimary   bool              `bun:"is_primary,notnull,default:false"`
+	CreatedAt   *time.Time        `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt   *time.Time        `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+type ProductImageView struct {
+	bun.BaseModel `bun:"tb_product_image,alias:_product_image"`
+
+	Id          string            `json:"id" bun:"id,pk"`
+	ProductId   string            `json:"product_id" bun:"product_id"`
+	Variant     string            `json:"variant" bun:"variant"`
+	URL         string            `json:"url" bun:"url"`
+	Thumbnails  map[string]string `json:"thumbnails" bun:"thumbnails,type:jsonb"`
+	ContentType string            `json:"content_type" bun:"content_type"`
+	Width       int32             `json:"width" bun:"width"`
+	Height      int32             `json:"height" bun:"height"`
+	Size        int64             `json:"size" bun:"size"`
+	SortOrder   int32             `json:"sort_order" bun:"sort_order"`
+	IsPrimary   bool              `json:"is_primary" bun:"is_primary"`
+	CreatedAt   time.Time         `json:"created_at" bun:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" bun:"updated_at"`
+}
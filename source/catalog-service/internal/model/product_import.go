@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Import of products from a CSV/XLSX file, rows are upserted by SKU in background and progress is polled.
+// Dry run validates every row and counts products which would be created or updated without writing them.
+type ProductImportJob struct {
+	bun.BaseModel `bun:"tb_product_import_job"`
+
+	Id             string                   `bun:"id,pk"`
+	FileName       string                   `bun:"file_name,notnull"`
+	Format         string                   `bun:"format,notnull"`
+	DryRun         bool                     `bun:"dry_run,notnull"`
+	Status         string                   `bun:"status,notnull"`
+	TotalRows      int32                    `bun:"total_rows,notnull"`
+	ProcessedRows  int32                    `bun:"processed_rows,notnull,default:0"`
+	CreatedCount   int32                    `bun:"created_count,notnull,default:0"`
+	UpdatedCount   int32                    `bun:"updated_count,notnull,default:0"`
+	ErrorCount     int32                    `bun:"error_count,notnull,default:0"`
+	Errors         []*ProductImportRowError `bun:"errors,type:jsonb,notnull"`
+	FailureMessage string                   `bun:"failure_message,notnull,default:''"`
+	ActorId        *string                  `bun:"actor_id"`
+	CreatedAt      *time.Time               `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt      *time.Time               `bun:"updated_at,notnull,default:current_timestamp"`
+	FinishedAt     *time.Time               `bun:"finished_at"`
+}
+
+type ProductImportJobView struct {
+	bun.BaseModel `bun:"tb_product_import_job,alias:_product_import_job"`
+
+	Id             string                   `json:"id" bun:"id,pk"`
+	FileName       string                   `json:"file_name" bun:"file_name"`
+	Format         string                   `json:"format" bun:"format"`
+	DryRun         bool                     `json:"dry_run" bun:"dry_run"`
+	Status         string                   `json:"status" bun:"status"`
+	TotalRows      int32                    `json:"total_rows" bun:"total_rows"`
+	ProcessedRows  int32                    `json:"processed_rows" bun:"processed_rows"`
+	CreatedCount   int32                    `json:"created_count" bun:"created_count"`
+	UpdatedCount   int32                    `json:"updated_count" bun:"updated_count"`
+	ErrorCount     int32                    `json:"error_count" bun:"error_count"`
+	Errors         []*ProductImportRowError `json:"errors,omitempty" bun:"errors,type:jsonb"`
+	FailureMessage string                   `json:"failure_message,omitempty" bun:"failure_message"`
+	ActorId        *string                  `json:"actor_id,omitempty" bun:"actor_id"`
+	CreatedAt      time.Time                `json:"created_at" bun:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at" bun:"updated_at"`
+	FinishedAt     *time.Time               `json:"finished_at,omitempty" bun:"finished_at"`
+}
+
+// Validation error of a row, row is the line (CSV) or row number (XLSX) in the file
+type ProductImportRowError struct {
+	Row     int32  `json:"row"`
+	Sku     string `json:"sku,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Product as exported, category and brand are given by name so that the file can be imported back
+type ProductExportView struct {
+	bun.BaseModel `bun:"tb_product,alias:_product"`
+
+	Id                 string `bun:"id"`
+	Sku                string `bun:"sku"`
+	Name               string `bun:"name"`
+	Description        string `bun:"description"`
+	Sex                string `bun:"sex"`
+	Price              int64  `bun:"price"`
+	DiscountPercentage int32  `bun:"discount_percentage"`
+	Stock              int32  `bun:"stock"`
+	ImageURL           string `bun:"image_url"`
+	CategoryName       string `bun:"category_name"`
+	BrandName          string `bun:"brand_name"`
+	Status             string `bun:"status"`
+}
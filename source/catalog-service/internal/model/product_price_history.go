@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Append-only record of price customers pay for product, a new entry is added whenever price or discount of product changes
+// and whenever a promotion of product starts or stops being in effect. Discount percentage and final price are the effective
+// ones at that time, promotion included.
+type ProductPriceHistory struct {
+	bun.BaseModel `bun:"tb_product_price_history"`
+
+	Id                 string     `bun:"id,pk"`
+	ProductId          string     `bun:"product_id,notnull"`
+	Price              int64      `bun:"price,notnull"`
+	DiscountPercentage int32      `bun:"discount_percentage,notnull"`
+	FinalPrice         int64      `bun:"final_price,notnull"`
+	PromotionId        *string    `bun:"promotion_id"`
+	ActorId            *string    `bun:"actor_id"`
+	CreatedAt          *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+type ProductPriceHistoryView struct {
+	bun.BaseModel `bun:"tb_product_price_history,alias:_product_price_history"`
+
+	Id                 string    `json:"id" bun:"id,pk"`
+	ProductId          string    `json:"product_id" bun:"product_id"`
+	Price              int64     `json:"price" bun:"price"`
+	DiscountPercentage int32     `json:"discount_percentage" bun:"discount_percentage"`
+	FinalPrice         int64     `json:"final_price" bun:"final_price"`
+	PromotionId        *string   `json:"promotion_id,omitempty" bun:"promotion_id"`
+	ActorId            *string   `json:"actor_id,omitempty" bun:"actor_id"`
+	CreatedAt          time.Time `json:"created_at" bun:"created_at"`
+}
+
+// Price of product after discount, rounded down per unit like prices of invoice details in order-service
+func FinalPrice(price int64, discountPercentage int32) int64 {
+	return price * int64(100-discountPercentage) / 100
+}
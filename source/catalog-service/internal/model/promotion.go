@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Time-boxed discount on products, categories (with their subcategories) or brands. Stock allotment limits units sold
+// under a flash sale and per user limit limits units each user buys under it, zero means unlimited.
+type Promotion struct {
+	bun.BaseModel `bun:"tb_promotion"`
+
+	Id                 string     `bun:"id,pk"`
+	Name               string     `bun:"name,notnull"`
+	Description        string     `bun:"description,notnull,default:''"`
+	DiscountPercentage int32      `bun:"discount_percentage,notnull"`
+	TargetType         string     `bun:"target_type,notnull"`
+	TargetIds          []string   `bun:"target_ids,type:jsonb,notnull"`
+	StartTime          *time.Time `bun:"start_time,notnull"`
+	EndTime            *time.Time `bun:"end_time,notnull"`
+	PerUserLimit       int32      `bun:"per_user_limit,notnull,default:0"`
+	StockAllotment     int32      `bun:"stock_allotment,notnull,default:0"`
+	SoldQuantity       int32      `bun:"sold_quantity,notnull,default:0"`
+	Status             string     `bun:"status,notnull"`
+	CreatedAt          *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt          *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+type PromotionView struct {
+	bun.BaseModel `bun:"tb_promotion,alias:_promotion"`
+
+	Id                 string    `json:"id" bun:"id,pk"`
+	Name               string    `json:"name" bun:"name"`
+	Description        string    `json:"description" bun:"description"`
+	DiscountPercentage int32     `json:"discount_percentage" bun:"discount_percentage"`
+	TargetType         string    `json:"target_type" bun:"target_type"`
+	TargetIds          []string  `json:"target_ids" bun:"target_ids,type:jsonb"`
+	StartTime          time.Time `json:"start_time" bun:"start_time"`
+	EndTime            time.Time `json:"end_time" bun:"end_time"`
+	PerUserLimit       int32     `json:"per_user_limit" bun:"per_user_limit"`
+	StockAllotment     int32     `json:"stock_allotment" bun:"stock_allotment"`
+	SoldQuantity       int32     `json:"sold_quantity" bun:"sold_quantity"`
+	Status             string    `json:"status" bun:"status"`
+	CreatedAt          time.Time `json:"created_at" bun:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" bun:"updated_at"`
+}
+
+// Units of product a user bought under a promotion, released when its invoice is cancelled
+type PromotionUsage struct {
+	bun.BaseModel `bun:"tb_promotion_usage"`
+
+	Id          string     `bun:"id,pk"`
+	PromotionId string     `bun:"promotion_id,notnull"`
+	UserId      string     `bun:"user_id,notnull"`
+	InvoiceId   string     `bun:"invoice_id,notnull"`
+	ProductId   string     `bun:"product_id,notnull"`
+	Quantity    int32      `bun:"quantity,notnull"`
+	CreatedAt   *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+// Promotion currently discounting a product, embedded in product view
+type ActivePromotionView struct {
+	Id                 string    `json:"id"`
+	Name               string    `json:"name"`
+	DiscountPercentage int32     `json:"discount_percentage"`
+	EndTime            time.Time `json:"end_time"`
+	StockAllotment     int32     `json:"stock_allotment"`
+	SoldQuantity       int32     `json:"sold_quantity"`
+}
+
+// Promotion in effect for a product, candidate for pricing it at checkout
+type ProductPromotionView struct {
+	ProductId          string `bun:"product_id"`
+	PromotionId        string `bun:"promotion_id"`
+	DiscountPercentage int32  `bun:"discount_percentage"`
+	PerUserLimit       int32  `bun:"per_user_limit"`
+	StockAllotment     int32  `bun:"stock_allotment"`
+	SoldQuantity       int32  `bun:"sold_quantity"`
+}
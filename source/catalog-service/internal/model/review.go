@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Review struct {
+	bun.BaseModel `bun:"tb_review"`
+
+	Id               string     `bun:"id,pk"`
+	ProductId        string     `bun:"product_id,notnull"`
+	UserId           string     `bun:"user_id,notnull"`
+	Rating           int32      `bun:"rating,notnull"`
+	Title            string     `bun:"title,notnull"`
+	Body             string     `bun:"body,notnull"`
+	PhotoURLs        []string   `bun:"photo_urls,type:jsonb,notnull"`
+	VerifiedPurchase bool       `bun:"verified_purchase,notnull,default:false"`
+	InvoiceId        *string    `bun:"invoice_id"`
+	Status           string     `bun:"status,notnull"`
+	ModeratedBy      *string    `bun:"moderated_by"`
+	ModerationNote   string     `bun:"moderation_note,notnull,default:''"`
+	CreatedAt        *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt        *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+type ReviewView struct {
+	bun.BaseModel `bun:"tb_review,alias:_review"`
+
+	Id               string    `json:"id" bun:"id,pk"`
+	ProductId        string    `json:"product_id" bun:"product_id"`
+	UserId           string    `json:"user_id" bun:"user_id"`
+	Rating           int32     `json:"rating" bun:"rating"`
+	Title            string    `json:"title" bun:"title"`
+	Body             string    `json:"body" bun:"body"`
+	PhotoURLs        []string  `json:"photo_urls" bun:"photo_urls,type:jsonb"`
+	VerifiedPurchase bool      `json:"verified_purchase" bun:"verified_purchase"`
+	Status           string    `json:"status" bun:"status"`
+	ModerationNote   string    `json:"moderation_note,omitempty" bun:"moderation_note"`
+	CreatedAt        time.Time `json:"created_at" bun:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" bun:"updated_at"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+
+type SearchReportView struct {
+	Type               string                 `json:"type"`
+	TotalSearches      int64                  `json:"total_searches"`
+	ZeroResultSearches int64                  `json:"zero_result_searches"`
+	ClickedSearches    int64                  `json:"clicked_searches"`
+	ClickThroughRate   float64                `json:"click_through_rate"`
+	Queries            []*SearchQueryStatView `json:"queries"`
+}
+
+type SearchQueryStatView struct {
+	Query              string  `json:"query"`
+	Searches           int64   `json:"searches"`
+	ZeroResultSearches int64   `json:"zero_result_searches"`
+	ClickedSearches    int64   `json:"clicked_searches"`
+	Clicks             int64   `json:"clicks"`
+	ClickThroughRate   float64 `json:"click_through_rate"`
+	AverageResultCount float64 `json:"average_result_count"`
+}
+
+// Proto -> View
+
+func FromSearchReportProtoToSearchReportView(searchReportProto *elasticsearchservicepb.SearchReport) *SearchReportView {
+	queryViews := make([]*SearchQueryStatView, len(searchReportProto.Queries))
+	for i, queryProto := range searchReportProto.Queries {
+		queryViews[i] = &SearchQueryStatView{
+			Query:              queryProto.Query,
+			Searches:           queryProto.Searches,
+			ZeroResultSearches: queryProto.ZeroResultSearches,
+			ClickedSearches:    queryProto.ClickedSearches,
+			Clicks:             queryProto.Clicks,
+			ClickThroughRate:   queryProto.ClickThroughRate,
+			AverageResultCount: queryProto.AverageResultCount,
+		}
+	}
+
+	return &SearchReportView{
+		Type:               searchReportProto.Type,
+		TotalSearches:      searchReportProto.TotalSearches,
+		ZeroResultSearches: searchReportProto.ZeroResultSearches,
+		ClickedSearches:    searchReportProto.ClickedSearches,
+		ClickThroughRate:   searchReportProto.ClickThroughRate,
+		Queries:            queryViews,
+	}
+}
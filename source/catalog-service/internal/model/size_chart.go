@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Measurement table of brand for category, products of brand are sized by chart of their category or else of its
+// nearest ancestor having one
+type SizeChart struct {
+	bun.BaseModel `bun:"tb_size_chart"`
+
+	Id         string           `bun:"id,pk"`
+	BrandId    string           `bun:"brand_id,notnull"`
+	CategoryId string           `bun:"category_id,notnull"`
+	Name       string           `bun:"name,notnull"`
+	Sizes      []*SizeChartSize `bun:"sizes,type:jsonb,notnull"`
+	CreatedAt  *time.Time       `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt  *time.Time       `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+// Size with ranges of body measurements (cm) it fits, sizes of chart are ordered from smallest
+type SizeChartSize struct {
+	Label        string                  `json:"label"`
+	Measurements []*SizeChartMeasurement `json:"measurements"`
+}
+
+type SizeChartMeasurement struct {
+	Measurement string  `json:"measurement"` // HEIGHT, CHEST, WAIST, HIP, INSEAM or FOOT_LENGTH
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+}
+
+type SizeChartView struct {
+	bun.BaseModel `bun:"tb_size_chart,alias:_size_chart"`
+
+	Id           string           `json:"id" bun:"id,pk"`
+	BrandId      string           `json:"brand_id" bun:"brand_id"`
+	BrandName    string           `json:"brand_name" bun:"brand_name"`
+	CategoryId   string           `json:"category_id" bun:"category_id"`
+	CategoryName string           `json:"category_name" bun:"category_name"`
+	Name         string           `json:"name" bun:"name"`
+	Sizes        []*SizeChartSize `json:"sizes" bun:"sizes,type:jsonb"`
+	CreatedAt    time.Time        `json:"created_at" bun:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at" bun:"updated_at"`
+}
+
+// Size of product chart suiting body measurements of user best
+type FitRecommendationView struct {
+	ProductId       string `json:"product_id"`
+	SizeChartId     string `json:"size_chart_id"`
+	RecommendedSize string `json:"recommended_size"`
+	// Every measurement of user compared is inside range of recommended size
+	ExactFit     bool                  `json:"exact_fit"`
+	Measurements []*FitMeasurementView `json:"measurements"`
+}
+
+type FitMeasurementView struct {
+	Measurement string  `json:"measurement"`
+	Value       float64 `json:"value"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Fit         string  `json:"fit"` // FIT, TOO_SMALL (size is small for body) or TOO_LARGE
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Former slug of a product, brand or category, lookups by it are redirected to the current slug of entity
+type SlugRedirect struct {
+	bun.BaseModel `bun:"tb_slug_redirect"`
+
+	Id         string     `bun:"id,pk"`
+	EntityType string     `bun:"entity_type,notnull"`
+	Slug       string     `bun:"slug,notnull"`
+	EntityId   string     `bun:"entity_id,notnull"`
+	CreatedAt  *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Append-only ledger entry of product stock in a warehouse, stock of product in warehouse equals sum of quantities of its movements
+// there and stock after is stock of product in that warehouse after the movement
+type StockMovement struct {
+	bun.BaseModel `bun:"tb_stock_movement"`
+
+	Id          string     `bun:"id,pk"`
+	ProductId   string     `bun:"product_id,notnull"`
+	WarehouseId string     `bun:"warehouse_id,notnull"`
+	Quantity    int32      `bun:"quantity,notnull"`
+	StockAfter  int32      `bun:"stock_after,notnull"`
+	Reason      string     `bun:"reason,notnull"`
+	InvoiceId   *string    `bun:"invoice_id"`
+	ActorId     *string    `bun:"actor_id"`
+	Note        string     `bun:"note,notnull,default:''"`
+	CreatedAt   *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+type StockMovementView struct {
+	bun.BaseModel `bun:"tb_stock_movement,alias:_stock_movement"`
+
+	Id          string    `json:"id" bun:"id,pk"`
+	ProductId   string    `json:"product_id" bun:"product_id"`
+	WarehouseId string    `json:"warehouse_id" bun:"warehouse_id"`
+	Quantity    int32     `json:"quantity" bun:"quantity"`
+	StockAfter  int32     `json:"stock_after" bun:"stock_after"`
+	Reason      string    `json:"reason" bun:"reason"`
+	InvoiceId   *string   `json:"invoice_id,omitempty" bun:"invoice_id"`
+	ActorId     *string   `json:"actor_id,omitempty" bun:"actor_id"`
+	Note        string    `json:"note,omitempty" bun:"note"`
+	CreatedAt   time.Time `json:"created_at" bun:"created_at"`
+}
+
+type StockMovementHistoryView struct {
+	ProductId       string                `json:"product_id"`
+	Stock           int32                 `json:"stock"`
+	LedgerStock     int32                 `json:"ledger_stock"`
+	WarehouseStocks []*WarehouseStockView `json:"warehouse_stocks"`
+	StockMovements  []*StockMovementView  `json:"stock_movements"`
+}
+
+// Warehouse id is empty for total stock of product
+type StockReconciliationView struct {
+	ProductId   string `json:"product_id" bun:"product_id"`
+	WarehouseId string `json:"warehouse_id,omitempty" bun:"warehouse_id"`
+	Stock       int32  `json:"stock" bun:"stock"`
+	LedgerStock int32  `json:"ledger_stock" bun:"ledger_stock"`
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Warehouse struct {
+	bun.BaseModel `bun:"tb_warehouse"`
+
+	Id        string     `bun:"id,pk"`
+	Code      string     `bun:"code,notnull,unique"`
+	Name      string     `bun:"name,notnull"`
+	Address   string     `bun:"address,notnull"`
+	Latitude  float64    `bun:"latitude,notnull"`
+	Longitude float64    `bun:"longitude,notnull"`
+	Priority  int32      `bun:"priority,notnull,default:0"`
+	IsActive  bool       `bun:"is_active,notnull,default:true"`
+	CreatedAt *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+type WarehouseView struct {
+	bun.BaseModel `bun:"tb_warehouse,alias:_warehouse"`
+
+	Id        string    `json:"id" bun:"id,pk"`
+	Code      string    `json:"code" bun:"code"`
+	Name      string    `json:"name" bun:"name"`
+	Address   string    `json:"address" bun:"address"`
+	Latitude  float64   `json:"latitude" bun:"latitude"`
+	Longitude float64   `json:"longitude" bun:"longitude"`
+	Priority  int32     `json:"priority" bun:"priority"`
+	IsActive  bool      `json:"is_active" bun:"is_active"`
+	CreatedAt time.Time `json:"created_at" bun:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bun:"updated_at"`
+}
+
+type WarehouseStock struct {
+	bun.BaseModel `bun:"tb_warehouse_stock"`
+
+	ProductId   string `bun:"product_id,pk"`
+	WarehouseId string `bun:"warehouse_id,pk"`
+	Stock       int32  `bun:"stock,notnull"`
+}
+
+type WarehouseStockView struct {
+	bun.BaseModel `bun:"tb_warehouse_stock,alias:_warehouse_stock"`
+
+	ProductId   string `json:"product_id" bun:"product_id"`
+	WarehouseId string `json:"warehouse_id" bun:"warehouse_id"`
+	Stock       int32  `json:"stock" bun:"stock"`
+
+	WarehouseCode      string  `json:"warehouse_code" bun:"warehouse_code"`
+	WarehouseName      string  `json:"warehouse_name" bun:"warehouse_name"`
+	WarehouseLatitude  float64 `json:"-" bun:"warehouse_latitude"`
+	WarehouseLongitude float64 `json:"-" bun:"warehouse_longitude"`
+	WarehousePriority  int32   `json:"-" bun:"warehouse_priority"`
+	WarehouseIsActive  bool    `json:"warehouse_is_active" bun:"warehouse_is_active"`
+}
+
+// Quantity of product taken from a warehouse to fulfill an invoice line, with price of product at checkout
+type StockAllocation struct {
+	ProductId          string
+	WarehouseId        string
+	Quantity           int32
+	Price              int64
+	DiscountPercentage int32
+	PromotionId        string
+	Pending            bool   // Quantity sold beyond stock of a pre-order or backorder product, not taken from any warehouse yet
+	Bundle             bool   // Quantity of bundle itself, its stock is taken through allocations of its components
+	BundleId           string // Bundle which the component is taken for, it is not priced on its own
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+)
+
+type attributeDefinitionRepository struct {
+}
+
+type AttributeDefinitionRepository interface {
+	GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.AttributeDefinitionView, error)
+	GetViewById(ctx context.Context, id string) (*model.AttributeDefinitionView, error)
+
+	GetById(ctx context.Context, id string) (*model.AttributeDefinition, error)
+	GetByCode(ctx context.Context, code string) (*model.AttributeDefinition, error)
+	Create(ctx context.Context, newAttributeDefinition *model.AttributeDefinition) error
+	Update(ctx context.Context, updatedAttributeDefinition *model.AttributeDefinition) error
+	DeleteById(ctx context.Context, id string) error
+}
+
+func NewAttributeDefinitionRepository() AttributeDefinitionRepository {
+	return &attributeDefinitionRepository{}
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.AttributeDefinitionView, error) {
+	var attributeDefinitions []*model.AttributeDefinitionView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&attributeDefinitions)
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_attribute_definition.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return attributeDefinitions, nil
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) GetViewById(ctx context.Context, id string) (*model.AttributeDefinitionView, error) {
+	attributeDefinition := new(model.AttributeDefinitionView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(attributeDefinition).Where("_attribute_definition.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return attributeDefinition, nil
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) GetById(ctx context.Context, id string) (*model.AttributeDefinition, error) {
+	attributeDefinition := new(model.AttributeDefinition)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(attributeDefinition).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return attributeDefinition, nil
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) GetByCode(ctx context.Context, code string) (*model.AttributeDefinition, error) {
+	attributeDefinition := new(model.AttributeDefinition)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(attributeDefinition).Where("code = ?", code)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return attributeDefinition, nil
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) Create(ctx context.Context, newAttributeDefinition *model.AttributeDefinition) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newAttributeDefinition).Returning("*").Exec(ctx)
+	return err
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) Update(ctx context.Context, updatedAttributeDefinition *model.AttributeDefinition) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedAttributeDefinition).Where("id = ?", updatedAttributeDefinition.Id).Exec(ctx)
+	return err
+}
+
+func (attributeDefinitionRepository *attributeDefinitionRepository) DeleteById(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.AttributeDefinition{}).Where("id = ?", id).Exec(ctx)
+	return err
+}
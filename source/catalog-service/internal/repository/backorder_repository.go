@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type backorderRepository struct {
+}
+
+// Returned (wrapped) when backorder of product would exceed its backorder limit or product no longer accepts backorders
+var ErrBackorderUnavailable = errors.New("backorder is no longer available")
+
+// Returned by Allocate when backorder was allocated or cancelled meanwhile
+var ErrBackorderNotPending = errors.New("backorder is not pending")
+
+type BackorderRepository interface {
+	GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, productId string, status string) ([]*model.BackorderView, error)
+	GetPendingByProductId(ctx context.Context, productId string) ([]*model.Backorder, error)
+	GetPendingProductIds(ctx context.Context) ([]string, error)
+
+	// Allocated backorders not yet applied to their invoice by order-service, oldest first
+	GetUnconfirmedAllocated(ctx context.Context, limit int) ([]*model.Backorder, error)
+
+	// Mark pending backorder as allocated with its stock allocations and take its stock with movements in one transaction
+	Allocate(ctx context.Context, backorder *model.Backorder, newStockMovements []*model.StockMovement) error
+	// Mark allocated backorder as applied to its invoice, confirming it again does nothing
+	ConfirmById(ctx context.Context, id string) error
+}
+
+func NewBackorderRepository() BackorderRepository {
+	return &backorderRepository{}
+}
+
+func (backorderRepository *backorderRepository) GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, productId string, status string) ([]*model.BackorderView, error) {
+	var backorders []*model.BackorderView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&backorders).
+		Column("_backorder.*").
+		ColumnExpr("_product.sku AS product_sku").
+		ColumnExpr("_product.name AS product_name").
+		ColumnExpr("_product.backorder_policy").
+		ColumnExpr("_product.expected_ship_date").
+		Join("JOIN tb_product AS _product ON _product.id = _backorder.product_id").
+		Offset(offset).
+		Limit(limit)
+
+	if productId != "" {
+		query = query.Where("_backorder.product_id = ?", productId)
+	}
+	if status != "" {
+		query = query.Where("_backorder.status = ?", status)
+	}
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_backorder.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return backorders, nil
+}
+
+// Pending backorders of product in allocation order, first in first out
+func (backorderRepository *backorderRepository) GetPendingByProductId(ctx context.Context, productId string) ([]*model.Backorder, error) {
+	var backorders []*model.Backorder
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&backorders).
+		Where("product_id = ?", productId).
+		Where("status = 'PENDING'").
+		Order("created_at ASC", "id ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return backorders, nil
+}
+
+func (backorderRepository *backorderRepository) GetPendingProductIds(ctx context.Context) ([]string, error) {
+	var productIds []string
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.Backorder)(nil)).
+		Distinct().
+		Column("product_id").
+		Where("status = 'PENDING'")
+
+	if err := query.Scan(ctx, &productIds); err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
+
+func (backorderRepository *backorderRepository) GetUnconfirmedAllocated(ctx context.Context, limit int) ([]*model.Backorder, error) {
+	var backorders []*model.Backorder
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&backorders).
+		Where("status = 'ALLOCATED'").
+		Where("confirmed_at IS NULL").
+		Where("stock_allocations IS NOT NULL").
+		Order("updated_at ASC", "id ASC").
+		Limit(limit)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return backorders, nil
+}
+
+func (backorderRepository *backorderRepository) Allocate(ctx context.Context, backorder *model.Backorder, newStockMovements []*model.StockMovement) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	timeUpdate := time.Now().UTC()
+
+	result, err := tx.NewUpdate().Model((*model.Backorder)(nil)).
+		Set("status = 'ALLOCATED'").
+		Set("stock_allocations = ?", backorder.StockAllocations).
+		Set("updated_at = ?", timeUpdate).
+		Where("id = ?", backorder.Id).
+		Where("status = 'PENDING'").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", ErrBackorderNotPending, backorder.Id)
+	}
+
+	if _, err := tx.NewUpdate().Model((*model.Product)(nil)).
+		Set("backorder_quantity = GREATEST(backorder_quantity - ?, 0)", backorder.Quantity).
+		Where("id = ?", backorder.ProductId).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (backorderRepository *backorderRepository) ConfirmById(ctx context.Context, id string) error {
+	query := infrastructure.PostgresDB.NewUpdate().Model((*model.Backorder)(nil)).
+		Set("confirmed_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Where("confirmed_at IS NULL")
+
+	if _, err := query.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Cancel backorders of cancelled invoice, pending ones leave the queue of their product and allocated ones give their stock
+// back to the warehouses they took it from. It returns ids of products whose stock changed.
+func cancelBackordersByInvoiceId(ctx context.Context, tx bun.Tx, invoiceId string, actorId *string) ([]string, error) {
+	timeUpdate := time.Now().UTC()
+
+	var cancelledBackorders []*model.Backorder
+	if _, err := tx.NewUpdate().Model((*model.Backorder)(nil)).
+		Set("status = 'CANCELLED'").
+		Set("updated_at = ?", timeUpdate).
+		Where("invoice_id = ?", invoiceId).
+		Where("status = 'PENDING'").
+		Returning("product_id, quantity").
+		Exec(ctx, &cancelledBackorders); err != nil {
+		return nil, err
+	}
+
+	productIds := []string{}
+	for _, cancelledBackorder := range cancelledBackorders {
+		if _, err := tx.NewUpdate().Model((*model.Product)(nil)).
+			Set("backorder_quantity = GREATEST(backorder_quantity - ?, 0)", cancelledBackorder.Quantity).
+			Where("id = ?", cancelledBackorder.ProductId).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		productIds = append(productIds, cancelledBackorder.ProductId)
+	}
+
+	// Backorders allocated before their allocations were kept are left to order-service, which restores them with other
+	// details of the invoice
+	var allocatedBackorders []*model.Backorder
+	if _, err := tx.NewUpdate().Model((*model.Backorder)(nil)).
+		Set("status = 'CANCELLED'").
+		Set("updated_at = ?", timeUpdate).
+		Where("invoice_id = ?", invoiceId).
+		Where("status = 'ALLOCATED'").
+		Where("stock_allocations IS NOT NULL").
+		Returning("product_id, stock_allocations").
+		Exec(ctx, &allocatedBackorders); err != nil {
+		return nil, err
+	}
+
+	newStockMovements := []*model.StockMovement{}
+	for _, allocatedBackorder := range allocatedBackorders {
+		for _, stockAllocation := range allocatedBackorder.StockAllocations {
+			newStockMovements = append(newStockMovements, &model.StockMovement{
+				Id:          uuid.New().String(),
+				ProductId:   allocatedBackorder.ProductId,
+				WarehouseId: stockAllocation.WarehouseId,
+				Quantity:    stockAllocation.Quantity,
+				Reason:      "CANCEL_RESTORE",
+				InvoiceId:   &invoiceId,
+				ActorId:     actorId,
+				Note:        "Allocated backorder of cancelled invoice",
+			})
+		}
+		productIds = append(productIds, allocatedBackorder.ProductId)
+	}
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
+
+// Queue backorders of a sale, backorder quantity of product is checked against its limit by the update itself
+func createBackorders(ctx context.Context, tx bun.Tx, newBackorders []*model.Backorder) error {
+	timeUpdate := time.Now().UTC()
+
+	for _, newBackorder := range newBackorders {
+		result, err := tx.NewUpdate().Model((*model.Product)(nil)).
+			Set("backorder_quantity = backorder_quantity + ?", newBackorder.Quantity).
+			Where("id = ?", newBackorder.ProductId).
+			Where("backorder_policy <> 'NONE'").
+			Where("(backorder_limit = 0 OR backorder_quantity + ? <= backorder_limit)", newBackorder.Quantity).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			return fmt.Errorf("%w for product id: %s", ErrBackorderUnavailable, newBackorder.ProductId)
+		}
+
+		newBackorder.CreatedAt = &timeUpdate
+		newBackorder.UpdatedAt = &timeUpdate
+	}
+
+	if len(newBackorders) != 0 {
+		if _, err := tx.NewInsert().Model(&newBackorders).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
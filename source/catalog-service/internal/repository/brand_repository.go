@@ -12,11 +12,13 @@ type brandRepository struct {
 }
 
 type BrandRepository interface {
-	GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.BrandView, error)
+	GetAllViews(ctx context.Context, sortFields []*utils.SortField, status string) ([]*model.BrandView, error)
 	GetViewById(ctx context.Context, id string) (*model.BrandView, error)
+	GetViewBySlug(ctx context.Context, slug string) (*model.BrandView, error)
 
 	GetById(ctx context.Context, id string) (*model.Brand, error)
 	GetByName(ctx context.Context, name string) (*model.Brand, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Brand, error)
 	Create(ctx context.Context, newBrand *model.Brand) error
 	Update(ctx context.Context, updatedBrand *model.Brand) error
 	DeleteById(ctx context.Context, id string) error
@@ -26,26 +28,48 @@ func NewBrandRepository() BrandRepository {
 	return &brandRepository{}
 }
 
-func (brandRepository *brandRepository) GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.BrandView, error) {
-	var brands []*model.BrandView
+// Empty status gets brands of every status. Views are cached until brand is written.
+func (brandRepository *brandRepository) GetAllViews(ctx context.Context, sortFields []*utils.SortField, status string) ([]*model.BrandView, error) {
+	key := "all:" + status + ":" + utils.FormatSorter(sortFields)
+	return infrastructure.GetOrLoadView(ctx, infrastructure.BrandViewCache, key, func(ctx context.Context) ([]*model.BrandView, error) {
+		var brands []*model.BrandView
 
-	query := infrastructure.PostgresDB.NewSelect().Model(&brands)
+		query := infrastructure.PostgresDB.NewSelect().Model(&brands)
 
-	for _, sortField := range sortFields {
-		query = query.Order(fmt.Sprintf("_brand.%s %s", sortField.Field, sortField.Direction))
-	}
+		if status != "" {
+			query = query.Where("_brand.status = ?", status)
+		}
 
-	if err := query.Scan(ctx); err != nil {
-		return nil, err
-	}
+		for _, sortField := range sortFields {
+			query = query.Order(fmt.Sprintf("_brand.%s %s", sortField.Field, sortField.Direction))
+		}
 
-	return brands, nil
+		if err := query.Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return brands, nil
+	})
 }
 
 func (brandRepository *brandRepository) GetViewById(ctx context.Context, id string) (*model.BrandView, error) {
+	return infrastructure.GetOrLoadView(ctx, infrastructure.BrandViewCache, "id:"+id, func(ctx context.Context) (*model.BrandView, error) {
+		brand := new(model.BrandView)
+
+		query := infrastructure.PostgresDB.NewSelect().Model(brand).Where("_brand.id = ?", id)
+
+		if err := query.Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return brand, nil
+	})
+}
+
+func (brandRepository *brandRepository) GetViewBySlug(ctx context.Context, slug string) (*model.BrandView, error) {
 	brand := new(model.BrandView)
 
-	query := infrastructure.PostgresDB.NewSelect().Model(brand).Where("_brand.id = ?", id)
+	query := infrastructure.PostgresDB.NewSelect().Model(brand).Where("_brand.slug = ?", slug)
 
 	if err := query.Scan(ctx); err != nil {
 		return nil, err
@@ -78,17 +102,51 @@ func (brandRepository *brandRepository) GetByName(ctx context.Context, name stri
 	return brand, nil
 }
 
+func (brandRepository *brandRepository) GetBySlug(ctx context.Context, slug string) (*model.Brand, error) {
+	brand := new(model.Brand)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(brand).Where("slug = ?", slug)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
 func (brandRepository *brandRepository) Create(ctx context.Context, newBrand *model.Brand) error {
-	_, err := infrastructure.PostgresDB.NewInsert().Model(newBrand).Returning("*").Exec(ctx)
-	return err
+	if _, err := infrastructure.PostgresDB.NewInsert().Model(newBrand).Returning("*").Exec(ctx); err != nil {
+		return err
+	}
+
+	infrastructure.BrandViewCache.InvalidateAll(ctx)
+	return nil
 }
 
 func (brandRepository *brandRepository) Update(ctx context.Context, updatedBrand *model.Brand) error {
-	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedBrand).Where("id = ?", updatedBrand.Id).Exec(ctx)
-	return err
+	if _, err := infrastructure.PostgresDB.NewUpdate().Model(updatedBrand).Where("id = ?", updatedBrand.Id).Exec(ctx); err != nil {
+		return err
+	}
+
+	infrastructure.BrandViewCache.InvalidateAll(ctx)
+	// Cached product views carry name of their brand
+	infrastructure.ProductViewCache.InvalidateAll(ctx)
+	return nil
 }
 
+// Soft delete, brand is archived and kept for products and invoices referencing it
 func (brandRepository *brandRepository) DeleteById(ctx context.Context, id string) error {
-	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.Brand{}).Where("id = ?", id).Exec(ctx)
-	return err
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Brand{}).
+		Set("status = 'ARCHIVED'").
+		Set("deleted_at = now()").
+		Set("updated_at = now()").
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	infrastructure.BrandViewCache.InvalidateAll(ctx)
+	infrastructure.ProductViewCache.InvalidateAll(ctx)
+	return nil
 }
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type bundleComponentRepository struct {
+}
+
+type BundleComponentRepository interface {
+	GetByBundleId(ctx context.Context, bundleId string) ([]*model.BundleComponent, error)
+	GetByListBundleId(ctx context.Context, bundleIds []string) ([]*model.BundleComponent, error)
+	GetBundleIdsByComponentId(ctx context.Context, componentId string) ([]string, error)
+
+	// Replace components of bundle and derive its stock and price (when priced off its components) from them in one transaction
+	ReplaceByBundleId(ctx context.Context, bundleId string, newBundleComponents []*model.BundleComponent) error
+	// Derive stock and price (when priced off their components) of bundles containing component again after component
+	// changed, price history of repriced bundles is recorded in the same transaction so that it is recorded once whichever
+	// instance derives it. Ids of bundles whose price changed are returned.
+	SyncByComponentId(ctx context.Context, componentId string) ([]string, error)
+}
+
+func NewBundleComponentRepository() BundleComponentRepository {
+	return &bundleComponentRepository{}
+}
+
+func (bundleComponentRepository *bundleComponentRepository) GetByBundleId(ctx context.Context, bundleId string) ([]*model.BundleComponent, error) {
+	var bundleComponents []*model.BundleComponent
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&bundleComponents).
+		Where("bundle_id = ?", bundleId).
+		Order("component_id ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return bundleComponents, nil
+}
+
+func (bundleComponentRepository *bundleComponentRepository) GetByListBundleId(ctx context.Context, bundleIds []string) ([]*model.BundleComponent, error) {
+	var bundleComponents []*model.BundleComponent
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&bundleComponents).
+		Where("bundle_id IN (?)", bun.In(bundleIds)).
+		Order("bundle_id ASC", "component_id ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return bundleComponents, nil
+}
+
+func (bundleComponentRepository *bundleComponentRepository) GetBundleIdsByComponentId(ctx context.Context, componentId string) ([]string, error) {
+	var bundleIds []string
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.BundleComponent)(nil)).
+		Column("bundle_id").
+		Where("component_id = ?", componentId).
+		Order("bundle_id ASC")
+
+	if err := query.Scan(ctx, &bundleIds); err != nil {
+		return nil, err
+	}
+
+	return bundleIds, nil
+}
+
+func (bundleComponentRepository *bundleComponentRepository) ReplaceByBundleId(ctx context.Context, bundleId string, newBundleComponents []*model.BundleComponent) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewDelete().Model((*model.BundleComponent)(nil)).Where("bundle_id = ?", bundleId).Exec(ctx); err != nil {
+		return err
+	}
+
+	if len(newBundleComponents) != 0 {
+		if _, err := tx.NewInsert().Model(&newBundleComponents).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := syncBundleStocks(ctx, tx, []string{bundleId}); err != nil {
+		return err
+	}
+	if _, err := syncBundlePrices(ctx, tx, []string{bundleId}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (bundleComponentRepository *bundleComponentRepository) SyncByComponentId(ctx context.Context, componentId string) ([]string, error) {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	bundleIds, err := getBundleIdsByListComponentId(ctx, tx, []string{componentId})
+	if err != nil || len(bundleIds) == 0 {
+		return nil, err
+	}
+
+	if err := syncBundleStocks(ctx, tx, bundleIds); err != nil {
+		return nil, err
+	}
+	changedIds, err := syncBundlePrices(ctx, tx, bundleIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createProductPriceHistories(ctx, tx, changedIds, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return changedIds, nil
+}
+
+// Ids of bundles containing any of products, ordered by id
+func getBundleIdsByListComponentId(ctx context.Context, db bun.IDB, componentIds []string) ([]string, error) {
+	var bundleIds []string
+
+	query := db.NewSelect().Model((*model.BundleComponent)(nil)).
+		Distinct().
+		Column("bundle_id").
+		Where("component_id IN (?)", bun.In(componentIds)).
+		Order("bundle_id ASC")
+
+	if err := query.Scan(ctx, &bundleIds); err != nil {
+		return nil, err
+	}
+
+	return bundleIds, nil
+}
+
+// Stock of bundle is the number of whole units which stock of its components makes up, a component which is not published
+// makes up none. Bundles are locked in the same order
+// in every transaction before their stock is derived, so that stock of components committed meanwhile is seen and concurrent
+// sales of their components do not deadlock.
+func syncBundleStocks(ctx context.Context, tx bun.Tx, bundleIds []string) error {
+	if len(bundleIds) == 0 {
+		return nil
+	}
+
+	var lockedIds []string
+	if err := tx.NewSelect().Model((*model.Product)(nil)).Column("id").Where("id IN (?)", bun.In(bundleIds)).Order("id ASC").For("UPDATE").Scan(ctx, &lockedIds); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tb_product AS _bundle
+		SET stock = COALESCE(_component_stock.stock, 0), updated_at = ?
+		FROM (
+			SELECT _locked_bundle.id AS bundle_id, MIN(CASE WHEN _component.status = 'PUBLISHED' THEN _component.stock / _bundle_component.quantity ELSE 0 END) AS stock
+			FROM tb_product AS _locked_bundle
+			LEFT JOIN tb_bundle_component AS _bundle_component ON _bundle_component.bundle_id = _locked_bundle.id
+			LEFT JOIN tb_product AS _component ON _component.id = _bundle_component.component_id
+			WHERE _locked_bundle.id IN (?)
+			GROUP BY _locked_bundle.id
+		) AS _component_stock
+		WHERE _bundle.id = _component_stock.bundle_id AND _bundle.stock <> COALESCE(_component_stock.stock, 0)
+	`
+	_, err := tx.NewRaw(query, time.Now().UTC(), bun.In(bundleIds)).Exec(ctx)
+	return err
+}
+
+// Price of bundle priced off its components is the sum of their prices, ids of bundles whose price changed are returned
+func syncBundlePrices(ctx context.Context, db bun.IDB, bundleIds []string) ([]string, error) {
+	var changedIds []string
+
+	query := `
+		UPDATE tb_product AS _bundle
+		SET price = _component_price.price, updated_at = ?
+		FROM (
+			SELECT _bundle_component.bundle_id, SUM(_component.price * _bundle_component.quantity) AS price
+			FROM tb_bundle_component AS _bundle_component
+			JOIN tb_product AS _component ON _component.id = _bundle_component.component_id
+			WHERE _bundle_component.bundle_id IN (?)
+			GROUP BY _bundle_component.bundle_id
+		) AS _component_price
+		WHERE _bundle.id = _component_price.bundle_id AND _bundle.bundle_pricing = 'PERCENTAGE_OFF' AND _bundle.price <> _component_price.price
+		RETURNING _bundle.id
+	`
+	if err := db.NewRaw(query, time.Now().UTC(), bun.In(bundleIds)).Scan(ctx, &changedIds); err != nil {
+		return nil, err
+	}
+
+	return changedIds, nil
+}
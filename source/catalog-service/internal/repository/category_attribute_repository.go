@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+)
+
+type categoryAttributeRepository struct {
+}
+
+type CategoryAttributeRepository interface {
+	GetViewsByCategory(ctx context.Context, categoryId string, categoryPath string) ([]*model.CategoryAttributeView, error)
+	ReplaceByCategoryId(ctx context.Context, categoryId string, categoryAttributes []*model.CategoryAttribute) error
+	CountByAttributeDefinitionId(ctx context.Context, attributeDefinitionId string) (int, error)
+}
+
+func NewCategoryAttributeRepository() CategoryAttributeRepository {
+	return &categoryAttributeRepository{}
+}
+
+// Attribute set of category with attributes of its ancestors, an attribute in the set of several of them takes
+// settings of the nearest one. Attributes are ordered by sort order, then by code.
+func (categoryAttributeRepository *categoryAttributeRepository) GetViewsByCategory(ctx context.Context, categoryId string, categoryPath string) ([]*model.CategoryAttributeView, error) {
+	var categoryAttributes []*model.CategoryAttributeView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&categoryAttributes).
+		Column("_category_attribute.*").
+		ColumnExpr("_attribute_definition.code, _attribute_definition.name, _attribute_definition.type, _attribute_definition.options, _attribute_definition.unit").
+		ColumnExpr("_category_attribute.category_id <> ? AS inherited", categoryId).
+		Join("JOIN tb_attribute_definition AS _attribute_definition ON _attribute_definition.id = _category_attribute.attribute_definition_id").
+		Join("JOIN tb_category AS _category ON _category.id = _category_attribute.category_id").
+		Where("position('/' || _category_attribute.category_id || '/' IN ?) > 0", categoryPath).
+		Order("_category.depth DESC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	nearestCategoryAttributes := []*model.CategoryAttributeView{}
+	seenAttributeDefinitionIds := map[string]bool{}
+	for _, categoryAttribute := range categoryAttributes {
+		if !seenAttributeDefinitionIds[categoryAttribute.AttributeDefinitionId] {
+			seenAttributeDefinitionIds[categoryAttribute.AttributeDefinitionId] = true
+			nearestCategoryAttributes = append(nearestCategoryAttributes, categoryAttribute)
+		}
+	}
+	sort.SliceStable(nearestCategoryAttributes, func(i, j int) bool {
+		if nearestCategoryAttributes[i].SortOrder != nearestCategoryAttributes[j].SortOrder {
+			return nearestCategoryAttributes[i].SortOrder < nearestCategoryAttributes[j].SortOrder
+		}
+		return nearestCategoryAttributes[i].Code < nearestCategoryAttributes[j].Code
+	})
+
+	return nearestCategoryAttributes, nil
+}
+
+func (categoryAttributeRepository *categoryAttributeRepository) ReplaceByCategoryId(ctx context.Context, categoryId string, categoryAttributes []*model.CategoryAttribute) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewDelete().Model(&model.CategoryAttribute{}).Where("category_id = ?", categoryId).Exec(ctx); err != nil {
+		return err
+	}
+
+	if len(categoryAttributes) != 0 {
+		if _, err := tx.NewInsert().Model(&categoryAttributes).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (categoryAttributeRepository *categoryAttributeRepository) CountByAttributeDefinitionId(ctx context.Context, attributeDefinitionId string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.CategoryAttribute{}).Where("attribute_definition_id = ?", attributeDefinitionId).Count(ctx)
+}
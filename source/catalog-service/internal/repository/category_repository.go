@@ -12,13 +12,17 @@ type categoryRepository struct {
 }
 
 type CategoryRepository interface {
-	GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.CategoryView, error)
+	GetAllViews(ctx context.Context, sortFields []*utils.SortField, status string) ([]*model.CategoryView, error)
 	GetViewById(ctx context.Context, id string) (*model.CategoryView, error)
+	GetViewBySlug(ctx context.Context, slug string) (*model.CategoryView, error)
 
 	GetById(ctx context.Context, id string) (*model.Category, error)
 	GetByName(ctx context.Context, name string) (*model.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Category, error)
+	CountUnarchivedByParentId(ctx context.Context, parentId string) (int, error)
 	Create(ctx context.Context, newCategory *model.Category) error
 	Update(ctx context.Context, updatedCategory *model.Category) error
+	Move(ctx context.Context, movedCategory *model.Category, oldPath string, oldDepth int32) error
 	DeleteById(ctx context.Context, id string) error
 }
 
@@ -26,26 +30,48 @@ func NewCategoryRepository() CategoryRepository {
 	return &categoryRepository{}
 }
 
-func (categoryRepository *categoryRepository) GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.CategoryView, error) {
-	var categories []*model.CategoryView
+// Empty status gets categories of every status. Views are cached until category is written.
+func (categoryRepository *categoryRepository) GetAllViews(ctx context.Context, sortFields []*utils.SortField, status string) ([]*model.CategoryView, error) {
+	key := "all:" + status + ":" + utils.FormatSorter(sortFields)
+	return infrastructure.GetOrLoadView(ctx, infrastructure.CategoryViewCache, key, func(ctx context.Context) ([]*model.CategoryView, error) {
+		var categories []*model.CategoryView
 
-	query := infrastructure.PostgresDB.NewSelect().Model(&categories)
+		query := infrastructure.PostgresDB.NewSelect().Model(&categories)
 
-	for _, sortField := range sortFields {
-		query = query.Order(fmt.Sprintf("_category.%s %s", sortField.Field, sortField.Direction))
-	}
+		if status != "" {
+			query = query.Where("_category.status = ?", status)
+		}
 
-	if err := query.Scan(ctx); err != nil {
-		return nil, err
-	}
+		for _, sortField := range sortFields {
+			query = query.Order(fmt.Sprintf("_category.%s %s", sortField.Field, sortField.Direction))
+		}
+
+		if err := query.Scan(ctx); err != nil {
+			return nil, err
+		}
 
-	return categories, nil
+		return categories, nil
+	})
 }
 
 func (categoryRepository *categoryRepository) GetViewById(ctx context.Context, id string) (*model.CategoryView, error) {
+	return infrastructure.GetOrLoadView(ctx, infrastructure.CategoryViewCache, "id:"+id, func(ctx context.Context) (*model.CategoryView, error) {
+		category := new(model.CategoryView)
+
+		query := infrastructure.PostgresDB.NewSelect().Model(category).Where("_category.id = ?", id)
+
+		if err := query.Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return category, nil
+	})
+}
+
+func (categoryRepository *categoryRepository) GetViewBySlug(ctx context.Context, slug string) (*model.CategoryView, error) {
 	category := new(model.CategoryView)
 
-	query := infrastructure.PostgresDB.NewSelect().Model(category).Where("_category.id = ?", id)
+	query := infrastructure.PostgresDB.NewSelect().Model(category).Where("_category.slug = ?", slug)
 
 	if err := query.Scan(ctx); err != nil {
 		return nil, err
@@ -78,17 +104,85 @@ func (categoryRepository *categoryRepository) GetByName(ctx context.Context, nam
 	return category, nil
 }
 
+func (categoryRepository *categoryRepository) GetBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	category := new(model.Category)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(category).Where("slug = ?", slug)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (categoryRepository *categoryRepository) CountUnarchivedByParentId(ctx context.Context, parentId string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.Category{}).Where("parent_id = ?", parentId).Where("status <> 'ARCHIVED'").Count(ctx)
+}
+
 func (categoryRepository *categoryRepository) Create(ctx context.Context, newCategory *model.Category) error {
-	_, err := infrastructure.PostgresDB.NewInsert().Model(newCategory).Returning("*").Exec(ctx)
-	return err
+	if _, err := infrastructure.PostgresDB.NewInsert().Model(newCategory).Returning("*").Exec(ctx); err != nil {
+		return err
+	}
+
+	infrastructure.CategoryViewCache.InvalidateAll(ctx)
+	return nil
 }
 
 func (categoryRepository *categoryRepository) Update(ctx context.Context, updatedCategory *model.Category) error {
-	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedCategory).Where("id = ?", updatedCategory.Id).Exec(ctx)
-	return err
+	if _, err := infrastructure.PostgresDB.NewUpdate().Model(updatedCategory).Where("id = ?", updatedCategory.Id).Exec(ctx); err != nil {
+		return err
+	}
+
+	infrastructure.CategoryViewCache.InvalidateAll(ctx)
+	// Cached product views carry name and breadcrumb of their category
+	infrastructure.ProductViewCache.InvalidateAll(ctx)
+	return nil
 }
 
+// Update moved category and rewrite path/depth of all its descendants in one transaction
+func (categoryRepository *categoryRepository) Move(ctx context.Context, movedCategory *model.Category, oldPath string, oldDepth int32) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewUpdate().Model(movedCategory).Where("id = ?", movedCategory.Id).Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewUpdate().Model(&model.Category{}).
+		Set("path = ? || substr(path, ?)", movedCategory.Path, len(oldPath)+1).
+		Set("depth = depth + ?", movedCategory.Depth-oldDepth).
+		Where("path LIKE ?", oldPath+"%").
+		Where("id != ?", movedCategory.Id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	infrastructure.CategoryViewCache.InvalidateAll(ctx)
+	infrastructure.ProductViewCache.InvalidateAll(ctx)
+	return nil
+}
+
+// Soft delete, category is archived and kept for products and invoices referencing it
 func (categoryRepository *categoryRepository) DeleteById(ctx context.Context, id string) error {
-	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.Category{}).Where("id = ?", id).Exec(ctx)
-	return err
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Category{}).
+		Set("status = 'ARCHIVED'").
+		Set("deleted_at = now()").
+		Set("updated_at = now()").
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	infrastructure.CategoryViewCache.InvalidateAll(ctx)
+	infrastructure.ProductViewCache.InvalidateAll(ctx)
+	return nil
 }
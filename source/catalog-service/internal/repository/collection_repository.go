@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+	"time"
+)
+
+type collectionRepository struct {
+}
+
+type CollectionRepository interface {
+	GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, status string, onlyLive bool) ([]*model.CollectionView, error)
+	GetViewById(ctx context.Context, id string) (*model.CollectionView, error)
+
+	GetById(ctx context.Context, id string) (*model.Collection, error)
+	Create(ctx context.Context, newCollection *model.Collection) error
+	Update(ctx context.Context, updatedCollection *model.Collection) error
+	DeleteById(ctx context.Context, id string) error
+
+	// Hand-picked products in collection order
+	GetProductIdsById(ctx context.Context, id string) ([]string, error)
+	ReplaceProductsById(ctx context.Context, id string, productIds []string) error
+}
+
+func NewCollectionRepository() CollectionRepository {
+	return &collectionRepository{}
+}
+
+const collectionIsLiveColumnExpr = `(
+	_collection.status = 'PUBLISHED'
+	AND (_collection.start_time IS NULL OR _collection.start_time <= now())
+	AND (_collection.end_time IS NULL OR _collection.end_time > now())
+) AS is_live`
+
+const collectionProductCountColumnExpr = `(
+	SELECT COUNT(*) FROM tb_collection_product AS _collection_product WHERE _collection_product.collection_id = _collection.id
+) AS product_count`
+
+func (collectionRepository *collectionRepository) GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, status string, onlyLive bool) ([]*model.CollectionView, error) {
+	var collections []*model.CollectionView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&collections).
+		Column("_collection.*").
+		ColumnExpr(collectionIsLiveColumnExpr).
+		ColumnExpr(collectionProductCountColumnExpr).
+		Offset(offset).
+		Limit(limit)
+
+	if status != "" {
+		query = query.Where("_collection.status = ?", status)
+	}
+	if onlyLive {
+		query = query.Where("_collection.status = 'PUBLISHED'").
+			Where("_collection.start_time IS NULL OR _collection.start_time <= now()").
+			Where("_collection.end_time IS NULL OR _collection.end_time > now()")
+	}
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_collection.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (collectionRepository *collectionRepository) GetViewById(ctx context.Context, id string) (*model.CollectionView, error) {
+	collection := new(model.CollectionView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(collection).
+		Column("_collection.*").
+		ColumnExpr(collectionIsLiveColumnExpr).
+		ColumnExpr(collectionProductCountColumnExpr).
+		Where("_collection.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (collectionRepository *collectionRepository) GetById(ctx context.Context, id string) (*model.Collection, error) {
+	collection := new(model.Collection)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(collection).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (collectionRepository *collectionRepository) Create(ctx context.Context, newCollection *model.Collection) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newCollection).Returning("*").Exec(ctx)
+	return err
+}
+
+func (collectionRepository *collectionRepository) Update(ctx context.Context, updatedCollection *model.Collection) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedCollection).Where("id = ?", updatedCollection.Id).Exec(ctx)
+	return err
+}
+
+func (collectionRepository *collectionRepository) DeleteById(ctx context.Context, id string) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewDelete().Model(&model.CollectionProduct{}).Where("collection_id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := tx.NewDelete().Model(&model.Collection{}).Where("id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (collectionRepository *collectionRepository) GetProductIdsById(ctx context.Context, id string) ([]string, error) {
+	var productIds []string
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.CollectionProduct)(nil)).
+		Column("product_id").
+		Where("collection_id = ?", id).
+		Order("sort_order ASC", "created_at ASC")
+
+	if err := query.Scan(ctx, &productIds); err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
+
+// Membership is replaced as a whole, sort order follows order of product ids
+func (collectionRepository *collectionRepository) ReplaceProductsById(ctx context.Context, id string, productIds []string) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewDelete().Model(&model.CollectionProduct{}).Where("collection_id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+
+	if len(productIds) != 0 {
+		timeCreate := time.Now().UTC()
+		collectionProducts := make([]*model.CollectionProduct, len(productIds))
+		for i, productId := range productIds {
+			collectionProducts[i] = &model.CollectionProduct{
+				CollectionId: id,
+				ProductId:    productId,
+				SortOrder:    int32(i),
+				CreatedAt:    &timeCreate,
+			}
+		}
+		if _, err := tx.NewInsert().Model(&collectionProducts).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.NewUpdate().Model((*model.Collection)(nil)).Set("updated_at = ?", time.Now().UTC()).Where("id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -7,8 +7,10 @@ import (
 	"math/rand"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
 
 	"github.com/google/uuid"
+	"github.com/uptrace/bun"
 )
 
 func InitTableCategory() {
@@ -33,16 +35,78 @@ func InitTableCategory() {
 
 		categoryData := []*model.Category{}
 
+		// First 3 categories are roots, the others are spread under them
 		for i := range 10 {
-			categoryData = append(categoryData, &model.Category{
-				Id:   uuid.New().String(),
-				Name: fmt.Sprintf("Name Of Category %v", i+1),
-			})
+			category := &model.Category{
+				Id:        uuid.New().String(),
+				Name:      fmt.Sprintf("Name Of Category %v", i+1),
+				SortOrder: int32(i),
+			}
+			category.Slug = utils.GenerateSlug(category.Name)
+			category.Path = "/" + category.Id + "/"
+			if i >= 3 {
+				parent := categoryData[(i-3)%3]
+				category.ParentId = &parent.Id
+				category.Path = parent.Path + category.Id + "/"
+				category.Depth = parent.Depth + 1
+			}
+			categoryData = append(categoryData, category)
 		}
 
 		if _, err := infrastructure.PostgresDB.NewInsert().Model(&categoryData).Exec(ctx); err != nil {
 			log.Fatal("Create data for table tb_category on PostgreSQL failed: ", err)
 		}
+	} else {
+		upgradeTableCategory(ctx)
+	}
+}
+
+// Upgrade table tb_category created before categories became a tree, every existing category becomes a root
+func upgradeTableCategory(ctx context.Context) {
+	query := `
+		ALTER TABLE tb_category
+			ADD COLUMN IF NOT EXISTS slug VARCHAR,
+			ADD COLUMN IF NOT EXISTS parent_id VARCHAR,
+			ADD COLUMN IF NOT EXISTS path VARCHAR,
+			ADD COLUMN IF NOT EXISTS depth INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'PUBLISHED',
+			ADD COLUMN IF NOT EXISTS low_stock_threshold INTEGER,
+			ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade table tb_category on PostgreSQL failed: ", err)
+	}
+
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, `UPDATE tb_category SET path = '/' || id || '/' WHERE path IS NULL`); err != nil {
+		log.Fatal("Upgrade path of table tb_category on PostgreSQL failed: ", err)
+	}
+
+	var categories []*model.Category
+	if err := infrastructure.PostgresDB.NewSelect().Model(&categories).Where("slug IS NULL").Scan(ctx); err != nil {
+		log.Fatal("Get categories without slug from table tb_category on PostgreSQL failed: ", err)
+	}
+	usedSlugs := map[string]bool{}
+	for _, category := range categories {
+		slug := utils.GenerateSlug(category.Name)
+		if slug == "" || usedSlugs[slug] {
+			slug = fmt.Sprintf("%s-%s", slug, category.Id[:8])
+		}
+		usedSlugs[slug] = true
+
+		if _, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Category{}).Set("slug = ?", slug).Where("id = ?", category.Id).Exec(ctx); err != nil {
+			log.Fatal("Upgrade slug of table tb_category on PostgreSQL failed: ", err)
+		}
+	}
+
+	query = `
+		ALTER TABLE tb_category
+			ALTER COLUMN slug SET NOT NULL,
+			ALTER COLUMN path SET NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS tb_category_slug_key ON tb_category (slug);
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade constraints of table tb_category on PostgreSQL failed: ", err)
 	}
 }
 
@@ -72,6 +136,7 @@ func InitTableBrand() {
 			brandData = append(brandData, &model.Brand{
 				Id:          uuid.New().String(),
 				Name:        fmt.Sprintf("Name Of Brand %v", i+1),
+				Slug:        utils.GenerateSlug(fmt.Sprintf("Name Of Brand %v", i+1)),
 				Description: fmt.Sprintf("Description Of Brand %v", i+1),
 			})
 		}
@@ -79,9 +144,25 @@ func InitTableBrand() {
 		if _, err := infrastructure.PostgresDB.NewInsert().Model(&brandData).Exec(ctx); err != nil {
 			log.Fatal("Create data for table tb_brand on PostgreSQL failed: ", err)
 		}
+	} else {
+		upgradeTableBrand(ctx)
 	}
 }
 
+// Upgrade table tb_brand created before brands had lifecycle status and slug, every existing brand is published
+func upgradeTableBrand(ctx context.Context) {
+	query := `
+		ALTER TABLE tb_brand
+			ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'PUBLISHED',
+			ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade table tb_brand on PostgreSQL failed: ", err)
+	}
+
+	upgradeSlugColumn(ctx, "tb_brand")
+}
+
 func InitTableProduct() {
 	ctx := context.Background()
 
@@ -116,9 +197,12 @@ func InitTableProduct() {
 		}
 
 		for i := range 50 {
+			productId := uuid.New().String()
 			productData = append(productData, &model.Product{
-				Id:                 uuid.New().String(),
+				Id:                 productId,
+				Sku:                model.GenerateSku(productId),
 				Name:               fmt.Sprintf("Name Of Product %v", i+1),
+				Slug:               utils.GenerateSlug(fmt.Sprintf("Name Of Product %v", i+1)),
 				Description:        fmt.Sprintf("Description Of Product %v", i+1),
 				Sex:                sexs[rand.Intn(len(sexs))],
 				Price:              50000 + int64(rand.Intn(91))*5000,
@@ -133,5 +217,698 @@ func InitTableProduct() {
 		if _, err := infrastructure.PostgresDB.NewInsert().Model(&productData).Exec(ctx); err != nil {
 			log.Fatal("Create data for table tb_product on PostgreSQL failed: ", err)
 		}
+	} else {
+		upgradeTableProduct(ctx)
+	}
+}
+
+// Upgrade table tb_product created before columns were added to model.Product
+func upgradeTableProduct(ctx context.Context) {
+	query := `
+		ALTER TABLE tb_product
+			ADD COLUMN IF NOT EXISTS average_rating DOUBLE PRECISION NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'PUBLISHED',
+			ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS sku VARCHAR,
+			ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]',
+			ADD COLUMN IF NOT EXISTS low_stock_threshold INTEGER,
+			ADD COLUMN IF NOT EXISTS low_stock_alerted_at TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS backorder_policy VARCHAR NOT NULL DEFAULT 'NONE',
+			ADD COLUMN IF NOT EXISTS expected_ship_date TIMESTAMPTZ,
+			ADD COLUMN IF NOT EXISTS backorder_limit INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS backorder_quantity INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS type VARCHAR NOT NULL DEFAULT 'SIMPLE',
+			ADD COLUMN IF NOT EXISTS bundle_pricing VARCHAR
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade table tb_product on PostgreSQL failed: ", err)
+	}
+
+	// Products created before SKUs existed get the same generated SKU as new products without one
+	query = `
+		UPDATE tb_product SET sku = 'SKU-' || upper(substr(replace(id, '-', ''), 1, 12)) WHERE sku IS NULL;
+		ALTER TABLE tb_product ALTER COLUMN sku SET NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS tb_product_sku_key ON tb_product (sku);
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade sku of table tb_product on PostgreSQL failed: ", err)
+	}
+
+	upgradeSlugColumn(ctx, "tb_product")
+}
+
+// Add slug column to table created before slugs existed, rows get a unique slug generated from their name
+func upgradeSlugColumn(ctx context.Context, tableName string) {
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, "ALTER TABLE ? ADD COLUMN IF NOT EXISTS slug VARCHAR", bun.Ident(tableName)); err != nil {
+		log.Fatalf("Upgrade table %s on PostgreSQL failed: %s", tableName, err)
+	}
+
+	var usedSlugs []string
+	if err := infrastructure.PostgresDB.NewSelect().Table(tableName).Column("slug").Where("slug IS NOT NULL").Scan(ctx, &usedSlugs); err != nil {
+		log.Fatalf("Get slugs from table %s on PostgreSQL failed: %s", tableName, err)
+	}
+	usedSlugMap := map[string]bool{}
+	for _, slug := range usedSlugs {
+		usedSlugMap[slug] = true
+	}
+
+	var rows []struct {
+		Id   string `bun:"id"`
+		Name string `bun:"name"`
+	}
+	if err := infrastructure.PostgresDB.NewSelect().Table(tableName).Column("id", "name").Where("slug IS NULL").Order("created_at ASC").Scan(ctx, &rows); err != nil {
+		log.Fatalf("Get rows without slug from table %s on PostgreSQL failed: %s", tableName, err)
+	}
+	for _, row := range rows {
+		baseSlug := utils.GenerateSlug(row.Name)
+		if baseSlug == "" {
+			baseSlug = row.Id[:8]
+		}
+		slug := baseSlug
+		for i := 2; usedSlugMap[slug]; i++ {
+			slug = fmt.Sprintf("%s-%d", baseSlug, i)
+		}
+		usedSlugMap[slug] = true
+
+		if _, err := infrastructure.PostgresDB.NewUpdate().Table(tableName).Set("slug = ?", slug).Where("id = ?", row.Id).Exec(ctx); err != nil {
+			log.Fatalf("Upgrade slug of table %s on PostgreSQL failed: %s", tableName, err)
+		}
+	}
+
+	query := `
+		ALTER TABLE ? ALTER COLUMN slug SET NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS ? ON ? (slug);
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query, bun.Ident(tableName), bun.Ident(tableName+"_slug_key"), bun.Ident(tableName)); err != nil {
+		log.Fatalf("Upgrade constraints of table %s on PostgreSQL failed: %s", tableName, err)
+	}
+}
+
+func InitTableProductImage() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_product_image").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_product_image on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.ProductImage{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_product_image on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_product_image_product_id_idx ON tb_product_image (product_id, sort_order)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_product_image on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableReview() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_review").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_review on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.Review{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_review on PostgreSQL failed: ", err)
+		}
+
+		// Each user reviews a product at most once
+		query := `
+			CREATE UNIQUE INDEX IF NOT EXISTS tb_review_product_id_user_id_key ON tb_review (product_id, user_id);
+			CREATE INDEX IF NOT EXISTS tb_review_product_id_status_idx ON tb_review (product_id, status);
+		`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_review on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableWarehouse() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_warehouse").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_warehouse on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.Warehouse{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_warehouse on PostgreSQL failed: ", err)
+		}
+
+		// Main warehouse has highest priority so it is default warehouse and keeps existing stock of products
+		warehouseData := []*model.Warehouse{
+			{Id: uuid.New().String(), Code: "MAIN", Name: "Main Warehouse", Address: "Thu Duc, Ho Chi Minh City", Latitude: 10.8700, Longitude: 106.8031, Priority: 100},
+			{Id: uuid.New().String(), Code: "HCM", Name: "Ho Chi Minh City Warehouse", Address: "District 7, Ho Chi Minh City", Latitude: 10.7340, Longitude: 106.7216, Priority: 50},
+			{Id: uuid.New().String(), Code: "HN", Name: "Ha Noi Warehouse", Address: "Long Bien, Ha Noi", Latitude: 21.0367, Longitude: 105.8945, Priority: 50},
+		}
+		if _, err := infrastructure.PostgresDB.NewInsert().Model(&warehouseData).Exec(ctx); err != nil {
+			log.Fatal("Create data for table tb_warehouse on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableWarehouseStock() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_warehouse_stock").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_warehouse_stock on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.WarehouseStock{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_warehouse_stock on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_warehouse_stock_warehouse_id_idx ON tb_warehouse_stock (warehouse_id)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_warehouse_stock on PostgreSQL failed: ", err)
+		}
+
+		// Existing stock of products was kept in a single place, move it to default warehouse
+		defaultWarehouseId, err := getDefaultWarehouseId(ctx, infrastructure.PostgresDB)
+		if err != nil {
+			log.Fatal("Get default warehouse from table tb_warehouse on PostgreSQL failed: ", err)
+		}
+
+		query = `
+			INSERT INTO tb_warehouse_stock (product_id, warehouse_id, stock)
+			SELECT id, ?, stock FROM tb_product WHERE stock <> 0
+		`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query, defaultWarehouseId); err != nil {
+			log.Fatal("Create data for table tb_warehouse_stock on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableStockMovement() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_stock_movement").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_stock_movement on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.StockMovement{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_stock_movement on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_stock_movement_product_id_idx ON tb_stock_movement (product_id, created_at)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_stock_movement on PostgreSQL failed: ", err)
+		}
+
+		defaultWarehouseId, err := getDefaultWarehouseId(ctx, infrastructure.PostgresDB)
+		if err != nil {
+			log.Fatal("Get default warehouse from table tb_warehouse on PostgreSQL failed: ", err)
+		}
+
+		// Open ledger with current stock of existing products so that ledger matches stock
+		var products []*model.Product
+		if err := infrastructure.PostgresDB.NewSelect().Model(&products).Column("id", "stock").Scan(ctx); err != nil {
+			log.Fatal("Get all products from table tb_product on PostgreSQL failed: ", err)
+		}
+
+		stockMovementData := []*model.StockMovement{}
+		for _, product := range products {
+			if product.Stock == 0 {
+				continue
+			}
+			stockMovementData = append(stockMovementData, &model.StockMovement{
+				Id:          uuid.New().String(),
+				ProductId:   product.Id,
+				WarehouseId: defaultWarehouseId,
+				Quantity:    product.Stock,
+				StockAfter:  product.Stock,
+				Reason:      "ADJUSTMENT",
+				Note:        "Opening balance",
+			})
+		}
+
+		if len(stockMovementData) != 0 {
+			if _, err := infrastructure.PostgresDB.NewInsert().Model(&stockMovementData).Exec(ctx); err != nil {
+				log.Fatal("Create data for table tb_stock_movement on PostgreSQL failed: ", err)
+			}
+		}
+	} else {
+		upgradeTableStockMovement(ctx)
+	}
+}
+
+// Upgrade table tb_stock_movement created before stock was kept per warehouse, every existing movement belongs to default warehouse
+func upgradeTableStockMovement(ctx context.Context) {
+	defaultWarehouseId, err := getDefaultWarehouseId(ctx, infrastructure.PostgresDB)
+	if err != nil {
+		log.Fatal("Get default warehouse from table tb_warehouse on PostgreSQL failed: ", err)
+	}
+
+	query := `
+		ALTER TABLE tb_stock_movement ADD COLUMN IF NOT EXISTS warehouse_id VARCHAR;
+		UPDATE tb_stock_movement SET warehouse_id = ? WHERE warehouse_id IS NULL;
+		ALTER TABLE tb_stock_movement ALTER COLUMN warehouse_id SET NOT NULL;
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query, defaultWarehouseId); err != nil {
+		log.Fatal("Upgrade table tb_stock_movement on PostgreSQL failed: ", err)
+	}
+}
+
+func InitTablePromotion() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_promotion").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_promotion on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.Promotion{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_promotion on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_promotion_status_idx ON tb_promotion (status, start_time, end_time)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_promotion on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTablePromotionUsage() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_promotion_usage").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_promotion_usage on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.PromotionUsage{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_promotion_usage on PostgreSQL failed: ", err)
+		}
+
+		query := `
+			CREATE INDEX IF NOT EXISTS tb_promotion_usage_promotion_id_idx ON tb_promotion_usage (promotion_id, user_id);
+			CREATE INDEX IF NOT EXISTS tb_promotion_usage_invoice_id_idx ON tb_promotion_usage (invoice_id);
+		`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create indexes for table tb_promotion_usage on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableProductPriceHistory() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_product_price_history").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_product_price_history on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.ProductPriceHistory{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_product_price_history on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_product_price_history_product_id_idx ON tb_product_price_history (product_id, created_at)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_product_price_history on PostgreSQL failed: ", err)
+		}
+
+		// Current price of existing products is recorded as of their creation since no earlier change is known
+		var products []*model.Product
+		if err := infrastructure.PostgresDB.NewSelect().Model(&products).Column("id", "price", "discount_percentage", "created_at").Scan(ctx); err != nil {
+			log.Fatal("Get all products from table tb_product on PostgreSQL failed: ", err)
+		}
+
+		productPriceHistoryData := make([]*model.ProductPriceHistory, len(products))
+		for i, product := range products {
+			productPriceHistoryData[i] = &model.ProductPriceHistory{
+				Id:                 uuid.New().String(),
+				ProductId:          product.Id,
+				Price:              product.Price,
+				DiscountPercentage: product.DiscountPercentage,
+				FinalPrice:         model.FinalPrice(product.Price, product.DiscountPercentage),
+				CreatedAt:          product.CreatedAt,
+			}
+		}
+
+		if len(productPriceHistoryData) != 0 {
+			if _, err := infrastructure.PostgresDB.NewInsert().Model(&productPriceHistoryData).Exec(ctx); err != nil {
+				log.Fatal("Create data for table tb_product_price_history on PostgreSQL failed: ", err)
+			}
+		}
+	} else {
+		upgradeTableProductPriceHistory(ctx)
+	}
+}
+
+// Upgrade table tb_product_price_history created before price history recorded promotions
+func upgradeTableProductPriceHistory(ctx context.Context) {
+	query := `ALTER TABLE tb_product_price_history ADD COLUMN IF NOT EXISTS promotion_id VARCHAR`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade table tb_product_price_history on PostgreSQL failed: ", err)
+	}
+}
+
+func InitTableProductImportJob() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_product_import_job").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_product_import_job on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.ProductImportJob{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_product_import_job on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableSlugRedirect() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_slug_redirect").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_slug_redirect on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.SlugRedirect{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_slug_redirect on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE UNIQUE INDEX IF NOT EXISTS tb_slug_redirect_entity_type_slug_key ON tb_slug_redirect (entity_type, slug)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_slug_redirect on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableAttributeDefinition() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_attribute_definition").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_attribute_definition on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.AttributeDefinition{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_attribute_definition on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableCategoryAttribute() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_category_attribute").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_category_attribute on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.CategoryAttribute{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_category_attribute on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_category_attribute_attribute_definition_id_idx ON tb_category_attribute (attribute_definition_id)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_category_attribute on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableProductAttributeValue() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_product_attribute_value").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_product_attribute_value on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.ProductAttributeValue{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_product_attribute_value on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_product_attribute_value_attribute_definition_id_idx ON tb_product_attribute_value (attribute_definition_id)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_product_attribute_value on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableCollection() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_collection").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_collection on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.Collection{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_collection on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_collection_status_idx ON tb_collection (status, start_time, end_time)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_collection on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableCollectionProduct() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_collection_product").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_collection_product on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.CollectionProduct{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_collection_product on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_collection_product_product_id_idx ON tb_collection_product (product_id)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_collection_product on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableBackorder() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_backorder").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_backorder on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.Backorder{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_backorder on PostgreSQL failed: ", err)
+		}
+
+		query := `
+			CREATE INDEX IF NOT EXISTS tb_backorder_product_id_status_idx ON tb_backorder (product_id, status, created_at);
+			CREATE INDEX IF NOT EXISTS tb_backorder_invoice_id_idx ON tb_backorder (invoice_id);
+			CREATE INDEX IF NOT EXISTS tb_backorder_unconfirmed_idx ON tb_backorder (updated_at) WHERE status = 'ALLOCATED' AND confirmed_at IS NULL;
+		`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_backorder on PostgreSQL failed: ", err)
+		}
+	} else {
+		upgradeTableBackorder(ctx)
+	}
+}
+
+// Upgrade table tb_backorder created before allocations were kept for order-service to pull them, backorders allocated
+// before are taken as confirmed since their event was already published
+func upgradeTableBackorder(ctx context.Context) {
+	query := `
+		ALTER TABLE tb_backorder ADD COLUMN IF NOT EXISTS stock_allocations JSONB;
+		ALTER TABLE tb_backorder ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMPTZ;
+		UPDATE tb_backorder SET confirmed_at = updated_at WHERE status = 'ALLOCATED' AND stock_allocations IS NULL AND confirmed_at IS NULL;
+		CREATE INDEX IF NOT EXISTS tb_backorder_unconfirmed_idx ON tb_backorder (updated_at) WHERE status = 'ALLOCATED' AND confirmed_at IS NULL;
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade table tb_backorder on PostgreSQL failed: ", err)
+	}
+}
+
+func InitTableBundleComponent() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_bundle_component").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_bundle_component on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.BundleComponent{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_bundle_component on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE INDEX IF NOT EXISTS tb_bundle_component_component_id_idx ON tb_bundle_component (component_id)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_bundle_component on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableSizeChart() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_size_chart").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_size_chart on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.SizeChart{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_size_chart on PostgreSQL failed: ", err)
+		}
+
+		query := `CREATE UNIQUE INDEX IF NOT EXISTS tb_size_chart_brand_id_category_id_key ON tb_size_chart (brand_id, category_id)`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_size_chart on PostgreSQL failed: ", err)
+		}
 	}
 }
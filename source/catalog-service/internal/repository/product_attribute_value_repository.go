@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+
+	"github.com/uptrace/bun"
+)
+
+type productAttributeValueRepository struct {
+}
+
+type ProductAttributeValueRepository interface {
+	ReplaceByProductId(ctx context.Context, productId string, productAttributeValues []*model.ProductAttributeValue) error
+	CountByAttributeDefinitionId(ctx context.Context, attributeDefinitionId string) (int, error)
+	CountByAttributeDefinitionIdAndListValue(ctx context.Context, attributeDefinitionId string, values []string) (int, error)
+	CountByAttributeDefinitionIdAndCategoryPath(ctx context.Context, attributeDefinitionId string, categoryPath string) (int, error)
+}
+
+func NewProductAttributeValueRepository() ProductAttributeValueRepository {
+	return &productAttributeValueRepository{}
+}
+
+func (productAttributeValueRepository *productAttributeValueRepository) ReplaceByProductId(ctx context.Context, productId string, productAttributeValues []*model.ProductAttributeValue) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewDelete().Model(&model.ProductAttributeValue{}).Where("product_id = ?", productId).Exec(ctx); err != nil {
+		return err
+	}
+
+	if len(productAttributeValues) != 0 {
+		if _, err := tx.NewInsert().Model(&productAttributeValues).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (productAttributeValueRepository *productAttributeValueRepository) CountByAttributeDefinitionId(ctx context.Context, attributeDefinitionId string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.ProductAttributeValue{}).Where("attribute_definition_id = ?", attributeDefinitionId).Count(ctx)
+}
+
+func (productAttributeValueRepository *productAttributeValueRepository) CountByAttributeDefinitionIdAndListValue(ctx context.Context, attributeDefinitionId string, values []string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.ProductAttributeValue{}).
+		Where("attribute_definition_id = ?", attributeDefinitionId).
+		Where("value IN (?)", bun.In(values)).
+		Count(ctx)
+}
+
+// Count values of attribute on products of category subtree
+func (productAttributeValueRepository *productAttributeValueRepository) CountByAttributeDefinitionIdAndCategoryPath(ctx context.Context, attributeDefinitionId string, categoryPath string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.ProductAttributeValue{}).
+		Where("attribute_definition_id = ?", attributeDefinitionId).
+		Where("product_id IN (SELECT id FROM tb_product WHERE category_id IN (SELECT id FROM tb_category WHERE path LIKE ?))", categoryPath+"%").
+		Count(ctx)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"time"
+)
+
+type productImageRepository struct {
+}
+
+type ProductImageRepository interface {
+	GetViewsByProductId(ctx context.Context, productId string, variant *string) ([]*model.ProductImageView, error)
+	GetViewById(ctx context.Context, id string) (*model.ProductImageView, error)
+
+	GetByProductId(ctx context.Context, productId string) ([]*model.ProductImage, error)
+	GetById(ctx context.Context, id string) (*model.ProductImage, error)
+	CountByProductId(ctx context.Context, productId string) (int, error)
+	Create(ctx context.Context, newProductImage *model.ProductImage) error
+	DeleteById(ctx context.Context, id string) error
+	DeleteByProductId(ctx context.Context, productId string) error
+
+	// Gallery ordering and primary image (primary image url is copied to tb_product.image_url)
+	UpdateSortOrders(ctx context.Context, productId string, orderedIds []string) error
+	SetPrimary(ctx context.Context, productImage *model.ProductImage) error
+	ClearPrimary(ctx context.Context, productId string) error
+}
+
+func NewProductImageRepository() ProductImageRepository {
+	return &productImageRepository{}
+}
+
+func (productImageRepository *productImageRepository) GetViewsByProductId(ctx context.Context, productId string, variant *string) ([]*model.ProductImageView, error) {
+	var productImages []*model.ProductImageView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&productImages).
+		Where("_product_image.product_id = ?", productId).
+		Order("_product_image.sort_order ASC", "_product_image.created_at ASC")
+
+	if variant != nil {
+		query = query.Where("_product_image.variant = ?", *variant)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productImages, nil
+}
+
+func (productImageRepository *productImageRepository) GetViewById(ctx context.Context, id string) (*model.ProductImageView, error) {
+	productImage := new(model.ProductImageView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(productImage).Where("_product_image.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productImage, nil
+}
+
+func (productImageRepository *productImageRepository) GetByProductId(ctx context.Context, productId string) ([]*model.ProductImage, error) {
+	var productImages []*model.ProductImage
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&productImages).
+		Where("product_id = ?", productId).
+		Order("sort_order ASC", "created_at ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productImages, nil
+}
+
+func (productImageRepository *productImageRepository) GetById(ctx context.Context, id string) (*model.ProductImage, error) {
+	productImage := new(model.ProductImage)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(productImage).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productImage, nil
+}
+
+func (productImageRepository *productImageRepository) CountByProductId(ctx context.Context, productId string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.ProductImage{}).Where("product_id = ?", productId).Count(ctx)
+}
+
+func (productImageRepository *productImageRepository) Create(ctx context.Context, newProductImage *model.ProductImage) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newProductImage).Returning("*").Exec(ctx)
+	return err
+}
+
+func (productImageRepository *productImageRepository) DeleteById(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.ProductImage{}).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+func (productImageRepository *productImageRepository) DeleteByProductId(ctx context.Context, productId string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.ProductImage{}).Where("product_id = ?", productId).Exec(ctx)
+	return err
+}
+
+func (productImageRepository *productImageRepository) UpdateSortOrders(ctx context.Context, productId string, orderedIds []string) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	timeUpdate := time.Now().UTC()
+	for i, id := range orderedIds {
+		if _, err := tx.NewUpdate().Model(&model.ProductImage{}).
+			Set("sort_order = ?", i).
+			Set("updated_at = ?", timeUpdate).
+			Where("id = ?", id).
+			Where("product_id = ?", productId).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (productImageRepository *productImageRepository) SetPrimary(ctx context.Context, productImage *model.ProductImage) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	timeUpdate := time.Now().UTC()
+	if _, err := tx.NewUpdate().Model(&model.ProductImage{}).
+		Set("is_primary = (id = ?)", productImage.Id).
+		Set("updated_at = ?", timeUpdate).
+		Where("product_id = ?", productImage.ProductId).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewUpdate().Model(&model.Product{}).
+		Set("image_url = ?", productImage.URL).
+		Set("updated_at = ?", timeUpdate).
+		Where("id = ?", productImage.ProductId).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (productImageRepository *productImageRepository) ClearPrimary(ctx context.Context, productId string) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Product{}).
+		Set("image_url = ''").
+		Set("updated_at = ?", time.Now().UTC()).
+		Where("id = ?", productId).
+		Exec(ctx)
+	return err
+}
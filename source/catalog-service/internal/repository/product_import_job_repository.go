@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+)
+
+type productImportJobRepository struct {
+}
+
+type ProductImportJobRepository interface {
+	GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField) ([]*model.ProductImportJobView, error)
+	GetViewById(ctx context.Context, id string) (*model.ProductImportJobView, error)
+
+	Create(ctx context.Context, newProductImportJob *model.ProductImportJob) error
+	Update(ctx context.Context, updatedProductImportJob *model.ProductImportJob) error
+	FailUnfinished(ctx context.Context, failureMessage string) error
+}
+
+func NewProductImportJobRepository() ProductImportJobRepository {
+	return &productImportJobRepository{}
+}
+
+// Row errors are left out of list, they are only loaded with a single job
+func (productImportJobRepository *productImportJobRepository) GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField) ([]*model.ProductImportJobView, error) {
+	var productImportJobs []*model.ProductImportJobView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&productImportJobs).
+		ExcludeColumn("errors").
+		Offset(offset).
+		Limit(limit)
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_product_import_job.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productImportJobs, nil
+}
+
+func (productImportJobRepository *productImportJobRepository) GetViewById(ctx context.Context, id string) (*model.ProductImportJobView, error) {
+	productImportJob := new(model.ProductImportJobView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(productImportJob).Where("_product_import_job.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productImportJob, nil
+}
+
+func (productImportJobRepository *productImportJobRepository) Create(ctx context.Context, newProductImportJob *model.ProductImportJob) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newProductImportJob).Returning("*").Exec(ctx)
+	return err
+}
+
+func (productImportJobRepository *productImportJobRepository) Update(ctx context.Context, updatedProductImportJob *model.ProductImportJob) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedProductImportJob).Where("id = ?", updatedProductImportJob.Id).Exec(ctx)
+	return err
+}
+
+// Jobs run in memory of the service, those still pending or running when it stopped are never finished
+func (productImportJobRepository *productImportJobRepository) FailUnfinished(ctx context.Context, failureMessage string) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model((*model.ProductImportJob)(nil)).
+		Set("status = 'FAILED'").
+		Set("failure_message = ?", failureMessage).
+		Set("finished_at = now()").
+		Set("updated_at = now()").
+		Where("status IN ('PENDING', 'RUNNING')").
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type productPriceHistoryRepository struct {
+}
+
+// Last record of price history of product
+const productLastPriceHistoryJoin = `LEFT JOIN LATERAL (
+	SELECT _last_price_history.price, _last_price_history.discount_percentage, _last_price_history.promotion_id
+	FROM tb_product_price_history AS _last_price_history
+	WHERE _last_price_history.product_id = _product.id
+	ORDER BY _last_price_history.created_at DESC
+	LIMIT 1
+) AS _last_price_history ON TRUE`
+
+// Price, effective discount or promotion in effect of product differ from its last record, or it has none yet (needs
+// _active_promotion and _last_price_history)
+const productPriceChangedCondition = `(_product.price, ` + productEffectiveDiscountPercentageExpr + `, ` + productEffectivePromotionIdExpr + `)
+	IS DISTINCT FROM (_last_price_history.price, _last_price_history.discount_percentage, _last_price_history.promotion_id)`
+
+type ProductPriceHistoryRepository interface {
+	GetViewsByProductId(ctx context.Context, productId string, offset int, limit int, sortFields []*utils.SortField) ([]*model.ProductPriceHistoryView, error)
+	GetProductIdsByCreatedAtRange(ctx context.Context, from time.Time, to time.Time) ([]string, error)
+
+	// Record price customers pay now for each of products, products whose price, discount and promotion in effect did not
+	// change since their last record are skipped
+	CreateByListProductId(ctx context.Context, productIds []string, actorId *string) error
+	DeleteByProductId(ctx context.Context, productId string) error
+}
+
+func NewProductPriceHistoryRepository() ProductPriceHistoryRepository {
+	return &productPriceHistoryRepository{}
+}
+
+func (productPriceHistoryRepository *productPriceHistoryRepository) GetViewsByProductId(ctx context.Context, productId string, offset int, limit int, sortFields []*utils.SortField) ([]*model.ProductPriceHistoryView, error) {
+	var productPriceHistories []*model.ProductPriceHistoryView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&productPriceHistories).
+		Where("_product_price_history.product_id = ?", productId).
+		Offset(offset).
+		Limit(limit)
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_product_price_history.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return productPriceHistories, nil
+}
+
+// Products having price history created in (from, to]
+func (productPriceHistoryRepository *productPriceHistoryRepository) GetProductIdsByCreatedAtRange(ctx context.Context, from time.Time, to time.Time) ([]string, error) {
+	var productIds []string
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.ProductPriceHistory)(nil)).
+		Distinct().
+		Column("_product_price_history.product_id").
+		Where("_product_price_history.created_at > ?", from).
+		Where("_product_price_history.created_at <= ?", to)
+
+	if err := query.Scan(ctx, &productIds); err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
+
+func (productPriceHistoryRepository *productPriceHistoryRepository) CreateByListProductId(ctx context.Context, productIds []string, actorId *string) error {
+	return createProductPriceHistories(ctx, infrastructure.PostgresDB, productIds, actorId)
+}
+
+func (productPriceHistoryRepository *productPriceHistoryRepository) DeleteByProductId(ctx context.Context, productId string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.ProductPriceHistory{}).Where("product_id = ?", productId).Exec(ctx)
+	return err
+}
+
+// Effective price is read with the same expressions as product views, so that history and final price shown to customers
+// never disagree
+func createProductPriceHistories(ctx context.Context, db bun.IDB, productIds []string, actorId *string) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	var newProductPriceHistories []*model.ProductPriceHistory
+	err := db.NewSelect().TableExpr("tb_product AS _product").
+		ColumnExpr("_product.id AS product_id").
+		ColumnExpr("_product.price").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productEffectivePromotionIdExpr+" AS promotion_id").
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join(productActivePromotionJoin).
+		Join(productLastPriceHistoryJoin).
+		Where("_product.id IN (?)", bun.In(productIds)).
+		Where(productPriceChangedCondition).
+		Scan(ctx, &newProductPriceHistories)
+	if err != nil {
+		return err
+	}
+	if len(newProductPriceHistories) == 0 {
+		return nil
+	}
+
+	for _, newProductPriceHistory := range newProductPriceHistories {
+		newProductPriceHistory.Id = uuid.New().String()
+		newProductPriceHistory.ActorId = actorId
+	}
+	_, err = db.NewInsert().Model(&newProductPriceHistories).Exec(ctx)
+	return err
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
 
 	"github.com/uptrace/bun"
 )
@@ -11,20 +13,146 @@ import (
 type productRepository struct {
 }
 
+// Ancestors of product category (itself included) ordered from root, for breadcrumb
+const productCategoryBreadcrumbColumnExpr = `(
+	SELECT json_agg(json_build_object('id', _ancestor.id, 'name', _ancestor.name, 'slug', _ancestor.slug) ORDER BY _ancestor.depth)
+	FROM tb_category AS _ancestor
+	WHERE position('/' || _ancestor.id || '/' IN _category.path) > 0
+) AS category_breadcrumb`
+
+// Attribute values of product ordered by code, values of NUMBER attributes are validated as numbers when they are set
+const productAttributesColumnExpr = `COALESCE((
+	SELECT json_agg(json_build_object(
+		'code', _attribute_definition.code,
+		'name', _attribute_definition.name,
+		'type', _attribute_definition.type,
+		'value', _product_attribute_value.value,
+		'unit', _attribute_definition.unit,
+		'number_value', CASE WHEN _attribute_definition.type = 'NUMBER' THEN _product_attribute_value.value::double precision END
+	) ORDER BY _attribute_definition.code)
+	FROM tb_product_attribute_value AS _product_attribute_value
+	JOIN tb_attribute_definition AS _attribute_definition ON _attribute_definition.id = _product_attribute_value.attribute_definition_id
+	WHERE _product_attribute_value.product_id = _product.id
+), '[]') AS attributes`
+
+// Components of bundle ordered by name, null for other products
+const productBundleComponentsColumnExpr = `(
+	SELECT json_agg(json_build_object(
+		'product_id', _component.id,
+		'sku', _component.sku,
+		'name', _component.name,
+		'quantity', _bundle_component.quantity,
+		'price', _component.price,
+		'stock', _component.stock,
+		'status', _component.status
+	) ORDER BY _component.name)
+	FROM tb_bundle_component AS _bundle_component
+	JOIN tb_product AS _component ON _component.id = _bundle_component.component_id
+	WHERE _bundle_component.bundle_id = _product.id
+) AS bundle_components`
+
+// Promotion discounts product when it targets the product, its brand, or its category or an ancestor of it (needs _category)
+const promotionTargetsProductCondition = `(
+	(_promotion.target_type = 'PRODUCT' AND _promotion.target_ids @> jsonb_build_array(_product.id)) OR
+	(_promotion.target_type = 'BRAND' AND _promotion.target_ids @> jsonb_build_array(_product.brand_id)) OR
+	(_promotion.target_type = 'CATEGORY' AND EXISTS (
+		SELECT 1 FROM jsonb_array_elements_text(_promotion.target_ids) AS _target(id)
+		WHERE position('/' || _target.id || '/' IN _category.path) > 0
+	))
+)`
+
+// Promotion in effect now, a flash sale stops being in effect once its stock allotment is sold out and stays expired even if
+// cancelled invoices give units back to its allotment
+const promotionInEffectCondition = `(
+	_promotion.status IN ('SCHEDULED', 'ACTIVE') AND _promotion.start_time <= now() AND _promotion.end_time > now() AND
+	(_promotion.stock_allotment = 0 OR _promotion.sold_quantity < _promotion.stock_allotment)
+)`
+
+// Best promotion in effect for product
+const productActivePromotionJoin = `LEFT JOIN LATERAL (
+	SELECT _promotion.*
+	FROM tb_promotion AS _promotion
+	WHERE ` + promotionInEffectCondition + ` AND ` + promotionTargetsProductCondition + `
+	ORDER BY _promotion.discount_percentage DESC, _promotion.end_time ASC
+	LIMIT 1
+) AS _active_promotion ON TRUE`
+
+// Discount percentage customers pay, promotion only applies when it beats discount of product (needs _active_promotion)
+const productEffectiveDiscountPercentageExpr = `GREATEST(_product.discount_percentage, COALESCE(_active_promotion.discount_percentage, 0))`
+
+// Discount percentage of product view is the effective one.
+// It comes after _product.* so that it overrides discount_percentage column of product.
+const productDiscountPercentageColumnExpr = productEffectiveDiscountPercentageExpr + ` AS discount_percentage`
+
+const productFinalPriceColumnExpr = `_product.price * (100 - ` + productEffectiveDiscountPercentageExpr + `) / 100 AS final_price`
+
+// Lowest final price of product over the last 30 days, counting the price in effect when the window starts and the price
+// now. Price history records promotions starting and stopping, so they are included like in final price (needs
+// _active_promotion).
+const productLowestPrice30dColumnExpr = `LEAST((
+	SELECT MIN(_product_price_history.final_price)
+	FROM tb_product_price_history AS _product_price_history
+	WHERE _product_price_history.product_id = _product.id AND _product_price_history.created_at >= COALESCE((
+		SELECT MAX(_earlier_price_history.created_at)
+		FROM tb_product_price_history AS _earlier_price_history
+		WHERE _earlier_price_history.product_id = _product.id AND _earlier_price_history.created_at <= now() - interval '30 days'
+	), now() - interval '30 days')
+), _product.price * (100 - ` + productEffectiveDiscountPercentageExpr + `) / 100) AS lowest_price_30d`
+
+// Promotion giving the effective discount of product, null when discount of product itself is not beaten (needs _active_promotion)
+const productEffectivePromotionIdExpr = `CASE WHEN _active_promotion.discount_percentage > _product.discount_percentage THEN _active_promotion.id END`
+
+const productActivePromotionColumnExpr = `CASE WHEN _active_promotion.discount_percentage > _product.discount_percentage THEN json_build_object(
+	'id', _active_promotion.id,
+	'name', _active_promotion.name,
+	'discount_percentage', _active_promotion.discount_percentage,
+	'end_time', _active_promotion.end_time,
+	'stock_allotment', _active_promotion.stock_allotment,
+	'sold_quantity', _active_promotion.sold_quantity
+) END AS active_promotion`
+
+// Threshold of product, else threshold of nearest category in its ancestors (itself included) setting one, else default (needs _category)
+const productLowStockThresholdColumnExpr = `COALESCE(_product.low_stock_threshold, (
+	SELECT _ancestor.low_stock_threshold
+	FROM tb_category AS _ancestor
+	WHERE position('/' || _ancestor.id || '/' IN _category.path) > 0 AND _ancestor.low_stock_threshold IS NOT NULL
+	ORDER BY _ancestor.depth DESC
+	LIMIT 1
+), ?) AS low_stock_threshold`
+
 type ProductRepository interface {
 	GetViewById(ctx context.Context, id string) (*model.ProductView, error)
+	// Cached view for lookups which tolerate a view as old as cache TTL, it is written through many repositories (stock,
+	// promotions, reviews...) so callers publishing views right after a write must use GetViewById
+	GetCachedViewById(ctx context.Context, id string) (*model.ProductView, error)
+	GetViewBySlug(ctx context.Context, slug string) (*model.ProductView, error)
+	GetViewsByListId(ctx context.Context, ids []string) ([]*model.ProductView, error)
 
 	GetByListId(ctx context.Context, ids []string) ([]*model.Product, error)
 	GetById(ctx context.Context, id string) (*model.Product, error)
-	Create(ctx context.Context, newProduct *model.Product) error
+	GetBySku(ctx context.Context, sku string) (*model.Product, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Product, error)
+	// Insert product and apply its initial stock movements in one transaction
+	Create(ctx context.Context, newProduct *model.Product, newStockMovements []*model.StockMovement) error
 	Update(ctx context.Context, updatedProduct *model.Product) error
 	DeleteById(ctx context.Context, id string) error
-
-	// Elasticsearch integration (init data for elasticsearch-service)
-	GetAllViews(ctx context.Context) ([]*model.ProductView, error)
-
-	// Order integration (extra features for order-service)
-	UpdateStocks(ctx context.Context, updatedProducts []*model.Product) error
+	GetArchivedViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField) ([]*model.ProductView, error)
+	CountPublishedByBrandId(ctx context.Context, brandId string) (int, error)
+	CountPublishedByCategoryPath(ctx context.Context, categoryPath string) (int, error)
+
+	// Export, products are read page by page ordered by id
+	GetExportViewsAfterId(ctx context.Context, afterId string, limit int) ([]*model.ProductExportView, error)
+
+	// Elasticsearch integration (init data for elasticsearch-service), published products are read page by page ordered by id
+	GetViewsAfterId(ctx context.Context, afterId string, limit int) ([]*model.ProductView, error)
+	GetViewsByCategoryPath(ctx context.Context, categoryPath string) ([]*model.ProductView, error)
+	GetViewsByAttributeDefinitionId(ctx context.Context, attributeDefinitionId string) ([]*model.ProductView, error)
+
+	// Low stock, threshold of products without one (product and categories) is default threshold
+	GetLowStockViewById(ctx context.Context, id string, defaultThreshold int32) (*model.LowStockProductView, error)
+	GetLowStockViews(ctx context.Context, defaultThreshold int32, onlyLowStock bool, limit int) ([]*model.LowStockProductView, error)
+	MarkLowStockAlerted(ctx context.Context, id string) (bool, error)
+	ClearLowStockAlerted(ctx context.Context, id string) error
 }
 
 func NewProductRepository() ProductRepository {
@@ -35,12 +163,20 @@ func (productRepository *productRepository) GetViewById(ctx context.Context, id
 	product := new(model.ProductView)
 
 	query := infrastructure.PostgresDB.NewSelect().Model(product).
-		TableExpr("tb_product AS _product").
 		Column("_product.*").
 		ColumnExpr("_category.name AS category_name").
 		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
 		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
 		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
 		Where("_product.id = ?", id)
 
 	if err := query.Scan(ctx); err != nil {
@@ -50,10 +186,43 @@ func (productRepository *productRepository) GetViewById(ctx context.Context, id
 	return product, nil
 }
 
+func (productRepository *productRepository) GetCachedViewById(ctx context.Context, id string) (*model.ProductView, error) {
+	return infrastructure.GetOrLoadView(ctx, infrastructure.ProductViewCache, id, func(ctx context.Context) (*model.ProductView, error) {
+		return productRepository.GetViewById(ctx, id)
+	})
+}
+
+func (productRepository *productRepository) GetViewBySlug(ctx context.Context, slug string) (*model.ProductView, error) {
+	product := new(model.ProductView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(product).
+		Column("_product.*").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
+		Where("_product.slug = ?", slug)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
 func (productRepository *productRepository) GetByListId(ctx context.Context, ids []string) ([]*model.Product, error) {
 	var products []*model.Product
 
-	query := infrastructure.PostgresDB.NewSelect().Model(products).Where("id IN (?)", bun.In(ids))
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).Where("id IN (?)", bun.In(ids))
 
 	if err := query.Scan(ctx); err != nil {
 		return nil, err
@@ -74,31 +243,217 @@ func (productRepository *productRepository) GetById(ctx context.Context, id stri
 	return product, nil
 }
 
-func (productRepository *productRepository) Create(ctx context.Context, newProduct *model.Product) error {
-	_, err := infrastructure.PostgresDB.NewInsert().Model(newProduct).Returning("*").Exec(ctx)
-	return err
+func (productRepository *productRepository) GetBySku(ctx context.Context, sku string) (*model.Product, error) {
+	product := new(model.Product)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(product).Where("sku = ?", sku)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (productRepository *productRepository) GetBySlug(ctx context.Context, slug string) (*model.Product, error) {
+	product := new(model.Product)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(product).Where("slug = ?", slug)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (productRepository *productRepository) Create(ctx context.Context, newProduct *model.Product, newStockMovements []*model.StockMovement) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewInsert().Model(newProduct).Returning("*").Exec(ctx); err != nil {
+		return err
+	}
+
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
+// Stock and backorder quantity are excluded, they only change through stock movements and backorders, low stock alert state
+// only changes through low stock evaluation
 func (productRepository *productRepository) Update(ctx context.Context, updatedProduct *model.Product) error {
-	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedProduct).Where("id = ?", updatedProduct.Id).Exec(ctx)
-	return err
+	if _, err := infrastructure.PostgresDB.NewUpdate().Model(updatedProduct).ExcludeColumn("stock", "backorder_quantity", "low_stock_alerted_at").Where("id = ?", updatedProduct.Id).Exec(ctx); err != nil {
+		return err
+	}
+
+	infrastructure.ProductViewCache.Invalidate(ctx, updatedProduct.Id)
+	return nil
 }
 
+// Soft delete, product is archived and kept for carts, invoices and stock ledger referencing it
 func (productRepository *productRepository) DeleteById(ctx context.Context, id string) error {
-	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.Product{}).Where("id = ?", id).Exec(ctx)
-	return err
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Product{}).
+		Set("status = 'ARCHIVED'").
+		Set("deleted_at = now()").
+		Set("updated_at = now()").
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	infrastructure.ProductViewCache.Invalidate(ctx, id)
+	return nil
 }
 
-func (productRepository *productRepository) GetAllViews(ctx context.Context) ([]*model.ProductView, error) {
+func (productRepository *productRepository) GetArchivedViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField) ([]*model.ProductView, error) {
 	var products []*model.ProductView
 
-	query := infrastructure.PostgresDB.NewSelect().Model(products).
-		TableExpr("tb_product AS _product").
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).
 		Column("_product.*").
 		ColumnExpr("_category.name AS category_name").
 		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
 		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
-		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id")
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
+		Where("_product.status = 'ARCHIVED'").
+		Offset(offset).
+		Limit(limit)
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_product.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (productRepository *productRepository) CountPublishedByBrandId(ctx context.Context, brandId string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.Product{}).Where("brand_id = ?", brandId).Where("status = 'PUBLISHED'").Count(ctx)
+}
+
+// Count published products of category subtree
+func (productRepository *productRepository) CountPublishedByCategoryPath(ctx context.Context, categoryPath string) (int, error) {
+	return infrastructure.PostgresDB.NewSelect().Model(&model.Product{}).
+		Where("category_id IN (SELECT id FROM tb_category WHERE path LIKE ?)", categoryPath+"%").
+		Where("status = 'PUBLISHED'").
+		Count(ctx)
+}
+
+func (productRepository *productRepository) GetExportViewsAfterId(ctx context.Context, afterId string, limit int) ([]*model.ProductExportView, error) {
+	var products []*model.ProductExportView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).
+		Column("_product.id", "_product.sku", "_product.name", "_product.description", "_product.sex", "_product.price",
+			"_product.discount_percentage", "_product.stock", "_product.image_url", "_product.status").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Where("_product.id > ?", afterId).
+		Order("_product.id ASC").
+		Limit(limit)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (productRepository *productRepository) GetViewsAfterId(ctx context.Context, afterId string, limit int) ([]*model.ProductView, error) {
+	var products []*model.ProductView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).
+		Column("_product.*").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
+		Where("_product.status = 'PUBLISHED'").
+		Where("_product.id > ?", afterId).
+		Order("_product.id ASC").
+		Limit(limit)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (productRepository *productRepository) GetViewsByCategoryPath(ctx context.Context, categoryPath string) ([]*model.ProductView, error) {
+	var products []*model.ProductView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).
+		Column("_product.*").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
+		Where("_category.path LIKE ?", categoryPath+"%")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (productRepository *productRepository) GetViewsByAttributeDefinitionId(ctx context.Context, attributeDefinitionId string) ([]*model.ProductView, error) {
+	var products []*model.ProductView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).
+		Column("_product.*").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
+		Where("_product.id IN (SELECT product_id FROM tb_product_attribute_value WHERE attribute_definition_id = ?)", attributeDefinitionId)
 
 	if err := query.Scan(ctx); err != nil {
 		return nil, err
@@ -110,13 +465,21 @@ func (productRepository *productRepository) GetAllViews(ctx context.Context) ([]
 func (productRepository *productRepository) GetViewsByListId(ctx context.Context, ids []string) ([]*model.ProductView, error) {
 	var products []*model.ProductView
 
-	query := infrastructure.PostgresDB.NewSelect().Model(products).
-		TableExpr("tb_product AS _product").
+	query := infrastructure.PostgresDB.NewSelect().Model(&products).
 		Column("_product.*").
 		ColumnExpr("_category.name AS category_name").
 		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productCategoryBreadcrumbColumnExpr).
+		ColumnExpr(productAttributesColumnExpr).
+		ColumnExpr(productBundleComponentsColumnExpr).
+		ColumnExpr("_product.discount_percentage AS base_discount_percentage").
+		ColumnExpr(productDiscountPercentageColumnExpr).
+		ColumnExpr(productActivePromotionColumnExpr).
+		ColumnExpr(productFinalPriceColumnExpr).
+		ColumnExpr(productLowestPrice30dColumnExpr).
 		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
 		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Join(productActivePromotionJoin).
 		Where("_product.id IN (?)", bun.In(ids))
 
 	if err := query.Scan(ctx); err != nil {
@@ -126,18 +489,82 @@ func (productRepository *productRepository) GetViewsByListId(ctx context.Context
 	return products, nil
 }
 
-func (productRepository *productRepository) UpdateStocks(ctx context.Context, updatedProducts []*model.Product) error {
-	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+func (productRepository *productRepository) GetLowStockViewById(ctx context.Context, id string, defaultThreshold int32) (*model.LowStockProductView, error) {
+	product := new(model.LowStockProductView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(product).
+		Column("_product.id", "_product.sku", "_product.name", "_product.stock", "_product.status",
+			"_product.category_id", "_product.brand_id", "_product.low_stock_alerted_at").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productLowStockThresholdColumnExpr, defaultThreshold).
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Where("_product.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// Published products ordered by how far stock is below threshold, then by stock. Bundles are left out, they are restocked
+// through their components.
+func (productRepository *productRepository) GetLowStockViews(ctx context.Context, defaultThreshold int32, onlyLowStock bool, limit int) ([]*model.LowStockProductView, error) {
+	var products []*model.LowStockProductView
+
+	subQuery := infrastructure.PostgresDB.NewSelect().Model((*model.LowStockProductView)(nil)).
+		Column("_product.id", "_product.sku", "_product.name", "_product.stock", "_product.status",
+			"_product.category_id", "_product.brand_id", "_product.low_stock_alerted_at").
+		ColumnExpr("_category.name AS category_name").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr(productLowStockThresholdColumnExpr, defaultThreshold).
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Where("_product.status = 'PUBLISHED'").
+		Where("_product.type <> 'BUNDLE'")
+
+	// Effective threshold is an alias of select list, so that it is filtered and ordered on by outer query
+	query := infrastructure.PostgresDB.NewSelect().TableExpr("(?) AS _low_stock_product", subQuery).
+		OrderExpr("_low_stock_product.stock - _low_stock_product.low_stock_threshold ASC").
+		OrderExpr("_low_stock_product.stock ASC").
+		Limit(limit)
+
+	if onlyLowStock {
+		query = query.Where("_low_stock_product.stock <= _low_stock_product.low_stock_threshold")
+	}
+
+	if err := query.Scan(ctx, &products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// Mark product as alerted unless it already is, it reports whether product was marked so that it is alerted only once
+func (productRepository *productRepository) MarkLowStockAlerted(ctx context.Context, id string) (bool, error) {
+	res, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Product{}).
+		Set("low_stock_alerted_at = now()").
+		Where("id = ?", id).
+		Where("low_stock_alerted_at IS NULL").
+		Exec(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
-	defer tx.Rollback()
 
-	for _, updatedProduct := range updatedProducts {
-		if _, err := tx.NewUpdate().Model(updatedProduct).Where("id = ?", updatedProduct.Id).Exec(ctx); err != nil {
-			return err
-		}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
 	}
 
-	return tx.Commit()
+	return rowsAffected != 0, nil
+}
+
+func (productRepository *productRepository) ClearLowStockAlerted(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(&model.Product{}).
+		Set("low_stock_alerted_at = NULL").
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
 }
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type promotionRepository struct {
+}
+
+// Returned (wrapped) when a promotion chosen for checkout ended, sold out its allotment or reached per user limit meanwhile
+var ErrPromotionUnavailable = errors.New("promotion is no longer available")
+
+type PromotionRepository interface {
+	GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, status string) ([]*model.PromotionView, error)
+	GetViewById(ctx context.Context, id string) (*model.PromotionView, error)
+
+	GetById(ctx context.Context, id string) (*model.Promotion, error)
+	Create(ctx context.Context, newPromotion *model.Promotion) error
+	Update(ctx context.Context, updatedPromotion *model.Promotion) error
+	DeleteById(ctx context.Context, id string) error
+	ExistsUsageById(ctx context.Context, id string) (bool, error)
+
+	// Products discounted by promotion, whether it is in effect or not
+	GetProductIdsById(ctx context.Context, id string) ([]string, error)
+
+	// Checkout pricing
+	GetInEffectByListProductId(ctx context.Context, productIds []string) ([]*model.ProductPromotionView, error)
+	GetUsedQuantityByIdAndUserId(ctx context.Context, id string, userId string) (int32, error)
+	ReleaseUsagesByInvoiceId(ctx context.Context, invoiceId string) ([]string, error)
+
+	// Scheduler, each promotion is returned once by the call which changes its status
+	ActivateStarted(ctx context.Context, now time.Time) ([]*model.Promotion, error)
+	ExpireEnded(ctx context.Context, now time.Time) ([]*model.Promotion, error)
+}
+
+func NewPromotionRepository() PromotionRepository {
+	return &promotionRepository{}
+}
+
+func (promotionRepository *promotionRepository) GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, status string) ([]*model.PromotionView, error) {
+	var promotions []*model.PromotionView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&promotions).
+		Offset(offset).
+		Limit(limit)
+
+	if status != "" {
+		query = query.Where("_promotion.status = ?", status)
+	}
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_promotion.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (promotionRepository *promotionRepository) GetViewById(ctx context.Context, id string) (*model.PromotionView, error) {
+	promotion := new(model.PromotionView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(promotion).Where("_promotion.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (promotionRepository *promotionRepository) GetById(ctx context.Context, id string) (*model.Promotion, error) {
+	promotion := new(model.Promotion)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(promotion).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (promotionRepository *promotionRepository) Create(ctx context.Context, newPromotion *model.Promotion) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newPromotion).Returning("*").Exec(ctx)
+	return err
+}
+
+// Sold quantity is excluded, it only changes through promotion usages
+func (promotionRepository *promotionRepository) Update(ctx context.Context, updatedPromotion *model.Promotion) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedPromotion).ExcludeColumn("sold_quantity").Where("id = ?", updatedPromotion.Id).Exec(ctx)
+	return err
+}
+
+func (promotionRepository *promotionRepository) DeleteById(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.Promotion{}).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+func (promotionRepository *promotionRepository) ExistsUsageById(ctx context.Context, id string) (bool, error) {
+	return infrastructure.PostgresDB.NewSelect().Model((*model.PromotionUsage)(nil)).Where("promotion_id = ?", id).Exists(ctx)
+}
+
+func (promotionRepository *promotionRepository) GetProductIdsById(ctx context.Context, id string) ([]string, error) {
+	var productIds []string
+
+	query := infrastructure.PostgresDB.NewSelect().TableExpr("tb_product AS _product").
+		Column("_product.id").
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_promotion AS _promotion ON _promotion.id = ?", id).
+		Where(promotionTargetsProductCondition)
+
+	if err := query.Scan(ctx, &productIds); err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
+
+func (promotionRepository *promotionRepository) GetInEffectByListProductId(ctx context.Context, productIds []string) ([]*model.ProductPromotionView, error) {
+	var productPromotions []*model.ProductPromotionView
+
+	query := infrastructure.PostgresDB.NewSelect().TableExpr("tb_product AS _product").
+		ColumnExpr("_product.id AS product_id").
+		ColumnExpr("_promotion.id AS promotion_id").
+		ColumnExpr("_promotion.discount_percentage").
+		ColumnExpr("_promotion.per_user_limit").
+		ColumnExpr("_promotion.stock_allotment").
+		ColumnExpr("_promotion.sold_quantity").
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_promotion AS _promotion ON "+promotionInEffectCondition+" AND "+promotionTargetsProductCondition).
+		Where("_product.id IN (?)", bun.In(productIds)).
+		Order("_promotion.discount_percentage DESC", "_promotion.end_time ASC")
+
+	if err := query.Scan(ctx, &productPromotions); err != nil {
+		return nil, err
+	}
+
+	return productPromotions, nil
+}
+
+func (promotionRepository *promotionRepository) GetUsedQuantityByIdAndUserId(ctx context.Context, id string, userId string) (int32, error) {
+	var usedQuantity int32
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.PromotionUsage)(nil)).
+		ColumnExpr("COALESCE(SUM(quantity), 0)").
+		Where("promotion_id = ?", id).
+		Where("user_id = ?", userId)
+
+	if err := query.Scan(ctx, &usedQuantity); err != nil {
+		return 0, err
+	}
+
+	return usedQuantity, nil
+}
+
+// Give units of cancelled invoice back to allotment and per user limit of its promotions, return ids of their products
+func (promotionRepository *promotionRepository) ReleaseUsagesByInvoiceId(ctx context.Context, invoiceId string) ([]string, error) {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var promotionUsages []*model.PromotionUsage
+	if err := tx.NewDelete().Model((*model.PromotionUsage)(nil)).Where("invoice_id = ?", invoiceId).Returning("*").Scan(ctx, &promotionUsages); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	productIds := []string{}
+	for _, promotionUsage := range promotionUsages {
+		_, err := tx.NewUpdate().Model((*model.Promotion)(nil)).
+			Set("sold_quantity = GREATEST(sold_quantity - ?, 0)", promotionUsage.Quantity).
+			Where("id = ?", promotionUsage.PromotionId).
+			Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+		productIds = append(productIds, promotionUsage.ProductId)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return productIds, nil
+}
+
+func (promotionRepository *promotionRepository) ActivateStarted(ctx context.Context, now time.Time) ([]*model.Promotion, error) {
+	var promotions []*model.Promotion
+
+	err := infrastructure.PostgresDB.NewUpdate().Model((*model.Promotion)(nil)).
+		Set("status = 'ACTIVE'").
+		Set("updated_at = ?", now).
+		Where("status = 'SCHEDULED'").
+		Where("start_time <= ?", now).
+		Where("end_time > ?", now).
+		Returning("*").
+		Scan(ctx, &promotions)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// Promotion expires at its end time, or earlier when its flash sale allotment is sold out
+func (promotionRepository *promotionRepository) ExpireEnded(ctx context.Context, now time.Time) ([]*model.Promotion, error) {
+	var promotions []*model.Promotion
+
+	err := infrastructure.PostgresDB.NewUpdate().Model((*model.Promotion)(nil)).
+		Set("status = 'EXPIRED'").
+		Set("updated_at = ?", now).
+		Where("status IN ('SCHEDULED', 'ACTIVE')").
+		WhereGroup(" AND ", func(query *bun.UpdateQuery) *bun.UpdateQuery {
+			return query.
+				Where("end_time <= ?", now).
+				WhereOr("stock_allotment > 0 AND sold_quantity >= stock_allotment")
+		}).
+		Returning("*").
+		Scan(ctx, &promotions)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// Claim units of each usage from allotment and per user limit of its promotion then record it, the promotion row is locked
+// by the update so concurrent checkouts under the same promotion are serialized on it
+func createPromotionUsages(ctx context.Context, tx bun.Tx, newPromotionUsages []*model.PromotionUsage) error {
+	timeUpdate := time.Now().UTC()
+
+	for _, newPromotionUsage := range newPromotionUsages {
+		result, err := tx.NewUpdate().Model((*model.Promotion)(nil)).
+			Set("sold_quantity = sold_quantity + ?", newPromotionUsage.Quantity).
+			Set("updated_at = ?", timeUpdate).
+			Where("id = ?", newPromotionUsage.PromotionId).
+			Where("status IN ('SCHEDULED', 'ACTIVE')").
+			Where("start_time <= ?", timeUpdate).
+			Where("end_time > ?", timeUpdate).
+			Where("(stock_allotment = 0 OR sold_quantity + ? <= stock_allotment)", newPromotionUsage.Quantity).
+			Where(`(per_user_limit = 0 OR per_user_limit >= ? + (
+				SELECT COALESCE(SUM(_promotion_usage.quantity), 0) FROM tb_promotion_usage AS _promotion_usage
+				WHERE _promotion_usage.promotion_id = ? AND _promotion_usage.user_id = ?
+			))`, newPromotionUsage.Quantity, newPromotionUsage.PromotionId, newPromotionUsage.UserId).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			return fmt.Errorf("%w: %s", ErrPromotionUnavailable, newPromotionUsage.PromotionId)
+		}
+
+		// Inserted one by one so that per user limit of next usages counts this one
+		newPromotionUsage.CreatedAt = &timeUpdate
+		if _, err := tx.NewInsert().Model(newPromotionUsage).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+	"time"
+)
+
+type reviewRepository struct {
+}
+
+type ReviewRepository interface {
+	GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, filter *ReviewFilter) ([]*model.ReviewView, error)
+	GetViewById(ctx context.Context, id string) (*model.ReviewView, error)
+
+	GetById(ctx context.Context, id string) (*model.Review, error)
+	GetByProductId(ctx context.Context, productId string) ([]*model.Review, error)
+	GetByProductIdAndUserId(ctx context.Context, productId string, userId string) (*model.Review, error)
+	Create(ctx context.Context, newReview *model.Review) error
+	Update(ctx context.Context, updatedReview *model.Review) error
+	DeleteById(ctx context.Context, id string) error
+	DeleteByProductId(ctx context.Context, productId string) error
+
+	// Recalculate average rating and rating count of product from its approved reviews
+	UpdateProductRating(ctx context.Context, productId string) error
+}
+
+type ReviewFilter struct {
+	ProductId        string
+	UserId           string
+	Status           string
+	Rating           int32
+	VerifiedPurchase bool
+}
+
+func NewReviewRepository() ReviewRepository {
+	return &reviewRepository{}
+}
+
+func (reviewRepository *reviewRepository) GetViews(ctx context.Context, offset int, limit int, sortFields []*utils.SortField, filter *ReviewFilter) ([]*model.ReviewView, error) {
+	var reviews []*model.ReviewView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&reviews).
+		Offset(offset).
+		Limit(limit)
+
+	if filter.ProductId != "" {
+		query = query.Where("_review.product_id = ?", filter.ProductId)
+	}
+	if filter.UserId != "" {
+		query = query.Where("_review.user_id = ?", filter.UserId)
+	}
+	if filter.Status != "" {
+		query = query.Where("_review.status = ?", filter.Status)
+	}
+	if filter.Rating != 0 {
+		query = query.Where("_review.rating = ?", filter.Rating)
+	}
+	if filter.VerifiedPurchase {
+		query = query.Where("_review.verified_purchase = TRUE")
+	}
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_review.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (reviewRepository *reviewRepository) GetViewById(ctx context.Context, id string) (*model.ReviewView, error) {
+	review := new(model.ReviewView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(review).Where("_review.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (reviewRepository *reviewRepository) GetById(ctx context.Context, id string) (*model.Review, error) {
+	review := new(model.Review)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(review).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (reviewRepository *reviewRepository) GetByProductId(ctx context.Context, productId string) ([]*model.Review, error) {
+	var reviews []*model.Review
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&reviews).Where("product_id = ?", productId)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (reviewRepository *reviewRepository) GetByProductIdAndUserId(ctx context.Context, productId string, userId string) (*model.Review, error) {
+	review := new(model.Review)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(review).Where("product_id = ?", productId).Where("user_id = ?", userId)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (reviewRepository *reviewRepository) Create(ctx context.Context, newReview *model.Review) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newReview).Returning("*").Exec(ctx)
+	return err
+}
+
+func (reviewRepository *reviewRepository) Update(ctx context.Context, updatedReview *model.Review) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedReview).Where("id = ?", updatedReview.Id).Exec(ctx)
+	return err
+}
+
+func (reviewRepository *reviewRepository) DeleteById(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.Review{}).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+func (reviewRepository *reviewRepository) DeleteByProductId(ctx context.Context, productId string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.Review{}).Where("product_id = ?", productId).Exec(ctx)
+	return err
+}
+
+func (reviewRepository *reviewRepository) UpdateProductRating(ctx context.Context, productId string) error {
+	query := `
+		UPDATE tb_product
+		SET
+			average_rating = COALESCE((SELECT ROUND(AVG(rating)::numeric, 2) FROM tb_review WHERE product_id = ?0 AND status = 'APPROVED'), 0),
+			rating_count = (SELECT COUNT(*) FROM tb_review WHERE product_id = ?0 AND status = 'APPROVED'),
+			updated_at = ?1
+		WHERE id = ?0
+	`
+	_, err := infrastructure.PostgresDB.ExecContext(ctx, query, productId, time.Now().UTC())
+	return err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+)
+
+type sizeChartRepository struct {
+}
+
+type SizeChartRepository interface {
+	// Empty brand id or category id does not filter by it
+	GetViews(ctx context.Context, brandId string, categoryId string) ([]*model.SizeChartView, error)
+	GetViewById(ctx context.Context, id string) (*model.SizeChartView, error)
+	// Chart of brand for category of path or else for its nearest ancestor having one
+	GetViewByBrandIdAndCategoryPath(ctx context.Context, brandId string, categoryPath string) (*model.SizeChartView, error)
+
+	GetById(ctx context.Context, id string) (*model.SizeChart, error)
+	GetByBrandIdAndCategoryId(ctx context.Context, brandId string, categoryId string) (*model.SizeChart, error)
+	Create(ctx context.Context, newSizeChart *model.SizeChart) error
+	Update(ctx context.Context, updatedSizeChart *model.SizeChart) error
+	DeleteById(ctx context.Context, id string) error
+}
+
+func NewSizeChartRepository() SizeChartRepository {
+	return &sizeChartRepository{}
+}
+
+func (sizeChartRepository *sizeChartRepository) GetViews(ctx context.Context, brandId string, categoryId string) ([]*model.SizeChartView, error) {
+	var sizeCharts []*model.SizeChartView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&sizeCharts).
+		Column("_size_chart.*").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr("_category.name AS category_name").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _size_chart.brand_id").
+		Join("JOIN tb_category AS _category ON _category.id = _size_chart.category_id").
+		Order("_brand.name ASC", "_category.path ASC")
+
+	if brandId != "" {
+		query = query.Where("_size_chart.brand_id = ?", brandId)
+	}
+	if categoryId != "" {
+		query = query.Where("_size_chart.category_id = ?", categoryId)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return sizeCharts, nil
+}
+
+func (sizeChartRepository *sizeChartRepository) GetViewById(ctx context.Context, id string) (*model.SizeChartView, error) {
+	sizeChart := new(model.SizeChartView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(sizeChart).
+		Column("_size_chart.*").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr("_category.name AS category_name").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _size_chart.brand_id").
+		Join("JOIN tb_category AS _category ON _category.id = _size_chart.category_id").
+		Where("_size_chart.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return sizeChart, nil
+}
+
+func (sizeChartRepository *sizeChartRepository) GetViewByBrandIdAndCategoryPath(ctx context.Context, brandId string, categoryPath string) (*model.SizeChartView, error) {
+	sizeChart := new(model.SizeChartView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(sizeChart).
+		Column("_size_chart.*").
+		ColumnExpr("_brand.name AS brand_name").
+		ColumnExpr("_category.name AS category_name").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _size_chart.brand_id").
+		Join("JOIN tb_category AS _category ON _category.id = _size_chart.category_id").
+		Where("_size_chart.brand_id = ?", brandId).
+		Where("position('/' || _size_chart.category_id || '/' IN ?) > 0", categoryPath).
+		Order("_category.depth DESC").
+		Limit(1)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return sizeChart, nil
+}
+
+func (sizeChartRepository *sizeChartRepository) GetById(ctx context.Context, id string) (*model.SizeChart, error) {
+	sizeChart := new(model.SizeChart)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(sizeChart).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return sizeChart, nil
+}
+
+func (sizeChartRepository *sizeChartRepository) GetByBrandIdAndCategoryId(ctx context.Context, brandId string, categoryId string) (*model.SizeChart, error) {
+	sizeChart := new(model.SizeChart)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(sizeChart).Where("brand_id = ?", brandId).Where("category_id = ?", categoryId)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return sizeChart, nil
+}
+
+func (sizeChartRepository *sizeChartRepository) Create(ctx context.Context, newSizeChart *model.SizeChart) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newSizeChart).Returning("*").Exec(ctx)
+	return err
+}
+
+func (sizeChartRepository *sizeChartRepository) Update(ctx context.Context, updatedSizeChart *model.SizeChart) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedSizeChart).Where("id = ?", updatedSizeChart.Id).Exec(ctx)
+	return err
+}
+
+func (sizeChartRepository *sizeChartRepository) DeleteById(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.SizeChart{}).Where("id = ?", id).Exec(ctx)
+	return err
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+)
+
+type slugRedirectRepository struct {
+}
+
+type SlugRedirectRepository interface {
+	GetByEntityTypeAndSlug(ctx context.Context, entityType string, slug string) (*model.SlugRedirect, error)
+	Upsert(ctx context.Context, slugRedirect *model.SlugRedirect) error
+	DeleteByEntityTypeAndSlug(ctx context.Context, entityType string, slug string) error
+}
+
+func NewSlugRedirectRepository() SlugRedirectRepository {
+	return &slugRedirectRepository{}
+}
+
+func (slugRedirectRepository *slugRedirectRepository) GetByEntityTypeAndSlug(ctx context.Context, entityType string, slug string) (*model.SlugRedirect, error) {
+	slugRedirect := new(model.SlugRedirect)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(slugRedirect).Where("entity_type = ?", entityType).Where("slug = ?", slug)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return slugRedirect, nil
+}
+
+// Slug given up by an entity may have redirected to another entity before, it now redirects to the latest one
+func (slugRedirectRepository *slugRedirectRepository) Upsert(ctx context.Context, slugRedirect *model.SlugRedirect) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(slugRedirect).
+		On("CONFLICT (entity_type, slug) DO UPDATE").
+		Set("entity_id = EXCLUDED.entity_id").
+		Set("created_at = EXCLUDED.created_at").
+		Exec(ctx)
+	return err
+}
+
+func (slugRedirectRepository *slugRedirectRepository) DeleteByEntityTypeAndSlug(ctx context.Context, entityType string, slug string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.SlugRedirect{}).Where("entity_type = ?", entityType).Where("slug = ?", slug).Exec(ctx)
+	return err
+}
@@ -0,0 +1,442 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type stockMovementRepository struct {
+}
+
+// Returned (wrapped) by CreateList when a movement would take stock of product in its warehouse below zero
+var ErrNotEnoughStock = errors.New("not enough stock")
+
+type StockMovementRepository interface {
+	GetViewsByProductId(ctx context.Context, productId string, offset int, limit int, sortFields []*utils.SortField, reason string, warehouseId string) ([]*model.StockMovementView, error)
+	GetLedgerStockByProductId(ctx context.Context, productId string) (int32, error)
+
+	// Apply quantities of movements to stock of products in their warehouses (default warehouse if empty) and append movements
+	// to ledger in one transaction
+	CreateList(ctx context.Context, newStockMovements []*model.StockMovement) error
+	// Same as CreateList for stock given back by cancelled invoice, backorders of the invoice are cancelled in the same
+	// transaction and allocated ones give their stock back. It returns ids of products whose stock changed, nothing is done
+	// when stock of the invoice has already been given back so that retried cancels never restore it twice.
+	CreateCancelRestore(ctx context.Context, invoiceId string, actorId *string, newStockMovements []*model.StockMovement) ([]string, error)
+	// Set stock of product to given stock by an adjustment of the difference, which is computed from stock locked inside the
+	// transaction so that concurrent movements are not overwritten, nothing is done when stock is already there
+	CreateAdjustment(ctx context.Context, newStockMovement *model.StockMovement, stock int32) error
+	// Same as CreateList and also claim promotion usages and queue backorders of the sale in the same transaction
+	CreateSale(ctx context.Context, newStockMovements []*model.StockMovement, newPromotionUsages []*model.PromotionUsage, newBackorders []*model.Backorder) error
+
+	// Find warehouse stocks which differ from ledger and products whose stock differs from sum of their warehouse stocks,
+	// reset them if not dry run
+	Reconcile(ctx context.Context, dryRun bool) ([]*model.StockReconciliationView, error)
+}
+
+func NewStockMovementRepository() StockMovementRepository {
+	return &stockMovementRepository{}
+}
+
+func (stockMovementRepository *stockMovementRepository) GetViewsByProductId(ctx context.Context, productId string, offset int, limit int, sortFields []*utils.SortField, reason string, warehouseId string) ([]*model.StockMovementView, error) {
+	var stockMovements []*model.StockMovementView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&stockMovements).
+		Where("_stock_movement.product_id = ?", productId).
+		Offset(offset).
+		Limit(limit)
+
+	if reason != "" {
+		query = query.Where("_stock_movement.reason = ?", reason)
+	}
+	if warehouseId != "" {
+		query = query.Where("_stock_movement.warehouse_id = ?", warehouseId)
+	}
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_stock_movement.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return stockMovements, nil
+}
+
+func (stockMovementRepository *stockMovementRepository) GetLedgerStockByProductId(ctx context.Context, productId string) (int32, error) {
+	var ledgerStock int32
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.StockMovement)(nil)).
+		ColumnExpr("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ?", productId)
+
+	if err := query.Scan(ctx, &ledgerStock); err != nil {
+		return 0, err
+	}
+
+	return ledgerStock, nil
+}
+
+func (stockMovementRepository *stockMovementRepository) CreateList(ctx context.Context, newStockMovements []*model.StockMovement) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (stockMovementRepository *stockMovementRepository) CreateCancelRestore(ctx context.Context, invoiceId string, actorId *string, newStockMovements []*model.StockMovement) ([]string, error) {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Concurrent cancels of the same invoice wait for each other before looking for movements of the first one
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", "CANCEL_RESTORE:"+invoiceId); err != nil {
+		return nil, err
+	}
+	restored, err := tx.NewSelect().Model((*model.StockMovement)(nil)).
+		Where("invoice_id = ?", invoiceId).
+		Where("reason = 'CANCEL_RESTORE'").
+		Exists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if restored {
+		return []string{}, nil
+	}
+
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return nil, err
+	}
+	productIds := make([]string, len(newStockMovements))
+	for i, newStockMovement := range newStockMovements {
+		productIds[i] = newStockMovement.ProductId
+	}
+
+	backorderProductIds, err := cancelBackordersByInvoiceId(ctx, tx, invoiceId, actorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return append(productIds, backorderProductIds...), nil
+}
+
+func (stockMovementRepository *stockMovementRepository) CreateAdjustment(ctx context.Context, newStockMovement *model.StockMovement, stock int32) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var productStock int32
+	if err := tx.NewSelect().Model((*model.Product)(nil)).Column("stock").Where("id = ?", newStockMovement.ProductId).For("UPDATE").Scan(ctx, &productStock); err != nil {
+		return err
+	}
+	if productStock == stock {
+		return nil
+	}
+
+	newStockMovement.Quantity = stock - productStock
+	newStockMovements := []*model.StockMovement{newStockMovement}
+	if newStockMovement.Quantity < 0 && newStockMovement.WarehouseId == "" {
+		spreadStockMovements, err := spreadStockDecrease(ctx, tx, newStockMovement)
+		if err != nil {
+			return err
+		}
+		newStockMovements = spreadStockMovements
+	}
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Decrease without warehouse is taken from default warehouse first, then from other warehouses in the same order (active
+// ones by priority), so that it does not fail while product still has stock elsewhere, product row is locked by caller
+func spreadStockDecrease(ctx context.Context, tx bun.Tx, newStockMovement *model.StockMovement) ([]*model.StockMovement, error) {
+	var warehouseStocks []*model.WarehouseStockView
+	err := tx.NewSelect().Model(&warehouseStocks).
+		Column("_warehouse_stock.warehouse_id", "_warehouse_stock.stock").
+		Join("JOIN tb_warehouse AS _warehouse ON _warehouse.id = _warehouse_stock.warehouse_id").
+		Where("_warehouse_stock.product_id = ?", newStockMovement.ProductId).
+		Where("_warehouse_stock.stock > 0").
+		Order("_warehouse.is_active DESC", "_warehouse.priority DESC", "_warehouse.created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newStockMovements := []*model.StockMovement{}
+	remainingQuantity := -newStockMovement.Quantity
+	for _, warehouseStock := range warehouseStocks {
+		if remainingQuantity == 0 {
+			break
+		}
+		quantity := min(warehouseStock.Stock, remainingQuantity)
+		spreadStockMovement := *newStockMovement
+		if len(newStockMovements) != 0 {
+			spreadStockMovement.Id = uuid.New().String()
+		}
+		spreadStockMovement.WarehouseId = warehouseStock.WarehouseId
+		spreadStockMovement.Quantity = -quantity
+		newStockMovements = append(newStockMovements, &spreadStockMovement)
+		remainingQuantity -= quantity
+	}
+	if remainingQuantity != 0 {
+		return nil, fmt.Errorf("%w for product id: %s", ErrNotEnoughStock, newStockMovement.ProductId)
+	}
+
+	return newStockMovements, nil
+}
+
+func (stockMovementRepository *stockMovementRepository) CreateSale(ctx context.Context, newStockMovements []*model.StockMovement, newPromotionUsages []*model.PromotionUsage, newBackorders []*model.Backorder) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := createStockMovements(ctx, tx, newStockMovements); err != nil {
+		return err
+	}
+
+	// Stock held for queued backorders can not be sold, it is checked before backorders of this sale are queued
+	soldProductIds := []string{}
+	for _, newStockMovement := range newStockMovements {
+		if newStockMovement.Quantity < 0 {
+			soldProductIds = append(soldProductIds, newStockMovement.ProductId)
+		}
+	}
+	if len(soldProductIds) != 0 {
+		var overtakingProductId string
+		err := tx.NewSelect().Model((*model.Product)(nil)).
+			Column("id").
+			Where("id IN (?)", bun.In(soldProductIds)).
+			Where("stock < backorder_quantity").
+			Limit(1).
+			Scan(ctx, &overtakingProductId)
+		if err == nil {
+			return fmt.Errorf("%w for product id: %s", ErrNotEnoughStock, overtakingProductId)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+	}
+
+	if err := createPromotionUsages(ctx, tx, newPromotionUsages); err != nil {
+		return err
+	}
+
+	if err := createBackorders(ctx, tx, newBackorders); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (stockMovementRepository *stockMovementRepository) Reconcile(ctx context.Context, dryRun bool) ([]*model.StockReconciliationView, error) {
+	var reconciliations []*model.StockReconciliationView
+
+	warehouseDriftQuery := `
+		SELECT _ledger.product_id, _ledger.warehouse_id, COALESCE(_warehouse_stock.stock, 0) AS stock, _ledger.ledger_stock
+		FROM (
+			SELECT product_id, warehouse_id, SUM(quantity) AS ledger_stock
+			FROM tb_stock_movement
+			GROUP BY product_id, warehouse_id
+		) AS _ledger
+		JOIN tb_product AS _product ON _product.id = _ledger.product_id
+		LEFT JOIN tb_warehouse_stock AS _warehouse_stock
+			ON _warehouse_stock.product_id = _ledger.product_id AND _warehouse_stock.warehouse_id = _ledger.warehouse_id
+		WHERE COALESCE(_warehouse_stock.stock, 0) <> _ledger.ledger_stock
+		UNION ALL
+		SELECT _warehouse_stock.product_id, _warehouse_stock.warehouse_id, _warehouse_stock.stock, 0 AS ledger_stock
+		FROM tb_warehouse_stock AS _warehouse_stock
+		WHERE _warehouse_stock.stock <> 0 AND NOT EXISTS (
+			SELECT 1 FROM tb_stock_movement AS _stock_movement
+			WHERE _stock_movement.product_id = _warehouse_stock.product_id AND _stock_movement.warehouse_id = _warehouse_stock.warehouse_id
+		)
+	`
+	// Stock of product is compared with its ledger, which equals sum of its warehouse stocks once they are reconciled. Bundles
+	// have no ledger, their stock is derived from their components.
+	productDriftQuery := `
+		SELECT _product.id AS product_id, '' AS warehouse_id, _product.stock AS stock, COALESCE(SUM(_stock_movement.quantity), 0) AS ledger_stock
+		FROM tb_product AS _product
+		LEFT JOIN tb_stock_movement AS _stock_movement ON _stock_movement.product_id = _product.id
+		WHERE _product.type <> 'BUNDLE'
+		GROUP BY _product.id, _product.stock
+		HAVING _product.stock <> COALESCE(SUM(_stock_movement.quantity), 0)
+	`
+
+	if dryRun {
+		if err := infrastructure.PostgresDB.NewRaw(warehouseDriftQuery+" UNION ALL "+productDriftQuery).Scan(ctx, &reconciliations); err != nil {
+			return nil, err
+		}
+		return reconciliations, nil
+	}
+
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var warehouseReconciliations []*model.StockReconciliationView
+	if err := tx.NewRaw(warehouseDriftQuery).Scan(ctx, &warehouseReconciliations); err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH _drift AS (` + warehouseDriftQuery + `)
+		INSERT INTO tb_warehouse_stock (product_id, warehouse_id, stock)
+		SELECT product_id, warehouse_id, ledger_stock FROM _drift
+		ON CONFLICT (product_id, warehouse_id) DO UPDATE SET stock = EXCLUDED.stock
+	`
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return nil, err
+	}
+
+	var productReconciliations []*model.StockReconciliationView
+	query = `
+		WITH _drift AS (` + productDriftQuery + `)
+		UPDATE tb_product
+		SET stock = _drift.ledger_stock, updated_at = ?
+		FROM _drift
+		WHERE tb_product.id = _drift.product_id
+		RETURNING _drift.product_id, _drift.warehouse_id, _drift.stock, _drift.ledger_stock
+	`
+	if err := tx.NewRaw(query, time.Now().UTC()).Scan(ctx, &productReconciliations); err != nil {
+		return nil, err
+	}
+
+	if len(productReconciliations) != 0 {
+		productIds := make([]string, len(productReconciliations))
+		for i, productReconciliation := range productReconciliations {
+			productIds[i] = productReconciliation.ProductId
+		}
+		bundleIds, err := getBundleIdsByListComponentId(ctx, tx, productIds)
+		if err != nil {
+			return nil, err
+		}
+		if err := syncBundleStocks(ctx, tx, bundleIds); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return append(warehouseReconciliations, productReconciliations...), nil
+}
+
+// Apply each movement to stock of its product and of its warehouse then append it to ledger, stock is checked by the update
+// itself so it never goes below zero. Product row is updated first so concurrent movements of a product are serialized on it.
+// Bundles are never moved themselves, stock of bundles containing moved products is derived again.
+func createStockMovements(ctx context.Context, tx bun.Tx, newStockMovements []*model.StockMovement) error {
+	timeUpdate := time.Now().UTC()
+
+	defaultWarehouseId := ""
+	for _, newStockMovement := range newStockMovements {
+		if newStockMovement.WarehouseId != "" {
+			continue
+		}
+		if defaultWarehouseId == "" {
+			id, err := getDefaultWarehouseId(ctx, tx)
+			if err != nil {
+				return fmt.Errorf("get default warehouse failed: %s", err.Error())
+			}
+			defaultWarehouseId = id
+		}
+		newStockMovement.WarehouseId = defaultWarehouseId
+	}
+
+	for _, newStockMovement := range newStockMovements {
+		var productStock int32
+		err := tx.NewUpdate().Model((*model.Product)(nil)).
+			Set("stock = stock + ?", newStockMovement.Quantity).
+			Set("updated_at = ?", timeUpdate).
+			Where("id = ?", newStockMovement.ProductId).
+			Where("type <> 'BUNDLE'").
+			Where("stock + ? >= 0", newStockMovement.Quantity).
+			Returning("stock").
+			Scan(ctx, &productStock)
+		if errors.Is(err, sql.ErrNoRows) {
+			var productType string
+			err := tx.NewSelect().Model((*model.Product)(nil)).Column("type").Where("id = ?", newStockMovement.ProductId).Scan(ctx, &productType)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("id of product not found: %s", newStockMovement.ProductId)
+			} else if err != nil {
+				return err
+			}
+			if productType == "BUNDLE" {
+				return fmt.Errorf("stock of bundle product id %s is derived from its components", newStockMovement.ProductId)
+			}
+			return fmt.Errorf("%w for product id: %s", ErrNotEnoughStock, newStockMovement.ProductId)
+		} else if err != nil {
+			return err
+		}
+
+		if newStockMovement.Quantity >= 0 {
+			err = tx.NewRaw(`
+				INSERT INTO tb_warehouse_stock (product_id, warehouse_id, stock) VALUES (?, ?, ?)
+				ON CONFLICT (product_id, warehouse_id) DO UPDATE SET stock = tb_warehouse_stock.stock + EXCLUDED.stock
+				RETURNING stock
+			`, newStockMovement.ProductId, newStockMovement.WarehouseId, newStockMovement.Quantity).Scan(ctx, &newStockMovement.StockAfter)
+		} else {
+			err = tx.NewUpdate().Model((*model.WarehouseStock)(nil)).
+				Set("stock = stock + ?", newStockMovement.Quantity).
+				Where("product_id = ?", newStockMovement.ProductId).
+				Where("warehouse_id = ?", newStockMovement.WarehouseId).
+				Where("stock + ? >= 0", newStockMovement.Quantity).
+				Returning("stock").
+				Scan(ctx, &newStockMovement.StockAfter)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w for product id: %s in warehouse id: %s", ErrNotEnoughStock, newStockMovement.ProductId, newStockMovement.WarehouseId)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		newStockMovement.CreatedAt = &timeUpdate
+	}
+
+	if len(newStockMovements) == 0 {
+		return nil
+	}
+
+	if _, err := tx.NewInsert().Model(&newStockMovements).Exec(ctx); err != nil {
+		return err
+	}
+
+	productIds := make([]string, len(newStockMovements))
+	for i, newStockMovement := range newStockMovements {
+		productIds[i] = newStockMovement.ProductId
+	}
+	bundleIds, err := getBundleIdsByListComponentId(ctx, tx, productIds)
+	if err != nil {
+		return err
+	}
+
+	return syncBundleStocks(ctx, tx, bundleIds)
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+
+	"github.com/uptrace/bun"
+)
+
+type warehouseRepository struct {
+}
+
+type WarehouseRepository interface {
+	GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.WarehouseView, error)
+	GetViewById(ctx context.Context, id string) (*model.WarehouseView, error)
+
+	GetById(ctx context.Context, id string) (*model.Warehouse, error)
+	GetByCode(ctx context.Context, code string) (*model.Warehouse, error)
+	Create(ctx context.Context, newWarehouse *model.Warehouse) error
+	Update(ctx context.Context, updatedWarehouse *model.Warehouse) error
+	DeleteById(ctx context.Context, id string) error
+
+	// Stock of products per warehouse, kept by stock movement ledger
+	GetStockViewsByProductId(ctx context.Context, productId string) ([]*model.WarehouseStockView, error)
+	GetActiveStockViewsByListProductId(ctx context.Context, productIds []string) ([]*model.WarehouseStockView, error)
+	GetTotalStockById(ctx context.Context, id string) (int64, error)
+	DeleteStocksByProductId(ctx context.Context, productId string) error
+}
+
+func NewWarehouseRepository() WarehouseRepository {
+	return &warehouseRepository{}
+}
+
+func (warehouseRepository *warehouseRepository) GetAllViews(ctx context.Context, sortFields []*utils.SortField) ([]*model.WarehouseView, error) {
+	var warehouses []*model.WarehouseView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&warehouses)
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_warehouse.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return warehouses, nil
+}
+
+func (warehouseRepository *warehouseRepository) GetViewById(ctx context.Context, id string) (*model.WarehouseView, error) {
+	warehouse := new(model.WarehouseView)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(warehouse).Where("_warehouse.id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (warehouseRepository *warehouseRepository) GetById(ctx context.Context, id string) (*model.Warehouse, error) {
+	warehouse := new(model.Warehouse)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(warehouse).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (warehouseRepository *warehouseRepository) GetByCode(ctx context.Context, code string) (*model.Warehouse, error) {
+	warehouse := new(model.Warehouse)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(warehouse).Where("code = ?", code)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (warehouseRepository *warehouseRepository) Create(ctx context.Context, newWarehouse *model.Warehouse) error {
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newWarehouse).Returning("*").Exec(ctx)
+	return err
+}
+
+func (warehouseRepository *warehouseRepository) Update(ctx context.Context, updatedWarehouse *model.Warehouse) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(updatedWarehouse).Where("id = ?", updatedWarehouse.Id).Exec(ctx)
+	return err
+}
+
+func (warehouseRepository *warehouseRepository) DeleteById(ctx context.Context, id string) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NewDelete().Model(&model.WarehouseStock{}).Where("warehouse_id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewDelete().Model(&model.Warehouse{}).Where("id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (warehouseRepository *warehouseRepository) GetStockViewsByProductId(ctx context.Context, productId string) ([]*model.WarehouseStockView, error) {
+	var warehouseStocks []*model.WarehouseStockView
+
+	query := newWarehouseStockViewQuery(&warehouseStocks).
+		Where("_warehouse_stock.product_id = ?", productId).
+		Order("_warehouse.priority DESC", "_warehouse.code ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return warehouseStocks, nil
+}
+
+func (warehouseRepository *warehouseRepository) GetActiveStockViewsByListProductId(ctx context.Context, productIds []string) ([]*model.WarehouseStockView, error) {
+	var warehouseStocks []*model.WarehouseStockView
+
+	query := newWarehouseStockViewQuery(&warehouseStocks).
+		Where("_warehouse_stock.product_id IN (?)", bun.In(productIds)).
+		Where("_warehouse.is_active = TRUE").
+		Where("_warehouse_stock.stock > 0")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return warehouseStocks, nil
+}
+
+func (warehouseRepository *warehouseRepository) GetTotalStockById(ctx context.Context, id string) (int64, error) {
+	var totalStock int64
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.WarehouseStock)(nil)).
+		ColumnExpr("COALESCE(SUM(stock), 0)").
+		Where("warehouse_id = ?", id)
+
+	if err := query.Scan(ctx, &totalStock); err != nil {
+		return 0, err
+	}
+
+	return totalStock, nil
+}
+
+func (warehouseRepository *warehouseRepository) DeleteStocksByProductId(ctx context.Context, productId string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.WarehouseStock{}).Where("product_id = ?", productId).Exec(ctx)
+	return err
+}
+
+func newWarehouseStockViewQuery(warehouseStocks *[]*model.WarehouseStockView) *bun.SelectQuery {
+	return infrastructure.PostgresDB.NewSelect().Model(warehouseStocks).
+		Column("_warehouse_stock.*").
+		ColumnExpr("_warehouse.code AS warehouse_code").
+		ColumnExpr("_warehouse.name AS warehouse_name").
+		ColumnExpr("_warehouse.latitude AS warehouse_latitude").
+		ColumnExpr("_warehouse.longitude AS warehouse_longitude").
+		ColumnExpr("_warehouse.priority AS warehouse_priority").
+		ColumnExpr("_warehouse.is_active AS warehouse_is_active").
+		Join("JOIN tb_warehouse AS _warehouse ON _warehouse.id = _warehouse_stock.warehouse_id")
+}
+
+// Active warehouse with highest priority, it receives stock when no warehouse is given
+func getDefaultWarehouseId(ctx context.Context, db bun.IDB) (string, error) {
+	var id string
+
+	query := db.NewSelect().Model((*model.Warehouse)(nil)).
+		Column("id").
+		Where("is_active = TRUE").
+		Order("priority DESC", "created_at ASC").
+		Limit(1)
+
+	if err := query.Scan(ctx, &id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
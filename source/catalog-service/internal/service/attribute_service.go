@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type attributeService struct {
+	attributeDefinitionRepository   repository.AttributeDefinitionRepository
+	categoryAttributeRepository     repository.CategoryAttributeRepository
+	productAttributeValueRepository repository.ProductAttributeValueRepository
+	categoryRepository              repository.CategoryRepository
+	productRepository               repository.ProductRepository
+}
+
+type AttributeService interface {
+	GetAllAttributeDefinitions(ctx context.Context, reqDTO *dto.GetAllAttributeDefinitionsRequest) ([]*model.AttributeDefinitionView, error)
+	GetAttributeDefinitionById(ctx context.Context, reqDTO *dto.GetAttributeDefinitionByIdRequest) (*model.AttributeDefinitionView, error)
+	CreateAttributeDefinition(ctx context.Context, reqDTO *dto.CreateAttributeDefinitionRequest) error
+	UpdateAttributeDefinitionById(ctx context.Context, reqDTO *dto.UpdateAttributeDefinitionByIdRequest) error
+	DeleteAttributeDefinitionById(ctx context.Context, reqDTO *dto.DeleteAttributeDefinitionByIdRequest) error
+	GetCategoryAttributes(ctx context.Context, reqDTO *dto.GetCategoryAttributesRequest) ([]*model.CategoryAttributeView, error)
+	UpdateCategoryAttributes(ctx context.Context, reqDTO *dto.UpdateCategoryAttributesRequest) error
+}
+
+func NewAttributeService(attributeDefinitionRepository repository.AttributeDefinitionRepository, categoryAttributeRepository repository.CategoryAttributeRepository, productAttributeValueRepository repository.ProductAttributeValueRepository, categoryRepository repository.CategoryRepository, productRepository repository.ProductRepository) AttributeService {
+	return &attributeService{
+		attributeDefinitionRepository:   attributeDefinitionRepository,
+		categoryAttributeRepository:     categoryAttributeRepository,
+		productAttributeValueRepository: productAttributeValueRepository,
+		categoryRepository:              categoryRepository,
+		productRepository:               productRepository,
+	}
+}
+
+func (attributeService *attributeService) GetAllAttributeDefinitions(ctx context.Context, reqDTO *dto.GetAllAttributeDefinitionsRequest) ([]*model.AttributeDefinitionView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	attributeDefinitions, err := attributeService.attributeDefinitionRepository.GetAllViews(ctx, sortFields)
+	if err != nil {
+		return nil, fmt.Errorf("query attributes from postgresql failed: %s", err.Error())
+	}
+
+	return attributeDefinitions, nil
+}
+
+func (attributeService *attributeService) GetAttributeDefinitionById(ctx context.Context, reqDTO *dto.GetAttributeDefinitionByIdRequest) (*model.AttributeDefinitionView, error) {
+	foundAttributeDefinition, err := attributeService.attributeDefinitionRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of attribute is not valid: %s", err.Error())
+	}
+
+	return foundAttributeDefinition, nil
+}
+
+func (attributeService *attributeService) CreateAttributeDefinition(ctx context.Context, reqDTO *dto.CreateAttributeDefinitionRequest) error {
+	if _, err := attributeService.attributeDefinitionRepository.GetByCode(ctx, reqDTO.Body.Code); err == nil {
+		return fmt.Errorf("code of attribute is already exists")
+	}
+
+	newAttributeDefinition := model.AttributeDefinition{
+		Id:      uuid.New().String(),
+		Code:    reqDTO.Body.Code,
+		Name:    reqDTO.Body.Name,
+		Type:    reqDTO.Body.Type,
+		Options: normalizeAttributeOptions(reqDTO.Body.Options),
+		Unit:    strings.TrimSpace(reqDTO.Body.Unit),
+	}
+	if err := checkAttributeDefinition(&newAttributeDefinition); err != nil {
+		return err
+	}
+	if err := attributeService.attributeDefinitionRepository.Create(ctx, &newAttributeDefinition); err != nil {
+		return fmt.Errorf("insert attribute to postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Code and type are fixed since they are keys of product values and of search filters
+func (attributeService *attributeService) UpdateAttributeDefinitionById(ctx context.Context, reqDTO *dto.UpdateAttributeDefinitionByIdRequest) error {
+	foundAttributeDefinition, err := attributeService.attributeDefinitionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of attribute is not valid: %s", err.Error())
+	}
+
+	if reqDTO.Body.Name != nil {
+		foundAttributeDefinition.Name = *reqDTO.Body.Name
+	}
+	if reqDTO.Body.Options != nil {
+		options := normalizeAttributeOptions(*reqDTO.Body.Options)
+		optionMap := map[string]bool{}
+		for _, option := range options {
+			optionMap[option] = true
+		}
+		removedOptions := []string{}
+		for _, option := range foundAttributeDefinition.Options {
+			if !optionMap[option] {
+				removedOptions = append(removedOptions, option)
+			}
+		}
+		if len(removedOptions) != 0 {
+			valueCount, err := attributeService.productAttributeValueRepository.CountByAttributeDefinitionIdAndListValue(ctx, foundAttributeDefinition.Id, removedOptions)
+			if err != nil {
+				return fmt.Errorf("query product attribute values from postgresql failed: %s", err.Error())
+			}
+			if valueCount > 0 {
+				return fmt.Errorf("removed options of attribute are still used by %d products", valueCount)
+			}
+		}
+		foundAttributeDefinition.Options = options
+	}
+	if reqDTO.Body.Unit != nil {
+		foundAttributeDefinition.Unit = strings.TrimSpace(*reqDTO.Body.Unit)
+	}
+	if err := checkAttributeDefinition(foundAttributeDefinition); err != nil {
+		return err
+	}
+	timeUpdate := time.Now().UTC()
+	foundAttributeDefinition.UpdatedAt = &timeUpdate
+
+	if err := attributeService.attributeDefinitionRepository.Update(ctx, foundAttributeDefinition); err != nil {
+		return fmt.Errorf("update attribute on postgresql failed: %s", err.Error())
+	}
+
+	attributeService.syncProductsOfAttributeDefinition(ctx, foundAttributeDefinition.Id)
+
+	return nil
+}
+
+func (attributeService *attributeService) DeleteAttributeDefinitionById(ctx context.Context, reqDTO *dto.DeleteAttributeDefinitionByIdRequest) error {
+	if _, err := attributeService.attributeDefinitionRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("id of attribute is not valid")
+	}
+
+	categoryCount, err := attributeService.categoryAttributeRepository.CountByAttributeDefinitionId(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("query category attributes from postgresql failed: %s", err.Error())
+	}
+	if categoryCount > 0 {
+		return fmt.Errorf("attribute is still in attribute set of %d categories", categoryCount)
+	}
+	valueCount, err := attributeService.productAttributeValueRepository.CountByAttributeDefinitionId(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("query product attribute values from postgresql failed: %s", err.Error())
+	}
+	if valueCount > 0 {
+		return fmt.Errorf("attribute is still used by %d products", valueCount)
+	}
+
+	if err := attributeService.attributeDefinitionRepository.DeleteById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("delete attribute from postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (attributeService *attributeService) GetCategoryAttributes(ctx context.Context, reqDTO *dto.GetCategoryAttributesRequest) ([]*model.CategoryAttributeView, error) {
+	foundCategory, err := attributeService.categoryRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of category is not valid: %s", err.Error())
+	}
+
+	categoryAttributes, err := attributeService.categoryAttributeRepository.GetViewsByCategory(ctx, foundCategory.Id, foundCategory.Path)
+	if err != nil {
+		return nil, fmt.Errorf("query category attributes from postgresql failed: %s", err.Error())
+	}
+
+	return categoryAttributes, nil
+}
+
+func (attributeService *attributeService) UpdateCategoryAttributes(ctx context.Context, reqDTO *dto.UpdateCategoryAttributesRequest) error {
+	foundCategory, err := attributeService.categoryRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of category is not valid: %s", err.Error())
+	}
+	if foundCategory.DeletedAt != nil {
+		return fmt.Errorf("category is archived, restore it first")
+	}
+
+	newCategoryAttributes := []*model.CategoryAttribute{}
+	newAttributeDefinitionIds := map[string]bool{}
+	for _, attribute := range reqDTO.Body.Attributes {
+		if newAttributeDefinitionIds[attribute.AttributeId] {
+			return fmt.Errorf("attribute id %s is duplicated", attribute.AttributeId)
+		}
+		if _, err := attributeService.attributeDefinitionRepository.GetById(ctx, attribute.AttributeId); err != nil {
+			return fmt.Errorf("attribute id %s not found", attribute.AttributeId)
+		}
+		newAttributeDefinitionIds[attribute.AttributeId] = true
+		newCategoryAttributes = append(newCategoryAttributes, &model.CategoryAttribute{
+			CategoryId:            foundCategory.Id,
+			AttributeDefinitionId: attribute.AttributeId,
+			IsRequired:            attribute.IsRequired,
+			SortOrder:             attribute.SortOrder,
+		})
+	}
+
+	// Attribute leaving the set must not leave values behind on products of category subtree, unless an ancestor still
+	// has it in its set
+	categoryAttributes, err := attributeService.categoryAttributeRepository.GetViewsByCategory(ctx, foundCategory.Id, foundCategory.Path)
+	if err != nil {
+		return fmt.Errorf("query category attributes from postgresql failed: %s", err.Error())
+	}
+	inheritedAttributeDefinitionIds := map[string]bool{}
+	if foundCategory.ParentId != nil {
+		parentCategory, err := attributeService.categoryRepository.GetById(ctx, *foundCategory.ParentId)
+		if err != nil {
+			return fmt.Errorf("id of parent category not found")
+		}
+		parentCategoryAttributes, err := attributeService.categoryAttributeRepository.GetViewsByCategory(ctx, parentCategory.Id, parentCategory.Path)
+		if err != nil {
+			return fmt.Errorf("query category attributes from postgresql failed: %s", err.Error())
+		}
+		for _, parentCategoryAttribute := range parentCategoryAttributes {
+			inheritedAttributeDefinitionIds[parentCategoryAttribute.AttributeDefinitionId] = true
+		}
+	}
+	for _, categoryAttribute := range categoryAttributes {
+		if categoryAttribute.Inherited || newAttributeDefinitionIds[categoryAttribute.AttributeDefinitionId] || inheritedAttributeDefinitionIds[categoryAttribute.AttributeDefinitionId] {
+			continue
+		}
+		valueCount, err := attributeService.productAttributeValueRepository.CountByAttributeDefinitionIdAndCategoryPath(ctx, categoryAttribute.AttributeDefinitionId, foundCategory.Path)
+		if err != nil {
+			return fmt.Errorf("query product attribute values from postgresql failed: %s", err.Error())
+		}
+		if valueCount > 0 {
+			return fmt.Errorf("attribute %s is still used by %d products of category", categoryAttribute.Code, valueCount)
+		}
+	}
+
+	if err := attributeService.categoryAttributeRepository.ReplaceByCategoryId(ctx, foundCategory.Id, newCategoryAttributes); err != nil {
+		return fmt.Errorf("update category attributes on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Republish products having a value of attribute so elasticsearch-service picks up its new name or unit
+func (attributeService *attributeService) syncProductsOfAttributeDefinition(ctx context.Context, attributeDefinitionId string) {
+	products, err := attributeService.productRepository.GetViewsByAttributeDefinitionId(ctx, attributeDefinitionId)
+	if err != nil {
+		log.Printf("Query products of attribute from postgresql failed: %s", err.Error())
+		return
+	}
+
+	for _, product := range products {
+		payload, _ := json.Marshal(product)
+		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+			log.Printf("Pulish event catalog-service.updated-product failed: %s", err.Error())
+		}
+	}
+}
+
+func checkAttributeDefinition(attributeDefinition *model.AttributeDefinition) error {
+	// Facet of product tags is returned under code tags
+	if attributeDefinition.Code == "tags" {
+		return fmt.Errorf("code tags is reserved")
+	}
+	if attributeDefinition.Type == "ENUM" && len(attributeDefinition.Options) == 0 {
+		return fmt.Errorf("options of ENUM attribute must not be empty")
+	}
+	if attributeDefinition.Type != "ENUM" && len(attributeDefinition.Options) != 0 {
+		return fmt.Errorf("options are only for ENUM attribute")
+	}
+	// Commas and pipes separate attributes and values in product search filter
+	for _, option := range attributeDefinition.Options {
+		if strings.ContainsAny(option, ",|") {
+			return fmt.Errorf("option %s of attribute must not contain ',' or '|'", option)
+		}
+	}
+	if attributeDefinition.Type != "NUMBER" && attributeDefinition.Unit != "" {
+		return fmt.Errorf("unit is only for NUMBER attribute")
+	}
+
+	return nil
+}
+
+// Options are trimmed, empty and duplicated options are dropped
+func normalizeAttributeOptions(options []string) []string {
+	normalizedOptions := []string{}
+	seenOptions := map[string]bool{}
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option != "" && !seenOptions[option] {
+			seenOptions[option] = true
+			normalizedOptions = append(normalizedOptions, option)
+		}
+	}
+
+	return normalizedOptions
+}
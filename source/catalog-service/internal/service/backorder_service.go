@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+
+	"github.com/google/uuid"
+)
+
+type backorderService struct {
+	backorderRepository     repository.BackorderRepository
+	productRepository       repository.ProductRepository
+	warehouseRepository     repository.WarehouseRepository
+	stockAllocationStrategy StockAllocationStrategy
+}
+
+type BackorderService interface {
+	GetBackorders(ctx context.Context, reqDTO *dto.GetBackordersRequest) ([]*model.BackorderView, error)
+	allocateBackorderLoop()
+
+	// Order integration (extra features for order-service)
+	GetUnconfirmedAllocatedBackorders(ctx context.Context, reqDTO *dto.GetUnconfirmedAllocatedBackordersRequest) ([]*model.AllocatedBackorderView, error)
+	ConfirmAllocatedBackorder(ctx context.Context, reqDTO *dto.ConfirmAllocatedBackorderRequest) error
+}
+
+func NewBackorderService(backorderRepository repository.BackorderRepository, productRepository repository.ProductRepository, warehouseRepository repository.WarehouseRepository, stockAllocationStrategy StockAllocationStrategy) BackorderService {
+	backorderService := &backorderService{
+		backorderRepository:     backorderRepository,
+		productRepository:       productRepository,
+		warehouseRepository:     warehouseRepository,
+		stockAllocationStrategy: stockAllocationStrategy,
+	}
+
+	go backorderService.allocateBackorderLoop()
+
+	return backorderService
+}
+
+func (backorderService *backorderService) GetBackorders(ctx context.Context, reqDTO *dto.GetBackordersRequest) ([]*model.BackorderView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	backorders, err := backorderService.backorderRepository.GetViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields, reqDTO.ProductId, reqDTO.Status)
+	if err != nil {
+		return nil, fmt.Errorf("query backorders from postgresql failed: %s", err.Error())
+	}
+
+	return backorders, nil
+}
+
+// Allocations are also announced by event catalog-service.allocated-backorder, which is not delivered to order-service
+// while it is down, so order-service pulls allocations it has not confirmed yet
+func (backorderService *backorderService) GetUnconfirmedAllocatedBackorders(ctx context.Context, reqDTO *dto.GetUnconfirmedAllocatedBackordersRequest) ([]*model.AllocatedBackorderView, error) {
+	limit := int(reqDTO.Limit)
+	if limit <= 0 {
+		limit = 100
+	}
+
+	backorders, err := backorderService.backorderRepository.GetUnconfirmedAllocated(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query allocated backorders from postgresql failed: %s", err.Error())
+	}
+
+	allocatedBackorders := make([]*model.AllocatedBackorderView, len(backorders))
+	for i, backorder := range backorders {
+		allocatedBackorders[i] = &model.AllocatedBackorderView{
+			Id:               backorder.Id,
+			InvoiceId:        backorder.InvoiceId,
+			ProductId:        backorder.ProductId,
+			Quantity:         backorder.Quantity,
+			StockAllocations: backorder.StockAllocations,
+		}
+	}
+
+	return allocatedBackorders, nil
+}
+
+func (backorderService *backorderService) ConfirmAllocatedBackorder(ctx context.Context, reqDTO *dto.ConfirmAllocatedBackorderRequest) error {
+	if err := backorderService.backorderRepository.ConfirmById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("confirm backorder on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Allocate pending backorders when stock of their product is replenished, whatever the stock movement was (receipt,
+// transfer, cancelled invoice, reconciliation, ...). Products with pending backorders are also checked on start so that
+// stock replenished while catalog-service was down is not missed.
+func (backorderService *backorderService) allocateBackorderLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.updated-product")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	if productIds, err := backorderService.backorderRepository.GetPendingProductIds(context.Background()); err != nil {
+		log.Printf("Query products with pending backorders from postgresql failed: %s", err.Error())
+	} else {
+		for _, productId := range productIds {
+			backorderService.allocatePendingBackorders(context.Background(), productId)
+		}
+	}
+
+	for msg := range ch {
+		var updatedProduct struct {
+			Id    string `json:"id"`
+			Stock int32  `json:"stock"`
+		}
+		if err := json.Unmarshal([]byte(msg.Payload), &updatedProduct); err != nil {
+			log.Printf("Parse payload from event catalog-service.updated-product failed: %s", err.Error())
+			continue
+		}
+		if updatedProduct.Stock <= 0 {
+			continue
+		}
+
+		backorderService.allocatePendingBackorders(context.Background(), updatedProduct.Id)
+	}
+}
+
+// Backorders are allocated whole and first in first out, allocation stops at the first one which stock cannot cover so that
+// later (smaller) backorders do not overtake it
+func (backorderService *backorderService) allocatePendingBackorders(ctx context.Context, productId string) {
+	backorders, err := backorderService.backorderRepository.GetPendingByProductId(ctx, productId)
+	if err != nil {
+		log.Printf("Query pending backorders of product %s from postgresql failed: %s", productId, err.Error())
+		return
+	}
+	if len(backorders) == 0 {
+		return
+	}
+
+	warehouseStocks, err := backorderService.warehouseRepository.GetActiveStockViewsByListProductId(ctx, []string{productId})
+	if err != nil {
+		log.Printf("Query warehouse stocks of product %s from postgresql failed: %s", productId, err.Error())
+		return
+	}
+
+	allocated := false
+	for _, backorder := range backorders {
+		var shippingLocation *dto.Location
+		if backorder.ShippingLatitude != nil && backorder.ShippingLongitude != nil {
+			shippingLocation = &dto.Location{
+				Latitude:  *backorder.ShippingLatitude,
+				Longitude: *backorder.ShippingLongitude,
+			}
+		}
+		stockAllocations, err := backorderService.stockAllocationStrategy.Allocate(productId, backorder.Quantity, warehouseStocks, shippingLocation)
+		if err != nil {
+			break
+		}
+
+		backorder.StockAllocations = make([]*model.AllocatedBackorderStock, len(stockAllocations))
+		newStockMovements := make([]*model.StockMovement, len(stockAllocations))
+		for i, stockAllocation := range stockAllocations {
+			backorder.StockAllocations[i] = &model.AllocatedBackorderStock{
+				WarehouseId: stockAllocation.WarehouseId,
+				Quantity:    stockAllocation.Quantity,
+			}
+			newStockMovements[i] = &model.StockMovement{
+				Id:          uuid.New().String(),
+				ProductId:   productId,
+				WarehouseId: stockAllocation.WarehouseId,
+				Quantity:    -stockAllocation.Quantity,
+				Reason:      "SALE",
+				InvoiceId:   &backorder.InvoiceId,
+				ActorId:     backorder.ActorId,
+				Note:        "Backorder allocation",
+			}
+		}
+		if err := backorderService.backorderRepository.Allocate(ctx, backorder, newStockMovements); err != nil {
+			// Another instance allocated it or stock was taken meanwhile, next stock update tries again
+			if !errors.Is(err, repository.ErrBackorderNotPending) && !errors.Is(err, repository.ErrNotEnoughStock) {
+				log.Printf("Allocate backorder %s on postgresql failed: %s", backorder.Id, err.Error())
+			}
+			break
+		}
+		allocated = true
+
+		allocatedBackorder := &model.AllocatedBackorderView{
+			Id:               backorder.Id,
+			InvoiceId:        backorder.InvoiceId,
+			ProductId:        productId,
+			Quantity:         backorder.Quantity,
+			StockAllocations: backorder.StockAllocations,
+		}
+		for _, stockAllocation := range stockAllocations {
+			// Stock taken by this backorder is no longer available to next ones
+			for _, warehouseStock := range warehouseStocks {
+				if warehouseStock.WarehouseId == stockAllocation.WarehouseId {
+					warehouseStock.Stock -= stockAllocation.Quantity
+				}
+			}
+		}
+		// Order-service pulls the allocation later if this event is lost
+		payload, _ := json.Marshal(allocatedBackorder)
+		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.allocated-backorder", payload).Err(); err != nil {
+			log.Printf("Pulish event catalog-service.allocated-backorder of backorder %s failed: %s", backorder.Id, err.Error())
+		}
+	}
+
+	if allocated {
+		updatedProductView, err := backorderService.productRepository.GetViewById(ctx, productId)
+		if err != nil {
+			log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", productId, err.Error())
+			return
+		}
+		payload, _ := json.Marshal(updatedProductView)
+		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+			log.Printf("Pulish event catalog-service.updated-product failed: %s", err.Error())
+		}
+	}
+}
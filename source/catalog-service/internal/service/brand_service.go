@@ -13,27 +13,45 @@ import (
 )
 
 type brandService struct {
-	brandRepository repository.BrandRepository
+	brandRepository        repository.BrandRepository
+	productRepository      repository.ProductRepository
+	slugRedirectRepository repository.SlugRedirectRepository
 }
 
 type BrandService interface {
 	GetAllBrands(ctx context.Context, reqDTO *dto.GetAllBrandsRequest) ([]*model.BrandView, error)
+	GetArchivedBrands(ctx context.Context, reqDTO *dto.GetArchivedBrandsRequest) ([]*model.BrandView, error)
 	GetBrandById(ctx context.Context, reqDTO *dto.GetBrandByIdRequest) (*model.BrandView, error)
+	GetBrandBySlug(ctx context.Context, reqDTO *dto.GetBrandBySlugRequest) (*model.BrandView, error)
 	CreateBrand(ctx context.Context, reqDTO *dto.CreateBrandRequest) error
 	UpdateBrandById(ctx context.Context, reqDTO *dto.UpdateBrandByIdRequest) error
 	DeleteBrandById(ctx context.Context, reqDTO *dto.DeleteBrandByIdRequest) error
+	RestoreBrandById(ctx context.Context, reqDTO *dto.RestoreBrandByIdRequest) error
 }
 
-func NewBrandService(brandRepository repository.BrandRepository) BrandService {
+func NewBrandService(brandRepository repository.BrandRepository, productRepository repository.ProductRepository, slugRedirectRepository repository.SlugRedirectRepository) BrandService {
 	return &brandService{
-		brandRepository: brandRepository,
+		brandRepository:        brandRepository,
+		productRepository:      productRepository,
+		slugRedirectRepository: slugRedirectRepository,
 	}
 }
 
 func (brandService *brandService) GetAllBrands(ctx context.Context, redDTO *dto.GetAllBrandsRequest) ([]*model.BrandView, error) {
 	sortFields := utils.ParseSorter(redDTO.SortBy)
 
-	brands, err := brandService.brandRepository.GetAllViews(ctx, sortFields)
+	brands, err := brandService.brandRepository.GetAllViews(ctx, sortFields, "PUBLISHED")
+	if err != nil {
+		return nil, fmt.Errorf("query brands from postgresql failed: %s", err.Error())
+	}
+
+	return brands, nil
+}
+
+func (brandService *brandService) GetArchivedBrands(ctx context.Context, reqDTO *dto.GetArchivedBrandsRequest) ([]*model.BrandView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	brands, err := brandService.brandRepository.GetAllViews(ctx, sortFields, "ARCHIVED")
 	if err != nil {
 		return nil, fmt.Errorf("query brands from postgresql failed: %s", err.Error())
 	}
@@ -46,6 +64,28 @@ func (brandService *brandService) GetBrandById(ctx context.Context, reqDTO *dto.
 	if err != nil {
 		return nil, fmt.Errorf("id of brand is not valid: %s", err.Error())
 	}
+	if foundBrand.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("id of brand is not valid")
+	}
+
+	return foundBrand, nil
+}
+
+// Former slug resolves to the brand it belonged to, caller tells it apart by the current slug of returned brand
+func (brandService *brandService) GetBrandBySlug(ctx context.Context, reqDTO *dto.GetBrandBySlugRequest) (*model.BrandView, error) {
+	foundBrand, err := brandService.brandRepository.GetViewBySlug(ctx, reqDTO.Slug)
+	if err != nil {
+		foundSlugRedirect, err := brandService.slugRedirectRepository.GetByEntityTypeAndSlug(ctx, "BRAND", reqDTO.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("slug of brand is not valid: %s", err.Error())
+		}
+		if foundBrand, err = brandService.brandRepository.GetViewById(ctx, foundSlugRedirect.EntityId); err != nil {
+			return nil, fmt.Errorf("slug of brand is not valid: %s", err.Error())
+		}
+	}
+	if foundBrand.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("slug of brand is not valid")
+	}
 
 	return foundBrand, nil
 }
@@ -58,11 +98,21 @@ func (brandService *brandService) CreateBrand(ctx context.Context, reqDTO *dto.C
 	newBrand := model.Brand{
 		Id:          uuid.New().String(),
 		Name:        reqDTO.Body.Name,
+		Slug:        reqDTO.Body.Slug,
 		Description: reqDTO.Body.Description,
+		Status:      reqDTO.Body.Status,
+	}
+	if newBrand.Slug == "" {
+		newBrand.Slug = generateUniqueSlug(newBrand.Name, newBrand.Id[:8], brandService.isSlugTaken(ctx, newBrand.Id))
+	} else if brandService.isSlugTaken(ctx, newBrand.Id)(newBrand.Slug) {
+		return fmt.Errorf("slug of brand is already exists")
 	}
 	if err := brandService.brandRepository.Create(ctx, &newBrand); err != nil {
 		return fmt.Errorf("insert brand to postgresql failed: %s", err.Error())
 	}
+	if err := recordSlugChange(ctx, brandService.slugRedirectRepository, "BRAND", newBrand.Id, "", newBrand.Slug); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -72,30 +122,66 @@ func (brandService *brandService) UpdateBrandById(ctx context.Context, reqDTO *d
 	if err != nil {
 		return fmt.Errorf("id of brand is not valid: %s", err.Error())
 	}
+	if foundBrand.DeletedAt != nil {
+		return fmt.Errorf("brand is archived, restore it first")
+	}
 
+	oldSlug := foundBrand.Slug
+	if reqDTO.Body.Slug != nil && *reqDTO.Body.Slug != foundBrand.Slug {
+		if brandService.isSlugTaken(ctx, foundBrand.Id)(*reqDTO.Body.Slug) {
+			return fmt.Errorf("slug of brand is already exists")
+		}
+		foundBrand.Slug = *reqDTO.Body.Slug
+	}
 	if reqDTO.Body.Name != nil {
 		if _, err := brandService.brandRepository.GetByName(ctx, *reqDTO.Body.Name); err == nil {
 			return fmt.Errorf("name of brand is already exists")
 		}
 		foundBrand.Name = *reqDTO.Body.Name
+		// Slug follows name unless it is edited explicitly
+		if reqDTO.Body.Slug == nil {
+			foundBrand.Slug = generateUniqueSlug(foundBrand.Name, foundBrand.Id[:8], brandService.isSlugTaken(ctx, foundBrand.Id))
+		}
 	}
 	if reqDTO.Body.Description != nil {
 		foundBrand.Description = *reqDTO.Body.Description
 	}
 	timeUpdate := time.Now().UTC()
+	if reqDTO.Body.Status != nil && *reqDTO.Body.Status != foundBrand.Status {
+		if *reqDTO.Body.Status != "PUBLISHED" {
+			if err := brandService.checkNoPublishedProducts(ctx, foundBrand.Id); err != nil {
+				return err
+			}
+		}
+		foundBrand.Status = *reqDTO.Body.Status
+		if foundBrand.Status == "ARCHIVED" {
+			foundBrand.DeletedAt = &timeUpdate
+		}
+	}
 	foundBrand.UpdatedAt = &timeUpdate
 
 	if err := brandService.brandRepository.Update(ctx, foundBrand); err != nil {
 		return fmt.Errorf("update brand on postgresql failed: %s", err.Error())
 	}
+	if err := recordSlugChange(ctx, brandService.slugRedirectRepository, "BRAND", foundBrand.Id, oldSlug, foundBrand.Slug); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func (brandService *brandService) DeleteBrandById(ctx context.Context, reqDTO *dto.DeleteBrandByIdRequest) error {
-	if _, err := brandService.brandRepository.GetById(ctx, reqDTO.Id); err != nil {
+	foundBrand, err := brandService.brandRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
 		return fmt.Errorf("id of brand is not valid")
 	}
+	if foundBrand.DeletedAt != nil {
+		return fmt.Errorf("brand is already archived")
+	}
+
+	if err := brandService.checkNoPublishedProducts(ctx, foundBrand.Id); err != nil {
+		return err
+	}
 
 	if err := brandService.brandRepository.DeleteById(ctx, reqDTO.Id); err != nil {
 		return fmt.Errorf("delete brand from postgresql failed: %s", err.Error())
@@ -103,3 +189,45 @@ func (brandService *brandService) DeleteBrandById(ctx context.Context, reqDTO *d
 
 	return nil
 }
+
+// Restored brand comes back as draft so that it is reviewed before being published again
+func (brandService *brandService) RestoreBrandById(ctx context.Context, reqDTO *dto.RestoreBrandByIdRequest) error {
+	foundBrand, err := brandService.brandRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of brand is not valid")
+	}
+	if foundBrand.Status != "ARCHIVED" {
+		return fmt.Errorf("brand is not archived")
+	}
+
+	timeUpdate := time.Now().UTC()
+	foundBrand.Status = "DRAFT"
+	foundBrand.DeletedAt = nil
+	foundBrand.UpdatedAt = &timeUpdate
+
+	if err := brandService.brandRepository.Update(ctx, foundBrand); err != nil {
+		return fmt.Errorf("update brand on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Brand is hidden or archived only when no published product still belongs to it
+func (brandService *brandService) checkNoPublishedProducts(ctx context.Context, brandId string) error {
+	productCount, err := brandService.productRepository.CountPublishedByBrandId(ctx, brandId)
+	if err != nil {
+		return fmt.Errorf("query products from postgresql failed: %s", err.Error())
+	}
+	if productCount > 0 {
+		return fmt.Errorf("brand still has published products")
+	}
+
+	return nil
+}
+
+func (brandService *brandService) isSlugTaken(ctx context.Context, brandId string) func(slug string) bool {
+	return func(slug string) bool {
+		foundBrand, err := brandService.brandRepository.GetBySlug(ctx, slug)
+		return err == nil && foundBrand.Id != brandId
+	}
+}
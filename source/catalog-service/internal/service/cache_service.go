@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+)
+
+type cacheService struct {
+}
+
+type CacheService interface {
+	GetCacheStats(ctx context.Context) ([]*model.CacheStatsView, error)
+	invalidateProductCacheLoop()
+}
+
+func NewCacheService() CacheService {
+	cacheService := &cacheService{}
+
+	go cacheService.invalidateProductCacheLoop()
+
+	return cacheService
+}
+
+func (cacheService *cacheService) GetCacheStats(ctx context.Context) ([]*model.CacheStatsView, error) {
+	viewCaches := []*infrastructure.ViewCache{infrastructure.ProductViewCache, infrastructure.BrandViewCache, infrastructure.CategoryViewCache}
+
+	cacheStatsViews := make([]*model.CacheStatsView, len(viewCaches))
+	for i, viewCache := range viewCaches {
+		stats := viewCache.Stats()
+		cacheStatsViews[i] = &model.CacheStatsView{
+			Name:       stats.Name,
+			TTLSeconds: int64(stats.TTL.Seconds()),
+			Hits:       stats.Hits,
+			Misses:     stats.Misses,
+			Loads:      stats.Loads,
+			Errors:     stats.Errors,
+		}
+		if stats.Hits+stats.Misses != 0 {
+			cacheStatsViews[i].HitRatio = float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+		}
+	}
+
+	return cacheStatsViews, nil
+}
+
+// Product views are written through many repositories besides product repository (stock, promotions, reviews, images...),
+// every one of them publishes product event after write, so cached view of product is dropped on its events
+func (cacheService *cacheService) invalidateProductCacheLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.updated-product", "catalog-service.deleted-product")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		// Payload of deleted product event is its id
+		id := msg.Payload
+		if msg.Channel == "catalog-service.updated-product" {
+			var productEvent struct {
+				Id string `json:"id"`
+			}
+			if err := json.Unmarshal([]byte(msg.Payload), &productEvent); err != nil {
+				log.Printf("Parse payload from event %s failed: %s", msg.Channel, err.Error())
+				continue
+			}
+			id = productEvent.Id
+		}
+
+		infrastructure.ProductViewCache.Invalidate(context.Background(), id)
+	}
+}
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"strings"
+	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/dto"
 	"thanhldt060802/internal/model"
 	"thanhldt060802/internal/repository"
@@ -13,27 +17,36 @@ import (
 )
 
 type categoryService struct {
-	categoryRepository repository.CategoryRepository
+	categoryRepository     repository.CategoryRepository
+	productRepository      repository.ProductRepository
+	slugRedirectRepository repository.SlugRedirectRepository
 }
 
 type CategoryService interface {
 	GetAllCategories(ctx context.Context, reqDTO *dto.GetAllCategoriesRequest) ([]*model.CategoryView, error)
+	GetArchivedCategories(ctx context.Context, reqDTO *dto.GetArchivedCategoriesRequest) ([]*model.CategoryView, error)
+	GetCategoryTree(ctx context.Context) ([]*model.CategoryTreeView, error)
 	GetCategoryById(ctx context.Context, reqDTO *dto.GetCategoryByIdRequest) (*model.CategoryView, error)
+	GetCategoryBySlug(ctx context.Context, reqDTO *dto.GetCategoryBySlugRequest) (*model.CategoryView, error)
 	CreateCategory(ctx context.Context, reqDTO *dto.CreateCategoryRequest) error
 	UpdateCategoryById(ctx context.Context, reqDTO *dto.UpdateCategoryByIdRequest) error
+	MoveCategoryById(ctx context.Context, reqDTO *dto.MoveCategoryByIdRequest) error
 	DeleteCategoryById(ctx context.Context, reqDTO *dto.DeleteCategoryByIdRequest) error
+	RestoreCategoryById(ctx context.Context, reqDTO *dto.RestoreCategoryByIdRequest) error
 }
 
-func NewCategoryService(categoryRepository repository.CategoryRepository) CategoryService {
+func NewCategoryService(categoryRepository repository.CategoryRepository, productRepository repository.ProductRepository, slugRedirectRepository repository.SlugRedirectRepository) CategoryService {
 	return &categoryService{
-		categoryRepository: categoryRepository,
+		categoryRepository:     categoryRepository,
+		productRepository:      productRepository,
+		slugRedirectRepository: slugRedirectRepository,
 	}
 }
 
 func (categoryService *categoryService) GetAllCategories(ctx context.Context, reqDTO *dto.GetAllCategoriesRequest) ([]*model.CategoryView, error) {
 	sortFields := utils.ParseSorter(reqDTO.SortBy)
 
-	categories, err := categoryService.categoryRepository.GetAllViews(ctx, sortFields)
+	categories, err := categoryService.categoryRepository.GetAllViews(ctx, sortFields, "PUBLISHED")
 	if err != nil {
 		return nil, fmt.Errorf("query categories from postgresql failed: %s", err.Error())
 	}
@@ -41,11 +54,75 @@ func (categoryService *categoryService) GetAllCategories(ctx context.Context, re
 	return categories, nil
 }
 
+func (categoryService *categoryService) GetArchivedCategories(ctx context.Context, reqDTO *dto.GetArchivedCategoriesRequest) ([]*model.CategoryView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	categories, err := categoryService.categoryRepository.GetAllViews(ctx, sortFields, "ARCHIVED")
+	if err != nil {
+		return nil, fmt.Errorf("query categories from postgresql failed: %s", err.Error())
+	}
+
+	return categories, nil
+}
+
+func (categoryService *categoryService) GetCategoryTree(ctx context.Context) ([]*model.CategoryTreeView, error) {
+	categories, err := categoryService.categoryRepository.GetAllViews(ctx, []*utils.SortField{
+		{Field: "depth", Direction: "ASC"},
+		{Field: "sort_order", Direction: "ASC"},
+		{Field: "name", Direction: "ASC"},
+	}, "PUBLISHED")
+	if err != nil {
+		return nil, fmt.Errorf("query categories from postgresql failed: %s", err.Error())
+	}
+
+	// Parents always come before their children since categories are ordered by depth, children of an unpublished
+	// category are left out with it
+	roots := []*model.CategoryTreeView{}
+	nodeMap := map[string]*model.CategoryTreeView{}
+	for _, category := range categories {
+		node := &model.CategoryTreeView{
+			CategoryView: *category,
+			Children:     []*model.CategoryTreeView{},
+		}
+		nodeMap[category.Id] = node
+
+		if category.ParentId == nil {
+			roots = append(roots, node)
+		} else if parent, ok := nodeMap[*category.ParentId]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	return roots, nil
+}
+
 func (categoryService *categoryService) GetCategoryById(ctx context.Context, reqDTO *dto.GetCategoryByIdRequest) (*model.CategoryView, error) {
 	foundCategory, err := categoryService.categoryRepository.GetViewById(ctx, reqDTO.Id)
 	if err != nil {
 		return nil, fmt.Errorf("id of category is not valid: %s", err.Error())
 	}
+	if foundCategory.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("id of category is not valid")
+	}
+
+	return foundCategory, nil
+}
+
+// Former slug resolves to the category it belonged to, caller tells it apart by the current slug of returned category
+func (categoryService *categoryService) GetCategoryBySlug(ctx context.Context, reqDTO *dto.GetCategoryBySlugRequest) (*model.CategoryView, error) {
+	foundCategory, err := categoryService.categoryRepository.GetViewBySlug(ctx, reqDTO.Slug)
+	if err != nil {
+		foundSlugRedirect, err := categoryService.slugRedirectRepository.GetByEntityTypeAndSlug(ctx, "CATEGORY", reqDTO.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("slug of category is not valid: %s", err.Error())
+		}
+		if foundCategory, err = categoryService.categoryRepository.GetViewById(ctx, foundSlugRedirect.EntityId); err != nil {
+			return nil, fmt.Errorf("slug of category is not valid: %s", err.Error())
+		}
+	}
+	if foundCategory.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("slug of category is not valid")
+	}
 
 	return foundCategory, nil
 }
@@ -56,12 +133,35 @@ func (categoryService *categoryService) CreateCategory(ctx context.Context, reqD
 	}
 
 	newCategory := model.Category{
-		Id:   uuid.New().String(),
-		Name: reqDTO.Body.Name,
+		Id:                uuid.New().String(),
+		Name:              reqDTO.Body.Name,
+		Slug:              reqDTO.Body.Slug,
+		SortOrder:         reqDTO.Body.SortOrder,
+		Status:            reqDTO.Body.Status,
+		LowStockThreshold: reqDTO.Body.LowStockThreshold,
 	}
+	if newCategory.Slug == "" {
+		newCategory.Slug = generateUniqueSlug(newCategory.Name, newCategory.Id[:8], categoryService.isSlugTaken(ctx, newCategory.Id))
+	} else if categoryService.isSlugTaken(ctx, newCategory.Id)(newCategory.Slug) {
+		return fmt.Errorf("slug of category is already exists")
+	}
+	newCategory.Path = "/" + newCategory.Id + "/"
+	if reqDTO.Body.ParentId != "" {
+		parentCategory, err := categoryService.categoryRepository.GetById(ctx, reqDTO.Body.ParentId)
+		if err != nil || parentCategory.DeletedAt != nil {
+			return fmt.Errorf("id of parent category not found")
+		}
+		newCategory.ParentId = &parentCategory.Id
+		newCategory.Path = parentCategory.Path + newCategory.Id + "/"
+		newCategory.Depth = parentCategory.Depth + 1
+	}
+
 	if err := categoryService.categoryRepository.Create(ctx, &newCategory); err != nil {
 		return fmt.Errorf("insert category to postgresql failed: %s", err.Error())
 	}
+	if err := recordSlugChange(ctx, categoryService.slugRedirectRepository, "CATEGORY", newCategory.Id, "", newCategory.Slug); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -71,27 +171,135 @@ func (categoryService *categoryService) UpdateCategoryById(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("id of category is not valid: %s", err.Error())
 	}
+	if foundCategory.DeletedAt != nil {
+		return fmt.Errorf("category is archived, restore it first")
+	}
 
+	breadcrumbChanged := false
+	oldSlug := foundCategory.Slug
+	if reqDTO.Body.Slug != nil && *reqDTO.Body.Slug != foundCategory.Slug {
+		if categoryService.isSlugTaken(ctx, foundCategory.Id)(*reqDTO.Body.Slug) {
+			return fmt.Errorf("slug of category is already exists")
+		}
+		foundCategory.Slug = *reqDTO.Body.Slug
+		breadcrumbChanged = true
+	}
 	if reqDTO.Body.Name != nil {
 		if _, err := categoryService.categoryRepository.GetByName(ctx, *reqDTO.Body.Name); err == nil {
 			return fmt.Errorf("name of category is already exists")
 		}
 		foundCategory.Name = *reqDTO.Body.Name
+		// Slug follows name unless it is edited explicitly
+		if reqDTO.Body.Slug == nil {
+			foundCategory.Slug = generateUniqueSlug(foundCategory.Name, foundCategory.Id[:8], categoryService.isSlugTaken(ctx, foundCategory.Id))
+		}
+		breadcrumbChanged = true
+	}
+	if reqDTO.Body.SortOrder != nil {
+		foundCategory.SortOrder = *reqDTO.Body.SortOrder
+	}
+	// Products of category subtree are evaluated again against their new threshold
+	lowStockThresholdChanged := false
+	if reqDTO.Body.LowStockThreshold != nil {
+		if *reqDTO.Body.LowStockThreshold < 0 {
+			foundCategory.LowStockThreshold = nil
+		} else {
+			foundCategory.LowStockThreshold = reqDTO.Body.LowStockThreshold
+		}
+		lowStockThresholdChanged = true
 	}
 	timeUpdate := time.Now().UTC()
+	if reqDTO.Body.Status != nil && *reqDTO.Body.Status != foundCategory.Status {
+		if *reqDTO.Body.Status != "PUBLISHED" {
+			if err := categoryService.checkNoPublishedProducts(ctx, foundCategory.Path); err != nil {
+				return err
+			}
+		}
+		if *reqDTO.Body.Status == "ARCHIVED" {
+			if err := categoryService.checkNoUnarchivedChildren(ctx, foundCategory.Id); err != nil {
+				return err
+			}
+			foundCategory.DeletedAt = &timeUpdate
+		}
+		foundCategory.Status = *reqDTO.Body.Status
+	}
 	foundCategory.UpdatedAt = &timeUpdate
 
 	if err := categoryService.categoryRepository.Update(ctx, foundCategory); err != nil {
 		return fmt.Errorf("update category on postgresql failed: %s", err.Error())
 	}
+	if err := recordSlugChange(ctx, categoryService.slugRedirectRepository, "CATEGORY", foundCategory.Id, oldSlug, foundCategory.Slug); err != nil {
+		return err
+	}
+
+	if breadcrumbChanged || lowStockThresholdChanged {
+		categoryService.syncProductsOfCategory(ctx, foundCategory.Path)
+	}
+
+	return nil
+}
+
+func (categoryService *categoryService) MoveCategoryById(ctx context.Context, reqDTO *dto.MoveCategoryByIdRequest) error {
+	foundCategory, err := categoryService.categoryRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of category is not valid: %s", err.Error())
+	}
+	if foundCategory.DeletedAt != nil {
+		return fmt.Errorf("category is archived, restore it first")
+	}
+
+	oldPath := foundCategory.Path
+	oldDepth := foundCategory.Depth
+
+	if reqDTO.Body.ParentId == "" {
+		foundCategory.ParentId = nil
+		foundCategory.Path = "/" + foundCategory.Id + "/"
+		foundCategory.Depth = 0
+	} else {
+		parentCategory, err := categoryService.categoryRepository.GetById(ctx, reqDTO.Body.ParentId)
+		if err != nil || parentCategory.DeletedAt != nil {
+			return fmt.Errorf("id of parent category not found")
+		}
+		// Parent must not be the category itself or one of its descendants
+		if strings.HasPrefix(parentCategory.Path, oldPath) {
+			return fmt.Errorf("category cannot be moved under itself or its descendants")
+		}
+		foundCategory.ParentId = &parentCategory.Id
+		foundCategory.Path = parentCategory.Path + foundCategory.Id + "/"
+		foundCategory.Depth = parentCategory.Depth + 1
+	}
+	if reqDTO.Body.SortOrder != nil {
+		foundCategory.SortOrder = *reqDTO.Body.SortOrder
+	}
+	timeUpdate := time.Now().UTC()
+	foundCategory.UpdatedAt = &timeUpdate
+
+	if err := categoryService.categoryRepository.Move(ctx, foundCategory, oldPath, oldDepth); err != nil {
+		return fmt.Errorf("move category on postgresql failed: %s", err.Error())
+	}
+
+	if foundCategory.Path != oldPath {
+		categoryService.syncProductsOfCategory(ctx, foundCategory.Path)
+	}
 
 	return nil
 }
 
 func (categoryService *categoryService) DeleteCategoryById(ctx context.Context, reqDTO *dto.DeleteCategoryByIdRequest) error {
-	if _, err := categoryService.categoryRepository.GetById(ctx, reqDTO.Id); err != nil {
+	foundCategory, err := categoryService.categoryRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
 		return fmt.Errorf("id of category is not valid")
 	}
+	if foundCategory.DeletedAt != nil {
+		return fmt.Errorf("category is already archived")
+	}
+
+	if err := categoryService.checkNoUnarchivedChildren(ctx, foundCategory.Id); err != nil {
+		return err
+	}
+	if err := categoryService.checkNoPublishedProducts(ctx, foundCategory.Path); err != nil {
+		return err
+	}
 
 	if err := categoryService.categoryRepository.DeleteById(ctx, reqDTO.Id); err != nil {
 		return fmt.Errorf("delete category from postgresql failed: %s", err.Error())
@@ -99,3 +307,82 @@ func (categoryService *categoryService) DeleteCategoryById(ctx context.Context,
 
 	return nil
 }
+
+// Restored category comes back as draft so that it is reviewed before being published again
+func (categoryService *categoryService) RestoreCategoryById(ctx context.Context, reqDTO *dto.RestoreCategoryByIdRequest) error {
+	foundCategory, err := categoryService.categoryRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of category is not valid")
+	}
+	if foundCategory.Status != "ARCHIVED" {
+		return fmt.Errorf("category is not archived")
+	}
+	if foundCategory.ParentId != nil {
+		parentCategory, err := categoryService.categoryRepository.GetById(ctx, *foundCategory.ParentId)
+		if err != nil {
+			return fmt.Errorf("id of parent category not found")
+		}
+		if parentCategory.DeletedAt != nil {
+			return fmt.Errorf("parent category is archived, restore it first")
+		}
+	}
+
+	timeUpdate := time.Now().UTC()
+	foundCategory.Status = "DRAFT"
+	foundCategory.DeletedAt = nil
+	foundCategory.UpdatedAt = &timeUpdate
+
+	if err := categoryService.categoryRepository.Update(ctx, foundCategory); err != nil {
+		return fmt.Errorf("update category on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Category is hidden or archived only when no published product still belongs to its subtree
+func (categoryService *categoryService) checkNoPublishedProducts(ctx context.Context, categoryPath string) error {
+	productCount, err := categoryService.productRepository.CountPublishedByCategoryPath(ctx, categoryPath)
+	if err != nil {
+		return fmt.Errorf("query products from postgresql failed: %s", err.Error())
+	}
+	if productCount > 0 {
+		return fmt.Errorf("category still has published products")
+	}
+
+	return nil
+}
+
+func (categoryService *categoryService) checkNoUnarchivedChildren(ctx context.Context, categoryId string) error {
+	childCount, err := categoryService.categoryRepository.CountUnarchivedByParentId(ctx, categoryId)
+	if err != nil {
+		return fmt.Errorf("query categories from postgresql failed: %s", err.Error())
+	}
+	if childCount > 0 {
+		return fmt.Errorf("category still has child categories")
+	}
+
+	return nil
+}
+
+func (categoryService *categoryService) isSlugTaken(ctx context.Context, categoryId string) func(slug string) bool {
+	return func(slug string) bool {
+		foundCategory, err := categoryService.categoryRepository.GetBySlug(ctx, slug)
+		return err == nil && foundCategory.Id != categoryId
+	}
+}
+
+// Republish products of category subtree so elasticsearch-service picks up their new breadcrumb and low stock is evaluated again
+func (categoryService *categoryService) syncProductsOfCategory(ctx context.Context, categoryPath string) {
+	products, err := categoryService.productRepository.GetViewsByCategoryPath(ctx, categoryPath)
+	if err != nil {
+		log.Printf("Query products of category from postgresql failed: %s", err.Error())
+		return
+	}
+
+	for _, product := range products {
+		payload, _ := json.Marshal(product)
+		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+			log.Printf("Pulish event catalog-service.updated-product failed: %s", err.Error())
+		}
+	}
+}
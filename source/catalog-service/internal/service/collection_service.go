@@ -0,0 +1,444 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"strconv"
+	"thanhldt060802/config"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type collectionService struct {
+	collectionRepository repository.CollectionRepository
+	productRepository    repository.ProductRepository
+	categoryRepository   repository.CategoryRepository
+	brandRepository      repository.BrandRepository
+}
+
+type CollectionService interface {
+	GetCollections(ctx context.Context, reqDTO *dto.GetCollectionsRequest) ([]*model.CollectionView, error)
+	GetCollectionById(ctx context.Context, reqDTO *dto.GetCollectionByIdRequest) (*model.CollectionView, error)
+	GetCollectionProducts(ctx context.Context, reqDTO *dto.GetCollectionProductsRequest) ([]*model.ProductView, error)
+	CreateCollection(ctx context.Context, reqDTO *dto.CreateCollectionRequest) (*model.CollectionView, error)
+	UpdateCollectionById(ctx context.Context, reqDTO *dto.UpdateCollectionByIdRequest) error
+	UpdateCollectionProducts(ctx context.Context, reqDTO *dto.UpdateCollectionProductsRequest) error
+	UploadCollectionBanner(ctx context.Context, reqDTO *dto.UploadCollectionBannerRequest) (*model.CollectionView, error)
+	DeleteCollectionBanner(ctx context.Context, reqDTO *dto.DeleteCollectionBannerRequest) error
+	DeleteCollectionById(ctx context.Context, reqDTO *dto.DeleteCollectionByIdRequest) error
+}
+
+func NewCollectionService(collectionRepository repository.CollectionRepository, productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, brandRepository repository.BrandRepository) CollectionService {
+	return &collectionService{
+		collectionRepository: collectionRepository,
+		productRepository:    productRepository,
+		categoryRepository:   categoryRepository,
+		brandRepository:      brandRepository,
+	}
+}
+
+// Customers only see live collections, admin and staff see every collection and may filter by status
+func (collectionService *collectionService) GetCollections(ctx context.Context, reqDTO *dto.GetCollectionsRequest) ([]*model.CollectionView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	status := ""
+	onlyLive := true
+	if isBackOfficeContext(ctx) {
+		status = reqDTO.Status
+		onlyLive = false
+	}
+
+	collections, err := collectionService.collectionRepository.GetViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields, status, onlyLive)
+	if err != nil {
+		return nil, fmt.Errorf("query collections from postgresql failed: %s", err.Error())
+	}
+
+	return collections, nil
+}
+
+func (collectionService *collectionService) GetCollectionById(ctx context.Context, reqDTO *dto.GetCollectionByIdRequest) (*model.CollectionView, error) {
+	foundCollection, err := collectionService.collectionRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of collection is not valid: %s", err.Error())
+	}
+	if !foundCollection.IsLive && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("id of collection is not valid")
+	}
+
+	return foundCollection, nil
+}
+
+// Hand-picked products come first in collection order, then products matching rule in order of rule.
+// Products are read from postgresql so that stock and price are live, unpublished products are skipped.
+func (collectionService *collectionService) GetCollectionProducts(ctx context.Context, reqDTO *dto.GetCollectionProductsRequest) ([]*model.ProductView, error) {
+	foundCollection, err := collectionService.collectionRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of collection is not valid: %s", err.Error())
+	}
+	if !foundCollection.IsLive && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("id of collection is not valid")
+	}
+
+	productIds, err := collectionService.collectionRepository.GetProductIdsById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query products of collection from postgresql failed: %s", err.Error())
+	}
+
+	if foundCollection.Rule != nil {
+		ruleProductIds, err := collectionService.getRuleProductIds(ctx, foundCollection.Rule)
+		if err != nil {
+			// Hand-picked products are still worth showing when search is down
+			log.Printf("Get products matching rule of collection %s failed: %s", reqDTO.Id, err.Error())
+		}
+		productIdSet := map[string]bool{}
+		for _, productId := range productIds {
+			productIdSet[productId] = true
+		}
+		for _, productId := range ruleProductIds {
+			if !productIdSet[productId] {
+				productIdSet[productId] = true
+				productIds = append(productIds, productId)
+			}
+		}
+	}
+
+	if len(productIds) == 0 {
+		return []*model.ProductView{}, nil
+	}
+
+	products, err := collectionService.productRepository.GetViewsByListId(ctx, productIds)
+	if err != nil {
+		return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
+	}
+	productMap := map[string]*model.ProductView{}
+	for _, product := range products {
+		productMap[product.Id] = product
+	}
+
+	collectionProducts := []*model.ProductView{}
+	for _, productId := range productIds {
+		if product, ok := productMap[productId]; ok && product.Status == "PUBLISHED" {
+			collectionProducts = append(collectionProducts, product)
+		}
+	}
+
+	offset := min(int(reqDTO.Offset), len(collectionProducts))
+	end := min(offset+int(reqDTO.Limit), len(collectionProducts))
+	return collectionProducts[offset:end], nil
+}
+
+func (collectionService *collectionService) CreateCollection(ctx context.Context, reqDTO *dto.CreateCollectionRequest) (*model.CollectionView, error) {
+	if err := checkCollectionSchedule(reqDTO.Body.StartTime, reqDTO.Body.EndTime); err != nil {
+		return nil, err
+	}
+
+	newCollection := model.Collection{
+		Id:          uuid.New().String(),
+		Name:        reqDTO.Body.Name,
+		Description: reqDTO.Body.Description,
+		Status:      reqDTO.Body.Status,
+	}
+	if newCollection.Status == "" {
+		newCollection.Status = "DRAFT"
+	}
+	if reqDTO.Body.StartTime != nil {
+		startTime := reqDTO.Body.StartTime.UTC()
+		newCollection.StartTime = &startTime
+	}
+	if reqDTO.Body.EndTime != nil {
+		endTime := reqDTO.Body.EndTime.UTC()
+		newCollection.EndTime = &endTime
+	}
+	if reqDTO.Body.Rule != nil {
+		rule, err := collectionService.buildCollectionRule(ctx, reqDTO.Body.Rule)
+		if err != nil {
+			return nil, err
+		}
+		newCollection.Rule = rule
+	}
+
+	if err := collectionService.collectionRepository.Create(ctx, &newCollection); err != nil {
+		return nil, fmt.Errorf("insert collection to postgresql failed: %s", err.Error())
+	}
+
+	newCollectionView, err := collectionService.collectionRepository.GetViewById(ctx, newCollection.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query collection from postgresql failed: %s", err.Error())
+	}
+
+	return newCollectionView, nil
+}
+
+func (collectionService *collectionService) UpdateCollectionById(ctx context.Context, reqDTO *dto.UpdateCollectionByIdRequest) error {
+	foundCollection, err := collectionService.collectionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of collection is not valid: %s", err.Error())
+	}
+
+	if reqDTO.Body.RemoveSchedule && (reqDTO.Body.StartTime != nil || reqDTO.Body.EndTime != nil) {
+		return fmt.Errorf("schedule of collection can not be removed and changed at the same time")
+	}
+	if reqDTO.Body.RemoveRule && reqDTO.Body.Rule != nil {
+		return fmt.Errorf("rule of collection can not be removed and changed at the same time")
+	}
+
+	if reqDTO.Body.Name != nil {
+		foundCollection.Name = *reqDTO.Body.Name
+	}
+	if reqDTO.Body.Description != nil {
+		foundCollection.Description = *reqDTO.Body.Description
+	}
+	if reqDTO.Body.Status != nil {
+		foundCollection.Status = *reqDTO.Body.Status
+	}
+	if reqDTO.Body.RemoveSchedule {
+		foundCollection.StartTime = nil
+		foundCollection.EndTime = nil
+	}
+	if reqDTO.Body.StartTime != nil {
+		startTime := reqDTO.Body.StartTime.UTC()
+		foundCollection.StartTime = &startTime
+	}
+	if reqDTO.Body.EndTime != nil {
+		endTime := reqDTO.Body.EndTime.UTC()
+		foundCollection.EndTime = &endTime
+	}
+	if err := checkCollectionSchedule(foundCollection.StartTime, foundCollection.EndTime); err != nil {
+		return err
+	}
+	if reqDTO.Body.RemoveRule {
+		foundCollection.Rule = nil
+	}
+	if reqDTO.Body.Rule != nil {
+		if foundCollection.Rule, err = collectionService.buildCollectionRule(ctx, reqDTO.Body.Rule); err != nil {
+			return err
+		}
+	}
+	timeUpdate := time.Now().UTC()
+	foundCollection.UpdatedAt = &timeUpdate
+
+	if err := collectionService.collectionRepository.Update(ctx, foundCollection); err != nil {
+		return fmt.Errorf("update collection on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (collectionService *collectionService) UpdateCollectionProducts(ctx context.Context, reqDTO *dto.UpdateCollectionProductsRequest) error {
+	if _, err := collectionService.collectionRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("id of collection is not valid: %s", err.Error())
+	}
+
+	productIdSet := map[string]bool{}
+	for _, productId := range reqDTO.Body.ProductIds {
+		if productIdSet[productId] {
+			return fmt.Errorf("id of product %s is duplicated", productId)
+		}
+		productIdSet[productId] = true
+	}
+	if len(reqDTO.Body.ProductIds) != 0 {
+		products, err := collectionService.productRepository.GetByListId(ctx, reqDTO.Body.ProductIds)
+		if err != nil {
+			return fmt.Errorf("query products from postgresql failed: %s", err.Error())
+		}
+		if len(products) != len(reqDTO.Body.ProductIds) {
+			return fmt.Errorf("id of product not found")
+		}
+	}
+
+	if err := collectionService.collectionRepository.ReplaceProductsById(ctx, reqDTO.Id, reqDTO.Body.ProductIds); err != nil {
+		return fmt.Errorf("update products of collection on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Banner is stored as uploaded, it replaces the current banner
+func (collectionService *collectionService) UploadCollectionBanner(ctx context.Context, reqDTO *dto.UploadCollectionBannerRequest) (*model.CollectionView, error) {
+	foundCollection, err := collectionService.collectionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of collection is not valid: %s", err.Error())
+	}
+
+	formData := reqDTO.RawBody.Data()
+
+	maxUploadSize := config.AppConfig.MediaMaxUploadSizeValue()
+	if formData.File.Size > maxUploadSize {
+		return nil, fmt.Errorf("size of image must not be greater than %d bytes", maxUploadSize)
+	}
+	content, err := io.ReadAll(io.LimitReader(formData.File, maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image failed: %s", err.Error())
+	}
+	if int64(len(content)) > maxUploadSize {
+		return nil, fmt.Errorf("size of image must not be greater than %d bytes", maxUploadSize)
+	}
+
+	_, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("image is not valid: %s", err.Error())
+	}
+
+	bannerImageKey := fmt.Sprintf("collections/%s/banner-%s%s", reqDTO.Id, uuid.New().String(), imageExtension(format))
+	bannerImageURL, err := infrastructure.MediaStorage.Put(ctx, bannerImageKey, content, "image/"+format)
+	if err != nil {
+		return nil, fmt.Errorf("store image failed: %s", err.Error())
+	}
+
+	oldBannerImageKey := foundCollection.BannerImageKey
+	foundCollection.BannerImageKey = bannerImageKey
+	foundCollection.BannerImageURL = bannerImageURL
+	timeUpdate := time.Now().UTC()
+	foundCollection.UpdatedAt = &timeUpdate
+
+	if err := collectionService.collectionRepository.Update(ctx, foundCollection); err != nil {
+		deleteCollectionBannerFile(ctx, bannerImageKey)
+		return nil, fmt.Errorf("update collection on postgresql failed: %s", err.Error())
+	}
+	deleteCollectionBannerFile(ctx, oldBannerImageKey)
+
+	updatedCollectionView, err := collectionService.collectionRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query collection from postgresql failed: %s", err.Error())
+	}
+
+	return updatedCollectionView, nil
+}
+
+func (collectionService *collectionService) DeleteCollectionBanner(ctx context.Context, reqDTO *dto.DeleteCollectionBannerRequest) error {
+	foundCollection, err := collectionService.collectionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of collection is not valid: %s", err.Error())
+	}
+	if foundCollection.BannerImageKey == "" {
+		return fmt.Errorf("collection has no banner")
+	}
+
+	oldBannerImageKey := foundCollection.BannerImageKey
+	foundCollection.BannerImageKey = ""
+	foundCollection.BannerImageURL = ""
+	timeUpdate := time.Now().UTC()
+	foundCollection.UpdatedAt = &timeUpdate
+
+	if err := collectionService.collectionRepository.Update(ctx, foundCollection); err != nil {
+		return fmt.Errorf("update collection on postgresql failed: %s", err.Error())
+	}
+	deleteCollectionBannerFile(ctx, oldBannerImageKey)
+
+	return nil
+}
+
+func (collectionService *collectionService) DeleteCollectionById(ctx context.Context, reqDTO *dto.DeleteCollectionByIdRequest) error {
+	foundCollection, err := collectionService.collectionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of collection is not valid")
+	}
+
+	if err := collectionService.collectionRepository.DeleteById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("delete collection from postgresql failed: %s", err.Error())
+	}
+	deleteCollectionBannerFile(ctx, foundCollection.BannerImageKey)
+
+	return nil
+}
+
+// Check category and brand of rule exist, defaults are filled in
+func (collectionService *collectionService) buildCollectionRule(ctx context.Context, ruleReqDTO *dto.CollectionRuleRequest) (*model.CollectionRule, error) {
+	if ruleReqDTO.CategoryId != "" {
+		if _, err := collectionService.categoryRepository.GetById(ctx, ruleReqDTO.CategoryId); err != nil {
+			return nil, fmt.Errorf("id of category not found: %s", ruleReqDTO.CategoryId)
+		}
+	}
+	if ruleReqDTO.BrandId != "" {
+		if _, err := collectionService.brandRepository.GetById(ctx, ruleReqDTO.BrandId); err != nil {
+			return nil, fmt.Errorf("id of brand not found: %s", ruleReqDTO.BrandId)
+		}
+	}
+	if ruleReqDTO.FinalPriceGte != nil && ruleReqDTO.FinalPriceLte != nil && *ruleReqDTO.FinalPriceGte > *ruleReqDTO.FinalPriceLte {
+		return nil, fmt.Errorf("final price range of rule is not valid")
+	}
+
+	rule := &model.CollectionRule{
+		CategoryId:    ruleReqDTO.CategoryId,
+		BrandId:       ruleReqDTO.BrandId,
+		Sex:           ruleReqDTO.Sex,
+		FinalPriceGte: ruleReqDTO.FinalPriceGte,
+		FinalPriceLte: ruleReqDTO.FinalPriceLte,
+		Attributes:    ruleReqDTO.Attributes,
+		Tags:          ruleReqDTO.Tags,
+		SortBy:        ruleReqDTO.SortBy,
+		Limit:         ruleReqDTO.Limit,
+	}
+	if rule.SortBy == "" {
+		rule.SortBy = "created_at:desc"
+	}
+	if rule.Limit == 0 {
+		rule.Limit = 20
+	}
+
+	return rule, nil
+}
+
+// Rule is evaluated by elasticsearch-service with the filters of product search
+func (collectionService *collectionService) getRuleProductIds(ctx context.Context, rule *model.CollectionRule) ([]string, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient == nil {
+		return nil, fmt.Errorf("elasticsearch-service is not running")
+	}
+
+	convertReqDTO := &elasticsearchservicepb.GetProductsRequest{}
+	convertReqDTO.Offset = 0
+	convertReqDTO.Limit = rule.Limit
+	convertReqDTO.SortBy = rule.SortBy
+	convertReqDTO.CategoryId = rule.CategoryId
+	convertReqDTO.BrandId = rule.BrandId
+	convertReqDTO.Sex = rule.Sex
+	if rule.FinalPriceGte != nil {
+		convertReqDTO.FinalPriceGte = strconv.FormatInt(*rule.FinalPriceGte, 10)
+	}
+	if rule.FinalPriceLte != nil {
+		convertReqDTO.FinalPriceLte = strconv.FormatInt(*rule.FinalPriceLte, 10)
+	}
+	convertReqDTO.Attributes = rule.Attributes
+	convertReqDTO.Tags = rule.Tags
+
+	grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetProducts(ctx, convertReqDTO)
+	if err != nil {
+		return nil, fmt.Errorf("get products from elasticsearch-service failed: %s", err.Error())
+	}
+
+	productIds := make([]string, len(grpcRes.Products))
+	for i, productProto := range grpcRes.Products {
+		productIds[i] = productProto.Id
+	}
+
+	return productIds, nil
+}
+
+func checkCollectionSchedule(startTime *time.Time, endTime *time.Time) error {
+	if startTime != nil && endTime != nil && !endTime.After(*startTime) {
+		return fmt.Errorf("end time of collection must be after start time")
+	}
+
+	return nil
+}
+
+// Failures are only logged since collection no longer refers to the file
+func deleteCollectionBannerFile(ctx context.Context, bannerImageKey string) {
+	if bannerImageKey == "" {
+		return
+	}
+	if err := infrastructure.MediaStorage.Delete(ctx, bannerImageKey); err != nil {
+		log.Printf("Delete media %s failed: %s", bannerImageKey, err.Error())
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"thanhldt060802/config"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+)
+
+// Max products of catalog evaluated by reorder suggestions when they are not only low stock products
+const reorderSuggestionMaxProducts = 10000
+
+type lowStockService struct {
+	productRepository repository.ProductRepository
+}
+
+type LowStockService interface {
+	GetLowStockProducts(ctx context.Context, reqDTO *dto.GetLowStockProductsRequest) ([]*model.LowStockProductView, error)
+	GetReorderSuggestions(ctx context.Context, reqDTO *dto.GetReorderSuggestionsRequest) ([]*model.ReorderSuggestionView, error)
+	evaluateLowStockLoop()
+}
+
+func NewLowStockService(productRepository repository.ProductRepository) LowStockService {
+	lowStockService := &lowStockService{
+		productRepository: productRepository,
+	}
+
+	go lowStockService.evaluateLowStockLoop()
+
+	return lowStockService
+}
+
+func (lowStockService *lowStockService) GetLowStockProducts(ctx context.Context, reqDTO *dto.GetLowStockProductsRequest) ([]*model.LowStockProductView, error) {
+	products, err := lowStockService.productRepository.GetLowStockViews(ctx, config.AppConfig.LowStockDefaultThresholdValue(), !reqDTO.IncludeAll, int(reqDTO.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("query low stock products from postgresql failed: %s", err.Error())
+	}
+
+	return products, nil
+}
+
+// Suggested quantity covers sales velocity (units sold per day in elasticsearch-service) over lead time and cover days,
+// plus threshold so that product is not low on stock again when stock arrives
+func (lowStockService *lowStockService) GetReorderSuggestions(ctx context.Context, reqDTO *dto.GetReorderSuggestionsRequest) ([]*model.ReorderSuggestionView, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient == nil {
+		return nil, fmt.Errorf("elasticsearch-service is not running")
+	}
+
+	limit := int(reqDTO.Limit)
+	if reqDTO.IncludeAll {
+		// Products are ordered by urgency after velocities are known, so that all of them are evaluated
+		limit = reorderSuggestionMaxProducts
+	}
+	products, err := lowStockService.productRepository.GetLowStockViews(ctx, config.AppConfig.LowStockDefaultThresholdValue(), !reqDTO.IncludeAll, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query low stock products from postgresql failed: %s", err.Error())
+	}
+	if len(products) == 0 {
+		return []*model.ReorderSuggestionView{}, nil
+	}
+
+	convertReqDTO := &elasticsearchservicepb.GetProductSalesVelocitiesRequest{}
+	convertReqDTO.ProductIds = make([]string, len(products))
+	for i, product := range products {
+		convertReqDTO.ProductIds[i] = product.Id
+	}
+	convertReqDTO.Days = reqDTO.Days
+
+	grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetProductSalesVelocities(ctx, convertReqDTO)
+	if err != nil {
+		return nil, fmt.Errorf("get product sales velocities from elasticsearch-service failed: %s", err.Error())
+	}
+	salesVelocityMap := make(map[string]*elasticsearchservicepb.ProductSalesVelocity, len(grpcRes.ProductSalesVelocities))
+	for _, salesVelocity := range grpcRes.ProductSalesVelocities {
+		salesVelocityMap[salesVelocity.ProductId] = salesVelocity
+	}
+
+	suggestions := make([]*model.ReorderSuggestionView, len(products))
+	for i, product := range products {
+		suggestion := &model.ReorderSuggestionView{LowStockProductView: product}
+		if salesVelocity, ok := salesVelocityMap[product.Id]; ok {
+			suggestion.UnitsSold = salesVelocity.UnitsSold
+			suggestion.DailyUnits = salesVelocity.DailyUnits
+		}
+		if suggestion.DailyUnits > 0 {
+			daysOfStock := math.Max(float64(product.Stock), 0) / suggestion.DailyUnits
+			suggestion.DaysOfStock = &daysOfStock
+		}
+		targetStock := int64(math.Ceil(suggestion.DailyUnits*float64(reqDTO.LeadTimeDays+reqDTO.CoverDays))) + int64(product.LowStockThreshold)
+		suggestion.SuggestedQuantity = max(targetStock-int64(product.Stock), 0)
+		suggestions[i] = suggestion
+	}
+
+	// Products running out soonest first, products without sales follow by suggested quantity
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if (suggestions[i].DaysOfStock == nil) != (suggestions[j].DaysOfStock == nil) {
+			return suggestions[i].DaysOfStock != nil
+		}
+		if suggestions[i].DaysOfStock != nil && *suggestions[i].DaysOfStock != *suggestions[j].DaysOfStock {
+			return *suggestions[i].DaysOfStock < *suggestions[j].DaysOfStock
+		}
+		return suggestions[i].SuggestedQuantity > suggestions[j].SuggestedQuantity
+	})
+	if len(suggestions) > int(reqDTO.Limit) {
+		suggestions = suggestions[:reqDTO.Limit]
+	}
+
+	return suggestions, nil
+}
+
+// Evaluate created and updated products against their threshold, a product is alerted once when it drops to or below
+// its threshold and can be alerted again after it is restocked above it
+func (lowStockService *lowStockService) evaluateLowStockLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.created-product", "catalog-service.updated-product")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var productEvent struct {
+			Id   string `json:"id"`
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal([]byte(msg.Payload), &productEvent); err != nil {
+			log.Printf("Parse payload from event %s failed: %s", msg.Channel, err.Error())
+			continue
+		}
+		// Bundles are restocked through their components, which are alerted themselves
+		if productEvent.Type == "BUNDLE" {
+			continue
+		}
+
+		ctx := context.Background()
+
+		product, err := lowStockService.productRepository.GetLowStockViewById(ctx, productEvent.Id, config.AppConfig.LowStockDefaultThresholdValue())
+		if err != nil {
+			log.Printf("Query low stock product %s from postgresql failed: %s", productEvent.Id, err.Error())
+			continue
+		}
+
+		if product.Status == "PUBLISHED" && product.Stock <= product.LowStockThreshold {
+			if product.LowStockAlertedAt != nil {
+				continue
+			}
+			marked, err := lowStockService.productRepository.MarkLowStockAlerted(ctx, product.Id)
+			if err != nil {
+				log.Printf("Mark product %s as low stock alerted on postgresql failed: %s", product.Id, err.Error())
+				continue
+			}
+			if !marked {
+				continue
+			}
+
+			payload, _ := json.Marshal(product)
+			if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.low-stock", payload).Err(); err != nil {
+				log.Printf("Pulish event catalog-service.low-stock of product %s failed: %s", product.Id, err.Error())
+				// Product is alerted again on its next update
+				lowStockService.productRepository.ClearLowStockAlerted(ctx, product.Id)
+			}
+		} else if product.LowStockAlertedAt != nil {
+			if err := lowStockService.productRepository.ClearLowStockAlerted(ctx, product.Id); err != nil {
+				log.Printf("Clear low stock alert of product %s on postgresql failed: %s", product.Id, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"path"
+	"thanhldt060802/config"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+
+	"github.com/google/uuid"
+)
+
+// Max width x height of uploaded image, checked from its header before it is decoded since a small compressed file can decode
+// to gigabytes of pixels
+const imageMaxPixels = 40 * 1000 * 1000
+
+// Thumbnail name -> max width/height in pixels, generated for every uploaded image
+var productImageThumbnailSizes = map[string]int{
+	"small":  150,
+	"medium": 400,
+	"large":  800,
+}
+
+type productImageService struct {
+	productImageRepository repository.ProductImageRepository
+	productRepository      repository.ProductRepository
+}
+
+type ProductImageService interface {
+	GetProductImages(ctx context.Context, reqDTO *dto.GetProductImagesRequest) ([]*model.ProductImageView, error)
+	UploadProductImage(ctx context.Context, reqDTO *dto.UploadProductImageRequest) (*model.ProductImageView, error)
+	ReorderProductImages(ctx context.Context, reqDTO *dto.ReorderProductImagesRequest) error
+	SetPrimaryProductImage(ctx context.Context, reqDTO *dto.SetPrimaryProductImageRequest) error
+	DeleteProductImage(ctx context.Context, reqDTO *dto.DeleteProductImageRequest) error
+}
+
+func NewProductImageService(productImageRepository repository.ProductImageRepository, productRepository repository.ProductRepository) ProductImageService {
+	return &productImageService{
+		productImageRepository: productImageRepository,
+		productRepository:      productRepository,
+	}
+}
+
+func (productImageService *productImageService) GetProductImages(ctx context.Context, reqDTO *dto.GetProductImagesRequest) ([]*model.ProductImageView, error) {
+	if _, err := productImageService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	var variant *string
+	if reqDTO.Variant != "" {
+		variant = &reqDTO.Variant
+	}
+
+	productImages, err := productImageService.productImageRepository.GetViewsByProductId(ctx, reqDTO.Id, variant)
+	if err != nil {
+		return nil, fmt.Errorf("query product images from postgresql failed: %s", err.Error())
+	}
+
+	return productImages, nil
+}
+
+func (productImageService *productImageService) UploadProductImage(ctx context.Context, reqDTO *dto.UploadProductImageRequest) (*model.ProductImageView, error) {
+	if _, err := productImageService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	formData := reqDTO.RawBody.Data()
+
+	maxUploadSize := config.AppConfig.MediaMaxUploadSizeValue()
+	if formData.File.Size > maxUploadSize {
+		return nil, fmt.Errorf("size of image must not be greater than %d bytes", maxUploadSize)
+	}
+	content, err := io.ReadAll(io.LimitReader(formData.File, maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image failed: %s", err.Error())
+	}
+	if int64(len(content)) > maxUploadSize {
+		return nil, fmt.Errorf("size of image must not be greater than %d bytes", maxUploadSize)
+	}
+
+	if _, err := checkImageDimensions(content); err != nil {
+		return nil, fmt.Errorf("image is not valid: %s", err.Error())
+	}
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("image is not valid: %s", err.Error())
+	}
+
+	imageId := uuid.New().String()
+	baseKey := fmt.Sprintf("products/%s/%s", reqDTO.Id, imageId)
+	storedKeys := []string{}
+
+	// Remove already stored files if any later step fails
+	success := false
+	defer func() {
+		if !success {
+			for _, key := range storedKeys {
+				if err := infrastructure.MediaStorage.Delete(context.Background(), key); err != nil {
+					log.Printf("Delete media %s failed: %s", key, err.Error())
+				}
+			}
+		}
+	}()
+
+	originalKey := baseKey + "/original" + imageExtension(format)
+	originalURL, err := infrastructure.MediaStorage.Put(ctx, originalKey, content, "image/"+format)
+	if err != nil {
+		return nil, fmt.Errorf("store image failed: %s", err.Error())
+	}
+	storedKeys = append(storedKeys, originalKey)
+
+	thumbnails := map[string]string{}
+	for name, size := range productImageThumbnailSizes {
+		thumbnailContent, thumbnailFormat, err := encodeImage(utils.ResizeImage(img, size), format)
+		if err != nil {
+			return nil, fmt.Errorf("generate %s thumbnail failed: %s", name, err.Error())
+		}
+
+		thumbnailKey := baseKey + "/" + name + imageExtension(thumbnailFormat)
+		thumbnailURL, err := infrastructure.MediaStorage.Put(ctx, thumbnailKey, thumbnailContent, "image/"+thumbnailFormat)
+		if err != nil {
+			return nil, fmt.Errorf("store %s thumbnail failed: %s", name, err.Error())
+		}
+		storedKeys = append(storedKeys, thumbnailKey)
+		thumbnails[name] = thumbnailURL
+	}
+
+	imageCount, err := productImageService.productImageRepository.CountByProductId(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query product images from postgresql failed: %s", err.Error())
+	}
+
+	newProductImage := model.ProductImage{
+		Id:          imageId,
+		ProductId:   reqDTO.Id,
+		Variant:     formData.Variant,
+		StorageKey:  originalKey,
+		URL:         originalURL,
+		Thumbnails:  thumbnails,
+		ContentType: "image/" + format,
+		Width:       int32(img.Bounds().Dx()),
+		Height:      int32(img.Bounds().Dy()),
+		Size:        int64(len(content)),
+		SortOrder:   int32(imageCount),
+	}
+	if err := productImageService.productImageRepository.Create(ctx, &newProductImage); err != nil {
+		return nil, fmt.Errorf("insert product image to postgresql failed: %s", err.Error())
+	}
+	success = true
+
+	// First image of product becomes primary image automatically
+	if formData.IsPrimary || imageCount == 0 {
+		if err := productImageService.productImageRepository.SetPrimary(ctx, &newProductImage); err != nil {
+			return nil, fmt.Errorf("update primary image on postgresql failed: %s", err.Error())
+		}
+		if err := productImageService.publishUpdatedProduct(ctx, reqDTO.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	newProductImageView, err := productImageService.productImageRepository.GetViewById(ctx, imageId)
+	if err != nil {
+		return nil, fmt.Errorf("query product image from postgresql failed: %s", err.Error())
+	}
+
+	return newProductImageView, nil
+}
+
+func (productImageService *productImageService) ReorderProductImages(ctx context.Context, reqDTO *dto.ReorderProductImagesRequest) error {
+	productImages, err := productImageService.productImageRepository.GetByProductId(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("query product images from postgresql failed: %s", err.Error())
+	}
+
+	// New order must contain every image of product exactly once
+	if len(reqDTO.Body.ImageIds) != len(productImages) {
+		return fmt.Errorf("image ids must contain all %d images of product", len(productImages))
+	}
+	imageIdSet := map[string]bool{}
+	for _, productImage := range productImages {
+		imageIdSet[productImage.Id] = true
+	}
+	for _, imageId := range reqDTO.Body.ImageIds {
+		if !imageIdSet[imageId] {
+			return fmt.Errorf("id of image %s is not valid or duplicated", imageId)
+		}
+		delete(imageIdSet, imageId)
+	}
+
+	if err := productImageService.productImageRepository.UpdateSortOrders(ctx, reqDTO.Id, reqDTO.Body.ImageIds); err != nil {
+		return fmt.Errorf("update product images on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (productImageService *productImageService) SetPrimaryProductImage(ctx context.Context, reqDTO *dto.SetPrimaryProductImageRequest) error {
+	foundProductImage, err := productImageService.productImageRepository.GetById(ctx, reqDTO.ImageId)
+	if err != nil || foundProductImage.ProductId != reqDTO.Id {
+		return fmt.Errorf("id of image is not valid")
+	}
+
+	if err := productImageService.productImageRepository.SetPrimary(ctx, foundProductImage); err != nil {
+		return fmt.Errorf("update primary image on postgresql failed: %s", err.Error())
+	}
+
+	return productImageService.publishUpdatedProduct(ctx, reqDTO.Id)
+}
+
+func (productImageService *productImageService) DeleteProductImage(ctx context.Context, reqDTO *dto.DeleteProductImageRequest) error {
+	foundProductImage, err := productImageService.productImageRepository.GetById(ctx, reqDTO.ImageId)
+	if err != nil || foundProductImage.ProductId != reqDTO.Id {
+		return fmt.Errorf("id of image is not valid")
+	}
+
+	if err := productImageService.productImageRepository.DeleteById(ctx, foundProductImage.Id); err != nil {
+		return fmt.Errorf("delete product image from postgresql failed: %s", err.Error())
+	}
+	deleteProductImageFiles(ctx, foundProductImage)
+
+	if !foundProductImage.IsPrimary {
+		return nil
+	}
+
+	// Next image in gallery takes over primary image
+	remainingProductImages, err := productImageService.productImageRepository.GetByProductId(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("query product images from postgresql failed: %s", err.Error())
+	}
+	if len(remainingProductImages) > 0 {
+		err = productImageService.productImageRepository.SetPrimary(ctx, remainingProductImages[0])
+	} else {
+		err = productImageService.productImageRepository.ClearPrimary(ctx, reqDTO.Id)
+	}
+	if err != nil {
+		return fmt.Errorf("update primary image on postgresql failed: %s", err.Error())
+	}
+
+	return productImageService.publishUpdatedProduct(ctx, reqDTO.Id)
+}
+
+func (productImageService *productImageService) publishUpdatedProduct(ctx context.Context, productId string) error {
+	updatedProductView, err := productImageService.productRepository.GetViewById(ctx, productId)
+	if err != nil {
+		log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", productId, err.Error())
+		return nil
+	}
+	payload, _ := json.Marshal(updatedProductView)
+	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+		return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Remove original and thumbnails of image from media storage, failures are only logged since database row is already gone
+func deleteProductImageFiles(ctx context.Context, productImage *model.ProductImage) {
+	keys := []string{productImage.StorageKey}
+	for _, thumbnailURL := range productImage.Thumbnails {
+		keys = append(keys, path.Join(path.Dir(productImage.StorageKey), path.Base(thumbnailURL)))
+	}
+
+	for _, key := range keys {
+		if err := infrastructure.MediaStorage.Delete(ctx, key); err != nil {
+			log.Printf("Delete media %s failed: %s", key, err.Error())
+		}
+	}
+}
+
+// Read dimensions and format from header of image, image is refused when it has more pixels than imageMaxPixels
+func checkImageDimensions(content []byte) (string, error) {
+	imageConfig, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	if imageConfig.Width <= 0 || imageConfig.Height <= 0 || int64(imageConfig.Width)*int64(imageConfig.Height) > imageMaxPixels {
+		return "", fmt.Errorf("dimensions %dx%d exceed %d pixels", imageConfig.Width, imageConfig.Height, imageMaxPixels)
+	}
+
+	return format, nil
+}
+
+// Resized images keep format of original image, except GIF which is flattened to PNG
+func encodeImage(img image.Image, format string) ([]byte, string, error) {
+	buffer := new(bytes.Buffer)
+
+	switch format {
+	case "jpeg":
+		if err := jpeg.Encode(buffer, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "jpeg", nil
+	default:
+		if err := png.Encode(buffer, img); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "png", nil
+	}
+}
+
+func imageExtension(format string) string {
+	switch format {
+	case "jpeg":
+		return ".jpg"
+	case "gif":
+		return ".gif"
+	default:
+		return ".png"
+	}
+}
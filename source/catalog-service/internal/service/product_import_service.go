@@ -0,0 +1,524 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"thanhldt060802/config"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type productImportService struct {
+	productImportJobRepository repository.ProductImportJobRepository
+	productRepository          repository.ProductRepository
+	categoryRepository         repository.CategoryRepository
+	brandRepository            repository.BrandRepository
+	productService             ProductService
+}
+
+// Columns of import and export files, category and brand are given by id or name
+var productImportColumns = []string{"sku", "name", "description", "sex", "price", "discount_percentage", "stock", "image_url", "category", "brand", "status"}
+
+// Same as pattern of sku in create product request
+var productSkuRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Progress of running job is saved every this many rows
+const productImportProgressBatchSize = 50
+
+// Products are read page by page while export is streamed
+const productExportBatchSize = 500
+
+type ProductImportService interface {
+	CreateProductImportJob(ctx context.Context, reqDTO *dto.CreateProductImportJobRequest) (*model.ProductImportJobView, error)
+	GetProductImportJobs(ctx context.Context, reqDTO *dto.GetProductImportJobsRequest) ([]*model.ProductImportJobView, error)
+	GetProductImportJobById(ctx context.Context, reqDTO *dto.GetProductImportJobByIdRequest) (*model.ProductImportJobView, error)
+	ExportProducts(ctx context.Context, reqDTO *dto.ExportProductsRequest, w io.Writer) error
+
+	// Background job
+	runProductImportJob(productImportJob *model.ProductImportJob, rows []*productImportRow)
+}
+
+// Data row of import file with its values by column, empty cells are left out
+type productImportRow struct {
+	number int32
+	values map[string]string
+}
+
+func NewProductImportService(productImportJobRepository repository.ProductImportJobRepository, productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, brandRepository repository.BrandRepository, productService ProductService) ProductImportService {
+	if err := productImportJobRepository.FailUnfinished(context.Background(), "import was interrupted by restart of catalog-service"); err != nil {
+		log.Printf("Fail unfinished product import jobs on postgresql failed: %s", err.Error())
+	}
+
+	return &productImportService{
+		productImportJobRepository: productImportJobRepository,
+		productRepository:          productRepository,
+		categoryRepository:         categoryRepository,
+		brandRepository:            brandRepository,
+		productService:             productService,
+	}
+}
+
+// File is parsed and checked before job is created, rows are then imported in background
+func (productImportService *productImportService) CreateProductImportJob(ctx context.Context, reqDTO *dto.CreateProductImportJobRequest) (*model.ProductImportJobView, error) {
+	formData := reqDTO.RawBody.Data()
+
+	maxUploadSize := config.AppConfig.MediaMaxUploadSizeValue()
+	if formData.File.Size > maxUploadSize {
+		return nil, fmt.Errorf("size of file must not be greater than %d bytes", maxUploadSize)
+	}
+	content, err := io.ReadAll(io.LimitReader(formData.File, maxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read file failed: %s", err.Error())
+	}
+	if int64(len(content)) > maxUploadSize {
+		return nil, fmt.Errorf("size of file must not be greater than %d bytes", maxUploadSize)
+	}
+
+	var format string
+	var records [][]string
+	switch strings.ToLower(filepath.Ext(formData.File.Filename)) {
+	case ".csv":
+		format = "CSV"
+		records, err = utils.ReadCSVRows(content)
+	case ".xlsx":
+		format = "XLSX"
+		records, err = utils.ReadXLSXRows(content, config.AppConfig.ProductImportMaxXLSXPartSizeValue())
+	default:
+		return nil, fmt.Errorf("file must be .csv or .xlsx")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("file is not valid: %s", err.Error())
+	}
+
+	rows, err := parseProductImportRecords(records)
+	if err != nil {
+		return nil, err
+	}
+	if maxRows := config.AppConfig.ProductImportMaxRowsValue(); len(rows) > maxRows {
+		return nil, fmt.Errorf("file must not have more than %d rows", maxRows)
+	}
+
+	newProductImportJob := &model.ProductImportJob{
+		Id:        uuid.New().String(),
+		FileName:  formData.File.Filename,
+		Format:    format,
+		DryRun:    formData.DryRun,
+		Status:    "PENDING",
+		TotalRows: int32(len(rows)),
+		Errors:    []*model.ProductImportRowError{},
+		ActorId:   actorIdFromContext(ctx),
+	}
+	if err := productImportService.productImportJobRepository.Create(ctx, newProductImportJob); err != nil {
+		return nil, fmt.Errorf("insert product import job to postgresql failed: %s", err.Error())
+	}
+
+	go productImportService.runProductImportJob(newProductImportJob, rows)
+
+	newProductImportJobView, err := productImportService.productImportJobRepository.GetViewById(ctx, newProductImportJob.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query product import job from postgresql failed: %s", err.Error())
+	}
+
+	return newProductImportJobView, nil
+}
+
+func (productImportService *productImportService) GetProductImportJobs(ctx context.Context, reqDTO *dto.GetProductImportJobsRequest) ([]*model.ProductImportJobView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	productImportJobs, err := productImportService.productImportJobRepository.GetViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields)
+	if err != nil {
+		return nil, fmt.Errorf("query product import jobs from postgresql failed: %s", err.Error())
+	}
+
+	return productImportJobs, nil
+}
+
+func (productImportService *productImportService) GetProductImportJobById(ctx context.Context, reqDTO *dto.GetProductImportJobByIdRequest) (*model.ProductImportJobView, error) {
+	foundProductImportJob, err := productImportService.productImportJobRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of product import job is not valid: %s", err.Error())
+	}
+
+	return foundProductImportJob, nil
+}
+
+// Export has the same columns as import so that it can be edited and imported back
+func (productImportService *productImportService) ExportProducts(ctx context.Context, reqDTO *dto.ExportProductsRequest, w io.Writer) error {
+	var writer utils.SpreadsheetWriter
+	if reqDTO.Format == "xlsx" {
+		xlsxWriter, err := utils.NewXLSXSpreadsheetWriter(w)
+		if err != nil {
+			return fmt.Errorf("write xlsx failed: %s", err.Error())
+		}
+		writer = xlsxWriter
+	} else {
+		writer = utils.NewCSVSpreadsheetWriter(w)
+	}
+
+	header := make([]any, len(productImportColumns))
+	for i, column := range productImportColumns {
+		header[i] = column
+	}
+	if err := writer.WriteRow(header); err != nil {
+		return fmt.Errorf("write export failed: %s", err.Error())
+	}
+
+	afterId := ""
+	for {
+		products, err := productImportService.productRepository.GetExportViewsAfterId(ctx, afterId, productExportBatchSize)
+		if err != nil {
+			return fmt.Errorf("query products from postgresql failed: %s", err.Error())
+		}
+
+		for _, product := range products {
+			row := []any{product.Sku, product.Name, product.Description, product.Sex, product.Price, product.DiscountPercentage,
+				product.Stock, product.ImageURL, product.CategoryName, product.BrandName, product.Status}
+			if err := writer.WriteRow(row); err != nil {
+				return fmt.Errorf("write export failed: %s", err.Error())
+			}
+		}
+
+		if len(products) < productExportBatchSize {
+			break
+		}
+		afterId = products[len(products)-1].Id
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("write export failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (productImportService *productImportService) runProductImportJob(productImportJob *model.ProductImportJob, rows []*productImportRow) {
+	// Products are written as the admin who uploaded the file so that stock movements and price history name them
+	ctx := context.Background()
+	if productImportJob.ActorId != nil {
+		ctx = context.WithValue(ctx, "user_id", *productImportJob.ActorId)
+	}
+
+	productImportJob.Status = "RUNNING"
+	productImportService.saveProductImportJob(ctx, productImportJob)
+
+	seenSkus := map[string]int32{}
+	for i, row := range rows {
+		created, rowErrors := productImportService.importProductRow(ctx, productImportJob.DryRun, row, seenSkus)
+		if len(rowErrors) > 0 {
+			productImportJob.ErrorCount++
+			productImportJob.Errors = append(productImportJob.Errors, rowErrors...)
+		} else if created {
+			productImportJob.CreatedCount++
+		} else {
+			productImportJob.UpdatedCount++
+		}
+		productImportJob.ProcessedRows++
+
+		if (i+1)%productImportProgressBatchSize == 0 {
+			productImportService.saveProductImportJob(ctx, productImportJob)
+		}
+	}
+
+	timeFinish := time.Now().UTC()
+	productImportJob.Status = "COMPLETED"
+	productImportJob.FinishedAt = &timeFinish
+	productImportService.saveProductImportJob(ctx, productImportJob)
+}
+
+func (productImportService *productImportService) saveProductImportJob(ctx context.Context, productImportJob *model.ProductImportJob) {
+	timeUpdate := time.Now().UTC()
+	productImportJob.UpdatedAt = &timeUpdate
+
+	if err := productImportService.productImportJobRepository.Update(ctx, productImportJob); err != nil {
+		log.Printf("Update product import job %s on postgresql failed: %s", productImportJob.Id, err.Error())
+	}
+}
+
+// Upsert product of row by sku, created tells whether product is new. Nothing is written on dry run or when row has
+// any error, dry run still checks row against the same rules as create and update product.
+func (productImportService *productImportService) importProductRow(ctx context.Context, dryRun bool, row *productImportRow, seenSkus map[string]int32) (bool, []*model.ProductImportRowError) {
+	sku := row.values["sku"]
+	rowErrors := []*model.ProductImportRowError{}
+	addError := func(column string, message string) {
+		rowErrors = append(rowErrors, &model.ProductImportRowError{
+			Row:     row.number,
+			Sku:     sku,
+			Column:  column,
+			Message: message,
+		})
+	}
+
+	if sku == "" {
+		addError("sku", "sku is required")
+		return false, rowErrors
+	}
+	if !productSkuRegexp.MatchString(sku) || len(sku) > 64 {
+		addError("sku", "sku must be letters, digits, '.', '_' or '-' and at most 64 characters")
+		return false, rowErrors
+	}
+	if firstRow, ok := seenSkus[sku]; ok {
+		addError("sku", fmt.Sprintf("sku is duplicated, already in row %d", firstRow))
+		return false, rowErrors
+	}
+	seenSkus[sku] = row.number
+
+	foundProduct, err := productImportService.productRepository.GetBySku(ctx, sku)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		addError("sku", fmt.Sprintf("query product from postgresql failed: %s", err.Error()))
+		return false, rowErrors
+	}
+	isNew := err != nil
+	if !isNew && foundProduct.DeletedAt != nil {
+		addError("sku", "product is archived, restore it first")
+		return false, rowErrors
+	}
+
+	if isNew {
+		for _, column := range []string{"name", "description", "sex", "price", "category", "brand"} {
+			if _, ok := row.values[column]; !ok {
+				addError(column, fmt.Sprintf("%s is required for new product", column))
+			}
+		}
+	}
+
+	var sex *string
+	if value, ok := row.values["sex"]; ok {
+		value = strings.ToUpper(value)
+		if value != "MALE" && value != "FEMALE" && value != "UNISEX" {
+			addError("sex", "sex must be MALE, FEMALE or UNISEX")
+		}
+		sex = &value
+	}
+	price, err := parseProductImportInteger(row.values, "price", 0, math.MaxInt64)
+	if err != nil {
+		addError("price", err.Error())
+	}
+	discountPercentage, err := parseProductImportInteger(row.values, "discount_percentage", 0, 100)
+	if err != nil {
+		addError("discount_percentage", err.Error())
+	}
+	stock, err := parseProductImportInteger(row.values, "stock", 0, math.MaxInt32)
+	if err != nil {
+		addError("stock", err.Error())
+	}
+	var status *string
+	if value, ok := row.values["status"]; ok {
+		value = strings.ToUpper(value)
+		if value != "DRAFT" && value != "PUBLISHED" && (isNew || value != "ARCHIVED") {
+			if isNew {
+				addError("status", "status of new product must be DRAFT or PUBLISHED")
+			} else {
+				addError("status", "status must be DRAFT, PUBLISHED or ARCHIVED")
+			}
+		}
+		status = &value
+	}
+
+	var categoryId *string
+	if value, ok := row.values["category"]; ok {
+		if foundCategory := productImportService.resolveCategory(ctx, value); foundCategory != nil {
+			categoryId = &foundCategory.Id
+		} else {
+			addError("category", fmt.Sprintf("category %s not found", value))
+		}
+	}
+	var brandId *string
+	if value, ok := row.values["brand"]; ok {
+		if foundBrand := productImportService.resolveBrand(ctx, value); foundBrand != nil {
+			brandId = &foundBrand.Id
+		} else {
+			addError("brand", fmt.Sprintf("brand %s not found", value))
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return isNew, rowErrors
+	}
+
+	name, hasName := row.values["name"]
+	description, hasDescription := row.values["description"]
+	imageURL, hasImageURL := row.values["image_url"]
+
+	if isNew {
+		createReqDTO := &dto.CreateProductRequest{}
+		createReqDTO.Body.Sku = sku
+		createReqDTO.Body.Name = name
+		createReqDTO.Body.Description = description
+		createReqDTO.Body.Sex = *sex
+		createReqDTO.Body.Price = *price
+		createReqDTO.Body.ImageURL = imageURL
+		createReqDTO.Body.CategoryId = *categoryId
+		createReqDTO.Body.BrandId = *brandId
+		createReqDTO.Body.Status = "PUBLISHED"
+		createReqDTO.Body.BackorderPolicy = "NONE"
+		createReqDTO.Body.Type = "SIMPLE"
+		if discountPercentage != nil {
+			createReqDTO.Body.DiscountPercentage = int32(*discountPercentage)
+		}
+		if stock != nil {
+			createReqDTO.Body.Stock = int32(*stock)
+		}
+		if status != nil {
+			createReqDTO.Body.Status = *status
+		}
+		// Dry run checks row the same way as the import itself, only writing is left out
+		if dryRun {
+			err = productImportService.productService.CheckCreateProduct(ctx, createReqDTO)
+		} else {
+			err = productImportService.productService.CreateProduct(ctx, createReqDTO)
+		}
+		if err != nil {
+			addError("", err.Error())
+		}
+		return true, rowErrors
+	}
+
+	updateReqDTO := &dto.UpdateProductByIdRequest{}
+	updateReqDTO.Id = foundProduct.Id
+	if hasName {
+		updateReqDTO.Body.Name = &name
+	}
+	if hasDescription {
+		updateReqDTO.Body.Description = &description
+	}
+	if hasImageURL {
+		updateReqDTO.Body.ImageURL = &imageURL
+	}
+	updateReqDTO.Body.Sex = sex
+	updateReqDTO.Body.Price = price
+	if discountPercentage != nil {
+		value := int32(*discountPercentage)
+		updateReqDTO.Body.DiscountPercentage = &value
+	}
+	if stock != nil {
+		value := int32(*stock)
+		updateReqDTO.Body.Stock = &value
+	}
+	updateReqDTO.Body.CategoryId = categoryId
+	updateReqDTO.Body.BrandId = brandId
+	updateReqDTO.Body.Status = status
+	if dryRun {
+		err = productImportService.productService.CheckUpdateProductById(ctx, updateReqDTO)
+	} else {
+		err = productImportService.productService.UpdateProductById(ctx, updateReqDTO)
+	}
+	if err != nil {
+		addError("", err.Error())
+	}
+	return false, rowErrors
+}
+
+// Value is tried as id first, then as name
+func (productImportService *productImportService) resolveCategory(ctx context.Context, value string) *model.Category {
+	foundCategory, err := productImportService.categoryRepository.GetById(ctx, value)
+	if err != nil {
+		if foundCategory, err = productImportService.categoryRepository.GetByName(ctx, value); err != nil {
+			return nil
+		}
+	}
+	if foundCategory.DeletedAt != nil {
+		return nil
+	}
+	return foundCategory
+}
+
+// Value is tried as id first, then as name
+func (productImportService *productImportService) resolveBrand(ctx context.Context, value string) *model.Brand {
+	foundBrand, err := productImportService.brandRepository.GetById(ctx, value)
+	if err != nil {
+		if foundBrand, err = productImportService.brandRepository.GetByName(ctx, value); err != nil {
+			return nil
+		}
+	}
+	if foundBrand.DeletedAt != nil {
+		return nil
+	}
+	return foundBrand
+}
+
+// First record is header, blank records are skipped but data rows keep their number in file
+func parseProductImportRecords(records [][]string) ([]*productImportRow, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file has no header row")
+	}
+
+	knownColumns := map[string]bool{}
+	for _, column := range productImportColumns {
+		knownColumns[column] = true
+	}
+	columns := make([]string, len(records[0]))
+	seenColumns := map[string]bool{}
+	for i, cell := range records[0] {
+		column := strings.ToLower(strings.TrimSpace(cell))
+		if column == "" {
+			continue
+		}
+		if !knownColumns[column] {
+			return nil, fmt.Errorf("column %s is not known, columns are %s", column, strings.Join(productImportColumns, ", "))
+		}
+		if seenColumns[column] {
+			return nil, fmt.Errorf("column %s is duplicated", column)
+		}
+		seenColumns[column] = true
+		columns[i] = column
+	}
+	if !seenColumns["sku"] {
+		return nil, fmt.Errorf("column sku is required")
+	}
+
+	rows := []*productImportRow{}
+	for i, record := range records[1:] {
+		row := &productImportRow{
+			number: int32(i + 2),
+			values: map[string]string{},
+		}
+		for j, cell := range record {
+			if j < len(columns) && columns[j] != "" {
+				if value := strings.TrimSpace(cell); value != "" {
+					row.values[columns[j]] = value
+				}
+			}
+		}
+		if len(row.values) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// Integer cell within [min, max], spreadsheets may store it as a float such as 150000.0. Nil when cell is empty.
+func parseProductImportInteger(values map[string]string, column string, min int64, max int64) (*int64, error) {
+	value, ok := values[column]
+	if !ok {
+		return nil, nil
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		floatNumber, floatErr := strconv.ParseFloat(value, 64)
+		if floatErr != nil || floatNumber != math.Trunc(floatNumber) || math.Abs(floatNumber) > math.MaxInt64 {
+			return nil, fmt.Errorf("%s must be an integer", column)
+		}
+		number = int64(floatNumber)
+	}
+	if number < min || number > max {
+		return nil, fmt.Errorf("%s must be between %d and %d", column, min, max)
+	}
+
+	return &number, nil
+}
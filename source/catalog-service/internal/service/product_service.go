@@ -3,90 +3,427 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/dto"
 	"thanhldt060802/internal/grpc/client/elasticsearchservicepb"
 	"thanhldt060802/internal/model"
 	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type productService struct {
-	productRepository  repository.ProductRepository
-	categoryRepository repository.CategoryRepository
-	brandRepository    repository.BrandRepository
+	productRepository               repository.ProductRepository
+	productPriceHistoryRepository   repository.ProductPriceHistoryRepository
+	categoryRepository              repository.CategoryRepository
+	brandRepository                 repository.BrandRepository
+	stockMovementRepository         repository.StockMovementRepository
+	warehouseRepository             repository.WarehouseRepository
+	promotionRepository             repository.PromotionRepository
+	slugRedirectRepository          repository.SlugRedirectRepository
+	categoryAttributeRepository     repository.CategoryAttributeRepository
+	productAttributeValueRepository repository.ProductAttributeValueRepository
+	bundleComponentRepository       repository.BundleComponentRepository
+	stockAllocationStrategy         StockAllocationStrategy
+	sizeChartService                SizeChartService
 }
 
+// Checkout allocates and prices again when stock of an allocated warehouse or units of a chosen promotion were taken by
+// a concurrent checkout meanwhile
+const maxStockAllocationAttempts = 3
+
+// Products per message of product stream
+const productStreamBatchSize = 500
+
+// Max ids of one batch lookup of products
+const productBatchMaxIds = 500
+
 type ProductService interface {
 	GetProductById(ctx context.Context, reqDTO *dto.GetProductByIdRequest) (*model.ProductView, error)
+	GetProductDetailById(ctx context.Context, reqDTO *dto.GetProductByIdRequest) (*model.ProductDetailView, error)
+	GetProductsByListId(ctx context.Context, reqDTO *dto.GetProductsByListIdRequest) ([]*model.ProductView, error)
+	GetProductBySlug(ctx context.Context, reqDTO *dto.GetProductBySlugRequest) (*model.ProductView, error)
 	CreateProduct(ctx context.Context, reqDTO *dto.CreateProductRequest) error
 	UpdateProductById(ctx context.Context, reqDTO *dto.UpdateProductByIdRequest) error
 	DeleteProductById(ctx context.Context, reqDTO *dto.DeleteProductByIdRequest) error
+	RestoreProductById(ctx context.Context, reqDTO *dto.RestoreProductByIdRequest) error
+	GetArchivedProducts(ctx context.Context, reqDTO *dto.GetArchivedProductsRequest) ([]*model.ProductView, error)
+	ClickProduct(ctx context.Context, reqDTO *dto.ClickProductRequest) error
+	GetProductPriceHistory(ctx context.Context, reqDTO *dto.GetProductPriceHistoryRequest) ([]*model.ProductPriceHistoryView, error)
+
+	// Check request the same way CreateProduct and UpdateProductById do without writing anything, for dry run of product import
+	CheckCreateProduct(ctx context.Context, reqDTO *dto.CreateProductRequest) error
+	CheckUpdateProductById(ctx context.Context, reqDTO *dto.UpdateProductByIdRequest) error
 
 	// Elasticsearch integration (init data for elasticsearch-service)
-	GetAllProducts(ctx context.Context) ([]*model.ProductView, error)
+	StreamAllProducts(ctx context.Context, send func(products []*model.ProductView) error) error
 
 	// Order integration (extra features for order-service)
-	UpdateProductStocksByListInvoiceDetail(ctx context.Context, reqDTO *dto.UpdateProductStocksByListInvoiceDetailRequest) error
+	UpdateProductStocksByListInvoiceDetail(ctx context.Context, reqDTO *dto.UpdateProductStocksByListInvoiceDetailRequest) ([]*model.StockAllocation, error)
 
 	// Elasticsearch integration features
-	GetProducts(ctx context.Context, reqDTO *dto.GetProductsRequest) ([]*model.ProductView, error)
+	GetProducts(ctx context.Context, reqDTO *dto.GetProductsRequest) ([]*model.ProductView, []*model.ProductFacetView, string, error)
+	GetSearchReport(ctx context.Context, reqDTO *dto.GetSearchReportRequest) (*model.SearchReportView, error)
+	GetProductRecommendations(ctx context.Context, reqDTO *dto.GetProductRecommendationsRequest) (*model.ProductRecommendationsView, error)
+	GetTopProducts(ctx context.Context, reqDTO *dto.GetTopProductsRequest) ([]*model.RankedProductView, error)
+	GetTrendingProducts(ctx context.Context, reqDTO *dto.GetTrendingProductsRequest) ([]*model.RankedProductView, error)
+
+	syncBundlesLoop()
 }
 
-func NewProductService(productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, brandRepository repository.BrandRepository) ProductService {
-	return &productService{
-		productRepository:  productRepository,
-		categoryRepository: categoryRepository,
-		brandRepository:    brandRepository,
+func NewProductService(productRepository repository.ProductRepository, productPriceHistoryRepository repository.ProductPriceHistoryRepository, categoryRepository repository.CategoryRepository, brandRepository repository.BrandRepository, stockMovementRepository repository.StockMovementRepository, warehouseRepository repository.WarehouseRepository, promotionRepository repository.PromotionRepository, slugRedirectRepository repository.SlugRedirectRepository, categoryAttributeRepository repository.CategoryAttributeRepository, productAttributeValueRepository repository.ProductAttributeValueRepository, bundleComponentRepository repository.BundleComponentRepository, stockAllocationStrategy StockAllocationStrategy, sizeChartService SizeChartService) ProductService {
+	productService := &productService{
+		productRepository:               productRepository,
+		productPriceHistoryRepository:   productPriceHistoryRepository,
+		categoryRepository:              categoryRepository,
+		brandRepository:                 brandRepository,
+		stockMovementRepository:         stockMovementRepository,
+		warehouseRepository:             warehouseRepository,
+		promotionRepository:             promotionRepository,
+		slugRedirectRepository:          slugRedirectRepository,
+		categoryAttributeRepository:     categoryAttributeRepository,
+		productAttributeValueRepository: productAttributeValueRepository,
+		bundleComponentRepository:       bundleComponentRepository,
+		stockAllocationStrategy:         stockAllocationStrategy,
+		sizeChartService:                sizeChartService,
 	}
+
+	go productService.syncBundlesLoop()
+
+	return productService
 }
 
 func (productService *productService) GetProductById(ctx context.Context, reqDTO *dto.GetProductByIdRequest) (*model.ProductView, error) {
-	foundProduct, err := productService.productRepository.GetViewById(ctx, reqDTO.Id)
+	foundProduct, err := productService.productRepository.GetCachedViewById(ctx, reqDTO.Id)
 	if err != nil {
 		return nil, fmt.Errorf("id of product is not valid: %s", err.Error())
 	}
+	// Customers (and other services adding products to carts or wishlists) only see published products
+	if foundProduct.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	return foundProduct, nil
+}
+
+// Product page also carries size chart of product and fit recommendation for logged in user, product without size chart or
+// user without body measurements (or user-service being down) only leaves them out
+func (productService *productService) GetProductDetailById(ctx context.Context, reqDTO *dto.GetProductByIdRequest) (*model.ProductDetailView, error) {
+	foundProduct, err := productService.GetProductById(ctx, reqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	productDetail := &model.ProductDetailView{ProductView: foundProduct}
+	foundSizeChart, err := productService.sizeChartService.GetProductSizeChart(ctx, &dto.GetProductSizeChartRequest{Id: foundProduct.Id})
+	if err != nil {
+		return productDetail, nil
+	}
+	productDetail.SizeChart = foundSizeChart
+	if userId, _ := ctx.Value("user_id").(string); userId != "" {
+		if fitRecommendation, err := productService.sizeChartService.GetProductFitRecommendation(ctx, &dto.GetProductFitRecommendationRequest{Id: foundProduct.Id}); err == nil {
+			productDetail.FitRecommendation = fitRecommendation
+		}
+	}
+
+	return productDetail, nil
+}
+
+// Products are returned in order of requested ids, unknown ids (and unpublished products for customers) are left out
+func (productService *productService) GetProductsByListId(ctx context.Context, reqDTO *dto.GetProductsByListIdRequest) ([]*model.ProductView, error) {
+	if len(reqDTO.Ids) > productBatchMaxIds {
+		return nil, fmt.Errorf("number of ids must not exceed %d", productBatchMaxIds)
+	}
+	if len(reqDTO.Ids) == 0 {
+		return []*model.ProductView{}, nil
+	}
+
+	products, err := productService.productRepository.GetViewsByListId(ctx, reqDTO.Ids)
+	if err != nil {
+		return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
+	}
+
+	productMap := make(map[string]*model.ProductView, len(products))
+	for _, product := range products {
+		if product.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+			continue
+		}
+		productMap[product.Id] = product
+	}
+
+	foundProducts := make([]*model.ProductView, 0, len(productMap))
+	for _, id := range reqDTO.Ids {
+		if product, ok := productMap[id]; ok {
+			foundProducts = append(foundProducts, product)
+			// Duplicated ids get product once
+			delete(productMap, id)
+		}
+	}
+
+	return foundProducts, nil
+}
+
+// Former slug resolves to the product it belonged to, caller tells it apart by the current slug of returned product
+func (productService *productService) GetProductBySlug(ctx context.Context, reqDTO *dto.GetProductBySlugRequest) (*model.ProductView, error) {
+	foundProduct, err := productService.productRepository.GetViewBySlug(ctx, reqDTO.Slug)
+	if err != nil {
+		foundSlugRedirect, err := productService.slugRedirectRepository.GetByEntityTypeAndSlug(ctx, "PRODUCT", reqDTO.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("slug of product is not valid: %s", err.Error())
+		}
+		if foundProduct, err = productService.productRepository.GetViewById(ctx, foundSlugRedirect.EntityId); err != nil {
+			return nil, fmt.Errorf("slug of product is not valid: %s", err.Error())
+		}
+	}
+	if foundProduct.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("slug of product is not valid")
+	}
 
 	return foundProduct, nil
 }
 
 func (productService *productService) CreateProduct(ctx context.Context, reqDTO *dto.CreateProductRequest) error {
-	newProduct := model.Product{
+	newProduct, newProductAttributeValues, newBundleComponents, err := productService.buildNewProduct(ctx, reqDTO)
+	if err != nil {
+		return err
+	}
+
+	// Initial stock enters given or default warehouse through ledger like any other stock movement, together with the product
+	if reqDTO.Body.WarehouseId != "" {
+		if _, err := productService.warehouseRepository.GetById(ctx, reqDTO.Body.WarehouseId); err != nil {
+			return fmt.Errorf("id of warehouse not found")
+		}
+	}
+	newStockMovements := []*model.StockMovement{}
+	if reqDTO.Body.Stock > 0 {
+		newStockMovements = append(newStockMovements, &model.StockMovement{
+			Id:          uuid.New().String(),
+			ProductId:   newProduct.Id,
+			WarehouseId: reqDTO.Body.WarehouseId,
+			Quantity:    reqDTO.Body.Stock,
+			Reason:      "PURCHASE",
+			ActorId:     actorIdFromContext(ctx),
+			Note:        "Initial stock",
+		})
+	}
+	if err := productService.productRepository.Create(ctx, newProduct, newStockMovements); err != nil {
+		return fmt.Errorf("insert product to postgresql failed: %s", err.Error())
+	}
+	if err := recordSlugChange(ctx, productService.slugRedirectRepository, "PRODUCT", newProduct.Id, "", newProduct.Slug); err != nil {
+		return err
+	}
+	if err := productService.productAttributeValueRepository.ReplaceByProductId(ctx, newProduct.Id, newProductAttributeValues); err != nil {
+		return fmt.Errorf("insert product attribute values to postgresql failed: %s", err.Error())
+	}
+	if newBundleComponents != nil {
+		if err := productService.bundleComponentRepository.ReplaceByBundleId(ctx, newProduct.Id, newBundleComponents); err != nil {
+			return fmt.Errorf("insert bundle components to postgresql failed: %s", err.Error())
+		}
+	}
+	if err := productService.createProductPriceHistory(ctx, newProduct); err != nil {
+		return err
+	}
+
+	newProductView, err := productService.productRepository.GetViewById(ctx, newProduct.Id)
+	if err != nil {
+		log.Printf("Query product %s from postgresql failed, event catalog-service.created-product is skipped: %s", newProduct.Id, err.Error())
+		return nil
+	}
+	payload, _ := json.Marshal(newProductView)
+	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.created-product", payload).Err(); err != nil {
+		return fmt.Errorf("pulish event catalog-service.created-product failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (productService *productService) CheckCreateProduct(ctx context.Context, reqDTO *dto.CreateProductRequest) error {
+	_, _, _, err := productService.buildNewProduct(ctx, reqDTO)
+	return err
+}
+
+// Check create product request and build product with its attribute values and bundle components, nothing is written
+func (productService *productService) buildNewProduct(ctx context.Context, reqDTO *dto.CreateProductRequest) (*model.Product, []*model.ProductAttributeValue, []*model.BundleComponent, error) {
+	foundCategory, err := productService.categoryRepository.GetById(ctx, reqDTO.Body.CategoryId)
+	if err != nil || foundCategory.DeletedAt != nil {
+		return nil, nil, nil, fmt.Errorf("id of category not found")
+	}
+	foundBrand, err := productService.brandRepository.GetById(ctx, reqDTO.Body.BrandId)
+	if err != nil || foundBrand.DeletedAt != nil {
+		return nil, nil, nil, fmt.Errorf("id of brand not found")
+	}
+	if reqDTO.Body.Status == "PUBLISHED" {
+		if err := checkPublishable(foundBrand, foundCategory); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	newProduct := &model.Product{
 		Id:                 uuid.New().String(),
+		Sku:                reqDTO.Body.Sku,
 		Name:               reqDTO.Body.Name,
+		Slug:               reqDTO.Body.Slug,
 		Description:        reqDTO.Body.Description,
 		Sex:                reqDTO.Body.Sex,
 		Price:              reqDTO.Body.Price,
 		DiscountPercentage: reqDTO.Body.DiscountPercentage,
-		Stock:              reqDTO.Body.Stock,
+		Stock:              0,
 		ImageURL:           reqDTO.Body.ImageURL,
 		CategoryId:         reqDTO.Body.CategoryId,
 		BrandId:            reqDTO.Body.BrandId,
+		Status:             reqDTO.Body.Status,
+		LowStockThreshold:  reqDTO.Body.LowStockThreshold,
+		BackorderPolicy:    reqDTO.Body.BackorderPolicy,
+		ExpectedShipDate:   reqDTO.Body.ExpectedShipDate,
+		BackorderLimit:     reqDTO.Body.BackorderLimit,
+		Type:               reqDTO.Body.Type,
+		BundlePricing:      reqDTO.Body.BundlePricing,
 	}
-	if err := productService.productRepository.Create(ctx, &newProduct); err != nil {
-		return fmt.Errorf("insert product to postgresql failed: %s", err.Error())
+	if err := checkBackorderPolicy(newProduct); err != nil {
+		return nil, nil, nil, err
+	}
+	var newBundleComponents []*model.BundleComponent
+	if newProduct.Type == "BUNDLE" {
+		if reqDTO.Body.Stock > 0 {
+			return nil, nil, nil, fmt.Errorf("stock of bundle is derived from its components")
+		}
+		if newBundleComponents, err = productService.buildBundleComponents(ctx, newProduct, reqDTO.Body.BundleComponents); err != nil {
+			return nil, nil, nil, err
+		}
+	} else if newProduct.BundlePricing != "" || len(reqDTO.Body.BundleComponents) != 0 {
+		return nil, nil, nil, fmt.Errorf("bundle pricing and components are only for bundle")
+	}
+	if newProduct.Tags, err = normalizeProductTags(reqDTO.Body.Tags); err != nil {
+		return nil, nil, nil, err
+	}
+	newProductAttributeValues, err := productService.buildProductAttributeValues(ctx, newProduct.Id, foundCategory, reqDTO.Body.Attributes, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if newProduct.Sku == "" {
+		newProduct.Sku = model.GenerateSku(newProduct.Id)
+	} else if _, err := productService.productRepository.GetBySku(ctx, newProduct.Sku); err == nil {
+		return nil, nil, nil, fmt.Errorf("sku of product is already exists")
+	}
+	if newProduct.Slug == "" {
+		newProduct.Slug = generateUniqueSlug(newProduct.Name, newProduct.Sku, productService.isSlugTaken(ctx, newProduct.Id))
+	} else if productService.isSlugTaken(ctx, newProduct.Id)(newProduct.Slug) {
+		return nil, nil, nil, fmt.Errorf("slug of product is already exists")
 	}
 
-	newProductView, _ := productService.productRepository.GetViewById(ctx, newProduct.Id)
-	payload, _ := json.Marshal(newProductView)
-	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.created-product", payload).Err(); err != nil {
-		return fmt.Errorf("pulish event catalog-service.created-product failed: %s", err.Error())
+	return newProduct, newProductAttributeValues, newBundleComponents, nil
+}
+
+func (productService *productService) UpdateProductById(ctx context.Context, reqDTO *dto.UpdateProductByIdRequest) error {
+	productUpdate, err := productService.buildProductUpdate(ctx, reqDTO)
+	if err != nil {
+		return err
+	}
+	foundProduct := productUpdate.product
+
+	if err := productService.productRepository.Update(ctx, foundProduct); err != nil {
+		return fmt.Errorf("update product on postgresql failed: %s", err.Error())
+	}
+	if err := recordSlugChange(ctx, productService.slugRedirectRepository, "PRODUCT", foundProduct.Id, productUpdate.oldSlug, foundProduct.Slug); err != nil {
+		return err
+	}
+	if productUpdate.productAttributeValues != nil {
+		if err := productService.productAttributeValueRepository.ReplaceByProductId(ctx, foundProduct.Id, productUpdate.productAttributeValues); err != nil {
+			return fmt.Errorf("update product attribute values on postgresql failed: %s", err.Error())
+		}
+	}
+	if productUpdate.bundleComponents != nil {
+		if err := productService.bundleComponentRepository.ReplaceByBundleId(ctx, foundProduct.Id, productUpdate.bundleComponents); err != nil {
+			return fmt.Errorf("update bundle components on postgresql failed: %s", err.Error())
+		}
+	}
+	if productUpdate.priceChanged {
+		if err := productService.createProductPriceHistory(ctx, foundProduct); err != nil {
+			return err
+		}
+	}
+
+	// Setting stock directly is recorded as adjustment by the difference in given warehouse, or spread over warehouses when
+	// none is given, taken against stock at the time of the adjustment rather than stock read above
+	if reqDTO.Body.Stock != nil {
+		newStockMovement := &model.StockMovement{
+			Id:          uuid.New().String(),
+			ProductId:   foundProduct.Id,
+			WarehouseId: reqDTO.Body.WarehouseId,
+			Reason:      "ADJUSTMENT",
+			ActorId:     actorIdFromContext(ctx),
+			Note:        "Stock set by product update",
+		}
+		if err := productService.stockMovementRepository.CreateAdjustment(ctx, newStockMovement, *reqDTO.Body.Stock); err != nil {
+			return fmt.Errorf("insert stock movement to postgresql failed: %s", err.Error())
+		}
+	}
+
+	updatedProductView, err := productService.productRepository.GetViewById(ctx, foundProduct.Id)
+	if err != nil {
+		log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", foundProduct.Id, err.Error())
+		return nil
+	}
+	payload, _ := json.Marshal(updatedProductView)
+	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+		return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
 	}
 
 	return nil
 }
 
-func (productService *productService) UpdateProductById(ctx context.Context, reqDTO *dto.UpdateProductByIdRequest) error {
+func (productService *productService) CheckUpdateProductById(ctx context.Context, reqDTO *dto.UpdateProductByIdRequest) error {
+	_, err := productService.buildProductUpdate(ctx, reqDTO)
+	return err
+}
+
+// Product with update applied and what else has to be written with it
+type productUpdate struct {
+	product                *model.Product
+	oldSlug                string
+	priceChanged           bool
+	productAttributeValues []*model.ProductAttributeValue
+	bundleComponents       []*model.BundleComponent
+}
+
+// Check update product request and apply it to product, nothing is written
+func (productService *productService) buildProductUpdate(ctx context.Context, reqDTO *dto.UpdateProductByIdRequest) (*productUpdate, error) {
 	foundProduct, err := productService.productRepository.GetById(ctx, reqDTO.Id)
 	if err != nil {
-		return fmt.Errorf("id of product is not valid: %s", err.Error())
+		return nil, fmt.Errorf("id of product is not valid: %s", err.Error())
+	}
+	if foundProduct.DeletedAt != nil {
+		return nil, fmt.Errorf("product is archived, restore it first")
 	}
 
-	if reqDTO.Body.Name != nil {
+	if reqDTO.Body.Sku != nil && *reqDTO.Body.Sku != foundProduct.Sku {
+		if _, err := productService.productRepository.GetBySku(ctx, *reqDTO.Body.Sku); err == nil {
+			return nil, fmt.Errorf("sku of product is already exists")
+		}
+		foundProduct.Sku = *reqDTO.Body.Sku
+	}
+	oldSlug := foundProduct.Slug
+	if reqDTO.Body.Slug != nil && *reqDTO.Body.Slug != foundProduct.Slug {
+		if productService.isSlugTaken(ctx, foundProduct.Id)(*reqDTO.Body.Slug) {
+			return nil, fmt.Errorf("slug of product is already exists")
+		}
+		foundProduct.Slug = *reqDTO.Body.Slug
+	}
+	if reqDTO.Body.Name != nil && *reqDTO.Body.Name != foundProduct.Name {
 		foundProduct.Name = *reqDTO.Body.Name
+		// Slug follows name unless it is edited explicitly
+		if reqDTO.Body.Slug == nil {
+			foundProduct.Slug = generateUniqueSlug(foundProduct.Name, foundProduct.Sku, productService.isSlugTaken(ctx, foundProduct.Id))
+		}
 	}
 	if reqDTO.Body.Description != nil {
 		foundProduct.Description = *reqDTO.Body.Description
@@ -94,50 +431,169 @@ func (productService *productService) UpdateProductById(ctx context.Context, req
 	if reqDTO.Body.Sex != nil {
 		foundProduct.Sex = *reqDTO.Body.Sex
 	}
+	oldPrice := foundProduct.Price
+	oldDiscountPercentage := foundProduct.DiscountPercentage
 	if reqDTO.Body.Price != nil {
 		foundProduct.Price = *reqDTO.Body.Price
 	}
 	if reqDTO.Body.DiscountPercentage != nil {
 		foundProduct.DiscountPercentage = *reqDTO.Body.DiscountPercentage
 	}
-	if reqDTO.Body.Stock != nil {
-		foundProduct.Stock = *reqDTO.Body.Stock
+	if reqDTO.Body.WarehouseId != "" {
+		if reqDTO.Body.Stock == nil {
+			return nil, fmt.Errorf("warehouse id of product is only used when setting stock")
+		}
+		if _, err := productService.warehouseRepository.GetById(ctx, reqDTO.Body.WarehouseId); err != nil {
+			return nil, fmt.Errorf("id of warehouse not found")
+		}
 	}
+	// Components are checked again whenever pricing of bundle changes, price of bundle priced off them follows them
+	var updatedBundleComponents []*model.BundleComponent
+	if foundProduct.Type == "BUNDLE" {
+		if reqDTO.Body.Stock != nil {
+			return nil, fmt.Errorf("stock of bundle is derived from its components")
+		}
+		if reqDTO.Body.BundlePricing != nil {
+			foundProduct.BundlePricing = *reqDTO.Body.BundlePricing
+		}
+		if reqDTO.Body.BundleComponents != nil || reqDTO.Body.BundlePricing != nil || reqDTO.Body.Price != nil {
+			var bundleComponentReqs []*dto.BundleComponentRequest
+			if reqDTO.Body.BundleComponents != nil {
+				bundleComponentReqs = *reqDTO.Body.BundleComponents
+			} else {
+				bundleComponents, err := productService.bundleComponentRepository.GetByBundleId(ctx, foundProduct.Id)
+				if err != nil {
+					return nil, fmt.Errorf("query bundle components from postgresql failed: %s", err.Error())
+				}
+				for _, bundleComponent := range bundleComponents {
+					bundleComponentReqs = append(bundleComponentReqs, &dto.BundleComponentRequest{
+						ProductId: bundleComponent.ComponentId,
+						Quantity:  bundleComponent.Quantity,
+					})
+				}
+			}
+			if updatedBundleComponents, err = productService.buildBundleComponents(ctx, foundProduct, bundleComponentReqs); err != nil {
+				return nil, err
+			}
+		}
+	} else if reqDTO.Body.BundlePricing != nil || reqDTO.Body.BundleComponents != nil {
+		return nil, fmt.Errorf("bundle pricing and components are only for bundle")
+	}
+	priceChanged := foundProduct.Price != oldPrice || foundProduct.DiscountPercentage != oldDiscountPercentage
 	if reqDTO.Body.ImageURL != nil {
 		foundProduct.ImageURL = *reqDTO.Body.ImageURL
 	}
-	if reqDTO.Body.CategoryId != nil {
-		if _, err = productService.categoryRepository.GetById(ctx, *reqDTO.Body.CategoryId); err != nil {
-			return fmt.Errorf("id of category not found")
+	// Attribute values are checked against attribute set of category whenever either of them changes
+	var updatedProductAttributeValues []*model.ProductAttributeValue
+	if reqDTO.Body.Attributes != nil || (reqDTO.Body.CategoryId != nil && *reqDTO.Body.CategoryId != foundProduct.CategoryId) {
+		foundCategory, err := productService.categoryRepository.GetById(ctx, foundProduct.CategoryId)
+		if reqDTO.Body.CategoryId != nil {
+			foundCategory, err = productService.categoryRepository.GetById(ctx, *reqDTO.Body.CategoryId)
+		}
+		if err != nil || foundCategory.DeletedAt != nil {
+			return nil, fmt.Errorf("id of category not found")
+		}
+		foundProduct.CategoryId = foundCategory.Id
+
+		attributes := map[string]string{}
+		if reqDTO.Body.Attributes != nil {
+			attributes = *reqDTO.Body.Attributes
+		} else {
+			foundProductView, err := productService.productRepository.GetViewById(ctx, foundProduct.Id)
+			if err != nil {
+				return nil, fmt.Errorf("query product from postgresql failed: %s", err.Error())
+			}
+			for _, productAttribute := range foundProductView.Attributes {
+				attributes[productAttribute.Code] = productAttribute.Value
+			}
+		}
+		if updatedProductAttributeValues, err = productService.buildProductAttributeValues(ctx, foundProduct.Id, foundCategory, attributes, reqDTO.Body.Attributes != nil); err != nil {
+			return nil, err
+		}
+	}
+	if reqDTO.Body.Tags != nil {
+		if foundProduct.Tags, err = normalizeProductTags(*reqDTO.Body.Tags); err != nil {
+			return nil, err
 		}
-		foundProduct.CategoryId = *reqDTO.Body.CategoryId
 	}
 	if reqDTO.Body.BrandId != nil {
-		if _, err = productService.brandRepository.GetById(ctx, *reqDTO.Body.BrandId); err != nil {
-			return fmt.Errorf("id of brand not found")
+		if foundBrand, err := productService.brandRepository.GetById(ctx, *reqDTO.Body.BrandId); err != nil || foundBrand.DeletedAt != nil {
+			return nil, fmt.Errorf("id of brand not found")
 		}
 		foundProduct.BrandId = *reqDTO.Body.BrandId
 	}
+	if reqDTO.Body.LowStockThreshold != nil {
+		if *reqDTO.Body.LowStockThreshold < 0 {
+			foundProduct.LowStockThreshold = nil
+		} else {
+			foundProduct.LowStockThreshold = reqDTO.Body.LowStockThreshold
+		}
+	}
+	if reqDTO.Body.BackorderPolicy != nil {
+		foundProduct.BackorderPolicy = *reqDTO.Body.BackorderPolicy
+	}
+	if reqDTO.Body.ExpectedShipDate != nil {
+		foundProduct.ExpectedShipDate = reqDTO.Body.ExpectedShipDate
+	}
+	if reqDTO.Body.BackorderLimit != nil {
+		foundProduct.BackorderLimit = *reqDTO.Body.BackorderLimit
+	}
+	if err := checkBackorderPolicy(foundProduct); err != nil {
+		return nil, err
+	}
 	timeUpdate := time.Now().UTC()
+	if reqDTO.Body.Status != nil {
+		foundProduct.Status = *reqDTO.Body.Status
+		if foundProduct.Status == "ARCHIVED" {
+			foundProduct.DeletedAt = &timeUpdate
+		}
+	}
+	// Publishing product or moving published product is checked against its (new) brand and category
+	if foundProduct.Status == "PUBLISHED" && (reqDTO.Body.Status != nil || reqDTO.Body.CategoryId != nil || reqDTO.Body.BrandId != nil) {
+		foundCategory, err := productService.categoryRepository.GetById(ctx, foundProduct.CategoryId)
+		if err != nil {
+			return nil, fmt.Errorf("id of category not found")
+		}
+		foundBrand, err := productService.brandRepository.GetById(ctx, foundProduct.BrandId)
+		if err != nil {
+			return nil, fmt.Errorf("id of brand not found")
+		}
+		if err := checkPublishable(foundBrand, foundCategory); err != nil {
+			return nil, err
+		}
+	}
 	foundProduct.UpdatedAt = &timeUpdate
 
-	if err := productService.productRepository.Update(ctx, foundProduct); err != nil {
-		return fmt.Errorf("update product on postgresql failed: %s", err.Error())
-	}
+	return &productUpdate{
+		product:                foundProduct,
+		oldSlug:                oldSlug,
+		priceChanged:           priceChanged,
+		productAttributeValues: updatedProductAttributeValues,
+		bundleComponents:       updatedBundleComponents,
+	}, nil
+}
 
-	updatedProductView, _ := productService.productRepository.GetViewById(ctx, foundProduct.Id)
-	payload, _ := json.Marshal(updatedProductView)
-	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
-		return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+// Published product is searchable and purchasable, so its brand and category must be published as well
+func checkPublishable(brand *model.Brand, category *model.Category) error {
+	if brand.Status != "PUBLISHED" {
+		return fmt.Errorf("brand of product is not published, publish it first")
+	}
+	if category.Status != "PUBLISHED" {
+		return fmt.Errorf("category of product is not published, publish it first")
 	}
 
 	return nil
 }
 
+// Soft delete, images, reviews, stock and price history of product are kept so that it can be restored
 func (productService *productService) DeleteProductById(ctx context.Context, reqDTO *dto.DeleteProductByIdRequest) error {
-	if _, err := productService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+	foundProduct, err := productService.productRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
 		return fmt.Errorf("id of product is not valid")
 	}
+	if foundProduct.DeletedAt != nil {
+		return fmt.Errorf("product is already archived")
+	}
 
 	if err := productService.productRepository.DeleteById(ctx, reqDTO.Id); err != nil {
 		return fmt.Errorf("delete product from postgresql failed: %s", err.Error())
@@ -150,8 +606,38 @@ func (productService *productService) DeleteProductById(ctx context.Context, req
 	return nil
 }
 
-func (productService *productService) GetAllProducts(ctx context.Context) ([]*model.ProductView, error) {
-	products, err := productService.productRepository.GetAllViews(ctx)
+// Restored product comes back as draft so that it is reviewed before being published again
+func (productService *productService) RestoreProductById(ctx context.Context, reqDTO *dto.RestoreProductByIdRequest) error {
+	foundProduct, err := productService.productRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of product is not valid")
+	}
+	if foundProduct.Status != "ARCHIVED" {
+		return fmt.Errorf("product is not archived")
+	}
+	if foundCategory, err := productService.categoryRepository.GetById(ctx, foundProduct.CategoryId); err != nil || foundCategory.DeletedAt != nil {
+		return fmt.Errorf("category of product is archived, restore it first")
+	}
+	if foundBrand, err := productService.brandRepository.GetById(ctx, foundProduct.BrandId); err != nil || foundBrand.DeletedAt != nil {
+		return fmt.Errorf("brand of product is archived, restore it first")
+	}
+
+	timeUpdate := time.Now().UTC()
+	foundProduct.Status = "DRAFT"
+	foundProduct.DeletedAt = nil
+	foundProduct.UpdatedAt = &timeUpdate
+
+	if err := productService.productRepository.Update(ctx, foundProduct); err != nil {
+		return fmt.Errorf("update product on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (productService *productService) GetArchivedProducts(ctx context.Context, reqDTO *dto.GetArchivedProductsRequest) ([]*model.ProductView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	products, err := productService.productRepository.GetArchivedViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields)
 	if err != nil {
 		return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
 	}
@@ -159,45 +645,485 @@ func (productService *productService) GetAllProducts(ctx context.Context) ([]*mo
 	return products, nil
 }
 
-func (productService *productService) UpdateProductStocksByListInvoiceDetail(ctx context.Context, reqDTO *dto.UpdateProductStocksByListInvoiceDetailRequest) error {
-	ids := make([]string, len(reqDTO.InvoiceDetails))
-	quantityMap := map[string]int32{}
-	for i, invoiceDetail := range reqDTO.InvoiceDetails {
-		ids[i] = invoiceDetail.ProductId
-		quantityMap[invoiceDetail.ProductId] = invoiceDetail.Quantity
+func (productService *productService) ClickProduct(ctx context.Context, reqDTO *dto.ClickProductRequest) error {
+	if _, err := productService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("id of product is not valid")
 	}
 
-	foundProducts, err := productService.productRepository.GetByListId(ctx, ids)
+	userId, _ := ctx.Value("user_id").(string)
+	productClickView := model.ProductClickView{
+		ProductId: reqDTO.Id,
+		SearchId:  reqDTO.SearchId,
+		UserId:    userId,
+		CreatedAt: time.Now(),
+	}
+	payload, _ := json.Marshal(productClickView)
+	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.clicked-product", payload).Err(); err != nil {
+		return fmt.Errorf("pulish event catalog-service.clicked-product failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (productService *productService) GetProductPriceHistory(ctx context.Context, reqDTO *dto.GetProductPriceHistoryRequest) ([]*model.ProductPriceHistoryView, error) {
+	if _, err := productService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+	productPriceHistories, err := productService.productPriceHistoryRepository.GetViewsByProductId(ctx, reqDTO.Id, int(reqDTO.Offset), int(reqDTO.Limit), sortFields)
 	if err != nil {
-		return fmt.Errorf("query products from postgresql failed: %s", err.Error())
+		return nil, fmt.Errorf("query product price history from postgresql failed: %s", err.Error())
+	}
+
+	return productPriceHistories, nil
+}
+
+// Record current price and discount of product, lowest price in the last 30 days is derived from these records
+func (productService *productService) createProductPriceHistory(ctx context.Context, product *model.Product) error {
+	if err := productService.productPriceHistoryRepository.CreateByListProductId(ctx, []string{product.Id}, actorIdFromContext(ctx)); err != nil {
+		return fmt.Errorf("insert product price history to postgresql failed: %s", err.Error())
 	}
 
-	for i := range foundProducts {
-		if foundProducts[i].Stock < quantityMap[foundProducts[i].Id] {
-			return fmt.Errorf("not enough stock for product id: %s", foundProducts[i].Id)
+	return nil
+}
+
+// Published products are sent page by page, so that no message grows with catalog
+func (productService *productService) StreamAllProducts(ctx context.Context, send func(products []*model.ProductView) error) error {
+	afterId := ""
+	for {
+		products, err := productService.productRepository.GetViewsAfterId(ctx, afterId, productStreamBatchSize)
+		if err != nil {
+			return fmt.Errorf("query products from postgresql failed: %s", err.Error())
+		}
+		if len(products) == 0 {
+			return nil
 		}
-		foundProducts[i].Stock = foundProducts[i].Stock - quantityMap[foundProducts[i].Id]
-		timeUpdate := time.Now().UTC()
-		foundProducts[i].UpdatedAt = &timeUpdate
+
+		if err := send(products); err != nil {
+			return fmt.Errorf("send products failed: %s", err.Error())
+		}
+
+		if len(products) < productStreamBatchSize {
+			return nil
+		}
+		afterId = products[len(products)-1].Id
 	}
+}
 
-	if err := productService.productRepository.UpdateStocks(ctx, foundProducts); err != nil {
-		return fmt.Errorf("update stock of products from postgresql failed: %s", err.Error())
+func (productService *productService) UpdateProductStocksByListInvoiceDetail(ctx context.Context, reqDTO *dto.UpdateProductStocksByListInvoiceDetailRequest) ([]*model.StockAllocation, error) {
+	if err := validateInvoiceDetailQuantities(reqDTO.InvoiceDetails); err != nil {
+		return nil, err
 	}
+	// Merge invoice details of the same product so that its stock is checked against total quantity
+	quantityMap := mergeInvoiceDetailQuantities(reqDTO.InvoiceDetails)
+
+	// Lock products in the same order in every transaction to avoid deadlocks between concurrent checkouts
+	ids := sortedQuantityMapIds(quantityMap)
+
+	var stockAllocations []*model.StockAllocation
+	var stockIds []string
+	for attempt := 1; ; attempt++ {
+		products, err := productService.productRepository.GetByListId(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
+		}
+		productMap := make(map[string]*model.Product, len(products))
+		for _, product := range products {
+			productMap[product.Id] = product
+		}
+
+		productPrices, newPromotionUsages, err := productService.priceProducts(ctx, productMap, ids, quantityMap, reqDTO.ActorId, reqDTO.InvoiceId)
+		if err != nil {
+			return nil, err
+		}
+
+		// Stock is taken from products bought on their own and from components of bundles, a product may be both
+		stockQuantityMap := map[string]int32{}
+		bundleIds := []string{}
+		for _, id := range ids {
+			if productMap[id].Type == "BUNDLE" {
+				bundleIds = append(bundleIds, id)
+			} else {
+				stockQuantityMap[id] += quantityMap[id]
+			}
+		}
+		var bundleComponents []*model.BundleComponent
+		if len(bundleIds) != 0 {
+			if bundleComponents, err = productService.bundleComponentRepository.GetByListBundleId(ctx, bundleIds); err != nil {
+				return nil, fmt.Errorf("query bundle components from postgresql failed: %s", err.Error())
+			}
+			componentIds := []string{}
+			for _, bundleComponent := range bundleComponents {
+				stockQuantityMap[bundleComponent.ComponentId] += quantityMap[bundleComponent.BundleId] * bundleComponent.Quantity
+				if _, ok := productMap[bundleComponent.ComponentId]; !ok {
+					componentIds = append(componentIds, bundleComponent.ComponentId)
+				}
+			}
+			if len(componentIds) != 0 {
+				components, err := productService.productRepository.GetByListId(ctx, componentIds)
+				if err != nil {
+					return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
+				}
+				for _, component := range components {
+					productMap[component.Id] = component
+				}
+			}
+			for _, bundleComponent := range bundleComponents {
+				if component, ok := productMap[bundleComponent.ComponentId]; !ok || component.Status != "PUBLISHED" {
+					return nil, fmt.Errorf("product id %s is not available", bundleComponent.BundleId)
+				}
+			}
+		}
+		// Stock of products is taken in order of id as well, components included
+		stockIds = sortedQuantityMapIds(stockQuantityMap)
+
+		warehouseStocks, err := productService.warehouseRepository.GetActiveStockViewsByListProductId(ctx, stockIds)
+		if err != nil {
+			return nil, fmt.Errorf("query warehouse stocks from postgresql failed: %s", err.Error())
+		}
+		warehouseStocksMap := map[string][]*model.WarehouseStockView{}
+		for _, warehouseStock := range warehouseStocks {
+			warehouseStocksMap[warehouseStock.ProductId] = append(warehouseStocksMap[warehouseStock.ProductId], warehouseStock)
+		}
+
+		stockAllocations = []*model.StockAllocation{}
+		newBackorders := []*model.Backorder{}
+		for _, id := range stockIds {
+			// Pre-order and backorder products take what warehouses have and queue the rest as a backorder, units of
+			// bundles are never backordered
+			allocatedQuantity := stockQuantityMap[id]
+			directQuantity := quantityMap[id]
+			// Stock is held for queued backorders first, they are allocated as soon as it arrives and new sales must not
+			// overtake them
+			product := productMap[id]
+			availableStock := int32(0)
+			for _, warehouseStock := range warehouseStocksMap[id] {
+				availableStock += warehouseStock.Stock
+			}
+			availableStock = max(availableStock-product.BackorderQuantity, 0)
+			if product.BackorderPolicy != "NONE" {
+				if backorderQuantity := min(allocatedQuantity-availableStock, directQuantity); backorderQuantity > 0 {
+					if product.BackorderLimit > 0 && product.BackorderQuantity+backorderQuantity > product.BackorderLimit {
+						return nil, fmt.Errorf("not enough stock for product id %s, only %d units can still be ordered", id, availableStock+max(product.BackorderLimit-product.BackorderQuantity, 0))
+					}
+					allocatedQuantity -= backorderQuantity
+					directQuantity -= backorderQuantity
+					stockAllocations = append(stockAllocations, &model.StockAllocation{
+						ProductId: id,
+						Quantity:  backorderQuantity,
+						Pending:   true,
+					})
+					newBackorder := &model.Backorder{
+						Id:        uuid.New().String(),
+						InvoiceId: reqDTO.InvoiceId,
+						ProductId: id,
+						Quantity:  backorderQuantity,
+						Status:    "PENDING",
+					}
+					if reqDTO.ActorId != "" {
+						newBackorder.ActorId = &reqDTO.ActorId
+					}
+					if reqDTO.ShippingLocation != nil {
+						newBackorder.ShippingLatitude = &reqDTO.ShippingLocation.Latitude
+						newBackorder.ShippingLongitude = &reqDTO.ShippingLocation.Longitude
+					}
+					newBackorders = append(newBackorders, newBackorder)
+				}
+			}
+
+			if allocatedQuantity > availableStock {
+				return nil, fmt.Errorf("not enough stock for product id %s, only %d units are available", id, availableStock)
+			}
+			productStockAllocations := []*model.StockAllocation{}
+			if allocatedQuantity > 0 {
+				if productStockAllocations, err = productService.stockAllocationStrategy.Allocate(id, allocatedQuantity, warehouseStocksMap[id], reqDTO.ShippingLocation); err != nil {
+					return nil, err
+				}
+			}
+			// Units bought on their own take the first allocations, then each bundle takes its units in order
+			stockAllocations = append(stockAllocations, takeStockAllocations(&productStockAllocations, directQuantity, "")...)
+			for _, bundleComponent := range bundleComponents {
+				if bundleComponent.ComponentId == id {
+					stockAllocations = append(stockAllocations, takeStockAllocations(&productStockAllocations, quantityMap[bundleComponent.BundleId]*bundleComponent.Quantity, bundleComponent.BundleId)...)
+				}
+			}
+		}
+		for _, bundleId := range bundleIds {
+			stockAllocations = append(stockAllocations, &model.StockAllocation{
+				ProductId: bundleId,
+				Quantity:  quantityMap[bundleId],
+				Bundle:    true,
+			})
+		}
+		for _, stockAllocation := range stockAllocations {
+			// Components are priced as part of their bundle
+			if stockAllocation.BundleId != "" {
+				continue
+			}
+			stockAllocation.Price = productPrices[stockAllocation.ProductId].Price
+			stockAllocation.DiscountPercentage = productPrices[stockAllocation.ProductId].DiscountPercentage
+			stockAllocation.PromotionId = productPrices[stockAllocation.ProductId].PromotionId
+		}
+
+		newStockMovements := []*model.StockMovement{}
+		for _, stockAllocation := range stockAllocations {
+			if stockAllocation.Pending || stockAllocation.Bundle {
+				continue
+			}
+			newStockMovement := &model.StockMovement{
+				Id:          uuid.New().String(),
+				ProductId:   stockAllocation.ProductId,
+				WarehouseId: stockAllocation.WarehouseId,
+				Quantity:    -stockAllocation.Quantity,
+				Reason:      "SALE",
+			}
+			if reqDTO.InvoiceId != "" {
+				newStockMovement.InvoiceId = &reqDTO.InvoiceId
+			}
+			if reqDTO.ActorId != "" {
+				newStockMovement.ActorId = &reqDTO.ActorId
+			}
+			newStockMovements = append(newStockMovements, newStockMovement)
+		}
 
-	for _, foundProduct := range foundProducts {
-		updatedProductView, _ := productService.productRepository.GetViewById(ctx, foundProduct.Id)
+		// Stock of every product is decreased, units of promotions are claimed and backorders are queued atomically, any
+		// missing product, insufficient stock, unavailable promotion or backorder rolls back all of them
+		err = productService.stockMovementRepository.CreateSale(ctx, newStockMovements, newPromotionUsages, newBackorders)
+		if err == nil {
+			break
+		}
+		retryable := errors.Is(err, repository.ErrNotEnoughStock) || errors.Is(err, repository.ErrPromotionUnavailable) || errors.Is(err, repository.ErrBackorderUnavailable)
+		if !retryable || attempt == maxStockAllocationAttempts {
+			return nil, fmt.Errorf("update stock of products from postgresql failed: %s", err.Error())
+		}
+	}
+
+	// Bundles are published once their components are
+	for _, id := range stockIds {
+		updatedProductView, err := productService.productRepository.GetViewById(ctx, id)
+		if err != nil {
+			log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", id, err.Error())
+			continue
+		}
 		payload, _ := json.Marshal(updatedProductView)
 		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
-			return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+			return nil, fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+		}
+	}
+
+	return stockAllocations, nil
+}
+
+func validateInvoiceDetailQuantities(invoiceDetails []dto.InvoiceDetail) error {
+	for _, invoiceDetail := range invoiceDetails {
+		if invoiceDetail.Quantity <= 0 {
+			return fmt.Errorf("quantity of product id %s must be positive", invoiceDetail.ProductId)
+		}
+	}
+
+	return nil
+}
+
+func mergeInvoiceDetailQuantities(invoiceDetails []dto.InvoiceDetail) map[string]int32 {
+	quantityMap := map[string]int32{}
+	for _, invoiceDetail := range invoiceDetails {
+		quantityMap[invoiceDetail.ProductId] += invoiceDetail.Quantity
+	}
+
+	return quantityMap
+}
+
+func sortedQuantityMapIds(quantityMap map[string]int32) []string {
+	ids := make([]string, 0, len(quantityMap))
+	for id := range quantityMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Take quantity from the front of allocations of a product, an allocation is split when only part of it is taken
+func takeStockAllocations(stockAllocations *[]*model.StockAllocation, quantity int32, bundleId string) []*model.StockAllocation {
+	takenStockAllocations := []*model.StockAllocation{}
+	for quantity > 0 && len(*stockAllocations) != 0 {
+		stockAllocation := (*stockAllocations)[0]
+		takenStockAllocation := *stockAllocation
+		takenStockAllocation.Quantity = min(stockAllocation.Quantity, quantity)
+		takenStockAllocation.BundleId = bundleId
+		takenStockAllocations = append(takenStockAllocations, &takenStockAllocation)
+
+		quantity -= takenStockAllocation.Quantity
+		stockAllocation.Quantity -= takenStockAllocation.Quantity
+		if stockAllocation.Quantity == 0 {
+			*stockAllocations = (*stockAllocations)[1:]
+		}
+	}
+
+	return takenStockAllocations
+}
+
+// Bundles follow their components, price of bundles priced off a component follows price of it and bundles are published
+// again whenever a component is updated (stock, price, status, ...) so that their derived stock and price are searchable
+func (productService *productService) syncBundlesLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.updated-product", "catalog-service.deleted-product")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		// Archived component makes up no unit of its bundles any more, payload of deleted product is its id
+		componentId := msg.Payload
+		if msg.Channel == "catalog-service.updated-product" {
+			var updatedProduct struct {
+				Id   string `json:"id"`
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal([]byte(msg.Payload), &updatedProduct); err != nil {
+				log.Printf("Parse payload from event catalog-service.updated-product failed: %s", err.Error())
+				continue
+			}
+			if updatedProduct.Type == "BUNDLE" {
+				continue
+			}
+			componentId = updatedProduct.Id
+		}
+
+		ctx := context.Background()
+
+		bundleIds, err := productService.bundleComponentRepository.GetBundleIdsByComponentId(ctx, componentId)
+		if err != nil {
+			log.Printf("Query bundles of product %s from postgresql failed: %s", componentId, err.Error())
+			continue
+		}
+		if len(bundleIds) == 0 {
+			continue
+		}
+
+		if _, err := productService.bundleComponentRepository.SyncByComponentId(ctx, componentId); err != nil {
+			log.Printf("Update bundles of product %s on postgresql failed: %s", componentId, err.Error())
+		}
+
+		for _, bundleId := range bundleIds {
+			updatedBundleView, err := productService.productRepository.GetViewById(ctx, bundleId)
+			if err != nil {
+				log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", bundleId, err.Error())
+				continue
+			}
+			payload, _ := json.Marshal(updatedBundleView)
+			if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+				log.Printf("Pulish event catalog-service.updated-product failed: %s", err.Error())
+			}
 		}
 	}
+}
+
+// Pre-order products ship on expected ship date, products which stop accepting backorders no longer need one. Bundles are
+// only sold from stock of their components.
+func checkBackorderPolicy(product *model.Product) error {
+	if product.Type == "BUNDLE" && product.BackorderPolicy != "NONE" {
+		return fmt.Errorf("bundle cannot be pre-ordered or backordered")
+	}
+
+	switch product.BackorderPolicy {
+	case "PRE_ORDER":
+		if product.ExpectedShipDate == nil {
+			return fmt.Errorf("expected ship date is required for pre-order product")
+		}
+	case "NONE":
+		product.ExpectedShipDate = nil
+	}
 
 	return nil
 }
 
-func (productService *productService) GetProducts(ctx context.Context, reqDTO *dto.GetProductsRequest) ([]*model.ProductView, error) {
+// Price of each product at checkout, discounted by the best promotion in effect which still fits whole quantity in its
+// allotment and per user limit, otherwise by discount of product itself
+func (productService *productService) priceProducts(ctx context.Context, productMap map[string]*model.Product, ids []string, quantityMap map[string]int32, userId string, invoiceId string) (map[string]*model.StockAllocation, []*model.PromotionUsage, error) {
+	productPrices := map[string]*model.StockAllocation{}
+	for _, id := range ids {
+		product, ok := productMap[id]
+		if !ok {
+			return nil, nil, fmt.Errorf("id of product not found: %s", id)
+		}
+		if product.Status != "PUBLISHED" {
+			return nil, nil, fmt.Errorf("product id %s is not available", product.Id)
+		}
+		productPrices[product.Id] = &model.StockAllocation{
+			ProductId:          product.Id,
+			Price:              product.Price,
+			DiscountPercentage: product.DiscountPercentage,
+		}
+	}
+
+	productPromotions, err := productService.promotionRepository.GetInEffectByListProductId(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query promotions from postgresql failed: %s", err.Error())
+	}
+
+	// Units claimed by this checkout so far, several products may share a promotion
+	claimedQuantityMap := map[string]int32{}
+	usedQuantityMap := map[string]int32{}
+	for _, productPromotion := range productPromotions {
+		productPrice := productPrices[productPromotion.ProductId]
+		if productPrice.PromotionId != "" || productPromotion.DiscountPercentage <= productPrice.DiscountPercentage {
+			continue
+		}
+
+		quantity := quantityMap[productPromotion.ProductId]
+		claimedQuantity := claimedQuantityMap[productPromotion.PromotionId]
+		if productPromotion.StockAllotment > 0 && productPromotion.SoldQuantity+claimedQuantity+quantity > productPromotion.StockAllotment {
+			continue
+		}
+		if productPromotion.PerUserLimit > 0 {
+			if userId == "" {
+				continue
+			}
+			usedQuantity, ok := usedQuantityMap[productPromotion.PromotionId]
+			if !ok {
+				if usedQuantity, err = productService.promotionRepository.GetUsedQuantityByIdAndUserId(ctx, productPromotion.PromotionId, userId); err != nil {
+					return nil, nil, fmt.Errorf("query promotion usages from postgresql failed: %s", err.Error())
+				}
+				usedQuantityMap[productPromotion.PromotionId] = usedQuantity
+			}
+			if usedQuantity+claimedQuantity+quantity > productPromotion.PerUserLimit {
+				continue
+			}
+		}
+
+		productPrice.DiscountPercentage = productPromotion.DiscountPercentage
+		productPrice.PromotionId = productPromotion.PromotionId
+		claimedQuantityMap[productPromotion.PromotionId] += quantity
+	}
+
+	newPromotionUsages := []*model.PromotionUsage{}
+	for _, id := range ids {
+		if productPrices[id].PromotionId == "" {
+			continue
+		}
+		newPromotionUsages = append(newPromotionUsages, &model.PromotionUsage{
+			Id:          uuid.New().String(),
+			PromotionId: productPrices[id].PromotionId,
+			UserId:      userId,
+			InvoiceId:   invoiceId,
+			ProductId:   id,
+			Quantity:    quantityMap[id],
+		})
+	}
+	// Lock promotions in the same order in every transaction to avoid deadlocks between concurrent checkouts
+	sort.SliceStable(newPromotionUsages, func(i, j int) bool {
+		return newPromotionUsages[i].PromotionId < newPromotionUsages[j].PromotionId
+	})
+
+	return productPrices, newPromotionUsages, nil
+}
+
+func (productService *productService) GetProducts(ctx context.Context, reqDTO *dto.GetProductsRequest) ([]*model.ProductView, []*model.ProductFacetView, string, error) {
 	if infrastructure.ElasticsearchServiceGRPCClient != nil {
+		searchId := uuid.New().String()
+		userId, _ := ctx.Value("user_id").(string)
+
 		convertReqDTO := &elasticsearchservicepb.GetProductsRequest{}
 		convertReqDTO.Offset = reqDTO.Offset
 		convertReqDTO.Limit = reqDTO.Limit
@@ -209,22 +1135,275 @@ func (productService *productService) GetProducts(ctx context.Context, reqDTO *d
 		convertReqDTO.Sex = reqDTO.Sex
 		convertReqDTO.PriceGte = reqDTO.PriceGTE
 		convertReqDTO.PriceLte = reqDTO.PriceLTE
+		convertReqDTO.FinalPriceGte = reqDTO.FinalPriceGTE
+		convertReqDTO.FinalPriceLte = reqDTO.FinalPriceLTE
 		convertReqDTO.DiscountPercentageGte = reqDTO.DiscountPercentageGTE
 		convertReqDTO.DiscountPercentageLte = reqDTO.DiscountPercentageLTE
 		convertReqDTO.StockGte = reqDTO.StockGTE
 		convertReqDTO.StockLte = reqDTO.StockLTE
+		convertReqDTO.AverageRatingGte = reqDTO.AverageRatingGTE
+		convertReqDTO.RatingCountGte = reqDTO.RatingCountGTE
 		convertReqDTO.CategoryName = reqDTO.CategoryName
 		convertReqDTO.BrandName = reqDTO.BrandName
 		convertReqDTO.CreatedAtGte = reqDTO.CreatedAtGTE
 		convertReqDTO.CreatedAtLte = reqDTO.CreatedAtLTE
+		convertReqDTO.Attributes = reqDTO.Attributes
+		convertReqDTO.Tags = reqDTO.Tags
+		convertReqDTO.UserId = userId
+		convertReqDTO.SearchId = searchId
 
 		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetProducts(ctx, convertReqDTO)
 		if err != nil {
-			return nil, fmt.Errorf("get products from elasticsearch-service failed: %s", err.Error())
+			return nil, nil, "", fmt.Errorf("get products from elasticsearch-service failed: %s", err.Error())
+		}
+
+		return model.FromListProductProtoToListProductView(grpcRes.Products), model.FromListProductFacetProtoToListProductFacetView(grpcRes.Facets), searchId, nil
+	} else {
+		return nil, nil, "", fmt.Errorf("elasticsearch-service is not running")
+	}
+}
+
+func (productService *productService) GetSearchReport(ctx context.Context, reqDTO *dto.GetSearchReportRequest) (*model.SearchReportView, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient != nil {
+		convertReqDTO := &elasticsearchservicepb.GetSearchReportRequest{}
+		convertReqDTO.Type = reqDTO.Type
+		convertReqDTO.Limit = reqDTO.Limit
+		convertReqDTO.CreatedAtGte = reqDTO.CreatedAtGTE
+		convertReqDTO.CreatedAtLte = reqDTO.CreatedAtLTE
+
+		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetSearchReport(ctx, convertReqDTO)
+		if err != nil {
+			return nil, fmt.Errorf("get search report from elasticsearch-service failed: %s", err.Error())
+		}
+
+		return model.FromSearchReportProtoToSearchReportView(grpcRes.SearchReport), nil
+	} else {
+		return nil, fmt.Errorf("elasticsearch-service is not running")
+	}
+}
+
+func (productService *productService) GetProductRecommendations(ctx context.Context, reqDTO *dto.GetProductRecommendationsRequest) (*model.ProductRecommendationsView, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient != nil {
+		convertReqDTO := &elasticsearchservicepb.GetProductRecommendationsRequest{}
+		convertReqDTO.ProductId = reqDTO.Id
+		convertReqDTO.Type = reqDTO.Type
+		convertReqDTO.Limit = reqDTO.Limit
+
+		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetProductRecommendations(ctx, convertReqDTO)
+		if err != nil {
+			return nil, fmt.Errorf("get product recommendations from elasticsearch-service failed: %s", err.Error())
 		}
 
-		return model.FromListProductProtoToListProductView(grpcRes.Products), nil
+		return model.FromProductRecommendationsProtoToProductRecommendationsView(grpcRes), nil
 	} else {
 		return nil, fmt.Errorf("elasticsearch-service is not running")
 	}
 }
+
+func (productService *productService) GetTopProducts(ctx context.Context, reqDTO *dto.GetTopProductsRequest) ([]*model.RankedProductView, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient != nil {
+		convertReqDTO := &elasticsearchservicepb.GetTopProductsRequest{}
+		convertReqDTO.Limit = reqDTO.Limit
+		convertReqDTO.Period = reqDTO.Period
+		convertReqDTO.CategoryId = reqDTO.CategoryId
+		convertReqDTO.BrandId = reqDTO.BrandId
+
+		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetTopProducts(ctx, convertReqDTO)
+		if err != nil {
+			return nil, fmt.Errorf("get top products from elasticsearch-service failed: %s", err.Error())
+		}
+
+		return model.FromListRankedProductProtoToListRankedProductView(grpcRes.Products), nil
+	} else {
+		return nil, fmt.Errorf("elasticsearch-service is not running")
+	}
+}
+
+func (productService *productService) GetTrendingProducts(ctx context.Context, reqDTO *dto.GetTrendingProductsRequest) ([]*model.RankedProductView, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient != nil {
+		convertReqDTO := &elasticsearchservicepb.GetTrendingProductsRequest{}
+		convertReqDTO.Limit = reqDTO.Limit
+		convertReqDTO.CategoryId = reqDTO.CategoryId
+		convertReqDTO.BrandId = reqDTO.BrandId
+
+		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetTrendingProducts(ctx, convertReqDTO)
+		if err != nil {
+			return nil, fmt.Errorf("get trending products from elasticsearch-service failed: %s", err.Error())
+		}
+
+		return model.FromListRankedProductProtoToListRankedProductView(grpcRes.Products), nil
+	} else {
+		return nil, fmt.Errorf("elasticsearch-service is not running")
+	}
+}
+
+// Attribute values must be in attribute set of category and match type of their attribute. Required attributes are only
+// enforced when attribute values are given so that products created before an attribute became required stay editable.
+func (productService *productService) buildProductAttributeValues(ctx context.Context, productId string, category *model.Category, attributes map[string]string, checkRequired bool) ([]*model.ProductAttributeValue, error) {
+	categoryAttributes, err := productService.categoryAttributeRepository.GetViewsByCategory(ctx, category.Id, category.Path)
+	if err != nil {
+		return nil, fmt.Errorf("query category attributes from postgresql failed: %s", err.Error())
+	}
+	categoryAttributeMap := map[string]*model.CategoryAttributeView{}
+	for _, categoryAttribute := range categoryAttributes {
+		categoryAttributeMap[categoryAttribute.Code] = categoryAttribute
+	}
+
+	productAttributeValues := []*model.ProductAttributeValue{}
+	for code, value := range attributes {
+		categoryAttribute, ok := categoryAttributeMap[code]
+		if !ok {
+			return nil, fmt.Errorf("attribute %s is not in attribute set of category", code)
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		switch categoryAttribute.Type {
+		case "ENUM":
+			if !slices.Contains(categoryAttribute.Options, value) {
+				return nil, fmt.Errorf("value of attribute %s must be one of %s", code, strings.Join(categoryAttribute.Options, ", "))
+			}
+		case "NUMBER":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("value of attribute %s must be a number", code)
+			}
+		}
+		productAttributeValues = append(productAttributeValues, &model.ProductAttributeValue{
+			ProductId:             productId,
+			AttributeDefinitionId: categoryAttribute.AttributeDefinitionId,
+			Value:                 value,
+		})
+	}
+
+	if checkRequired {
+		for _, categoryAttribute := range categoryAttributes {
+			if categoryAttribute.IsRequired && strings.TrimSpace(attributes[categoryAttribute.Code]) == "" {
+				return nil, fmt.Errorf("attribute %s is required by category", categoryAttribute.Code)
+			}
+		}
+	}
+
+	return productAttributeValues, nil
+}
+
+// Tags are trimmed and lower-cased, empty and duplicated tags are dropped
+// Components of bundle must be distinct products which are not archived and are not bundles themselves. Price of bundle
+// priced off its components is set to sum of their prices.
+func (productService *productService) buildBundleComponents(ctx context.Context, bundle *model.Product, bundleComponentReqs []*dto.BundleComponentRequest) ([]*model.BundleComponent, error) {
+	if bundle.BundlePricing == "" {
+		return nil, fmt.Errorf("bundle pricing is required for bundle")
+	}
+	if len(bundleComponentReqs) == 0 {
+		return nil, fmt.Errorf("components are required for bundle")
+	}
+
+	componentIds := make([]string, len(bundleComponentReqs))
+	for i, bundleComponentReq := range bundleComponentReqs {
+		if slices.Contains(componentIds[:i], bundleComponentReq.ProductId) {
+			return nil, fmt.Errorf("component product id %s is duplicated", bundleComponentReq.ProductId)
+		}
+		componentIds[i] = bundleComponentReq.ProductId
+	}
+	components, err := productService.productRepository.GetByListId(ctx, componentIds)
+	if err != nil {
+		return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
+	}
+	componentMap := make(map[string]*model.Product, len(components))
+	for _, component := range components {
+		componentMap[component.Id] = component
+	}
+
+	newBundleComponents := make([]*model.BundleComponent, len(bundleComponentReqs))
+	componentsPrice := int64(0)
+	for i, bundleComponentReq := range bundleComponentReqs {
+		component, ok := componentMap[bundleComponentReq.ProductId]
+		if !ok || component.DeletedAt != nil || component.Id == bundle.Id {
+			return nil, fmt.Errorf("id of component product not found: %s", bundleComponentReq.ProductId)
+		}
+		if component.Type == "BUNDLE" {
+			return nil, fmt.Errorf("component product id %s is a bundle", component.Id)
+		}
+		newBundleComponents[i] = &model.BundleComponent{
+			BundleId:    bundle.Id,
+			ComponentId: component.Id,
+			Quantity:    bundleComponentReq.Quantity,
+		}
+		componentsPrice += component.Price * int64(bundleComponentReq.Quantity)
+	}
+	if bundle.BundlePricing == "PERCENTAGE_OFF" {
+		bundle.Price = componentsPrice
+	}
+
+	return newBundleComponents, nil
+}
+
+func normalizeProductTags(tags []string) ([]string, error) {
+	normalizedTags := []string{}
+	seenTags := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seenTags[tag] {
+			continue
+		}
+		if len([]rune(tag)) > 50 || strings.Contains(tag, ",") {
+			return nil, fmt.Errorf("tag %s must be at most 50 characters without ','", tag)
+		}
+		seenTags[tag] = true
+		normalizedTags = append(normalizedTags, tag)
+	}
+
+	return normalizedTags, nil
+}
+
+func (productService *productService) isSlugTaken(ctx context.Context, productId string) func(slug string) bool {
+	return func(slug string) bool {
+		foundProduct, err := productService.productRepository.GetBySlug(ctx, slug)
+		return err == nil && foundProduct.Id != productId
+	}
+}
+
+// Slug generated from name gets a numeric suffix while it is taken, fallback is used for names without any letter or digit
+func generateUniqueSlug(name string, fallback string, isTaken func(slug string) bool) string {
+	baseSlug := utils.GenerateSlug(name)
+	if baseSlug == "" {
+		baseSlug = utils.GenerateSlug(fallback)
+	}
+
+	slug := baseSlug
+	for i := 2; isTaken(slug); i++ {
+		slug = fmt.Sprintf("%s-%d", baseSlug, i)
+	}
+
+	return slug
+}
+
+// Former slug of entity keeps redirecting to it, current slug of entity must not redirect anywhere else
+func recordSlugChange(ctx context.Context, slugRedirectRepository repository.SlugRedirectRepository, entityType string, entityId string, oldSlug string, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	if oldSlug != "" {
+		newSlugRedirect := &model.SlugRedirect{
+			Id:         uuid.New().String(),
+			EntityType: entityType,
+			Slug:       oldSlug,
+			EntityId:   entityId,
+		}
+		if err := slugRedirectRepository.Upsert(ctx, newSlugRedirect); err != nil {
+			return fmt.Errorf("insert slug redirect to postgresql failed: %s", err.Error())
+		}
+	}
+	if err := slugRedirectRepository.DeleteByEntityTypeAndSlug(ctx, entityType, newSlug); err != nil {
+		return fmt.Errorf("delete slug redirect from postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// ADMIN and STAFF also see products, brands and categories which are not published
+func isBackOfficeContext(ctx context.Context) bool {
+	roleName, _ := ctx.Value("role_name").(string)
+	return roleName == "ADMIN" || roleName == "STAFF"
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"thanhldt060802/config"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var stockLoadTestWorkers = flag.Int("stock-load-test.workers", 100, "Number of concurrent checkouts of stock load test.")
+var stockLoadTestStock = flag.Int("stock-load-test.stock", 20, "Initial stock of product A of stock load test, product B gets half of it.")
+
+// Concurrent checkout load test for stock decrement, run from catalog-service directory against a migrated development
+// database and Redis (it is skipped when POSTGRES_HOST is not set):
+//
+//	POSTGRES_HOST=localhost POSTGRES_PORT=5432 POSTGRES_PASSWORD=... go test ./internal/service -run StockLoadTest -stock-load-test.workers 200
+//
+// Two temporary products are created, product A with stock and product B with half of it. Every worker checks out one of
+// each in a single call, so exactly stock/2 checkouts must succeed, B must end at 0 and A must never be decreased by a
+// failed checkout. Temporary products, their stock movements and warehouse stocks are removed afterwards.
+func TestStockLoadTestUpdateProductStocksByListInvoiceDetail(t *testing.T) {
+	if os.Getenv("POSTGRES_HOST") == "" {
+		t.Skip("POSTGRES_HOST is not set, stock load test needs a development database")
+	}
+	if *stockLoadTestStock < 2 {
+		t.Fatal("stock of stock load test must be at least 2")
+	}
+
+	config.AppConfig = &config.Config{
+		PostgresHost:            config.GetEnv("POSTGRES_HOST", "localhost"),
+		PostgresPort:            config.GetEnv("POSTGRES_PORT", "5432"),
+		PostgresUser:            config.GetEnv("POSTGRES_USER", "postgres"),
+		PostgresPassword:        config.GetEnv("POSTGRES_PASSWORD", ""),
+		PostgresDB:              config.GetEnv("POSTGRES_DB", "my_db"),
+		RedisHost:               config.GetEnv("REDIS_HOST", "localhost"),
+		RedisPort:               config.GetEnv("REDIS_PORT", "6379"),
+		RedisPassword:           config.GetEnv("REDIS_PASSWORD", ""),
+		StockAllocationStrategy: "priority",
+		CacheProductTTL:         "0",
+		CacheBrandCategoryTTL:   "0",
+	}
+	infrastructure.InitPostgesDB()
+	infrastructure.InitRedisClient()
+	infrastructure.InitViewCaches()
+	// Cleanups run last in first out, connections are closed after temporary products are removed
+	t.Cleanup(func() {
+		infrastructure.PostgresDB.Close()
+		infrastructure.RedisClient.Close()
+	})
+
+	ctx := context.Background()
+	productRepository := repository.NewProductRepository()
+	stockMovementRepository := repository.NewStockMovementRepository()
+	productService := NewProductService(productRepository, repository.NewProductPriceHistoryRepository(), repository.NewCategoryRepository(), repository.NewBrandRepository(), stockMovementRepository, repository.NewWarehouseRepository(), repository.NewPromotionRepository(), repository.NewSlugRedirectRepository(), repository.NewCategoryAttributeRepository(), repository.NewProductAttributeValueRepository(), repository.NewBundleComponentRepository(), NewStockAllocationStrategy("priority"), nil)
+
+	stockA := int32(*stockLoadTestStock)
+	stockB := int32(*stockLoadTestStock / 2)
+	productA := newStockLoadTestProduct(t, ctx, productRepository, "A", stockA)
+	productB := newStockLoadTestProduct(t, ctx, productRepository, "B", stockB)
+	t.Cleanup(func() {
+		cleanUpStockLoadTest(ctx, productA.Id, productB.Id)
+	})
+
+	var succeeded, notEnoughStock, failed int64
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for range *stockLoadTestWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := productService.UpdateProductStocksByListInvoiceDetail(ctx, &dto.UpdateProductStocksByListInvoiceDetailRequest{
+				InvoiceDetails: []dto.InvoiceDetail{
+					{ProductId: productA.Id, Quantity: 1},
+					{ProductId: productB.Id, Quantity: 1},
+				},
+				InvoiceId: uuid.New().String(),
+			})
+			switch {
+			case err == nil:
+				atomic.AddInt64(&succeeded, 1)
+			case strings.Contains(err.Error(), "not enough stock"):
+				atomic.AddInt64(&notEnoughStock, 1)
+			default:
+				atomic.AddInt64(&failed, 1)
+				t.Logf("Checkout failed: %s", err.Error())
+			}
+		}()
+	}
+
+	startTime := time.Now()
+	close(start)
+	wg.Wait()
+	t.Logf("%d checkouts in %s: %d succeeded, %d not enough stock, %d failed", *stockLoadTestWorkers, time.Since(startTime), succeeded, notEnoughStock, failed)
+
+	if failed != 0 {
+		t.Errorf("%d checkouts failed with unexpected error", failed)
+	}
+	if expectedSucceeded := min(int64(*stockLoadTestWorkers), int64(stockB)); succeeded != expectedSucceeded {
+		t.Errorf("%d checkouts succeeded, expected %d", succeeded, expectedSucceeded)
+	}
+	for _, product := range []struct {
+		id    string
+		stock int32
+	}{{productA.Id, stockA}, {productB.Id, stockB}} {
+		foundProduct, err := productRepository.GetById(ctx, product.id)
+		if err != nil {
+			t.Fatalf("Get product failed: %s", err.Error())
+		}
+		ledgerStock, err := stockMovementRepository.GetLedgerStockByProductId(ctx, product.id)
+		if err != nil {
+			t.Fatalf("Get ledger stock failed: %s", err.Error())
+		}
+
+		if expectedStock := product.stock - int32(succeeded); foundProduct.Stock != expectedStock {
+			t.Errorf("stock of product %s is %d, expected %d", product.id, foundProduct.Stock, expectedStock)
+		}
+		if ledgerStock != foundProduct.Stock {
+			t.Errorf("ledger stock of product %s is %d, stock is %d", product.id, ledgerStock, foundProduct.Stock)
+		}
+	}
+}
+
+func newStockLoadTestProduct(t *testing.T, ctx context.Context, productRepository repository.ProductRepository, name string, stock int32) *model.Product {
+	var categoryId, brandId string
+	if err := infrastructure.PostgresDB.NewSelect().Model((*model.Category)(nil)).Column("id").Limit(1).Scan(ctx, &categoryId); err != nil {
+		t.Fatalf("Get category failed: %s", err.Error())
+	}
+	if err := infrastructure.PostgresDB.NewSelect().Model((*model.Brand)(nil)).Column("id").Limit(1).Scan(ctx, &brandId); err != nil {
+		t.Fatalf("Get brand failed: %s", err.Error())
+	}
+
+	productId := uuid.New().String()
+	product := &model.Product{
+		Id:              productId,
+		Sku:             model.GenerateSku(productId),
+		Slug:            "stock-load-test-" + productId,
+		Name:            fmt.Sprintf("Stock Load Test Product %s", name),
+		Description:     "Temporary product of stock load test",
+		Sex:             "UNISEX",
+		Price:           100000,
+		ImageURL:        "image.png",
+		CategoryId:      categoryId,
+		BrandId:         brandId,
+		Status:          "PUBLISHED",
+		BackorderPolicy: "NONE",
+	}
+	// Empty warehouse id puts initial stock in default warehouse
+	newStockMovements := []*model.StockMovement{{
+		Id:        uuid.New().String(),
+		ProductId: productId,
+		Quantity:  stock,
+		Reason:    "PURCHASE",
+		Note:      "Stock load test",
+	}}
+	if err := productRepository.Create(ctx, product, newStockMovements); err != nil {
+		t.Fatalf("Create product failed: %s", err.Error())
+	}
+
+	return product
+}
+
+func cleanUpStockLoadTest(ctx context.Context, productIds ...string) {
+	if _, err := infrastructure.PostgresDB.NewDelete().Model((*model.StockMovement)(nil)).Where("product_id IN (?)", bun.In(productIds)).Exec(ctx); err != nil {
+		fmt.Printf("Delete stock movements of stock load test failed: %s\n", err.Error())
+	}
+	if _, err := infrastructure.PostgresDB.NewDelete().Model((*model.WarehouseStock)(nil)).Where("product_id IN (?)", bun.In(productIds)).Exec(ctx); err != nil {
+		fmt.Printf("Delete warehouse stocks of stock load test failed: %s\n", err.Error())
+	}
+	if _, err := infrastructure.PostgresDB.NewDelete().Model((*model.Product)(nil)).Where("id IN (?)", bun.In(productIds)).Exec(ctx); err != nil {
+		fmt.Printf("Delete products of stock load test failed: %s\n", err.Error())
+	}
+	// Checkouts published the temporary products, other services drop them again
+	for _, productId := range productIds {
+		infrastructure.RedisClient.Publish(ctx, "catalog-service.deleted-product", productId)
+	}
+}
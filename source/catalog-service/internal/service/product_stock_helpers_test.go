@@ -0,0 +1,137 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"thanhldt060802/internal/dto"
+)
+
+func TestValidateInvoiceDetailQuantities(t *testing.T) {
+	testCases := []struct {
+		name           string
+		invoiceDetails []dto.InvoiceDetail
+		wantErr        string
+	}{
+		{
+			name:           "no invoice details",
+			invoiceDetails: nil,
+		},
+		{
+			name: "positive quantities",
+			invoiceDetails: []dto.InvoiceDetail{
+				{ProductId: "a", Quantity: 1},
+				{ProductId: "b", Quantity: 3},
+			},
+		},
+		{
+			name: "zero quantity",
+			invoiceDetails: []dto.InvoiceDetail{
+				{ProductId: "a", Quantity: 1},
+				{ProductId: "b", Quantity: 0},
+			},
+			wantErr: "quantity of product id b must be positive",
+		},
+		{
+			name: "negative quantity of duplicated product",
+			invoiceDetails: []dto.InvoiceDetail{
+				{ProductId: "a", Quantity: 5},
+				{ProductId: "a", Quantity: -2},
+			},
+			wantErr: "quantity of product id a must be positive",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateInvoiceDetailQuantities(testCase.invoiceDetails)
+			if testCase.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			if err == nil || err.Error() != testCase.wantErr {
+				t.Fatalf("error = %v, want %s", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeInvoiceDetailQuantities(t *testing.T) {
+	testCases := []struct {
+		name           string
+		invoiceDetails []dto.InvoiceDetail
+		want           map[string]int32
+	}{
+		{
+			name:           "no invoice details",
+			invoiceDetails: nil,
+			want:           map[string]int32{},
+		},
+		{
+			name: "distinct products",
+			invoiceDetails: []dto.InvoiceDetail{
+				{ProductId: "a", Quantity: 1},
+				{ProductId: "b", Quantity: 2},
+			},
+			want: map[string]int32{"a": 1, "b": 2},
+		},
+		{
+			name: "duplicated products are summed",
+			invoiceDetails: []dto.InvoiceDetail{
+				{ProductId: "a", Quantity: 1},
+				{ProductId: "b", Quantity: 2},
+				{ProductId: "a", Quantity: 4, WarehouseId: "w1"},
+			},
+			want: map[string]int32{"a": 5, "b": 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := mergeInvoiceDetailQuantities(testCase.invoiceDetails); !reflect.DeepEqual(got, testCase.want) {
+				t.Fatalf("quantity map = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestSortedQuantityMapIds(t *testing.T) {
+	testCases := []struct {
+		name        string
+		quantityMap map[string]int32
+		want        []string
+	}{
+		{
+			name:        "empty map",
+			quantityMap: map[string]int32{},
+			want:        []string{},
+		},
+		{
+			name:        "ids are sorted",
+			quantityMap: map[string]int32{"c": 1, "a": 2, "b": 3},
+			want:        []string{"a", "b", "c"},
+		},
+		{
+			name: "uuids are sorted",
+			quantityMap: map[string]int32{
+				"f47ac10b-58cc-4372-a567-0e02b2c3d479": 1,
+				"0b1c2d3e-4f50-4617-8283-94a5b6c7d8e9": 1,
+				"7c9e6679-7425-40de-944b-e07fc1f90ae7": 1,
+			},
+			want: []string{
+				"0b1c2d3e-4f50-4617-8283-94a5b6c7d8e9",
+				"7c9e6679-7425-40de-944b-e07fc1f90ae7",
+				"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := sortedQuantityMapIds(testCase.quantityMap); !reflect.DeepEqual(got, testCase.want) {
+				t.Fatalf("ids = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
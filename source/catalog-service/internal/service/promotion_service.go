@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"thanhldt060802/config"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type promotionService struct {
+	promotionRepository           repository.PromotionRepository
+	productRepository             repository.ProductRepository
+	productPriceHistoryRepository repository.ProductPriceHistoryRepository
+	categoryRepository            repository.CategoryRepository
+	brandRepository               repository.BrandRepository
+}
+
+type PromotionService interface {
+	GetPromotions(ctx context.Context, reqDTO *dto.GetPromotionsRequest) ([]*model.PromotionView, error)
+	GetPromotionById(ctx context.Context, reqDTO *dto.GetPromotionByIdRequest) (*model.PromotionView, error)
+	CreatePromotion(ctx context.Context, reqDTO *dto.CreatePromotionRequest) error
+	UpdatePromotionById(ctx context.Context, reqDTO *dto.UpdatePromotionByIdRequest) error
+	CancelPromotionById(ctx context.Context, reqDTO *dto.CancelPromotionByIdRequest) error
+	DeletePromotionById(ctx context.Context, reqDTO *dto.DeletePromotionByIdRequest) error
+	syncPromotionStatusLoop()
+}
+
+func NewPromotionService(promotionRepository repository.PromotionRepository, productRepository repository.ProductRepository, productPriceHistoryRepository repository.ProductPriceHistoryRepository, categoryRepository repository.CategoryRepository, brandRepository repository.BrandRepository) PromotionService {
+	promotionService := &promotionService{
+		promotionRepository:           promotionRepository,
+		productRepository:             productRepository,
+		productPriceHistoryRepository: productPriceHistoryRepository,
+		categoryRepository:            categoryRepository,
+		brandRepository:               brandRepository,
+	}
+
+	go promotionService.syncPromotionStatusLoop()
+
+	return promotionService
+}
+
+func (promotionService *promotionService) GetPromotions(ctx context.Context, reqDTO *dto.GetPromotionsRequest) ([]*model.PromotionView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	promotions, err := promotionService.promotionRepository.GetViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields, reqDTO.Status)
+	if err != nil {
+		return nil, fmt.Errorf("query promotions from postgresql failed: %s", err.Error())
+	}
+
+	return promotions, nil
+}
+
+func (promotionService *promotionService) GetPromotionById(ctx context.Context, reqDTO *dto.GetPromotionByIdRequest) (*model.PromotionView, error) {
+	foundPromotion, err := promotionService.promotionRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of promotion is not valid: %s", err.Error())
+	}
+
+	return foundPromotion, nil
+}
+
+func (promotionService *promotionService) CreatePromotion(ctx context.Context, reqDTO *dto.CreatePromotionRequest) error {
+	if !reqDTO.Body.EndTime.After(reqDTO.Body.StartTime) {
+		return fmt.Errorf("end time of promotion must be after start time")
+	}
+	if !reqDTO.Body.EndTime.After(time.Now()) {
+		return fmt.Errorf("end time of promotion must be in the future")
+	}
+	targetIds, err := promotionService.validateTargets(ctx, reqDTO.Body.TargetType, reqDTO.Body.TargetIds)
+	if err != nil {
+		return err
+	}
+
+	startTime := reqDTO.Body.StartTime.UTC()
+	endTime := reqDTO.Body.EndTime.UTC()
+	newPromotion := model.Promotion{
+		Id:                 uuid.New().String(),
+		Name:               reqDTO.Body.Name,
+		Description:        reqDTO.Body.Description,
+		DiscountPercentage: reqDTO.Body.DiscountPercentage,
+		TargetType:         reqDTO.Body.TargetType,
+		TargetIds:          targetIds,
+		StartTime:          &startTime,
+		EndTime:            &endTime,
+		PerUserLimit:       reqDTO.Body.PerUserLimit,
+		StockAllotment:     reqDTO.Body.StockAllotment,
+		Status:             "SCHEDULED",
+	}
+	if err := promotionService.promotionRepository.Create(ctx, &newPromotion); err != nil {
+		return fmt.Errorf("insert promotion to postgresql failed: %s", err.Error())
+	}
+
+	// Promotion starting right away is activated by the next tick of scheduler
+	return nil
+}
+
+func (promotionService *promotionService) UpdatePromotionById(ctx context.Context, reqDTO *dto.UpdatePromotionByIdRequest) error {
+	foundPromotion, err := promotionService.promotionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of promotion is not valid: %s", err.Error())
+	}
+	if foundPromotion.Status == "EXPIRED" || foundPromotion.Status == "CANCELLED" {
+		return fmt.Errorf("promotion is already %s", foundPromotion.Status)
+	}
+
+	// Discount and targets are fixed once promotion started so that invoices under it stay consistent
+	started := foundPromotion.Status != "SCHEDULED" || !foundPromotion.StartTime.After(time.Now())
+	if started && (reqDTO.Body.DiscountPercentage != nil || reqDTO.Body.TargetType != nil || reqDTO.Body.TargetIds != nil || reqDTO.Body.StartTime != nil) {
+		return fmt.Errorf("discount percentage, targets and start time of promotion can not be changed after it started")
+	}
+
+	if reqDTO.Body.Name != nil {
+		foundPromotion.Name = *reqDTO.Body.Name
+	}
+	if reqDTO.Body.Description != nil {
+		foundPromotion.Description = *reqDTO.Body.Description
+	}
+	if reqDTO.Body.DiscountPercentage != nil {
+		foundPromotion.DiscountPercentage = *reqDTO.Body.DiscountPercentage
+	}
+	if reqDTO.Body.TargetType != nil || reqDTO.Body.TargetIds != nil {
+		targetType := foundPromotion.TargetType
+		if reqDTO.Body.TargetType != nil {
+			targetType = *reqDTO.Body.TargetType
+		}
+		targetIds := foundPromotion.TargetIds
+		if reqDTO.Body.TargetIds != nil {
+			targetIds = reqDTO.Body.TargetIds
+		} else if targetType != foundPromotion.TargetType {
+			return fmt.Errorf("target ids of promotion are required when target type changes")
+		}
+		if targetIds, err = promotionService.validateTargets(ctx, targetType, targetIds); err != nil {
+			return err
+		}
+		foundPromotion.TargetType = targetType
+		foundPromotion.TargetIds = targetIds
+	}
+	if reqDTO.Body.StartTime != nil {
+		startTime := reqDTO.Body.StartTime.UTC()
+		foundPromotion.StartTime = &startTime
+	}
+	if reqDTO.Body.EndTime != nil {
+		endTime := reqDTO.Body.EndTime.UTC()
+		foundPromotion.EndTime = &endTime
+	}
+	if !foundPromotion.EndTime.After(*foundPromotion.StartTime) {
+		return fmt.Errorf("end time of promotion must be after start time")
+	}
+	if reqDTO.Body.PerUserLimit != nil {
+		foundPromotion.PerUserLimit = *reqDTO.Body.PerUserLimit
+	}
+	if reqDTO.Body.StockAllotment != nil {
+		foundPromotion.StockAllotment = *reqDTO.Body.StockAllotment
+	}
+	timeUpdate := time.Now().UTC()
+	foundPromotion.UpdatedAt = &timeUpdate
+
+	if err := promotionService.promotionRepository.Update(ctx, foundPromotion); err != nil {
+		return fmt.Errorf("update promotion on postgresql failed: %s", err.Error())
+	}
+
+	// End time and allotment of active promotion are shown in product views
+	if foundPromotion.Status == "ACTIVE" {
+		return promotionService.syncPromotionProducts(ctx, foundPromotion.Id)
+	}
+
+	return nil
+}
+
+func (promotionService *promotionService) CancelPromotionById(ctx context.Context, reqDTO *dto.CancelPromotionByIdRequest) error {
+	foundPromotion, err := promotionService.promotionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of promotion is not valid: %s", err.Error())
+	}
+	if foundPromotion.Status == "EXPIRED" || foundPromotion.Status == "CANCELLED" {
+		return fmt.Errorf("promotion is already %s", foundPromotion.Status)
+	}
+
+	foundPromotion.Status = "CANCELLED"
+	timeUpdate := time.Now().UTC()
+	foundPromotion.UpdatedAt = &timeUpdate
+
+	if err := promotionService.promotionRepository.Update(ctx, foundPromotion); err != nil {
+		return fmt.Errorf("update promotion on postgresql failed: %s", err.Error())
+	}
+
+	return promotionService.syncPromotionProducts(ctx, foundPromotion.Id)
+}
+
+func (promotionService *promotionService) DeletePromotionById(ctx context.Context, reqDTO *dto.DeletePromotionByIdRequest) error {
+	foundPromotion, err := promotionService.promotionRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of promotion is not valid")
+	}
+
+	// Usages tell which invoices were discounted by promotion, such promotion can only be cancelled
+	used, err := promotionService.promotionRepository.ExistsUsageById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("query promotion usages from postgresql failed: %s", err.Error())
+	}
+	if used {
+		return fmt.Errorf("promotion was already used by invoices, cancel it instead")
+	}
+
+	// Products are resolved before deleting since their targets are gone after that
+	var productIds []string
+	if foundPromotion.Status == "ACTIVE" {
+		if productIds, err = promotionService.promotionRepository.GetProductIdsById(ctx, reqDTO.Id); err != nil {
+			return fmt.Errorf("query products of promotion from postgresql failed: %s", err.Error())
+		}
+	}
+
+	if err := promotionService.promotionRepository.DeleteById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("delete promotion from postgresql failed: %s", err.Error())
+	}
+
+	return promotionService.syncProductPrices(ctx, productIds)
+}
+
+// Check targets of promotion exist, duplicated ids are removed
+func (promotionService *promotionService) validateTargets(ctx context.Context, targetType string, targetIds []string) ([]string, error) {
+	uniqueTargetIds := []string{}
+	targetIdSet := map[string]struct{}{}
+	for _, targetId := range targetIds {
+		if _, ok := targetIdSet[targetId]; !ok {
+			targetIdSet[targetId] = struct{}{}
+			uniqueTargetIds = append(uniqueTargetIds, targetId)
+		}
+	}
+
+	switch targetType {
+	case "PRODUCT":
+		products, err := promotionService.productRepository.GetByListId(ctx, uniqueTargetIds)
+		if err != nil {
+			return nil, fmt.Errorf("query products from postgresql failed: %s", err.Error())
+		}
+		if len(products) != len(uniqueTargetIds) {
+			return nil, fmt.Errorf("id of product not found")
+		}
+	case "CATEGORY":
+		for _, targetId := range uniqueTargetIds {
+			if _, err := promotionService.categoryRepository.GetById(ctx, targetId); err != nil {
+				return nil, fmt.Errorf("id of category not found: %s", targetId)
+			}
+		}
+	case "BRAND":
+		for _, targetId := range uniqueTargetIds {
+			if _, err := promotionService.brandRepository.GetById(ctx, targetId); err != nil {
+				return nil, fmt.Errorf("id of brand not found: %s", targetId)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("target type of promotion is not valid")
+	}
+
+	return uniqueTargetIds, nil
+}
+
+// Products of promotion are synced whenever promotion starts, stops or changes while in effect
+func (promotionService *promotionService) syncPromotionProducts(ctx context.Context, id string) error {
+	productIds, err := promotionService.promotionRepository.GetProductIdsById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("query products of promotion from postgresql failed: %s", err.Error())
+	}
+
+	return promotionService.syncProductPrices(ctx, productIds)
+}
+
+// Record price history of products whose price changed with promotion starting or stopping being in effect, then republish
+// them so elasticsearch-service indexes their new discount
+func (promotionService *promotionService) syncProductPrices(ctx context.Context, productIds []string) error {
+	if err := promotionService.productPriceHistoryRepository.CreateByListProductId(ctx, productIds, actorIdFromContext(ctx)); err != nil {
+		return fmt.Errorf("insert product price history to postgresql failed: %s", err.Error())
+	}
+
+	return promotionService.publishUpdatedProducts(ctx, productIds)
+}
+
+// Activate promotions reaching start time and expire promotions reaching end time or selling out allotment, and refresh
+// lowest price of last 30 days of products whose price history slides out of the window
+func (promotionService *promotionService) syncPromotionStatusLoop() {
+	ticker := time.NewTicker(config.AppConfig.PromotionSchedulerIntervalValue())
+	defer ticker.Stop()
+
+	// Window boundary crossed while service was down is caught up for the last day
+	lastSyncedAt := time.Now().UTC().Add(-24 * time.Hour)
+
+	for range ticker.C {
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		// Activating and expiring do not depend on each other, one failing does not hold the other back
+		activatedPromotions, err := promotionService.promotionRepository.ActivateStarted(ctx, now)
+		if err != nil {
+			log.Printf("Activate started promotions failed: %s", err.Error())
+		}
+		expiredPromotions, err := promotionService.promotionRepository.ExpireEnded(ctx, now)
+		if err != nil {
+			log.Printf("Expire ended promotions failed: %s", err.Error())
+		}
+
+		for _, promotion := range append(activatedPromotions, expiredPromotions...) {
+			if err := promotionService.syncPromotionProducts(ctx, promotion.Id); err != nil {
+				log.Printf("Sync products of promotion %s failed: %s", promotion.Id, err.Error())
+			} else {
+				log.Printf("Promotion %s is %s", promotion.Id, promotion.Status)
+			}
+		}
+
+		if err := promotionService.syncLowestPrice30dProducts(ctx, lastSyncedAt, now); err != nil {
+			log.Printf("Sync lowest price of last 30 days of products failed: %s", err.Error())
+			continue
+		}
+		lastSyncedAt = now
+	}
+}
+
+// Lowest price of last 30 days only changes without a price change when price history reaches 30 days of age, products
+// whose price history crossed it since last sync are republished so that search index does not keep the old value
+func (promotionService *promotionService) syncLowestPrice30dProducts(ctx context.Context, lastSyncedAt time.Time, now time.Time) error {
+	window := 30 * 24 * time.Hour
+	productIds, err := promotionService.productPriceHistoryRepository.GetProductIdsByCreatedAtRange(ctx, lastSyncedAt.Add(-window), now.Add(-window))
+	if err != nil {
+		return fmt.Errorf("query products of price history from postgresql failed: %s", err.Error())
+	}
+
+	return promotionService.publishUpdatedProducts(ctx, productIds)
+}
+
+func (promotionService *promotionService) publishUpdatedProducts(ctx context.Context, productIds []string) error {
+	for _, productId := range productIds {
+		updatedProductView, err := promotionService.productRepository.GetViewById(ctx, productId)
+		if err != nil {
+			log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", productId, err.Error())
+			continue
+		}
+		payload, _ := json.Marshal(updatedProductView)
+		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+			return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+		}
+	}
+
+	return nil
+}
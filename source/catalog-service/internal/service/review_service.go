@@ -0,0 +1,275 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"thanhldt060802/config"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/client/orderservicepb"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	reviewMaxPhotos    = 5
+	reviewPhotoMaxSize = 1200
+)
+
+type reviewService struct {
+	reviewRepository  repository.ReviewRepository
+	productRepository repository.ProductRepository
+}
+
+type ReviewService interface {
+	GetProductReviews(ctx context.Context, reqDTO *dto.GetProductReviewsRequest) ([]*model.ReviewView, error)
+	CreateReview(ctx context.Context, reqDTO *dto.CreateReviewRequest) (*model.ReviewView, error)
+	DeleteReviewById(ctx context.Context, reqDTO *dto.DeleteReviewByIdRequest) error
+
+	// Moderation features for ADMIN and STAFF
+	GetReviews(ctx context.Context, reqDTO *dto.GetReviewsRequest) ([]*model.ReviewView, error)
+	ModerateReviewById(ctx context.Context, reqDTO *dto.ModerateReviewByIdRequest) error
+}
+
+func NewReviewService(reviewRepository repository.ReviewRepository, productRepository repository.ProductRepository) ReviewService {
+	return &reviewService{
+		reviewRepository:  reviewRepository,
+		productRepository: productRepository,
+	}
+}
+
+func (reviewService *reviewService) GetProductReviews(ctx context.Context, reqDTO *dto.GetProductReviewsRequest) ([]*model.ReviewView, error) {
+	if _, err := reviewService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	rating, _ := strconv.Atoi(reqDTO.Rating)
+	filter := &repository.ReviewFilter{
+		ProductId:        reqDTO.Id,
+		Status:           "APPROVED",
+		Rating:           int32(rating),
+		VerifiedPurchase: reqDTO.VerifiedOnly,
+	}
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	reviews, err := reviewService.reviewRepository.GetViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields, filter)
+	if err != nil {
+		return nil, fmt.Errorf("query reviews from postgresql failed: %s", err.Error())
+	}
+
+	return reviews, nil
+}
+
+func (reviewService *reviewService) CreateReview(ctx context.Context, reqDTO *dto.CreateReviewRequest) (*model.ReviewView, error) {
+	userId, _ := ctx.Value("user_id").(string)
+
+	if _, err := reviewService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+	if _, err := reviewService.reviewRepository.GetByProductIdAndUserId(ctx, reqDTO.Id, userId); err == nil {
+		return nil, fmt.Errorf("product is already reviewed by user")
+	}
+
+	formData := reqDTO.RawBody.Data()
+	if len(formData.Photos) > reviewMaxPhotos {
+		return nil, fmt.Errorf("review must not have more than %d photos", reviewMaxPhotos)
+	}
+
+	// Verified purchase badge requires a DONE invoice of user containing product, review is refused rather than stored
+	// unverified when order-service cannot tell
+	if infrastructure.OrderServiceGRPCClient == nil {
+		return nil, fmt.Errorf("cannot verify purchase of product, order-service is not running, try again later")
+	}
+	convertReqDTO := &orderservicepb.CheckPurchasedProductRequest{}
+	convertReqDTO.UserId = userId
+	convertReqDTO.ProductId = reqDTO.Id
+
+	grpcRes, err := infrastructure.OrderServiceGRPCClient.CheckPurchasedProduct(ctx, convertReqDTO)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify purchase of product, order-service is unreachable, try again later: %s", err.Error())
+	}
+	var invoiceId *string
+	if grpcRes.Purchased {
+		invoiceId = &grpcRes.InvoiceId
+	}
+
+	newReview := model.Review{
+		Id:               uuid.New().String(),
+		ProductId:        reqDTO.Id,
+		UserId:           userId,
+		Rating:           formData.Rating,
+		Title:            formData.Title,
+		Body:             formData.Body,
+		PhotoURLs:        []string{},
+		VerifiedPurchase: invoiceId != nil,
+		InvoiceId:        invoiceId,
+		Status:           "PENDING",
+	}
+
+	// Remove already stored photos if any later step fails
+	storedKeys := []string{}
+	success := false
+	defer func() {
+		if !success {
+			for _, key := range storedKeys {
+				if err := infrastructure.MediaStorage.Delete(context.Background(), key); err != nil {
+					log.Printf("Delete media %s failed: %s", key, err.Error())
+				}
+			}
+		}
+	}()
+
+	maxUploadSize := config.AppConfig.MediaMaxUploadSizeValue()
+	for i, photo := range formData.Photos {
+		if photo.Size > maxUploadSize {
+			return nil, fmt.Errorf("size of photo must not be greater than %d bytes", maxUploadSize)
+		}
+		content, err := io.ReadAll(io.LimitReader(photo, maxUploadSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("read photo failed: %s", err.Error())
+		}
+		if int64(len(content)) > maxUploadSize {
+			return nil, fmt.Errorf("size of photo must not be greater than %d bytes", maxUploadSize)
+		}
+		if _, err := checkImageDimensions(content); err != nil {
+			return nil, fmt.Errorf("photo is not valid: %s", err.Error())
+		}
+		img, format, err := image.Decode(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("photo is not valid: %s", err.Error())
+		}
+
+		photoContent, photoFormat, err := encodeImage(utils.ResizeImage(img, reviewPhotoMaxSize), format)
+		if err != nil {
+			return nil, fmt.Errorf("resize photo failed: %s", err.Error())
+		}
+
+		photoKey := fmt.Sprintf("reviews/%s/%d%s", newReview.Id, i+1, imageExtension(photoFormat))
+		photoURL, err := infrastructure.MediaStorage.Put(ctx, photoKey, photoContent, "image/"+photoFormat)
+		if err != nil {
+			return nil, fmt.Errorf("store photo failed: %s", err.Error())
+		}
+		storedKeys = append(storedKeys, photoKey)
+		newReview.PhotoURLs = append(newReview.PhotoURLs, photoURL)
+	}
+
+	if err := reviewService.reviewRepository.Create(ctx, &newReview); err != nil {
+		return nil, fmt.Errorf("insert review to postgresql failed: %s", err.Error())
+	}
+	success = true
+
+	newReviewView, err := reviewService.reviewRepository.GetViewById(ctx, newReview.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query review from postgresql failed: %s", err.Error())
+	}
+
+	return newReviewView, nil
+}
+
+func (reviewService *reviewService) DeleteReviewById(ctx context.Context, reqDTO *dto.DeleteReviewByIdRequest) error {
+	foundReview, err := reviewService.reviewRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of review is not valid")
+	}
+
+	// Users delete their own reviews, ADMIN and STAFF delete any review
+	userId, _ := ctx.Value("user_id").(string)
+	roleName, _ := ctx.Value("role_name").(string)
+	if foundReview.UserId != userId && roleName != "ADMIN" && roleName != "STAFF" {
+		return fmt.Errorf("review does not belong to user")
+	}
+
+	if err := reviewService.reviewRepository.DeleteById(ctx, foundReview.Id); err != nil {
+		return fmt.Errorf("delete review from postgresql failed: %s", err.Error())
+	}
+	deleteReviewPhotos(ctx, foundReview)
+
+	if foundReview.Status == "APPROVED" {
+		return reviewService.syncProductRating(ctx, foundReview.ProductId)
+	}
+
+	return nil
+}
+
+func (reviewService *reviewService) GetReviews(ctx context.Context, reqDTO *dto.GetReviewsRequest) ([]*model.ReviewView, error) {
+	filter := &repository.ReviewFilter{
+		ProductId: reqDTO.ProductId,
+		UserId:    reqDTO.UserId,
+		Status:    reqDTO.Status,
+	}
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	reviews, err := reviewService.reviewRepository.GetViews(ctx, int(reqDTO.Offset), int(reqDTO.Limit), sortFields, filter)
+	if err != nil {
+		return nil, fmt.Errorf("query reviews from postgresql failed: %s", err.Error())
+	}
+
+	return reviews, nil
+}
+
+func (reviewService *reviewService) ModerateReviewById(ctx context.Context, reqDTO *dto.ModerateReviewByIdRequest) error {
+	foundReview, err := reviewService.reviewRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of review is not valid")
+	}
+
+	moderatorId, _ := ctx.Value("user_id").(string)
+	oldStatus := foundReview.Status
+
+	foundReview.Status = reqDTO.Body.Status
+	foundReview.ModerationNote = reqDTO.Body.Note
+	foundReview.ModeratedBy = &moderatorId
+	timeUpdate := time.Now().UTC()
+	foundReview.UpdatedAt = &timeUpdate
+
+	if err := reviewService.reviewRepository.Update(ctx, foundReview); err != nil {
+		return fmt.Errorf("update review on postgresql failed: %s", err.Error())
+	}
+
+	// Only approved reviews count towards rating of product
+	if oldStatus == "APPROVED" || foundReview.Status == "APPROVED" {
+		return reviewService.syncProductRating(ctx, foundReview.ProductId)
+	}
+
+	return nil
+}
+
+// Recalculate rating of product and republish it so elasticsearch-service indexes new rating
+func (reviewService *reviewService) syncProductRating(ctx context.Context, productId string) error {
+	if err := reviewService.reviewRepository.UpdateProductRating(ctx, productId); err != nil {
+		return fmt.Errorf("update rating of product on postgresql failed: %s", err.Error())
+	}
+
+	updatedProductView, err := reviewService.productRepository.GetViewById(ctx, productId)
+	if err != nil {
+		log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", productId, err.Error())
+		return nil
+	}
+	payload, _ := json.Marshal(updatedProductView)
+	if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+		return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Remove photos of review from media storage, failures are only logged since database row is already gone
+func deleteReviewPhotos(ctx context.Context, review *model.Review) {
+	for _, photoURL := range review.PhotoURLs {
+		key := fmt.Sprintf("reviews/%s/%s", review.Id, path.Base(photoURL))
+		if err := infrastructure.MediaStorage.Delete(ctx, key); err != nil {
+			log.Printf("Delete media %s failed: %s", key, err.Error())
+		}
+	}
+}
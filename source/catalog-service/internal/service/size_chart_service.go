@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/client/userservicepb"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type sizeChartService struct {
+	sizeChartRepository repository.SizeChartRepository
+	productRepository   repository.ProductRepository
+	categoryRepository  repository.CategoryRepository
+	brandRepository     repository.BrandRepository
+}
+
+type SizeChartService interface {
+	GetSizeCharts(ctx context.Context, reqDTO *dto.GetSizeChartsRequest) ([]*model.SizeChartView, error)
+	GetSizeChartById(ctx context.Context, reqDTO *dto.GetSizeChartByIdRequest) (*model.SizeChartView, error)
+	CreateSizeChart(ctx context.Context, reqDTO *dto.CreateSizeChartRequest) (*model.SizeChartView, error)
+	UpdateSizeChartById(ctx context.Context, reqDTO *dto.UpdateSizeChartByIdRequest) error
+	DeleteSizeChartById(ctx context.Context, reqDTO *dto.DeleteSizeChartByIdRequest) error
+
+	GetProductSizeChart(ctx context.Context, reqDTO *dto.GetProductSizeChartRequest) (*model.SizeChartView, error)
+	GetProductFitRecommendation(ctx context.Context, reqDTO *dto.GetProductFitRecommendationRequest) (*model.FitRecommendationView, error)
+}
+
+func NewSizeChartService(sizeChartRepository repository.SizeChartRepository, productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, brandRepository repository.BrandRepository) SizeChartService {
+	return &sizeChartService{
+		sizeChartRepository: sizeChartRepository,
+		productRepository:   productRepository,
+		categoryRepository:  categoryRepository,
+		brandRepository:     brandRepository,
+	}
+}
+
+func (sizeChartService *sizeChartService) GetSizeCharts(ctx context.Context, reqDTO *dto.GetSizeChartsRequest) ([]*model.SizeChartView, error) {
+	sizeCharts, err := sizeChartService.sizeChartRepository.GetViews(ctx, reqDTO.BrandId, reqDTO.CategoryId)
+	if err != nil {
+		return nil, fmt.Errorf("query size charts from postgresql failed: %s", err.Error())
+	}
+
+	return sizeCharts, nil
+}
+
+func (sizeChartService *sizeChartService) GetSizeChartById(ctx context.Context, reqDTO *dto.GetSizeChartByIdRequest) (*model.SizeChartView, error) {
+	foundSizeChart, err := sizeChartService.sizeChartRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of size chart is not valid: %s", err.Error())
+	}
+
+	return foundSizeChart, nil
+}
+
+func (sizeChartService *sizeChartService) CreateSizeChart(ctx context.Context, reqDTO *dto.CreateSizeChartRequest) (*model.SizeChartView, error) {
+	foundBrand, err := sizeChartService.brandRepository.GetById(ctx, reqDTO.Body.BrandId)
+	if err != nil {
+		return nil, fmt.Errorf("id of brand is not valid: %s", err.Error())
+	}
+	if foundBrand.Status == "ARCHIVED" {
+		return nil, fmt.Errorf("brand is archived")
+	}
+	foundCategory, err := sizeChartService.categoryRepository.GetById(ctx, reqDTO.Body.CategoryId)
+	if err != nil {
+		return nil, fmt.Errorf("id of category is not valid: %s", err.Error())
+	}
+	if foundCategory.Status == "ARCHIVED" {
+		return nil, fmt.Errorf("category is archived")
+	}
+	if _, err := sizeChartService.sizeChartRepository.GetByBrandIdAndCategoryId(ctx, reqDTO.Body.BrandId, reqDTO.Body.CategoryId); err == nil {
+		return nil, fmt.Errorf("size chart of brand for category already exists")
+	}
+
+	sizes, err := buildSizeChartSizes(reqDTO.Body.Sizes)
+	if err != nil {
+		return nil, err
+	}
+
+	newSizeChart := model.SizeChart{
+		Id:         uuid.New().String(),
+		BrandId:    reqDTO.Body.BrandId,
+		CategoryId: reqDTO.Body.CategoryId,
+		Name:       reqDTO.Body.Name,
+		Sizes:      sizes,
+	}
+	if err := sizeChartService.sizeChartRepository.Create(ctx, &newSizeChart); err != nil {
+		return nil, fmt.Errorf("insert size chart to postgresql failed: %s", err.Error())
+	}
+
+	newSizeChartView, err := sizeChartService.sizeChartRepository.GetViewById(ctx, newSizeChart.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query size chart from postgresql failed: %s", err.Error())
+	}
+
+	return newSizeChartView, nil
+}
+
+func (sizeChartService *sizeChartService) UpdateSizeChartById(ctx context.Context, reqDTO *dto.UpdateSizeChartByIdRequest) error {
+	foundSizeChart, err := sizeChartService.sizeChartRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of size chart is not valid: %s", err.Error())
+	}
+
+	if reqDTO.Body.Name != nil {
+		foundSizeChart.Name = *reqDTO.Body.Name
+	}
+	if reqDTO.Body.Sizes != nil {
+		sizes, err := buildSizeChartSizes(reqDTO.Body.Sizes)
+		if err != nil {
+			return err
+		}
+		foundSizeChart.Sizes = sizes
+	}
+	timeUpdate := time.Now().UTC()
+	foundSizeChart.UpdatedAt = &timeUpdate
+
+	if err := sizeChartService.sizeChartRepository.Update(ctx, foundSizeChart); err != nil {
+		return fmt.Errorf("update size chart on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (sizeChartService *sizeChartService) DeleteSizeChartById(ctx context.Context, reqDTO *dto.DeleteSizeChartByIdRequest) error {
+	if _, err := sizeChartService.sizeChartRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("id of size chart is not valid: %s", err.Error())
+	}
+
+	if err := sizeChartService.sizeChartRepository.DeleteById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("delete size chart from postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (sizeChartService *sizeChartService) GetProductSizeChart(ctx context.Context, reqDTO *dto.GetProductSizeChartRequest) (*model.SizeChartView, error) {
+	foundProduct, err := sizeChartService.productRepository.GetCachedViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of product is not valid: %s", err.Error())
+	}
+	if foundProduct.Status != "PUBLISHED" && !isBackOfficeContext(ctx) {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	foundCategory, err := sizeChartService.categoryRepository.GetViewById(ctx, foundProduct.CategoryId)
+	if err != nil {
+		return nil, fmt.Errorf("query category from postgresql failed: %s", err.Error())
+	}
+
+	foundSizeChart, err := sizeChartService.sizeChartRepository.GetViewByBrandIdAndCategoryPath(ctx, foundProduct.BrandId, foundCategory.Path)
+	if err != nil {
+		return nil, fmt.Errorf("product has no size chart: %s", err.Error())
+	}
+
+	return foundSizeChart, nil
+}
+
+// Size whose ranges body measurements of user fall outside of the fewest times is recommended, then the one they are
+// closest to (relative to width of ranges). Between equally good sizes the larger one is recommended.
+func (sizeChartService *sizeChartService) GetProductFitRecommendation(ctx context.Context, reqDTO *dto.GetProductFitRecommendationRequest) (*model.FitRecommendationView, error) {
+	if infrastructure.UserServiceGRPCClient == nil {
+		return nil, fmt.Errorf("user-service is not running")
+	}
+
+	userId, _ := ctx.Value("user_id").(string)
+	if reqDTO.UserId != "" && reqDTO.UserId != userId {
+		if !isBackOfficeContext(ctx) {
+			return nil, fmt.Errorf("user id is not valid: no permission")
+		}
+		userId = reqDTO.UserId
+	}
+
+	foundSizeChart, err := sizeChartService.GetProductSizeChart(ctx, &dto.GetProductSizeChartRequest{Id: reqDTO.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	grpcRes, err := infrastructure.UserServiceGRPCClient.GetBodyMeasurementsByUserId(ctx, &userservicepb.GetBodyMeasurementsByUserIdRequest{UserId: userId})
+	if err != nil {
+		return nil, fmt.Errorf("get body measurements of user from user-service failed: %s", err.Error())
+	}
+	bodyMeasurements := bodyMeasurementMap(grpcRes.BodyMeasurements)
+	if len(bodyMeasurements) == 0 {
+		return nil, fmt.Errorf("user has no body measurements")
+	}
+
+	var recommendedSize *model.SizeChartSize
+	var recommendedMeasurements []*model.FitMeasurementView
+	bestOutside, bestDistance := math.MaxInt, math.MaxFloat64
+	for _, size := range foundSizeChart.Sizes {
+		var measurements []*model.FitMeasurementView
+		outside, distance := 0, 0.0
+		for _, sizeChartMeasurement := range size.Measurements {
+			value, ok := bodyMeasurements[sizeChartMeasurement.Measurement]
+			if !ok {
+				continue
+			}
+
+			width := math.Max(sizeChartMeasurement.Max-sizeChartMeasurement.Min, 1)
+			fit := "FIT"
+			if value < sizeChartMeasurement.Min {
+				fit = "TOO_LARGE"
+				outside++
+				distance += (sizeChartMeasurement.Min - value) / width
+			} else if value > sizeChartMeasurement.Max {
+				fit = "TOO_SMALL"
+				outside++
+				distance += (value - sizeChartMeasurement.Max) / width
+			}
+
+			measurements = append(measurements, &model.FitMeasurementView{
+				Measurement: sizeChartMeasurement.Measurement,
+				Value:       value,
+				Min:         sizeChartMeasurement.Min,
+				Max:         sizeChartMeasurement.Max,
+				Fit:         fit,
+			})
+		}
+		if len(measurements) == 0 {
+			continue
+		}
+
+		if outside < bestOutside || (outside == bestOutside && distance <= bestDistance) {
+			recommendedSize = size
+			recommendedMeasurements = measurements
+			bestOutside, bestDistance = outside, distance
+		}
+	}
+	if recommendedSize == nil {
+		return nil, fmt.Errorf("body measurements of user are not in size chart of product")
+	}
+
+	return &model.FitRecommendationView{
+		ProductId:       reqDTO.Id,
+		SizeChartId:     foundSizeChart.Id,
+		RecommendedSize: recommendedSize.Label,
+		ExactFit:        bestOutside == 0,
+		Measurements:    recommendedMeasurements,
+	}, nil
+}
+
+// Sizes keep order of request, labels are unique in chart and measurements are unique in size
+func buildSizeChartSizes(sizeRequests []*dto.SizeChartSizeRequest) ([]*model.SizeChartSize, error) {
+	sizes := make([]*model.SizeChartSize, len(sizeRequests))
+	labelSet := map[string]struct{}{}
+	for i, sizeRequest := range sizeRequests {
+		label := strings.TrimSpace(sizeRequest.Label)
+		if label == "" {
+			return nil, fmt.Errorf("label of size must not be empty")
+		}
+		if _, ok := labelSet[strings.ToUpper(label)]; ok {
+			return nil, fmt.Errorf("label of size %s is duplicated", label)
+		}
+		labelSet[strings.ToUpper(label)] = struct{}{}
+
+		size := &model.SizeChartSize{
+			Label:        label,
+			Measurements: make([]*model.SizeChartMeasurement, len(sizeRequest.Measurements)),
+		}
+		measurementSet := map[string]struct{}{}
+		for j, measurementRequest := range sizeRequest.Measurements {
+			if _, ok := measurementSet[measurementRequest.Measurement]; ok {
+				return nil, fmt.Errorf("measurement %s of size %s is duplicated", measurementRequest.Measurement, label)
+			}
+			measurementSet[measurementRequest.Measurement] = struct{}{}
+			if measurementRequest.Min > measurementRequest.Max {
+				return nil, fmt.Errorf("min of measurement %s of size %s must not exceed max", measurementRequest.Measurement, label)
+			}
+
+			size.Measurements[j] = &model.SizeChartMeasurement{
+				Measurement: measurementRequest.Measurement,
+				Min:         measurementRequest.Min,
+				Max:         measurementRequest.Max,
+			}
+		}
+		sizes[i] = size
+	}
+
+	return sizes, nil
+}
+
+// Measurements given by user keyed like measurements of size chart, 0 is not given
+func bodyMeasurementMap(bodyMeasurements *userservicepb.BodyMeasurements) map[string]float64 {
+	measurementMap := map[string]float64{}
+	if bodyMeasurements == nil {
+		return measurementMap
+	}
+
+	for measurement, value := range map[string]float64{
+		"HEIGHT":      bodyMeasurements.Height,
+		"CHEST":       bodyMeasurements.Chest,
+		"WAIST":       bodyMeasurements.Waist,
+		"HIP":         bodyMeasurements.Hip,
+		"INSEAM":      bodyMeasurements.Inseam,
+		"FOOT_LENGTH": bodyMeasurements.FootLength,
+	} {
+		if value > 0 {
+			measurementMap[measurement] = value
+		}
+	}
+
+	return measurementMap
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+)
+
+// Decide which warehouses fulfill quantity of product in an invoice, warehouse stocks are active warehouses having the product
+type StockAllocationStrategy interface {
+	Allocate(productId string, quantity int32, warehouseStocks []*model.WarehouseStockView, destination *dto.Location) ([]*model.StockAllocation, error)
+}
+
+func NewStockAllocationStrategy(name string) StockAllocationStrategy {
+	switch name {
+	case "nearest":
+		return &nearestStockAllocationStrategy{}
+	case "split":
+		return &splitStockAllocationStrategy{}
+	default:
+		return &priorityStockAllocationStrategy{}
+	}
+}
+
+// Ship from the warehouse with highest priority which has whole quantity, split by priority only when none has
+type priorityStockAllocationStrategy struct {
+}
+
+func (strategy *priorityStockAllocationStrategy) Allocate(productId string, quantity int32, warehouseStocks []*model.WarehouseStockView, destination *dto.Location) ([]*model.StockAllocation, error) {
+	return allocateFromOrderedWarehouses(productId, quantity, sortWarehouseStocksByPriority(warehouseStocks), true)
+}
+
+// Ship from the nearest warehouse to shipping location which has whole quantity, split by distance only when none has.
+// Without shipping location it works as priority strategy.
+type nearestStockAllocationStrategy struct {
+}
+
+func (strategy *nearestStockAllocationStrategy) Allocate(productId string, quantity int32, warehouseStocks []*model.WarehouseStockView, destination *dto.Location) ([]*model.StockAllocation, error) {
+	orderedWarehouseStocks := sortWarehouseStocksByPriority(warehouseStocks)
+	if destination != nil {
+		sort.SliceStable(orderedWarehouseStocks, func(i, j int) bool {
+			return distanceInKm(orderedWarehouseStocks[i], destination) < distanceInKm(orderedWarehouseStocks[j], destination)
+		})
+	}
+
+	return allocateFromOrderedWarehouses(productId, quantity, orderedWarehouseStocks, true)
+}
+
+// Take as much as possible from warehouses in priority order, which drains high priority warehouses first
+type splitStockAllocationStrategy struct {
+}
+
+func (strategy *splitStockAllocationStrategy) Allocate(productId string, quantity int32, warehouseStocks []*model.WarehouseStockView, destination *dto.Location) ([]*model.StockAllocation, error) {
+	return allocateFromOrderedWarehouses(productId, quantity, sortWarehouseStocksByPriority(warehouseStocks), false)
+}
+
+func allocateFromOrderedWarehouses(productId string, quantity int32, orderedWarehouseStocks []*model.WarehouseStockView, preferSingleWarehouse bool) ([]*model.StockAllocation, error) {
+	if preferSingleWarehouse {
+		for _, warehouseStock := range orderedWarehouseStocks {
+			if warehouseStock.Stock >= quantity {
+				return []*model.StockAllocation{{ProductId: productId, WarehouseId: warehouseStock.WarehouseId, Quantity: quantity}}, nil
+			}
+		}
+	}
+
+	allocations := []*model.StockAllocation{}
+	remaining := quantity
+	for _, warehouseStock := range orderedWarehouseStocks {
+		if remaining == 0 {
+			break
+		}
+		if warehouseStock.Stock <= 0 {
+			continue
+		}
+		allocatedQuantity := min(warehouseStock.Stock, remaining)
+		allocations = append(allocations, &model.StockAllocation{ProductId: productId, WarehouseId: warehouseStock.WarehouseId, Quantity: allocatedQuantity})
+		remaining -= allocatedQuantity
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("not enough stock for product id: %s", productId)
+	}
+
+	return allocations, nil
+}
+
+func sortWarehouseStocksByPriority(warehouseStocks []*model.WarehouseStockView) []*model.WarehouseStockView {
+	orderedWarehouseStocks := append([]*model.WarehouseStockView{}, warehouseStocks...)
+	sort.SliceStable(orderedWarehouseStocks, func(i, j int) bool {
+		if orderedWarehouseStocks[i].WarehousePriority != orderedWarehouseStocks[j].WarehousePriority {
+			return orderedWarehouseStocks[i].WarehousePriority > orderedWarehouseStocks[j].WarehousePriority
+		}
+		return orderedWarehouseStocks[i].WarehouseCode < orderedWarehouseStocks[j].WarehouseCode
+	})
+	return orderedWarehouseStocks
+}
+
+// Great-circle distance by haversine formula
+func distanceInKm(warehouseStock *model.WarehouseStockView, destination *dto.Location) float64 {
+	const earthRadiusInKm = 6371.0
+	toRadian := func(degree float64) float64 { return degree * math.Pi / 180 }
+
+	dLatitude := toRadian(destination.Latitude - warehouseStock.WarehouseLatitude)
+	dLongitude := toRadian(destination.Longitude - warehouseStock.WarehouseLongitude)
+	a := math.Sin(dLatitude/2)*math.Sin(dLatitude/2) +
+		math.Cos(toRadian(warehouseStock.WarehouseLatitude))*math.Cos(toRadian(destination.Latitude))*math.Sin(dLongitude/2)*math.Sin(dLongitude/2)
+
+	return earthRadiusInKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+
+	"github.com/google/uuid"
+)
+
+type stockMovementService struct {
+	stockMovementRepository repository.StockMovementRepository
+	productRepository       repository.ProductRepository
+	warehouseRepository     repository.WarehouseRepository
+	promotionRepository     repository.PromotionRepository
+}
+
+type StockMovementService interface {
+	GetStockMovements(ctx context.Context, reqDTO *dto.GetStockMovementsRequest) (*model.StockMovementHistoryView, error)
+	CreateStockMovement(ctx context.Context, reqDTO *dto.CreateStockMovementRequest) error
+	ReconcileStockMovements(ctx context.Context, reqDTO *dto.ReconcileStockMovementsRequest) ([]*model.StockReconciliationView, error)
+
+	// Order integration (extra features for order-service)
+	RestoreProductStocksByListInvoiceDetail(ctx context.Context, reqDTO *dto.RestoreProductStocksByListInvoiceDetailRequest) error
+}
+
+func NewStockMovementService(stockMovementRepository repository.StockMovementRepository, productRepository repository.ProductRepository, warehouseRepository repository.WarehouseRepository, promotionRepository repository.PromotionRepository) StockMovementService {
+	return &stockMovementService{
+		stockMovementRepository: stockMovementRepository,
+		productRepository:       productRepository,
+		warehouseRepository:     warehouseRepository,
+		promotionRepository:     promotionRepository,
+	}
+}
+
+func (stockMovementService *stockMovementService) GetStockMovements(ctx context.Context, reqDTO *dto.GetStockMovementsRequest) (*model.StockMovementHistoryView, error) {
+	foundProduct, err := stockMovementService.productRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+	stockMovements, err := stockMovementService.stockMovementRepository.GetViewsByProductId(ctx, reqDTO.Id, reqDTO.Offset, reqDTO.Limit, sortFields, reqDTO.Reason, reqDTO.WarehouseId)
+	if err != nil {
+		return nil, fmt.Errorf("query stock movements from postgresql failed: %s", err.Error())
+	}
+
+	ledgerStock, err := stockMovementService.stockMovementRepository.GetLedgerStockByProductId(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query ledger stock from postgresql failed: %s", err.Error())
+	}
+
+	warehouseStocks, err := stockMovementService.warehouseRepository.GetStockViewsByProductId(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query warehouse stocks from postgresql failed: %s", err.Error())
+	}
+
+	return &model.StockMovementHistoryView{
+		ProductId:       foundProduct.Id,
+		Stock:           foundProduct.Stock,
+		LedgerStock:     ledgerStock,
+		WarehouseStocks: warehouseStocks,
+		StockMovements:  stockMovements,
+	}, nil
+}
+
+func (stockMovementService *stockMovementService) CreateStockMovement(ctx context.Context, reqDTO *dto.CreateStockMovementRequest) error {
+	if _, err := stockMovementService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("id of product is not valid")
+	}
+
+	if reqDTO.Body.Quantity == 0 {
+		return fmt.Errorf("quantity of stock movement must not be zero")
+	}
+	if reqDTO.Body.Reason != "ADJUSTMENT" && reqDTO.Body.Quantity < 0 {
+		return fmt.Errorf("quantity of %s stock movement must be positive", reqDTO.Body.Reason)
+	}
+	if reqDTO.Body.WarehouseId != "" {
+		if _, err := stockMovementService.warehouseRepository.GetById(ctx, reqDTO.Body.WarehouseId); err != nil {
+			return fmt.Errorf("id of warehouse not found")
+		}
+	}
+
+	newStockMovement := &model.StockMovement{
+		Id:          uuid.New().String(),
+		ProductId:   reqDTO.Id,
+		WarehouseId: reqDTO.Body.WarehouseId,
+		Quantity:    reqDTO.Body.Quantity,
+		Reason:      reqDTO.Body.Reason,
+		ActorId:     actorIdFromContext(ctx),
+		Note:        reqDTO.Body.Note,
+	}
+	if reqDTO.Body.InvoiceId != "" {
+		newStockMovement.InvoiceId = &reqDTO.Body.InvoiceId
+	}
+
+	if err := stockMovementService.stockMovementRepository.CreateList(ctx, []*model.StockMovement{newStockMovement}); err != nil {
+		return fmt.Errorf("insert stock movement to postgresql failed: %s", err.Error())
+	}
+
+	return stockMovementService.publishUpdatedProducts(ctx, []string{reqDTO.Id})
+}
+
+func (stockMovementService *stockMovementService) ReconcileStockMovements(ctx context.Context, reqDTO *dto.ReconcileStockMovementsRequest) ([]*model.StockReconciliationView, error) {
+	reconciliations, err := stockMovementService.stockMovementRepository.Reconcile(ctx, reqDTO.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile stock of products with ledger on postgresql failed: %s", err.Error())
+	}
+
+	if !reqDTO.DryRun {
+		productIdMap := map[string]bool{}
+		productIds := []string{}
+		for _, reconciliation := range reconciliations {
+			if !productIdMap[reconciliation.ProductId] {
+				productIdMap[reconciliation.ProductId] = true
+				productIds = append(productIds, reconciliation.ProductId)
+			}
+		}
+		if err := stockMovementService.publishUpdatedProducts(ctx, productIds); err != nil {
+			return nil, err
+		}
+	}
+
+	return reconciliations, nil
+}
+
+func (stockMovementService *stockMovementService) RestoreProductStocksByListInvoiceDetail(ctx context.Context, reqDTO *dto.RestoreProductStocksByListInvoiceDetailRequest) error {
+	if reqDTO.Reason != "CANCEL_RESTORE" && reqDTO.Reason != "RETURN" {
+		return fmt.Errorf("reason of restoring stock must be CANCEL_RESTORE or RETURN")
+	}
+
+	newStockMovements := []*model.StockMovement{}
+	productIds := []string{}
+	for _, invoiceDetail := range reqDTO.InvoiceDetails {
+		// Stock returns to warehouse which fulfilled invoice detail, default warehouse for invoices before warehouses existed
+		newStockMovement := &model.StockMovement{
+			Id:          uuid.New().String(),
+			ProductId:   invoiceDetail.ProductId,
+			WarehouseId: invoiceDetail.WarehouseId,
+			Quantity:    invoiceDetail.Quantity,
+			Reason:      reqDTO.Reason,
+		}
+		if reqDTO.InvoiceId != "" {
+			newStockMovement.InvoiceId = &reqDTO.InvoiceId
+		}
+		if reqDTO.ActorId != "" {
+			newStockMovement.ActorId = &reqDTO.ActorId
+		}
+		newStockMovements = append(newStockMovements, newStockMovement)
+		productIds = append(productIds, invoiceDetail.ProductId)
+	}
+
+	// Stock of cancelled invoice is given back once however many times order-service retries the cancel
+	if reqDTO.Reason == "CANCEL_RESTORE" && reqDTO.InvoiceId != "" {
+		var actorId *string
+		if reqDTO.ActorId != "" {
+			actorId = &reqDTO.ActorId
+		}
+		restoredProductIds, err := stockMovementService.stockMovementRepository.CreateCancelRestore(ctx, reqDTO.InvoiceId, actorId, newStockMovements)
+		if err != nil {
+			return fmt.Errorf("restore stock of products on postgresql failed: %s", err.Error())
+		}
+		productIds = restoredProductIds
+	} else if err := stockMovementService.stockMovementRepository.CreateList(ctx, newStockMovements); err != nil {
+		return fmt.Errorf("restore stock of products on postgresql failed: %s", err.Error())
+	}
+
+	// Units bought under promotions by cancelled invoice go back to their allotments and per user limits, returned
+	// items keep their promotion usage since they were sold under it
+	if reqDTO.Reason == "CANCEL_RESTORE" && reqDTO.InvoiceId != "" {
+		promotionProductIds, err := stockMovementService.promotionRepository.ReleaseUsagesByInvoiceId(ctx, reqDTO.InvoiceId)
+		if err != nil {
+			return fmt.Errorf("release promotion usages on postgresql failed: %s", err.Error())
+		}
+		productIds = append(productIds, promotionProductIds...)
+	}
+
+	return stockMovementService.publishUpdatedProducts(ctx, productIds)
+}
+
+func (stockMovementService *stockMovementService) publishUpdatedProducts(ctx context.Context, productIds []string) error {
+	for _, productId := range productIds {
+		updatedProductView, err := stockMovementService.productRepository.GetViewById(ctx, productId)
+		if err != nil {
+			log.Printf("Query product %s from postgresql failed, event catalog-service.updated-product is skipped: %s", productId, err.Error())
+			continue
+		}
+		payload, _ := json.Marshal(updatedProductView)
+		if err := infrastructure.RedisClient.Publish(ctx, "catalog-service.updated-product", payload).Err(); err != nil {
+			return fmt.Errorf("pulish event catalog-service.updated-product failed: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// User who causes stock movement, nil for system movements
+func actorIdFromContext(ctx context.Context) *string {
+	if userId, _ := ctx.Value("user_id").(string); userId != "" {
+		return &userId
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type warehouseService struct {
+	warehouseRepository repository.WarehouseRepository
+	productRepository   repository.ProductRepository
+}
+
+type WarehouseService interface {
+	GetAllWarehouses(ctx context.Context, reqDTO *dto.GetAllWarehousesRequest) ([]*model.WarehouseView, error)
+	GetWarehouseById(ctx context.Context, reqDTO *dto.GetWarehouseByIdRequest) (*model.WarehouseView, error)
+	CreateWarehouse(ctx context.Context, reqDTO *dto.CreateWarehouseRequest) error
+	UpdateWarehouseById(ctx context.Context, reqDTO *dto.UpdateWarehouseByIdRequest) error
+	DeleteWarehouseById(ctx context.Context, reqDTO *dto.DeleteWarehouseByIdRequest) error
+	GetWarehouseStocksByProductId(ctx context.Context, reqDTO *dto.GetWarehouseStocksByProductIdRequest) ([]*model.WarehouseStockView, error)
+}
+
+func NewWarehouseService(warehouseRepository repository.WarehouseRepository, productRepository repository.ProductRepository) WarehouseService {
+	return &warehouseService{
+		warehouseRepository: warehouseRepository,
+		productRepository:   productRepository,
+	}
+}
+
+func (warehouseService *warehouseService) GetAllWarehouses(ctx context.Context, reqDTO *dto.GetAllWarehousesRequest) ([]*model.WarehouseView, error) {
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	warehouses, err := warehouseService.warehouseRepository.GetAllViews(ctx, sortFields)
+	if err != nil {
+		return nil, fmt.Errorf("query warehouses from postgresql failed: %s", err.Error())
+	}
+
+	return warehouses, nil
+}
+
+func (warehouseService *warehouseService) GetWarehouseById(ctx context.Context, reqDTO *dto.GetWarehouseByIdRequest) (*model.WarehouseView, error) {
+	foundWarehouse, err := warehouseService.warehouseRepository.GetViewById(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("id of warehouse is not valid: %s", err.Error())
+	}
+
+	return foundWarehouse, nil
+}
+
+func (warehouseService *warehouseService) CreateWarehouse(ctx context.Context, reqDTO *dto.CreateWarehouseRequest) error {
+	if _, err := warehouseService.warehouseRepository.GetByCode(ctx, reqDTO.Body.Code); err == nil {
+		return fmt.Errorf("code of warehouse is already exists")
+	}
+
+	newWarehouse := model.Warehouse{
+		Id:        uuid.New().String(),
+		Code:      reqDTO.Body.Code,
+		Name:      reqDTO.Body.Name,
+		Address:   reqDTO.Body.Address,
+		Latitude:  reqDTO.Body.Latitude,
+		Longitude: reqDTO.Body.Longitude,
+		Priority:  reqDTO.Body.Priority,
+		IsActive:  true,
+	}
+	if err := warehouseService.warehouseRepository.Create(ctx, &newWarehouse); err != nil {
+		return fmt.Errorf("insert warehouse to postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (warehouseService *warehouseService) UpdateWarehouseById(ctx context.Context, reqDTO *dto.UpdateWarehouseByIdRequest) error {
+	foundWarehouse, err := warehouseService.warehouseRepository.GetById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("id of warehouse is not valid: %s", err.Error())
+	}
+
+	if reqDTO.Body.Code != nil && *reqDTO.Body.Code != foundWarehouse.Code {
+		if _, err := warehouseService.warehouseRepository.GetByCode(ctx, *reqDTO.Body.Code); err == nil {
+			return fmt.Errorf("code of warehouse is already exists")
+		}
+		foundWarehouse.Code = *reqDTO.Body.Code
+	}
+	if reqDTO.Body.Name != nil {
+		foundWarehouse.Name = *reqDTO.Body.Name
+	}
+	if reqDTO.Body.Address != nil {
+		foundWarehouse.Address = *reqDTO.Body.Address
+	}
+	if reqDTO.Body.Latitude != nil {
+		foundWarehouse.Latitude = *reqDTO.Body.Latitude
+	}
+	if reqDTO.Body.Longitude != nil {
+		foundWarehouse.Longitude = *reqDTO.Body.Longitude
+	}
+	if reqDTO.Body.Priority != nil {
+		foundWarehouse.Priority = *reqDTO.Body.Priority
+	}
+	if reqDTO.Body.IsActive != nil {
+		foundWarehouse.IsActive = *reqDTO.Body.IsActive
+	}
+	timeUpdate := time.Now().UTC()
+	foundWarehouse.UpdatedAt = &timeUpdate
+
+	if err := warehouseService.warehouseRepository.Update(ctx, foundWarehouse); err != nil {
+		return fmt.Errorf("update warehouse on postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (warehouseService *warehouseService) DeleteWarehouseById(ctx context.Context, reqDTO *dto.DeleteWarehouseByIdRequest) error {
+	if _, err := warehouseService.warehouseRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("id of warehouse is not valid")
+	}
+
+	// Stock must be moved out by adjustments first so that ledger keeps track of it
+	totalStock, err := warehouseService.warehouseRepository.GetTotalStockById(ctx, reqDTO.Id)
+	if err != nil {
+		return fmt.Errorf("query stock of warehouse from postgresql failed: %s", err.Error())
+	}
+	if totalStock != 0 {
+		return fmt.Errorf("warehouse still has %d items in stock", totalStock)
+	}
+
+	if err := warehouseService.warehouseRepository.DeleteById(ctx, reqDTO.Id); err != nil {
+		return fmt.Errorf("delete warehouse from postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (warehouseService *warehouseService) GetWarehouseStocksByProductId(ctx context.Context, reqDTO *dto.GetWarehouseStocksByProductIdRequest) ([]*model.WarehouseStockView, error) {
+	if _, err := warehouseService.productRepository.GetById(ctx, reqDTO.Id); err != nil {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	warehouseStocks, err := warehouseService.warehouseRepository.GetStockViewsByProductId(ctx, reqDTO.Id)
+	if err != nil {
+		return nil, fmt.Errorf("query warehouse stocks from postgresql failed: %s", err.Error())
+	}
+
+	return warehouseStocks, nil
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Resize image to fit inside a maxSize x maxSize box keeping aspect ratio, smaller images are returned as they are.
+// Every destination pixel is the average of the source pixels it covers, which keeps thumbnails smooth when downscaling.
+func ResizeImage(src image.Image, maxSize int) image.Image {
+	srcBounds := src.Bounds()
+	srcWidth, srcHeight := srcBounds.Dx(), srcBounds.Dy()
+	if srcWidth <= maxSize && srcHeight <= maxSize {
+		return src
+	}
+
+	dstWidth, dstHeight := maxSize, maxSize
+	if srcWidth > srcHeight {
+		dstHeight = max(1, srcHeight*maxSize/srcWidth)
+	} else {
+		dstWidth = max(1, srcWidth*maxSize/srcHeight)
+	}
+
+	// Normalize source to NRGBA so pixels can be read directly from Pix
+	nrgba := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
+	draw.Draw(nrgba, nrgba.Bounds(), src, srcBounds.Min, draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for dstY := range dstHeight {
+		srcY0 := dstY * srcHeight / dstHeight
+		srcY1 := max(srcY0+1, (dstY+1)*srcHeight/dstHeight)
+		for dstX := range dstWidth {
+			srcX0 := dstX * srcWidth / dstWidth
+			srcX1 := max(srcX0+1, (dstX+1)*srcWidth/dstWidth)
+
+			var r, g, b, a, count int
+			for srcY := srcY0; srcY < srcY1; srcY++ {
+				offset := nrgba.PixOffset(srcX0, srcY)
+				for srcX := srcX0; srcX < srcX1; srcX++ {
+					r += int(nrgba.Pix[offset])
+					g += int(nrgba.Pix[offset+1])
+					b += int(nrgba.Pix[offset+2])
+					a += int(nrgba.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(dstX, dstY)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Vietnamese letters which are not decomposed into base letter + combining marks
+var slugReplacer = strings.NewReplacer("đ", "d", "Đ", "d")
+
+// Generate URL-friendly slug, for example "Áo Thun Đen" -> "ao-thun-den"
+func GenerateSlug(text string) string {
+	decomposed := norm.NFD.String(slugReplacer.Replace(text))
+
+	var builder strings.Builder
+	lastIsDash := true
+	for _, r := range decomposed {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Drop tone and accent marks
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			builder.WriteRune(unicode.ToLower(r))
+			lastIsDash = false
+		default:
+			if !lastIsDash {
+				builder.WriteRune('-')
+				lastIsDash = true
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "-")
+}
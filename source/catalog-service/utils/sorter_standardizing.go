@@ -32,3 +32,13 @@ func ParseSorter(sortBy string) []*SortField {
 
 	return sortFields
 }
+
+// Inverse of ParseSorter, sort fields are formatted back to "field:direction,..."
+func FormatSorter(sortFields []*SortField) string {
+	items := make([]string, len(sortFields))
+	for i, sortField := range sortFields {
+		items[i] = sortField.Field + ":" + sortField.Direction
+	}
+
+	return strings.Join(items, ",")
+}
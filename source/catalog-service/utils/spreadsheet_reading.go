@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Read rows of CSV file, row i of result is line i+1 of file so that rows can be reported by their line number
+func ReadCSVRows(content []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows := [][]string{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Blank lines are skipped by reader, keep line numbers by padding them back
+		line, _ := reader.FieldPos(0)
+		for line > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+		rows = append(rows, record)
+	}
+
+	return rows, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipId string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// Plain text is in <t>, rich text is split into runs
+func (xlsxText *xlsxText) String() string {
+	if len(xlsxText.Runs) == 0 {
+		return xlsxText.Text
+	}
+	var builder strings.Builder
+	for _, run := range xlsxText.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Last column (XFD) and last row of a worksheet, and most cells read from it once gaps between cells are padded
+const (
+	xlsxMaxColumns = 16384
+	xlsxMaxRows    = 1048576
+	xlsxMaxCells   = 1 << 22
+)
+
+// Read rows of first worksheet of XLSX file, row i of result is row i+1 of worksheet so that rows can be reported by
+// their row number. Each part is decompressed up to maxPartSize bytes so that a small file can not expand without bound.
+func ReadXLSXRows(content []byte, maxPartSize int64) ([][]string, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("file is not a valid xlsx: %s", err.Error())
+	}
+	files := map[string]*zip.File{}
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	var workbook xlsxWorkbook
+	if err := readXLSXPart(files, "xl/workbook.xml", maxPartSize, &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("xlsx has no worksheet")
+	}
+	var relationships xlsxRelationships
+	if err := readXLSXPart(files, "xl/_rels/workbook.xml.rels", maxPartSize, &relationships); err != nil {
+		return nil, err
+	}
+	worksheetName := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.Id == workbook.Sheets[0].RelationshipId {
+			// Target is relative to xl/ unless it is absolute
+			if strings.HasPrefix(relationship.Target, "/") {
+				worksheetName = strings.TrimPrefix(relationship.Target, "/")
+			} else {
+				worksheetName = path.Join("xl", relationship.Target)
+			}
+		}
+	}
+	if worksheetName == "" {
+		return nil, fmt.Errorf("first worksheet of xlsx not found")
+	}
+
+	// Workbook without any text cell has no shared strings part
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := readXLSXPart(files, "xl/sharedStrings.xml", maxPartSize, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	var worksheet xlsxWorksheet
+	if err := readXLSXPart(files, worksheetName, maxPartSize, &worksheet); err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	cellCount := 0
+	for _, xlsxRow := range worksheet.Rows {
+		if xlsxRow.Number > xlsxMaxRows {
+			return nil, fmt.Errorf("row %d is beyond last row %d of worksheet", xlsxRow.Number, xlsxMaxRows)
+		}
+		// Empty rows are not stored, keep row numbers by padding them back
+		for xlsxRow.Number > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+
+		row := []string{}
+		for i, cell := range xlsxRow.Cells {
+			column := i
+			if cell.Reference != "" {
+				index, err := xlsxColumnIndex(cell.Reference)
+				if err != nil {
+					return nil, err
+				}
+				column = index
+			}
+			if column >= xlsxMaxColumns {
+				return nil, fmt.Errorf("row %d has more than %d cells", xlsxRow.Number, xlsxMaxColumns)
+			}
+			if column >= len(row) {
+				cellCount += column + 1 - len(row)
+				if cellCount > xlsxMaxCells {
+					return nil, fmt.Errorf("worksheet has more than %d cells", xlsxMaxCells)
+				}
+			}
+			for column >= len(row) {
+				row = append(row, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("cell %s refers to unknown shared string", cell.Reference)
+				}
+				row[column] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				row[column] = cell.Inline.String()
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func readXLSXPart(files map[string]*zip.File, name string, maxPartSize int64, v any) error {
+	file, ok := files[name]
+	if !ok {
+		return fmt.Errorf("xlsx has no part %s", name)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("open part %s of xlsx failed: %s", name, err.Error())
+	}
+	defer reader.Close()
+
+	// Declared size of entry can not be trusted, decompressed bytes are counted instead
+	data, err := io.ReadAll(io.LimitReader(reader, maxPartSize+1))
+	if err != nil {
+		return fmt.Errorf("read part %s of xlsx failed: %s", name, err.Error())
+	}
+	if int64(len(data)) > maxPartSize {
+		return fmt.Errorf("part %s of xlsx must not be greater than %d bytes decompressed", name, maxPartSize)
+	}
+
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil && err != io.EOF {
+		return fmt.Errorf("parse part %s of xlsx failed: %s", name, err.Error())
+	}
+
+	return nil
+}
+
+// Zero-based column index of cell reference, for example "C12" -> 2. References past last column XFD are rejected.
+func xlsxColumnIndex(reference string) (int, error) {
+	index := 0
+	for _, r := range reference {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+		if index > xlsxMaxColumns {
+			return 0, fmt.Errorf("cell %s is beyond last column XFD", reference)
+		}
+	}
+	if index == 0 {
+		return 0, fmt.Errorf("cell reference %s is not valid", reference)
+	}
+	return index - 1, nil
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Write rows of a spreadsheet one by one so that large exports are streamed instead of built in memory.
+// Values are strings or numbers, Close must be called to finish the file.
+type SpreadsheetWriter interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+type csvSpreadsheetWriter struct {
+	writer *csv.Writer
+}
+
+func NewCSVSpreadsheetWriter(w io.Writer) SpreadsheetWriter {
+	return &csvSpreadsheetWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+func (csvSpreadsheetWriter *csvSpreadsheetWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = fmt.Sprint(value)
+	}
+	return csvSpreadsheetWriter.writer.Write(record)
+}
+
+func (csvSpreadsheetWriter *csvSpreadsheetWriter) Close() error {
+	csvSpreadsheetWriter.writer.Flush()
+	return csvSpreadsheetWriter.writer.Error()
+}
+
+// Parts of a workbook with a single worksheet, the worksheet itself is written last so that its rows are streamed
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+type xlsxSpreadsheetWriter struct {
+	zipWriter   *zip.Writer
+	sheetWriter io.Writer
+	rowCount    int
+}
+
+func NewXLSXSpreadsheetWriter(w io.Writer) (SpreadsheetWriter, error) {
+	zipWriter := zip.NewWriter(w)
+
+	for _, part := range xlsxStaticParts {
+		partWriter, err := zipWriter.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(partWriter, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetWriter, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheetWriter, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+
+	return &xlsxSpreadsheetWriter{
+		zipWriter:   zipWriter,
+		sheetWriter: sheetWriter,
+	}, nil
+}
+
+// Strings are written inline so that no shared strings part has to be built before the worksheet
+func (xlsxSpreadsheetWriter *xlsxSpreadsheetWriter) WriteRow(values []any) error {
+	xlsxSpreadsheetWriter.rowCount++
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `<row r="%d">`, xlsxSpreadsheetWriter.rowCount)
+	for _, value := range values {
+		switch value.(type) {
+		case int, int32, int64, float32, float64:
+			fmt.Fprintf(&builder, `<c><v>%v</v></c>`, value)
+		default:
+			builder.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			if err := xml.EscapeText(&builder, []byte(fmt.Sprint(value))); err != nil {
+				return err
+			}
+			builder.WriteString(`</t></is></c>`)
+		}
+	}
+	builder.WriteString(`</row>`)
+
+	_, err := io.WriteString(xlsxSpreadsheetWriter.sheetWriter, builder.String())
+	return err
+}
+
+func (xlsxSpreadsheetWriter *xlsxSpreadsheetWriter) Close() error {
+	if _, err := io.WriteString(xlsxSpreadsheetWriter.sheetWriter, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return xlsxSpreadsheetWriter.zipWriter.Close()
+}
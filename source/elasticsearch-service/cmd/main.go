@@ -15,10 +15,14 @@ func main() {
 	defer infrastructure.RedisClient.Close()
 	infrastructure.InitAllServiceGRPCClients()
 
+	searchAnalyticsService := service.NewSearchAnalyticsService()
+
 	grpcimpl.StartGRPCServer(grpcimpl.NewElasticsearchServiceGRPCImpl(
 		service.NewUserService(config.AppConfig.SyncAvailableDataFromUserService),
-		service.NewCatalogService(config.AppConfig.SyncAvailableDataFromCatalogService),
+		service.NewCatalogService(config.AppConfig.SyncAvailableDataFromCatalogService, searchAnalyticsService),
 		service.NewOrderService(config.AppConfig.SyncAvailableDataFromOrderService),
+		service.NewLeaderboardService(),
+		searchAnalyticsService,
 	))
 
 	select {}
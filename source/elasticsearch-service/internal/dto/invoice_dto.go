@@ -15,31 +15,123 @@ type InvoiceView struct {
 	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	InvoiceDetails []InvoiceDetailView `json:"invoice_details"`
+}
+
+type InvoiceDetailView struct {
+	Id                  string `json:"id"`
+	InvoiceId           string `json:"invoice_id"`
+	ProductId           string `json:"product_id"`
+	Price               int64  `json:"price"`
+	DiscountPercentage  int32  `json:"discount_percentage"`
+	Quantity            int32  `json:"quantity"`
+	TotalPrice          int64  `json:"total_price"`
+	ProductName         string `json:"product_name"`
+	ProductCategoryId   string `json:"product_category_id"`
+	ProductCategoryName string `json:"product_category_name"`
+	ProductBrandId      string `json:"product_brand_id"`
+	ProductBrandName    string `json:"product_brand_name"`
+	WarehouseId         string `json:"warehouse_id"`
+	WarehouseName       string `json:"warehouse_name"`
+	PromotionId         string `json:"promotion_id,omitempty"`
+}
+
+type SalesReport struct {
+	StartTime         string               `json:"start_time"`
+	EndTime           string               `json:"end_time"`
+	TimeInterval      string               `json:"time_interval"`
+	TotalRevenue      int64                `json:"total_revenue"`
+	TotalOrders       int64                `json:"total_orders"`
+	AverageOrderValue float64              `json:"average_order_value"`
+	TotalUnits        int64                `json:"total_units"`
+	Details           []*SalesReportDetail `json:"details"`
+}
+
+type SalesReportDetail struct {
+	StartTime         string              `json:"start_time"`
+	EndTime           string              `json:"end_time"`
+	Revenue           int64               `json:"revenue"`
+	Orders            int64               `json:"orders"`
+	AverageOrderValue float64             `json:"average_order_value"`
+	Units             int64               `json:"units"`
+	Groups            []*SalesReportGroup `json:"groups"`
+}
+
+type SalesReportGroup struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Units   int64  `json:"units"`
+	Revenue int64  `json:"revenue"`
 }
 
 // Receive
 
 func FromInvoiceProtoToInvoiceView(invoiceProto *orderservicepb.Invoice) *InvoiceView {
+	invoiceDetails := make([]InvoiceDetailView, len(invoiceProto.InvoiceDetails))
+	for i, invoiceDetailProto := range invoiceProto.InvoiceDetails {
+		invoiceDetails[i] = InvoiceDetailView{
+			Id:                  invoiceDetailProto.Id,
+			InvoiceId:           invoiceDetailProto.InvoiceId,
+			ProductId:           invoiceDetailProto.ProductId,
+			Price:               invoiceDetailProto.Price,
+			DiscountPercentage:  invoiceDetailProto.DiscountPercentage,
+			Quantity:            invoiceDetailProto.Quantity,
+			TotalPrice:          invoiceDetailProto.TotalPrice,
+			ProductName:         invoiceDetailProto.ProductName,
+			ProductCategoryId:   invoiceDetailProto.ProductCategoryId,
+			ProductCategoryName: invoiceDetailProto.ProductCategoryName,
+			ProductBrandId:      invoiceDetailProto.ProductBrandId,
+			ProductBrandName:    invoiceDetailProto.ProductBrandName,
+			WarehouseId:         invoiceDetailProto.WarehouseId,
+			WarehouseName:       invoiceDetailProto.WarehouseName,
+			PromotionId:         invoiceDetailProto.PromotionId,
+		}
+	}
+
 	return &InvoiceView{
-		Id:          invoiceProto.Id,
-		UserId:      invoiceProto.UserId,
-		TotalAmount: invoiceProto.TotalAmount,
-		Status:      invoiceProto.Status,
-		CreatedAt:   invoiceProto.CreatedAt.AsTime(),
-		UpdatedAt:   invoiceProto.UpdatedAt.AsTime(),
+		Id:             invoiceProto.Id,
+		UserId:         invoiceProto.UserId,
+		TotalAmount:    invoiceProto.TotalAmount,
+		Status:         invoiceProto.Status,
+		CreatedAt:      invoiceProto.CreatedAt.AsTime(),
+		UpdatedAt:      invoiceProto.UpdatedAt.AsTime(),
+		InvoiceDetails: invoiceDetails,
 	}
 }
 
 // Send
 
 func FromInvoiceViewToInvoiceProto(invoiceView *InvoiceView) *elasticsearchservicepb.Invoice {
+	invoiceDetailProtos := make([]*elasticsearchservicepb.InvoiceDetail, len(invoiceView.InvoiceDetails))
+	for i, invoiceDetailView := range invoiceView.InvoiceDetails {
+		invoiceDetailProtos[i] = &elasticsearchservicepb.InvoiceDetail{
+			Id:                  invoiceDetailView.Id,
+			InvoiceId:           invoiceDetailView.InvoiceId,
+			ProductId:           invoiceDetailView.ProductId,
+			Price:               invoiceDetailView.Price,
+			DiscountPercentage:  invoiceDetailView.DiscountPercentage,
+			Quantity:            invoiceDetailView.Quantity,
+			TotalPrice:          invoiceDetailView.TotalPrice,
+			ProductName:         invoiceDetailView.ProductName,
+			ProductCategoryId:   invoiceDetailView.ProductCategoryId,
+			ProductCategoryName: invoiceDetailView.ProductCategoryName,
+			ProductBrandId:      invoiceDetailView.ProductBrandId,
+			ProductBrandName:    invoiceDetailView.ProductBrandName,
+			WarehouseId:         invoiceDetailView.WarehouseId,
+			WarehouseName:       invoiceDetailView.WarehouseName,
+			PromotionId:         invoiceDetailView.PromotionId,
+		}
+	}
+
 	return &elasticsearchservicepb.Invoice{
-		Id:          invoiceView.Id,
-		UserId:      invoiceView.UserId,
-		TotalAmount: invoiceView.TotalAmount,
-		Status:      invoiceView.Status,
-		CreatedAt:   timestamppb.New(invoiceView.CreatedAt),
-		UpdatedAt:   timestamppb.New(invoiceView.UpdatedAt),
+		Id:             invoiceView.Id,
+		UserId:         invoiceView.UserId,
+		TotalAmount:    invoiceView.TotalAmount,
+		Status:         invoiceView.Status,
+		CreatedAt:      timestamppb.New(invoiceView.CreatedAt),
+		UpdatedAt:      timestamppb.New(invoiceView.UpdatedAt),
+		InvoiceDetails: invoiceDetailProtos,
 	}
 }
 
@@ -51,3 +143,39 @@ func FromListInvoiceViewToListInvoiceProto(invoiceViews []InvoiceView) []*elasti
 
 	return invoiceProtos
 }
+
+func FromSalesReportToSalesReportProto(salesReport *SalesReport) *elasticsearchservicepb.SalesReport {
+	detailProtos := make([]*elasticsearchservicepb.SalesReportDetail, len(salesReport.Details))
+	for i, detail := range salesReport.Details {
+		groupProtos := make([]*elasticsearchservicepb.SalesReportGroup, len(detail.Groups))
+		for j, group := range detail.Groups {
+			groupProtos[j] = &elasticsearchservicepb.SalesReportGroup{
+				Id:      group.Id,
+				Name:    group.Name,
+				Units:   group.Units,
+				Revenue: group.Revenue,
+			}
+		}
+
+		detailProtos[i] = &elasticsearchservicepb.SalesReportDetail{
+			StartTime:         detail.StartTime,
+			EndTime:           detail.EndTime,
+			Revenue:           detail.Revenue,
+			Orders:            detail.Orders,
+			AverageOrderValue: detail.AverageOrderValue,
+			Units:             detail.Units,
+			Groups:            groupProtos,
+		}
+	}
+
+	return &elasticsearchservicepb.SalesReport{
+		StartTime:         salesReport.StartTime,
+		EndTime:           salesReport.EndTime,
+		TimeInterval:      salesReport.TimeInterval,
+		TotalRevenue:      salesReport.TotalRevenue,
+		TotalOrders:       salesReport.TotalOrders,
+		AverageOrderValue: salesReport.AverageOrderValue,
+		TotalUnits:        salesReport.TotalUnits,
+		Details:           detailProtos,
+	}
+}
@@ -11,6 +11,7 @@ import (
 type ProductView struct {
 	Id                 string    `json:"id"`
 	Name               string    `json:"name"`
+	Slug               string    `json:"slug"`
 	Description        string    `json:"description"`
 	Sex                string    `json:"sex"`
 	Price              int64     `json:"price"`
@@ -21,8 +22,34 @@ type ProductView struct {
 	CategoryName       string    `json:"category_name"`
 	BrandId            string    `json:"brand_id"`
 	BrandName          string    `json:"brand_name"`
+	AverageRating      float64   `json:"average_rating"`
+	RatingCount        int32     `json:"rating_count"`
+	FinalPrice         int64     `json:"final_price"`
+	LowestPrice30d     int64     `json:"lowest_price_30d"`
+	Status             string    `json:"status"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+
+	CategoryBreadcrumb []CategoryBreadcrumbView `json:"category_breadcrumb"`
+	Attributes         []ProductAttributeView   `json:"attributes"`
+	Tags               []string                 `json:"tags"`
+}
+
+type CategoryBreadcrumbView struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Values are indexed as keywords so any attribute can be filtered and faceted without a mapping change,
+// number value is also indexed for range filters of NUMBER attributes
+type ProductAttributeView struct {
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Value       string   `json:"value"`
+	Unit        string   `json:"unit,omitempty"`
+	NumberValue *float64 `json:"number_value,omitempty"`
 }
 
 // Receive
@@ -31,6 +58,7 @@ func FromProductProtoToProductView(productProto *catalogservicepb.Product) *Prod
 	return &ProductView{
 		Id:                 productProto.Id,
 		Name:               productProto.Name,
+		Slug:               productProto.Slug,
 		Description:        productProto.Description,
 		Sex:                productProto.Sex,
 		Price:              productProto.Price,
@@ -41,9 +69,49 @@ func FromProductProtoToProductView(productProto *catalogservicepb.Product) *Prod
 		CategoryName:       productProto.CategoryName,
 		BrandId:            productProto.BrandId,
 		BrandName:          productProto.BrandName,
+		AverageRating:      productProto.AverageRating,
+		RatingCount:        productProto.RatingCount,
+		FinalPrice:         productProto.FinalPrice,
+		LowestPrice30d:     productProto.LowestPrice_30D,
+		Status:             productProto.Status,
 		CreatedAt:          productProto.CreatedAt.AsTime(),
 		UpdatedAt:          productProto.UpdatedAt.AsTime(),
+		CategoryBreadcrumb: FromListCategoryBreadcrumbProtoToListCategoryBreadcrumbView(productProto.CategoryBreadcrumb),
+		Attributes:         FromListProductAttributeProtoToListProductAttributeView(productProto.Attributes),
+		Tags:               productProto.Tags,
+	}
+}
+
+func FromListCategoryBreadcrumbProtoToListCategoryBreadcrumbView(categoryBreadcrumbProtos []*catalogservicepb.CategoryBreadcrumb) []CategoryBreadcrumbView {
+	categoryBreadcrumbViews := make([]CategoryBreadcrumbView, len(categoryBreadcrumbProtos))
+	for i := range categoryBreadcrumbViews {
+		categoryBreadcrumbViews[i] = CategoryBreadcrumbView{
+			Id:   categoryBreadcrumbProtos[i].Id,
+			Name: categoryBreadcrumbProtos[i].Name,
+			Slug: categoryBreadcrumbProtos[i].Slug,
+		}
 	}
+
+	return categoryBreadcrumbViews
+}
+
+func FromListProductAttributeProtoToListProductAttributeView(productAttributeProtos []*catalogservicepb.ProductAttribute) []ProductAttributeView {
+	productAttributeViews := make([]ProductAttributeView, len(productAttributeProtos))
+	for i := range productAttributeViews {
+		productAttributeViews[i] = ProductAttributeView{
+			Code:  productAttributeProtos[i].Code,
+			Name:  productAttributeProtos[i].Name,
+			Type:  productAttributeProtos[i].Type,
+			Value: productAttributeProtos[i].Value,
+			Unit:  productAttributeProtos[i].Unit,
+		}
+		if productAttributeProtos[i].Type == "NUMBER" {
+			numberValue := productAttributeProtos[i].NumberValue
+			productAttributeViews[i].NumberValue = &numberValue
+		}
+	}
+
+	return productAttributeViews
 }
 
 // Send
@@ -52,6 +120,7 @@ func FromProductViewToProductProto(productView *ProductView) *elasticsearchservi
 	return &elasticsearchservicepb.Product{
 		Id:                 productView.Id,
 		Name:               productView.Name,
+		Slug:               productView.Slug,
 		Description:        productView.Description,
 		Sex:                productView.Sex,
 		Price:              productView.Price,
@@ -62,11 +131,50 @@ func FromProductViewToProductProto(productView *ProductView) *elasticsearchservi
 		CategoryName:       productView.CategoryName,
 		BrandId:            productView.BrandId,
 		BrandName:          productView.BrandName,
+		AverageRating:      productView.AverageRating,
+		RatingCount:        productView.RatingCount,
+		FinalPrice:         productView.FinalPrice,
+		LowestPrice_30D:    productView.LowestPrice30d,
+		Status:             productView.Status,
 		CreatedAt:          timestamppb.New(productView.CreatedAt),
 		UpdatedAt:          timestamppb.New(productView.UpdatedAt),
+		CategoryBreadcrumb: FromListCategoryBreadcrumbViewToListCategoryBreadcrumbProto(productView.CategoryBreadcrumb),
+		Attributes:         FromListProductAttributeViewToListProductAttributeProto(productView.Attributes),
+		Tags:               productView.Tags,
 	}
 }
 
+func FromListCategoryBreadcrumbViewToListCategoryBreadcrumbProto(categoryBreadcrumbViews []CategoryBreadcrumbView) []*elasticsearchservicepb.CategoryBreadcrumb {
+	categoryBreadcrumbProtos := make([]*elasticsearchservicepb.CategoryBreadcrumb, len(categoryBreadcrumbViews))
+	for i := range categoryBreadcrumbProtos {
+		categoryBreadcrumbProtos[i] = &elasticsearchservicepb.CategoryBreadcrumb{
+			Id:   categoryBreadcrumbViews[i].Id,
+			Name: categoryBreadcrumbViews[i].Name,
+			Slug: categoryBreadcrumbViews[i].Slug,
+		}
+	}
+
+	return categoryBreadcrumbProtos
+}
+
+func FromListProductAttributeViewToListProductAttributeProto(productAttributeViews []ProductAttributeView) []*elasticsearchservicepb.ProductAttribute {
+	productAttributeProtos := make([]*elasticsearchservicepb.ProductAttribute, len(productAttributeViews))
+	for i := range productAttributeProtos {
+		productAttributeProtos[i] = &elasticsearchservicepb.ProductAttribute{
+			Code:  productAttributeViews[i].Code,
+			Name:  productAttributeViews[i].Name,
+			Type:  productAttributeViews[i].Type,
+			Value: productAttributeViews[i].Value,
+			Unit:  productAttributeViews[i].Unit,
+		}
+		if productAttributeViews[i].NumberValue != nil {
+			productAttributeProtos[i].NumberValue = *productAttributeViews[i].NumberValue
+		}
+	}
+
+	return productAttributeProtos
+}
+
 func FromListProductViewToListProductProto(productViews []ProductView) []*elasticsearchservicepb.Product {
 	userProtos := make([]*elasticsearchservicepb.Product, len(productViews))
 	for i := range userProtos {
@@ -75,3 +183,24 @@ func FromListProductViewToListProductProto(productViews []ProductView) []*elasti
 
 	return userProtos
 }
+
+type RankedProductView struct {
+	Product   ProductView `json:"product"`
+	Rank      int32       `json:"rank"`
+	UnitsSold int64       `json:"units_sold"`
+	Score     float64     `json:"score"`
+}
+
+func FromListRankedProductViewToListRankedProductProto(rankedProductViews []RankedProductView) []*elasticsearchservicepb.RankedProduct {
+	rankedProductProtos := make([]*elasticsearchservicepb.RankedProduct, len(rankedProductViews))
+	for i := range rankedProductProtos {
+		rankedProductProtos[i] = &elasticsearchservicepb.RankedProduct{
+			Product:   FromProductViewToProductProto(&rankedProductViews[i].Product),
+			Rank:      rankedProductViews[i].Rank,
+			UnitsSold: rankedProductViews[i].UnitsSold,
+			Score:     rankedProductViews[i].Score,
+		}
+	}
+
+	return rankedProductProtos
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"thanhldt060802/internal/grpc/service/elasticsearchservicepb"
+	"time"
+)
+
+type SearchQueryView struct {
+	Id          string            `json:"id"`
+	Query       string            `json:"query"`
+	Filters     map[string]string `json:"filters"`
+	SortBy      string            `json:"sort_by"`
+	Offset      int32             `json:"offset"`
+	Limit       int32             `json:"limit"`
+	ResultCount int64             `json:"result_count"`
+	LatencyMs   int64             `json:"latency_ms"`
+	UserId      string            `json:"user_id,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+type SearchClickView struct {
+	SearchId  string    `json:"search_id,omitempty"`
+	Query     string    `json:"query"`
+	ProductId string    `json:"product_id"`
+	UserId    string    `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SearchReport struct {
+	Type               string             `json:"type"`
+	TotalSearches      int64              `json:"total_searches"`
+	ZeroResultSearches int64              `json:"zero_result_searches"`
+	ClickedSearches    int64              `json:"clicked_searches"`
+	ClickThroughRate   float64            `json:"click_through_rate"`
+	Queries            []*SearchQueryStat `json:"queries"`
+}
+
+type SearchQueryStat struct {
+	Query              string  `json:"query"`
+	Searches           int64   `json:"searches"`
+	ZeroResultSearches int64   `json:"zero_result_searches"`
+	ClickedSearches    int64   `json:"clicked_searches"`
+	Clicks             int64   `json:"clicks"`
+	ClickThroughRate   float64 `json:"click_through_rate"`
+	AverageResultCount float64 `json:"average_result_count"`
+}
+
+// Send
+
+func FromSearchReportToSearchReportProto(searchReport *SearchReport) *elasticsearchservicepb.SearchReport {
+	queryProtos := make([]*elasticsearchservicepb.SearchQueryStat, len(searchReport.Queries))
+	for i, query := range searchReport.Queries {
+		queryProtos[i] = &elasticsearchservicepb.SearchQueryStat{
+			Query:              query.Query,
+			Searches:           query.Searches,
+			ZeroResultSearches: query.ZeroResultSearches,
+			ClickedSearches:    query.ClickedSearches,
+			Clicks:             query.Clicks,
+			ClickThroughRate:   query.ClickThroughRate,
+			AverageResultCount: query.AverageResultCount,
+		}
+	}
+
+	return &elasticsearchservicepb.SearchReport{
+		Type:               searchReport.Type,
+		TotalSearches:      searchReport.TotalSearches,
+		ZeroResultSearches: searchReport.ZeroResultSearches,
+		ClickedSearches:    searchReport.ClickedSearches,
+		ClickThroughRate:   searchReport.ClickThroughRate,
+		Queries:            queryProtos,
+	}
+}
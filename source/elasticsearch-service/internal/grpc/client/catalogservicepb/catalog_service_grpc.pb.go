@@ -19,18 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CatalogServiceGRPC_GetAllProducts_FullMethodName                         = "/catalogservice.CatalogServiceGRPC/GetAllProducts"
-	CatalogServiceGRPC_GetProductById_FullMethodName                         = "/catalogservice.CatalogServiceGRPC/GetProductById"
-	CatalogServiceGRPC_UpdateProductStocksByListInvoiceDetail_FullMethodName = "/catalogservice.CatalogServiceGRPC/UpdateProductStocksByListInvoiceDetail"
+	CatalogServiceGRPC_StreamAllProducts_FullMethodName                       = "/catalogservice.CatalogServiceGRPC/StreamAllProducts"
+	CatalogServiceGRPC_GetProductById_FullMethodName                          = "/catalogservice.CatalogServiceGRPC/GetProductById"
+	CatalogServiceGRPC_GetProductsByIds_FullMethodName                        = "/catalogservice.CatalogServiceGRPC/GetProductsByIds"
+	CatalogServiceGRPC_UpdateProductStocksByListInvoiceDetail_FullMethodName  = "/catalogservice.CatalogServiceGRPC/UpdateProductStocksByListInvoiceDetail"
+	CatalogServiceGRPC_RestoreProductStocksByListInvoiceDetail_FullMethodName = "/catalogservice.CatalogServiceGRPC/RestoreProductStocksByListInvoiceDetail"
+	CatalogServiceGRPC_GetUnconfirmedAllocatedBackorders_FullMethodName       = "/catalogservice.CatalogServiceGRPC/GetUnconfirmedAllocatedBackorders"
+	CatalogServiceGRPC_ConfirmAllocatedBackorder_FullMethodName               = "/catalogservice.CatalogServiceGRPC/ConfirmAllocatedBackorder"
 )
 
 // CatalogServiceGRPCClient is the client API for CatalogServiceGRPC service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CatalogServiceGRPCClient interface {
-	GetAllProducts(ctx context.Context, in *GetAllProductsRequest, opts ...grpc.CallOption) (*GetAllProductsResponse, error)
+	StreamAllProducts(ctx context.Context, in *StreamAllProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllProductsResponse], error)
 	GetProductById(ctx context.Context, in *GetProductByIdRequest, opts ...grpc.CallOption) (*GetProductByIdResponse, error)
+	GetProductsByIds(ctx context.Context, in *GetProductsByIdsRequest, opts ...grpc.CallOption) (*GetProductsByIdsResponse, error)
 	UpdateProductStocksByListInvoiceDetail(ctx context.Context, in *UpdateProductStocksByListInvoiceDetailRequest, opts ...grpc.CallOption) (*UpdateProductStocksByListInvoiceDetailResponse, error)
+	RestoreProductStocksByListInvoiceDetail(ctx context.Context, in *RestoreProductStocksByListInvoiceDetailRequest, opts ...grpc.CallOption) (*RestoreProductStocksByListInvoiceDetailResponse, error)
+	GetUnconfirmedAllocatedBackorders(ctx context.Context, in *GetUnconfirmedAllocatedBackordersRequest, opts ...grpc.CallOption) (*GetUnconfirmedAllocatedBackordersResponse, error)
+	ConfirmAllocatedBackorder(ctx context.Context, in *ConfirmAllocatedBackorderRequest, opts ...grpc.CallOption) (*ConfirmAllocatedBackorderResponse, error)
 }
 
 type catalogServiceGRPCClient struct {
@@ -41,16 +49,25 @@ func NewCatalogServiceGRPCClient(cc grpc.ClientConnInterface) CatalogServiceGRPC
 	return &catalogServiceGRPCClient{cc}
 }
 
-func (c *catalogServiceGRPCClient) GetAllProducts(ctx context.Context, in *GetAllProductsRequest, opts ...grpc.CallOption) (*GetAllProductsResponse, error) {
+func (c *catalogServiceGRPCClient) StreamAllProducts(ctx context.Context, in *StreamAllProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllProductsResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetAllProductsResponse)
-	err := c.cc.Invoke(ctx, CatalogServiceGRPC_GetAllProducts_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogServiceGRPC_ServiceDesc.Streams[0], CatalogServiceGRPC_StreamAllProducts_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[StreamAllProductsRequest, StreamAllProductsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogServiceGRPC_StreamAllProductsClient = grpc.ServerStreamingClient[StreamAllProductsResponse]
+
 func (c *catalogServiceGRPCClient) GetProductById(ctx context.Context, in *GetProductByIdRequest, opts ...grpc.CallOption) (*GetProductByIdResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetProductByIdResponse)
@@ -61,6 +78,16 @@ func (c *catalogServiceGRPCClient) GetProductById(ctx context.Context, in *GetPr
 	return out, nil
 }
 
+func (c *catalogServiceGRPCClient) GetProductsByIds(ctx context.Context, in *GetProductsByIdsRequest, opts ...grpc.CallOption) (*GetProductsByIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductsByIdsResponse)
+	err := c.cc.Invoke(ctx, CatalogServiceGRPC_GetProductsByIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceGRPCClient) UpdateProductStocksByListInvoiceDetail(ctx context.Context, in *UpdateProductStocksByListInvoiceDetailRequest, opts ...grpc.CallOption) (*UpdateProductStocksByListInvoiceDetailResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateProductStocksByListInvoiceDetailResponse)
@@ -71,13 +98,47 @@ func (c *catalogServiceGRPCClient) UpdateProductStocksByListInvoiceDetail(ctx co
 	return out, nil
 }
 
+func (c *catalogServiceGRPCClient) RestoreProductStocksByListInvoiceDetail(ctx context.Context, in *RestoreProductStocksByListInvoiceDetailRequest, opts ...grpc.CallOption) (*RestoreProductStocksByListInvoiceDetailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreProductStocksByListInvoiceDetailResponse)
+	err := c.cc.Invoke(ctx, CatalogServiceGRPC_RestoreProductStocksByListInvoiceDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceGRPCClient) GetUnconfirmedAllocatedBackorders(ctx context.Context, in *GetUnconfirmedAllocatedBackordersRequest, opts ...grpc.CallOption) (*GetUnconfirmedAllocatedBackordersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnconfirmedAllocatedBackordersResponse)
+	err := c.cc.Invoke(ctx, CatalogServiceGRPC_GetUnconfirmedAllocatedBackorders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceGRPCClient) ConfirmAllocatedBackorder(ctx context.Context, in *ConfirmAllocatedBackorderRequest, opts ...grpc.CallOption) (*ConfirmAllocatedBackorderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmAllocatedBackorderResponse)
+	err := c.cc.Invoke(ctx, CatalogServiceGRPC_ConfirmAllocatedBackorder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServiceGRPCServer is the server API for CatalogServiceGRPC service.
 // All implementations must embed UnimplementedCatalogServiceGRPCServer
 // for forward compatibility.
 type CatalogServiceGRPCServer interface {
-	GetAllProducts(context.Context, *GetAllProductsRequest) (*GetAllProductsResponse, error)
+	StreamAllProducts(*StreamAllProductsRequest, grpc.ServerStreamingServer[StreamAllProductsResponse]) error
 	GetProductById(context.Context, *GetProductByIdRequest) (*GetProductByIdResponse, error)
+	GetProductsByIds(context.Context, *GetProductsByIdsRequest) (*GetProductsByIdsResponse, error)
 	UpdateProductStocksByListInvoiceDetail(context.Context, *UpdateProductStocksByListInvoiceDetailRequest) (*UpdateProductStocksByListInvoiceDetailResponse, error)
+	RestoreProductStocksByListInvoiceDetail(context.Context, *RestoreProductStocksByListInvoiceDetailRequest) (*RestoreProductStocksByListInvoiceDetailResponse, error)
+	GetUnconfirmedAllocatedBackorders(context.Context, *GetUnconfirmedAllocatedBackordersRequest) (*GetUnconfirmedAllocatedBackordersResponse, error)
+	ConfirmAllocatedBackorder(context.Context, *ConfirmAllocatedBackorderRequest) (*ConfirmAllocatedBackorderResponse, error)
 	mustEmbedUnimplementedCatalogServiceGRPCServer()
 }
 
@@ -88,15 +149,27 @@ type CatalogServiceGRPCServer interface {
 // pointer dereference when methods are called.
 type UnimplementedCatalogServiceGRPCServer struct{}
 
-func (UnimplementedCatalogServiceGRPCServer) GetAllProducts(context.Context, *GetAllProductsRequest) (*GetAllProductsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllProducts not implemented")
+func (UnimplementedCatalogServiceGRPCServer) StreamAllProducts(*StreamAllProductsRequest, grpc.ServerStreamingServer[StreamAllProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAllProducts not implemented")
 }
 func (UnimplementedCatalogServiceGRPCServer) GetProductById(context.Context, *GetProductByIdRequest) (*GetProductByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProductById not implemented")
 }
+func (UnimplementedCatalogServiceGRPCServer) GetProductsByIds(context.Context, *GetProductsByIdsRequest) (*GetProductsByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByIds not implemented")
+}
 func (UnimplementedCatalogServiceGRPCServer) UpdateProductStocksByListInvoiceDetail(context.Context, *UpdateProductStocksByListInvoiceDetailRequest) (*UpdateProductStocksByListInvoiceDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProductStocksByListInvoiceDetail not implemented")
 }
+func (UnimplementedCatalogServiceGRPCServer) RestoreProductStocksByListInvoiceDetail(context.Context, *RestoreProductStocksByListInvoiceDetailRequest) (*RestoreProductStocksByListInvoiceDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreProductStocksByListInvoiceDetail not implemented")
+}
+func (UnimplementedCatalogServiceGRPCServer) GetUnconfirmedAllocatedBackorders(context.Context, *GetUnconfirmedAllocatedBackordersRequest) (*GetUnconfirmedAllocatedBackordersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnconfirmedAllocatedBackorders not implemented")
+}
+func (UnimplementedCatalogServiceGRPCServer) ConfirmAllocatedBackorder(context.Context, *ConfirmAllocatedBackorderRequest) (*ConfirmAllocatedBackorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmAllocatedBackorder not implemented")
+}
 func (UnimplementedCatalogServiceGRPCServer) mustEmbedUnimplementedCatalogServiceGRPCServer() {}
 func (UnimplementedCatalogServiceGRPCServer) testEmbeddedByValue()                            {}
 
@@ -118,38 +191,49 @@ func RegisterCatalogServiceGRPCServer(s grpc.ServiceRegistrar, srv CatalogServic
 	s.RegisterService(&CatalogServiceGRPC_ServiceDesc, srv)
 }
 
-func _CatalogServiceGRPC_GetAllProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllProductsRequest)
+func _CatalogServiceGRPC_StreamAllProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAllProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceGRPCServer).StreamAllProducts(m, &grpc.GenericServerStream[StreamAllProductsRequest, StreamAllProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogServiceGRPC_StreamAllProductsServer = grpc.ServerStreamingServer[StreamAllProductsResponse]
+
+func _CatalogServiceGRPC_GetProductById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByIdRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceGRPCServer).GetAllProducts(ctx, in)
+		return srv.(CatalogServiceGRPCServer).GetProductById(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogServiceGRPC_GetAllProducts_FullMethodName,
+		FullMethod: CatalogServiceGRPC_GetProductById_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceGRPCServer).GetAllProducts(ctx, req.(*GetAllProductsRequest))
+		return srv.(CatalogServiceGRPCServer).GetProductById(ctx, req.(*GetProductByIdRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CatalogServiceGRPC_GetProductById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProductByIdRequest)
+func _CatalogServiceGRPC_GetProductsByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductsByIdsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CatalogServiceGRPCServer).GetProductById(ctx, in)
+		return srv.(CatalogServiceGRPCServer).GetProductsByIds(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CatalogServiceGRPC_GetProductById_FullMethodName,
+		FullMethod: CatalogServiceGRPC_GetProductsByIds_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CatalogServiceGRPCServer).GetProductById(ctx, req.(*GetProductByIdRequest))
+		return srv.(CatalogServiceGRPCServer).GetProductsByIds(ctx, req.(*GetProductsByIdsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -172,6 +256,60 @@ func _CatalogServiceGRPC_UpdateProductStocksByListInvoiceDetail_Handler(srv inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogServiceGRPC_RestoreProductStocksByListInvoiceDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProductStocksByListInvoiceDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceGRPCServer).RestoreProductStocksByListInvoiceDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogServiceGRPC_RestoreProductStocksByListInvoiceDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceGRPCServer).RestoreProductStocksByListInvoiceDetail(ctx, req.(*RestoreProductStocksByListInvoiceDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogServiceGRPC_GetUnconfirmedAllocatedBackorders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnconfirmedAllocatedBackordersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceGRPCServer).GetUnconfirmedAllocatedBackorders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogServiceGRPC_GetUnconfirmedAllocatedBackorders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceGRPCServer).GetUnconfirmedAllocatedBackorders(ctx, req.(*GetUnconfirmedAllocatedBackordersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogServiceGRPC_ConfirmAllocatedBackorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmAllocatedBackorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceGRPCServer).ConfirmAllocatedBackorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogServiceGRPC_ConfirmAllocatedBackorder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceGRPCServer).ConfirmAllocatedBackorder(ctx, req.(*ConfirmAllocatedBackorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CatalogServiceGRPC_ServiceDesc is the grpc.ServiceDesc for CatalogServiceGRPC service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -179,19 +317,37 @@ var CatalogServiceGRPC_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "catalogservice.CatalogServiceGRPC",
 	HandlerType: (*CatalogServiceGRPCServer)(nil),
 	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetAllProducts",
-			Handler:    _CatalogServiceGRPC_GetAllProducts_Handler,
-		},
 		{
 			MethodName: "GetProductById",
 			Handler:    _CatalogServiceGRPC_GetProductById_Handler,
 		},
+		{
+			MethodName: "GetProductsByIds",
+			Handler:    _CatalogServiceGRPC_GetProductsByIds_Handler,
+		},
 		{
 			MethodName: "UpdateProductStocksByListInvoiceDetail",
 			Handler:    _CatalogServiceGRPC_UpdateProductStocksByListInvoiceDetail_Handler,
 		},
+		{
+			MethodName: "RestoreProductStocksByListInvoiceDetail",
+			Handler:    _CatalogServiceGRPC_RestoreProductStocksByListInvoiceDetail_Handler,
+		},
+		{
+			MethodName: "GetUnconfirmedAllocatedBackorders",
+			Handler:    _CatalogServiceGRPC_GetUnconfirmedAllocatedBackorders_Handler,
+		},
+		{
+			MethodName: "ConfirmAllocatedBackorder",
+			Handler:    _CatalogServiceGRPC_ConfirmAllocatedBackorder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAllProducts",
+			Handler:       _CatalogServiceGRPC_StreamAllProducts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "catalog_service.proto",
 }
@@ -302,6 +302,14 @@ type GetProductsRequest struct {
 	BrandName             string                 `protobuf:"bytes,16,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
 	CreatedAtGte          string                 `protobuf:"bytes,17,opt,name=created_at_gte,json=createdAtGte,proto3" json:"created_at_gte,omitempty"`
 	CreatedAtLte          string                 `protobuf:"bytes,18,opt,name=created_at_lte,json=createdAtLte,proto3" json:"created_at_lte,omitempty"`
+	UserId                string                 `protobuf:"bytes,19,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SearchId              string                 `protobuf:"bytes,20,opt,name=search_id,json=searchId,proto3" json:"search_id,omitempty"`
+	AverageRatingGte      string                 `protobuf:"bytes,21,opt,name=average_rating_gte,json=averageRatingGte,proto3" json:"average_rating_gte,omitempty"`
+	RatingCountGte        string                 `protobuf:"bytes,22,opt,name=rating_count_gte,json=ratingCountGte,proto3" json:"rating_count_gte,omitempty"`
+	FinalPriceGte         string                 `protobuf:"bytes,23,opt,name=final_price_gte,json=finalPriceGte,proto3" json:"final_price_gte,omitempty"`
+	FinalPriceLte         string                 `protobuf:"bytes,24,opt,name=final_price_lte,json=finalPriceLte,proto3" json:"final_price_lte,omitempty"`
+	Attributes            string                 `protobuf:"bytes,25,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	Tags                  string                 `protobuf:"bytes,26,opt,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -462,9 +470,66 @@ func (x *GetProductsRequest) GetCreatedAtLte() string {
 	return ""
 }
 
+func (x *GetProductsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetSearchId() string {
+	if x != nil {
+		return x.SearchId
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetAverageRatingGte() string {
+	if x != nil {
+		return x.AverageRatingGte
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetRatingCountGte() string {
+	if x != nil {
+		return x.RatingCountGte
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetFinalPriceGte() string {
+	if x != nil {
+		return x.FinalPriceGte
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetFinalPriceLte() string {
+	if x != nil {
+		return x.FinalPriceLte
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetAttributes() string {
+	if x != nil {
+		return x.Attributes
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetTags() string {
+	if x != nil {
+		return x.Tags
+	}
+	return ""
+}
+
 type GetProductsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Facets        []*ProductFacet        `protobuf:"bytes,2,rep,name=facets,proto3" json:"facets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -494,53 +559,1782 @@ func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
-func (*GetProductsResponse) Descriptor() ([]byte, []int) {
-	return file_elasticsearch_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetProductsResponse) GetFacets() []*ProductFacet {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+type ProductFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Buckets       []*ProductFacetBucket  `protobuf:"bytes,3,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductFacet) Reset() {
+	*x = ProductFacet{}
+	mi := &file_elasticsearch_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductFacet) ProtoMessage() {}
+
+func (x *ProductFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductFacet.ProtoReflect.Descriptor instead.
+func (*ProductFacet) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProductFacet) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ProductFacet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductFacet) GetBuckets() []*ProductFacetBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type ProductFacetBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductFacetBucket) Reset() {
+	*x = ProductFacetBucket{}
+	mi := &file_elasticsearch_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductFacetBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductFacetBucket) ProtoMessage() {}
+
+func (x *ProductFacetBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductFacetBucket.ProtoReflect.Descriptor instead.
+func (*ProductFacetBucket) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProductFacetBucket) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProductFacetBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetSearchReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	CreatedAtGte  string                 `protobuf:"bytes,3,opt,name=created_at_gte,json=createdAtGte,proto3" json:"created_at_gte,omitempty"`
+	CreatedAtLte  string                 `protobuf:"bytes,4,opt,name=created_at_lte,json=createdAtLte,proto3" json:"created_at_lte,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchReportRequest) Reset() {
+	*x = GetSearchReportRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchReportRequest) ProtoMessage() {}
+
+func (x *GetSearchReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSearchReportRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetSearchReportRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetSearchReportRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetSearchReportRequest) GetCreatedAtGte() string {
+	if x != nil {
+		return x.CreatedAtGte
+	}
+	return ""
+}
+
+func (x *GetSearchReportRequest) GetCreatedAtLte() string {
+	if x != nil {
+		return x.CreatedAtLte
+	}
+	return ""
+}
+
+type GetSearchReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SearchReport  *SearchReport          `protobuf:"bytes,1,opt,name=search_report,json=searchReport,proto3" json:"search_report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchReportResponse) Reset() {
+	*x = GetSearchReportResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchReportResponse) ProtoMessage() {}
+
+func (x *GetSearchReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchReportResponse.ProtoReflect.Descriptor instead.
+func (*GetSearchReportResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSearchReportResponse) GetSearchReport() *SearchReport {
+	if x != nil {
+		return x.SearchReport
+	}
+	return nil
+}
+
+type SearchReport struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Type               string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	TotalSearches      int64                  `protobuf:"varint,2,opt,name=total_searches,json=totalSearches,proto3" json:"total_searches,omitempty"`
+	ZeroResultSearches int64                  `protobuf:"varint,3,opt,name=zero_result_searches,json=zeroResultSearches,proto3" json:"zero_result_searches,omitempty"`
+	ClickedSearches    int64                  `protobuf:"varint,4,opt,name=clicked_searches,json=clickedSearches,proto3" json:"clicked_searches,omitempty"`
+	ClickThroughRate   float64                `protobuf:"fixed64,5,opt,name=click_through_rate,json=clickThroughRate,proto3" json:"click_through_rate,omitempty"`
+	Queries            []*SearchQueryStat     `protobuf:"bytes,6,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SearchReport) Reset() {
+	*x = SearchReport{}
+	mi := &file_elasticsearch_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReport) ProtoMessage() {}
+
+func (x *SearchReport) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReport.ProtoReflect.Descriptor instead.
+func (*SearchReport) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchReport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchReport) GetTotalSearches() int64 {
+	if x != nil {
+		return x.TotalSearches
+	}
+	return 0
+}
+
+func (x *SearchReport) GetZeroResultSearches() int64 {
+	if x != nil {
+		return x.ZeroResultSearches
+	}
+	return 0
+}
+
+func (x *SearchReport) GetClickedSearches() int64 {
+	if x != nil {
+		return x.ClickedSearches
+	}
+	return 0
+}
+
+func (x *SearchReport) GetClickThroughRate() float64 {
+	if x != nil {
+		return x.ClickThroughRate
+	}
+	return 0
+}
+
+func (x *SearchReport) GetQueries() []*SearchQueryStat {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+type SearchQueryStat struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Query              string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Searches           int64                  `protobuf:"varint,2,opt,name=searches,proto3" json:"searches,omitempty"`
+	ZeroResultSearches int64                  `protobuf:"varint,3,opt,name=zero_result_searches,json=zeroResultSearches,proto3" json:"zero_result_searches,omitempty"`
+	ClickedSearches    int64                  `protobuf:"varint,4,opt,name=clicked_searches,json=clickedSearches,proto3" json:"clicked_searches,omitempty"`
+	Clicks             int64                  `protobuf:"varint,5,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	ClickThroughRate   float64                `protobuf:"fixed64,6,opt,name=click_through_rate,json=clickThroughRate,proto3" json:"click_through_rate,omitempty"`
+	AverageResultCount float64                `protobuf:"fixed64,7,opt,name=average_result_count,json=averageResultCount,proto3" json:"average_result_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SearchQueryStat) Reset() {
+	*x = SearchQueryStat{}
+	mi := &file_elasticsearch_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchQueryStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchQueryStat) ProtoMessage() {}
+
+func (x *SearchQueryStat) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchQueryStat.ProtoReflect.Descriptor instead.
+func (*SearchQueryStat) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchQueryStat) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchQueryStat) GetSearches() int64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+func (x *SearchQueryStat) GetZeroResultSearches() int64 {
+	if x != nil {
+		return x.ZeroResultSearches
+	}
+	return 0
+}
+
+func (x *SearchQueryStat) GetClickedSearches() int64 {
+	if x != nil {
+		return x.ClickedSearches
+	}
+	return 0
+}
+
+func (x *SearchQueryStat) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *SearchQueryStat) GetClickThroughRate() float64 {
+	if x != nil {
+		return x.ClickThroughRate
+	}
+	return 0
+}
+
+func (x *SearchQueryStat) GetAverageResultCount() float64 {
+	if x != nil {
+		return x.AverageResultCount
+	}
+	return 0
+}
+
+type Product struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Sex                string                 `protobuf:"bytes,4,opt,name=sex,proto3" json:"sex,omitempty"`
+	Price              int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	DiscountPercentage int32                  `protobuf:"varint,6,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
+	Stock              int32                  `protobuf:"varint,7,opt,name=stock,proto3" json:"stock,omitempty"`
+	ImageUrl           string                 `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	CategoryId         string                 `protobuf:"bytes,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CategoryName       string                 `protobuf:"bytes,10,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	BrandId            string                 `protobuf:"bytes,11,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	BrandName          string                 `protobuf:"bytes,12,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CategoryBreadcrumb []*CategoryBreadcrumb  `protobuf:"bytes,15,rep,name=category_breadcrumb,json=categoryBreadcrumb,proto3" json:"category_breadcrumb,omitempty"`
+	AverageRating      float64                `protobuf:"fixed64,16,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	RatingCount        int32                  `protobuf:"varint,17,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	FinalPrice         int64                  `protobuf:"varint,18,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
+	LowestPrice_30D    int64                  `protobuf:"varint,19,opt,name=lowest_price_30d,json=lowestPrice30d,proto3" json:"lowest_price_30d,omitempty"`
+	Status             string                 `protobuf:"bytes,20,opt,name=status,proto3" json:"status,omitempty"`
+	Slug               string                 `protobuf:"bytes,21,opt,name=slug,proto3" json:"slug,omitempty"`
+	Attributes         []*ProductAttribute    `protobuf:"bytes,22,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Tags               []string               `protobuf:"bytes,23,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_elasticsearch_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetSex() string {
+	if x != nil {
+		return x.Sex
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetDiscountPercentage() int32 {
+	if x != nil {
+		return x.DiscountPercentage
+	}
+	return 0
+}
+
+func (x *Product) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Product) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Product) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Product) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+func (x *Product) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *Product) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetCategoryBreadcrumb() []*CategoryBreadcrumb {
+	if x != nil {
+		return x.CategoryBreadcrumb
+	}
+	return nil
+}
+
+func (x *Product) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *Product) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Product) GetFinalPrice() int64 {
+	if x != nil {
+		return x.FinalPrice
+	}
+	return 0
+}
+
+func (x *Product) GetLowestPrice_30D() int64 {
+	if x != nil {
+		return x.LowestPrice_30D
+	}
+	return 0
+}
+
+func (x *Product) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Product) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Product) GetAttributes() []*ProductAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Product) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ProductAttribute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	NumberValue   float64                `protobuf:"fixed64,6,opt,name=number_value,json=numberValue,proto3" json:"number_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAttribute) Reset() {
+	*x = ProductAttribute{}
+	mi := &file_elasticsearch_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAttribute) ProtoMessage() {}
+
+func (x *ProductAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAttribute.ProtoReflect.Descriptor instead.
+func (*ProductAttribute) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ProductAttribute) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetNumberValue() float64 {
+	if x != nil {
+		return x.NumberValue
+	}
+	return 0
+}
+
+type CategoryBreadcrumb struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryBreadcrumb) Reset() {
+	*x = CategoryBreadcrumb{}
+	mi := &file_elasticsearch_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryBreadcrumb) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryBreadcrumb) ProtoMessage() {}
+
+func (x *CategoryBreadcrumb) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryBreadcrumb.ProtoReflect.Descriptor instead.
+func (*CategoryBreadcrumb) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CategoryBreadcrumb) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CategoryBreadcrumb) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryBreadcrumb) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type GetProductRecommendationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRecommendationsRequest) Reset() {
+	*x = GetProductRecommendationsRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetProductRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProductRecommendationsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductRecommendationsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetProductRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetProductRecommendationsResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	SimilarProducts          []*Product             `protobuf:"bytes,1,rep,name=similar_products,json=similarProducts,proto3" json:"similar_products,omitempty"`
+	FrequentlyBoughtTogether []*Product             `protobuf:"bytes,2,rep,name=frequently_bought_together,json=frequentlyBoughtTogether,proto3" json:"frequently_bought_together,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetProductRecommendationsResponse) Reset() {
+	*x = GetProductRecommendationsResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetProductRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProductRecommendationsResponse) GetSimilarProducts() []*Product {
+	if x != nil {
+		return x.SimilarProducts
+	}
+	return nil
+}
+
+func (x *GetProductRecommendationsResponse) GetFrequentlyBoughtTogether() []*Product {
+	if x != nil {
+		return x.FrequentlyBoughtTogether
+	}
+	return nil
+}
+
+type GetTopProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Period        string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	BrandId       string                 `protobuf:"bytes,4,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopProductsRequest) Reset() {
+	*x = GetTopProductsRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopProductsRequest) ProtoMessage() {}
+
+func (x *GetTopProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopProductsRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTopProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTopProductsRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetTopProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *GetTopProductsRequest) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+type GetTopProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*RankedProduct       `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopProductsResponse) Reset() {
+	*x = GetTopProductsResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopProductsResponse) ProtoMessage() {}
+
+func (x *GetTopProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetTopProductsResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTopProductsResponse) GetProducts() []*RankedProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type GetTrendingProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	BrandId       string                 `protobuf:"bytes,3,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingProductsRequest) Reset() {
+	*x = GetTrendingProductsRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingProductsRequest) ProtoMessage() {}
+
+func (x *GetTrendingProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingProductsRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTrendingProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTrendingProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *GetTrendingProductsRequest) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+type GetTrendingProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*RankedProduct       `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingProductsResponse) Reset() {
+	*x = GetTrendingProductsResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingProductsResponse) ProtoMessage() {}
+
+func (x *GetTrendingProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendingProductsResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTrendingProductsResponse) GetProducts() []*RankedProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type RankedProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Rank          int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	UnitsSold     int64                  `protobuf:"varint,3,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankedProduct) Reset() {
+	*x = RankedProduct{}
+	mi := &file_elasticsearch_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedProduct) ProtoMessage() {}
+
+func (x *RankedProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedProduct.ProtoReflect.Descriptor instead.
+func (*RankedProduct) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RankedProduct) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *RankedProduct) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankedProduct) GetUnitsSold() int64 {
+	if x != nil {
+		return x.UnitsSold
+	}
+	return 0
+}
+
+func (x *RankedProduct) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetInvoicesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Offset         int32                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	SortBy         string                 `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	UserId         string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TotalAmountGte string                 `protobuf:"bytes,5,opt,name=total_amount_gte,json=totalAmountGte,proto3" json:"total_amount_gte,omitempty"`
+	TotalAmountLte string                 `protobuf:"bytes,6,opt,name=total_amount_lte,json=totalAmountLte,proto3" json:"total_amount_lte,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAtGte   string                 `protobuf:"bytes,8,opt,name=created_at_gte,json=createdAtGte,proto3" json:"created_at_gte,omitempty"`
+	CreatedAtLte   string                 `protobuf:"bytes,9,opt,name=created_at_lte,json=createdAtLte,proto3" json:"created_at_lte,omitempty"`
+	ProductId      string                 `protobuf:"bytes,10,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName    string                 `protobuf:"bytes,11,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	CategoryId     string                 `protobuf:"bytes,12,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CategoryName   string                 `protobuf:"bytes,13,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	BrandId        string                 `protobuf:"bytes,14,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	BrandName      string                 `protobuf:"bytes,15,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetInvoicesRequest) Reset() {
+	*x = GetInvoicesRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoicesRequest) ProtoMessage() {}
+
+func (x *GetInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetInvoicesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetInvoicesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetInvoicesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetTotalAmountGte() string {
+	if x != nil {
+		return x.TotalAmountGte
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetTotalAmountLte() string {
+	if x != nil {
+		return x.TotalAmountLte
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetCreatedAtGte() string {
+	if x != nil {
+		return x.CreatedAtGte
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetCreatedAtLte() string {
+	if x != nil {
+		return x.CreatedAtLte
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *GetInvoicesRequest) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+type GetInvoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoices      []*Invoice             `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoicesResponse) Reset() {
+	*x = GetInvoicesResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoicesResponse) ProtoMessage() {}
+
+func (x *GetInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetInvoicesResponse) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+type Invoice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TotalAmount    int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	InvoiceDetails []*InvoiceDetail       `protobuf:"bytes,7,rep,name=invoice_details,json=invoiceDetails,proto3" json:"invoice_details,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_elasticsearch_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Invoice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invoice) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Invoice) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Invoice) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Invoice) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetInvoiceDetails() []*InvoiceDetail {
+	if x != nil {
+		return x.InvoiceDetails
+	}
+	return nil
+}
+
+type InvoiceDetail struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InvoiceId           string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	ProductId           string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price               int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	DiscountPercentage  int32                  `protobuf:"varint,5,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
+	Quantity            int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TotalPrice          int64                  `protobuf:"varint,7,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	ProductName         string                 `protobuf:"bytes,8,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ProductCategoryId   string                 `protobuf:"bytes,9,opt,name=product_category_id,json=productCategoryId,proto3" json:"product_category_id,omitempty"`
+	ProductCategoryName string                 `protobuf:"bytes,10,opt,name=product_category_name,json=productCategoryName,proto3" json:"product_category_name,omitempty"`
+	ProductBrandId      string                 `protobuf:"bytes,11,opt,name=product_brand_id,json=productBrandId,proto3" json:"product_brand_id,omitempty"`
+	ProductBrandName    string                 `protobuf:"bytes,12,opt,name=product_brand_name,json=productBrandName,proto3" json:"product_brand_name,omitempty"`
+	WarehouseId         string                 `protobuf:"bytes,13,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	WarehouseName       string                 `protobuf:"bytes,14,opt,name=warehouse_name,json=warehouseName,proto3" json:"warehouse_name,omitempty"`
+	PromotionId         string                 `protobuf:"bytes,15,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InvoiceDetail) Reset() {
+	*x = InvoiceDetail{}
+	mi := &file_elasticsearch_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceDetail) ProtoMessage() {}
+
+func (x *InvoiceDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceDetail.ProtoReflect.Descriptor instead.
+func (*InvoiceDetail) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InvoiceDetail) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetDiscountPercentage() int32 {
+	if x != nil {
+		return x.DiscountPercentage
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *InvoiceDetail) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductCategoryId() string {
+	if x != nil {
+		return x.ProductCategoryId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductCategoryName() string {
+	if x != nil {
+		return x.ProductCategoryName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductBrandId() string {
+	if x != nil {
+		return x.ProductBrandId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetProductBrandName() string {
+	if x != nil {
+		return x.ProductBrandName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetWarehouseName() string {
+	if x != nil {
+		return x.WarehouseName
+	}
+	return ""
+}
+
+func (x *InvoiceDetail) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+type GetSalesReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeInterval  string                 `protobuf:"bytes,1,opt,name=time_interval,json=timeInterval,proto3" json:"time_interval,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAtGte  string                 `protobuf:"bytes,3,opt,name=created_at_gte,json=createdAtGte,proto3" json:"created_at_gte,omitempty"`
+	CreatedAtLte  string                 `protobuf:"bytes,4,opt,name=created_at_lte,json=createdAtLte,proto3" json:"created_at_lte,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,5,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSalesReportRequest) Reset() {
+	*x = GetSalesReportRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSalesReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSalesReportRequest) ProtoMessage() {}
+
+func (x *GetSalesReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSalesReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSalesReportRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetSalesReportRequest) GetTimeInterval() string {
+	if x != nil {
+		return x.TimeInterval
+	}
+	return ""
+}
+
+func (x *GetSalesReportRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetSalesReportRequest) GetCreatedAtGte() string {
+	if x != nil {
+		return x.CreatedAtGte
+	}
+	return ""
+}
+
+func (x *GetSalesReportRequest) GetCreatedAtLte() string {
+	if x != nil {
+		return x.CreatedAtLte
+	}
+	return ""
+}
+
+func (x *GetSalesReportRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+type GetSalesReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SalesReport   *SalesReport           `protobuf:"bytes,1,opt,name=sales_report,json=salesReport,proto3" json:"sales_report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSalesReportResponse) Reset() {
+	*x = GetSalesReportResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSalesReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSalesReportResponse) ProtoMessage() {}
+
+func (x *GetSalesReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSalesReportResponse.ProtoReflect.Descriptor instead.
+func (*GetSalesReportResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetProductsResponse) GetProducts() []*Product {
+func (x *GetSalesReportResponse) GetSalesReport() *SalesReport {
 	if x != nil {
-		return x.Products
+		return x.SalesReport
 	}
 	return nil
 }
 
-type Product struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Sex                string                 `protobuf:"bytes,4,opt,name=sex,proto3" json:"sex,omitempty"`
-	Price              int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
-	DiscountPercentage int32                  `protobuf:"varint,6,opt,name=discount_percentage,json=discountPercentage,proto3" json:"discount_percentage,omitempty"`
-	Stock              int32                  `protobuf:"varint,7,opt,name=stock,proto3" json:"stock,omitempty"`
-	ImageUrl           string                 `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	CategoryId         string                 `protobuf:"bytes,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	CategoryName       string                 `protobuf:"bytes,10,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
-	BrandId            string                 `protobuf:"bytes,11,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
-	BrandName          string                 `protobuf:"bytes,12,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
-	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type SalesReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StartTime         string                 `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           string                 `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TimeInterval      string                 `protobuf:"bytes,3,opt,name=time_interval,json=timeInterval,proto3" json:"time_interval,omitempty"`
+	TotalRevenue      int64                  `protobuf:"varint,4,opt,name=total_revenue,json=totalRevenue,proto3" json:"total_revenue,omitempty"`
+	TotalOrders       int64                  `protobuf:"varint,5,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
+	AverageOrderValue float64                `protobuf:"fixed64,6,opt,name=average_order_value,json=averageOrderValue,proto3" json:"average_order_value,omitempty"`
+	TotalUnits        int64                  `protobuf:"varint,7,opt,name=total_units,json=totalUnits,proto3" json:"total_units,omitempty"`
+	Details           []*SalesReportDetail   `protobuf:"bytes,8,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *Product) Reset() {
-	*x = Product{}
-	mi := &file_elasticsearch_service_proto_msgTypes[5]
+func (x *SalesReport) Reset() {
+	*x = SalesReport{}
+	mi := &file_elasticsearch_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Product) String() string {
+func (x *SalesReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Product) ProtoMessage() {}
+func (*SalesReport) ProtoMessage() {}
 
-func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_elasticsearch_service_proto_msgTypes[5]
+func (x *SalesReport) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,139 +2345,184 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Product.ProtoReflect.Descriptor instead.
-func (*Product) Descriptor() ([]byte, []int) {
-	return file_elasticsearch_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SalesReport.ProtoReflect.Descriptor instead.
+func (*SalesReport) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *Product) GetId() string {
+func (x *SalesReport) GetStartTime() string {
 	if x != nil {
-		return x.Id
+		return x.StartTime
 	}
 	return ""
 }
 
-func (x *Product) GetName() string {
+func (x *SalesReport) GetEndTime() string {
 	if x != nil {
-		return x.Name
+		return x.EndTime
 	}
 	return ""
 }
 
-func (x *Product) GetDescription() string {
+func (x *SalesReport) GetTimeInterval() string {
 	if x != nil {
-		return x.Description
+		return x.TimeInterval
 	}
 	return ""
 }
 
-func (x *Product) GetSex() string {
+func (x *SalesReport) GetTotalRevenue() int64 {
 	if x != nil {
-		return x.Sex
+		return x.TotalRevenue
 	}
-	return ""
+	return 0
 }
 
-func (x *Product) GetPrice() int64 {
+func (x *SalesReport) GetTotalOrders() int64 {
 	if x != nil {
-		return x.Price
+		return x.TotalOrders
 	}
 	return 0
 }
 
-func (x *Product) GetDiscountPercentage() int32 {
+func (x *SalesReport) GetAverageOrderValue() float64 {
 	if x != nil {
-		return x.DiscountPercentage
+		return x.AverageOrderValue
 	}
 	return 0
 }
 
-func (x *Product) GetStock() int32 {
+func (x *SalesReport) GetTotalUnits() int64 {
 	if x != nil {
-		return x.Stock
+		return x.TotalUnits
 	}
 	return 0
 }
 
-func (x *Product) GetImageUrl() string {
+func (x *SalesReport) GetDetails() []*SalesReportDetail {
 	if x != nil {
-		return x.ImageUrl
+		return x.Details
 	}
-	return ""
+	return nil
 }
 
-func (x *Product) GetCategoryId() string {
+type SalesReportDetail struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StartTime         string                 `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           string                 `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Revenue           int64                  `protobuf:"varint,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Orders            int64                  `protobuf:"varint,4,opt,name=orders,proto3" json:"orders,omitempty"`
+	AverageOrderValue float64                `protobuf:"fixed64,5,opt,name=average_order_value,json=averageOrderValue,proto3" json:"average_order_value,omitempty"`
+	Units             int64                  `protobuf:"varint,6,opt,name=units,proto3" json:"units,omitempty"`
+	Groups            []*SalesReportGroup    `protobuf:"bytes,7,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SalesReportDetail) Reset() {
+	*x = SalesReportDetail{}
+	mi := &file_elasticsearch_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesReportDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesReportDetail) ProtoMessage() {}
+
+func (x *SalesReportDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[28]
 	if x != nil {
-		return x.CategoryId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Product) GetCategoryName() string {
+// Deprecated: Use SalesReportDetail.ProtoReflect.Descriptor instead.
+func (*SalesReportDetail) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SalesReportDetail) GetStartTime() string {
 	if x != nil {
-		return x.CategoryName
+		return x.StartTime
 	}
 	return ""
 }
 
-func (x *Product) GetBrandId() string {
+func (x *SalesReportDetail) GetEndTime() string {
 	if x != nil {
-		return x.BrandId
+		return x.EndTime
 	}
 	return ""
 }
 
-func (x *Product) GetBrandName() string {
+func (x *SalesReportDetail) GetRevenue() int64 {
 	if x != nil {
-		return x.BrandName
+		return x.Revenue
 	}
-	return ""
+	return 0
 }
 
-func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SalesReportDetail) GetOrders() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Orders
 	}
-	return nil
+	return 0
 }
 
-func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *SalesReportDetail) GetAverageOrderValue() float64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.AverageOrderValue
+	}
+	return 0
+}
+
+func (x *SalesReportDetail) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *SalesReportDetail) GetGroups() []*SalesReportGroup {
+	if x != nil {
+		return x.Groups
 	}
 	return nil
 }
 
-type GetInvoicesRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Offset         int32                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
-	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	SortBy         string                 `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
-	UserId         string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TotalAmountGte string                 `protobuf:"bytes,5,opt,name=total_amount_gte,json=totalAmountGte,proto3" json:"total_amount_gte,omitempty"`
-	TotalAmountLte string                 `protobuf:"bytes,6,opt,name=total_amount_lte,json=totalAmountLte,proto3" json:"total_amount_lte,omitempty"`
-	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAtGte   string                 `protobuf:"bytes,8,opt,name=created_at_gte,json=createdAtGte,proto3" json:"created_at_gte,omitempty"`
-	CreatedAtLte   string                 `protobuf:"bytes,9,opt,name=created_at_lte,json=createdAtLte,proto3" json:"created_at_lte,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type SalesReportGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Units         int64                  `protobuf:"varint,3,opt,name=units,proto3" json:"units,omitempty"`
+	Revenue       int64                  `protobuf:"varint,4,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInvoicesRequest) Reset() {
-	*x = GetInvoicesRequest{}
-	mi := &file_elasticsearch_service_proto_msgTypes[6]
+func (x *SalesReportGroup) Reset() {
+	*x = SalesReportGroup{}
+	mi := &file_elasticsearch_service_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInvoicesRequest) String() string {
+func (x *SalesReportGroup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInvoicesRequest) ProtoMessage() {}
+func (*SalesReportGroup) ProtoMessage() {}
 
-func (x *GetInvoicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_elasticsearch_service_proto_msgTypes[6]
+func (x *SalesReportGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,96 +2533,113 @@ func (x *GetInvoicesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInvoicesRequest.ProtoReflect.Descriptor instead.
-func (*GetInvoicesRequest) Descriptor() ([]byte, []int) {
-	return file_elasticsearch_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SalesReportGroup.ProtoReflect.Descriptor instead.
+func (*SalesReportGroup) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetInvoicesRequest) GetOffset() int32 {
+func (x *SalesReportGroup) GetId() string {
 	if x != nil {
-		return x.Offset
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *GetInvoicesRequest) GetLimit() int32 {
+func (x *SalesReportGroup) GetName() string {
 	if x != nil {
-		return x.Limit
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *GetInvoicesRequest) GetSortBy() string {
+func (x *SalesReportGroup) GetUnits() int64 {
 	if x != nil {
-		return x.SortBy
+		return x.Units
 	}
-	return ""
+	return 0
 }
 
-func (x *GetInvoicesRequest) GetUserId() string {
+func (x *SalesReportGroup) GetRevenue() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Revenue
 	}
-	return ""
+	return 0
 }
 
-func (x *GetInvoicesRequest) GetTotalAmountGte() string {
-	if x != nil {
-		return x.TotalAmountGte
-	}
-	return ""
+type GetProductSalesVelocitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInvoicesRequest) GetTotalAmountLte() string {
-	if x != nil {
-		return x.TotalAmountLte
-	}
-	return ""
+func (x *GetProductSalesVelocitiesRequest) Reset() {
+	*x = GetProductSalesVelocitiesRequest{}
+	mi := &file_elasticsearch_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInvoicesRequest) GetStatus() string {
+func (x *GetProductSalesVelocitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductSalesVelocitiesRequest) ProtoMessage() {}
+
+func (x *GetProductSalesVelocitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[30]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *GetInvoicesRequest) GetCreatedAtGte() string {
+// Deprecated: Use GetProductSalesVelocitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetProductSalesVelocitiesRequest) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetProductSalesVelocitiesRequest) GetProductIds() []string {
 	if x != nil {
-		return x.CreatedAtGte
+		return x.ProductIds
 	}
-	return ""
+	return nil
 }
 
-func (x *GetInvoicesRequest) GetCreatedAtLte() string {
+func (x *GetProductSalesVelocitiesRequest) GetDays() int32 {
 	if x != nil {
-		return x.CreatedAtLte
+		return x.Days
 	}
-	return ""
+	return 0
 }
 
-type GetInvoicesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Invoices      []*Invoice             `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetProductSalesVelocitiesResponse struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	ProductSalesVelocities []*ProductSalesVelocity `protobuf:"bytes,1,rep,name=product_sales_velocities,json=productSalesVelocities,proto3" json:"product_sales_velocities,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *GetInvoicesResponse) Reset() {
-	*x = GetInvoicesResponse{}
-	mi := &file_elasticsearch_service_proto_msgTypes[7]
+func (x *GetProductSalesVelocitiesResponse) Reset() {
+	*x = GetProductSalesVelocitiesResponse{}
+	mi := &file_elasticsearch_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInvoicesResponse) String() string {
+func (x *GetProductSalesVelocitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInvoicesResponse) ProtoMessage() {}
+func (*GetProductSalesVelocitiesResponse) ProtoMessage() {}
 
-func (x *GetInvoicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_elasticsearch_service_proto_msgTypes[7]
+func (x *GetProductSalesVelocitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,45 +2650,42 @@ func (x *GetInvoicesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInvoicesResponse.ProtoReflect.Descriptor instead.
-func (*GetInvoicesResponse) Descriptor() ([]byte, []int) {
-	return file_elasticsearch_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetProductSalesVelocitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetProductSalesVelocitiesResponse) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *GetInvoicesResponse) GetInvoices() []*Invoice {
+func (x *GetProductSalesVelocitiesResponse) GetProductSalesVelocities() []*ProductSalesVelocity {
 	if x != nil {
-		return x.Invoices
+		return x.ProductSalesVelocities
 	}
 	return nil
 }
 
-type Invoice struct {
+type ProductSalesVelocity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TotalAmount   int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UnitsSold     int64                  `protobuf:"varint,2,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`
+	DailyUnits    float64                `protobuf:"fixed64,3,opt,name=daily_units,json=dailyUnits,proto3" json:"daily_units,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Invoice) Reset() {
-	*x = Invoice{}
-	mi := &file_elasticsearch_service_proto_msgTypes[8]
+func (x *ProductSalesVelocity) Reset() {
+	*x = ProductSalesVelocity{}
+	mi := &file_elasticsearch_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Invoice) String() string {
+func (x *ProductSalesVelocity) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Invoice) ProtoMessage() {}
+func (*ProductSalesVelocity) ProtoMessage() {}
 
-func (x *Invoice) ProtoReflect() protoreflect.Message {
-	mi := &file_elasticsearch_service_proto_msgTypes[8]
+func (x *ProductSalesVelocity) ProtoReflect() protoreflect.Message {
+	mi := &file_elasticsearch_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,51 +2696,30 @@ func (x *Invoice) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
-func (*Invoice) Descriptor() ([]byte, []int) {
-	return file_elasticsearch_service_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *Invoice) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ProductSalesVelocity.ProtoReflect.Descriptor instead.
+func (*ProductSalesVelocity) Descriptor() ([]byte, []int) {
+	return file_elasticsearch_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *Invoice) GetUserId() string {
+func (x *ProductSalesVelocity) GetProductId() string {
 	if x != nil {
-		return x.UserId
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Invoice) GetTotalAmount() int64 {
+func (x *ProductSalesVelocity) GetUnitsSold() int64 {
 	if x != nil {
-		return x.TotalAmount
+		return x.UnitsSold
 	}
 	return 0
 }
 
-func (x *Invoice) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-func (x *Invoice) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *Invoice) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *ProductSalesVelocity) GetDailyUnits() float64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.DailyUnits
 	}
-	return nil
+	return 0
 }
 
 var File_elasticsearch_service_proto protoreflect.FileDescriptor
@@ -919,7 +2751,7 @@ const file_elasticsearch_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd3\x04\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe5\x06\n" +
 	"\x12GetProductsRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
@@ -941,9 +2773,49 @@ const file_elasticsearch_service_proto_rawDesc = "" +
 	"\n" +
 	"brand_name\x18\x10 \x01(\tR\tbrandName\x12$\n" +
 	"\x0ecreated_at_gte\x18\x11 \x01(\tR\fcreatedAtGte\x12$\n" +
-	"\x0ecreated_at_lte\x18\x12 \x01(\tR\fcreatedAtLte\"R\n" +
+	"\x0ecreated_at_lte\x18\x12 \x01(\tR\fcreatedAtLte\x12\x17\n" +
+	"\auser_id\x18\x13 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tsearch_id\x18\x14 \x01(\tR\bsearchId\x12,\n" +
+	"\x12average_rating_gte\x18\x15 \x01(\tR\x10averageRatingGte\x12(\n" +
+	"\x10rating_count_gte\x18\x16 \x01(\tR\x0eratingCountGte\x12&\n" +
+	"\x0ffinal_price_gte\x18\x17 \x01(\tR\rfinalPriceGte\x12&\n" +
+	"\x0ffinal_price_lte\x18\x18 \x01(\tR\rfinalPriceLte\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x19 \x01(\tR\n" +
+	"attributes\x12\x12\n" +
+	"\x04tags\x18\x1a \x01(\tR\x04tags\"\x90\x01\n" +
 	"\x13GetProductsResponse\x12;\n" +
-	"\bproducts\x18\x01 \x03(\v2\x1f.elasticsearchservicepb.ProductR\bproducts\"\xd1\x03\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1f.elasticsearchservicepb.ProductR\bproducts\x12<\n" +
+	"\x06facets\x18\x02 \x03(\v2$.elasticsearchservicepb.ProductFacetR\x06facets\"|\n" +
+	"\fProductFacet\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12D\n" +
+	"\abuckets\x18\x03 \x03(\v2*.elasticsearchservicepb.ProductFacetBucketR\abuckets\"@\n" +
+	"\x12ProductFacetBucket\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\x8e\x01\n" +
+	"\x16GetSearchReportRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12$\n" +
+	"\x0ecreated_at_gte\x18\x03 \x01(\tR\fcreatedAtGte\x12$\n" +
+	"\x0ecreated_at_lte\x18\x04 \x01(\tR\fcreatedAtLte\"d\n" +
+	"\x17GetSearchReportResponse\x12I\n" +
+	"\rsearch_report\x18\x01 \x01(\v2$.elasticsearchservicepb.SearchReportR\fsearchReport\"\x97\x02\n" +
+	"\fSearchReport\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12%\n" +
+	"\x0etotal_searches\x18\x02 \x01(\x03R\rtotalSearches\x120\n" +
+	"\x14zero_result_searches\x18\x03 \x01(\x03R\x12zeroResultSearches\x12)\n" +
+	"\x10clicked_searches\x18\x04 \x01(\x03R\x0fclickedSearches\x12,\n" +
+	"\x12click_through_rate\x18\x05 \x01(\x01R\x10clickThroughRate\x12A\n" +
+	"\aqueries\x18\x06 \x03(\v2'.elasticsearchservicepb.SearchQueryStatR\aqueries\"\x98\x02\n" +
+	"\x0fSearchQueryStat\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bsearches\x18\x02 \x01(\x03R\bsearches\x120\n" +
+	"\x14zero_result_searches\x18\x03 \x01(\x03R\x12zeroResultSearches\x12)\n" +
+	"\x10clicked_searches\x18\x04 \x01(\x03R\x0fclickedSearches\x12\x16\n" +
+	"\x06clicks\x18\x05 \x01(\x03R\x06clicks\x12,\n" +
+	"\x12click_through_rate\x18\x06 \x01(\x01R\x10clickThroughRate\x120\n" +
+	"\x14average_result_count\x18\a \x01(\x01R\x12averageResultCount\"\xcd\x06\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -963,7 +2835,59 @@ const file_elasticsearch_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xac\x02\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12[\n" +
+	"\x13category_breadcrumb\x18\x0f \x03(\v2*.elasticsearchservicepb.CategoryBreadcrumbR\x12categoryBreadcrumb\x12%\n" +
+	"\x0eaverage_rating\x18\x10 \x01(\x01R\raverageRating\x12!\n" +
+	"\frating_count\x18\x11 \x01(\x05R\vratingCount\x12\x1f\n" +
+	"\vfinal_price\x18\x12 \x01(\x03R\n" +
+	"finalPrice\x12(\n" +
+	"\x10lowest_price_30d\x18\x13 \x01(\x03R\x0elowestPrice30d\x12\x16\n" +
+	"\x06status\x18\x14 \x01(\tR\x06status\x12\x12\n" +
+	"\x04slug\x18\x15 \x01(\tR\x04slug\x12H\n" +
+	"\n" +
+	"attributes\x18\x16 \x03(\v2(.elasticsearchservicepb.ProductAttributeR\n" +
+	"attributes\x12\x12\n" +
+	"\x04tags\x18\x17 \x03(\tR\x04tags\"\x9b\x01\n" +
+	"\x10ProductAttribute\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12!\n" +
+	"\fnumber_value\x18\x06 \x01(\x01R\vnumberValue\"L\n" +
+	"\x12CategoryBreadcrumb\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\"k\n" +
+	" GetProductRecommendationsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xce\x01\n" +
+	"!GetProductRecommendationsResponse\x12J\n" +
+	"\x10similar_products\x18\x01 \x03(\v2\x1f.elasticsearchservicepb.ProductR\x0fsimilarProducts\x12]\n" +
+	"\x1afrequently_bought_together\x18\x02 \x03(\v2\x1f.elasticsearchservicepb.ProductR\x18frequentlyBoughtTogether\"\x81\x01\n" +
+	"\x15GetTopProductsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\tR\n" +
+	"categoryId\x12\x19\n" +
+	"\bbrand_id\x18\x04 \x01(\tR\abrandId\"[\n" +
+	"\x16GetTopProductsResponse\x12A\n" +
+	"\bproducts\x18\x01 \x03(\v2%.elasticsearchservicepb.RankedProductR\bproducts\"n\n" +
+	"\x1aGetTrendingProductsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x19\n" +
+	"\bbrand_id\x18\x03 \x01(\tR\abrandId\"`\n" +
+	"\x1bGetTrendingProductsResponse\x12A\n" +
+	"\bproducts\x18\x01 \x03(\v2%.elasticsearchservicepb.RankedProductR\bproducts\"\x93\x01\n" +
+	"\rRankedProduct\x129\n" +
+	"\aproduct\x18\x01 \x01(\v2\x1f.elasticsearchservicepb.ProductR\aproduct\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x05R\x04rank\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x03 \x01(\x03R\tunitsSold\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\"\xee\x03\n" +
 	"\x12GetInvoicesRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
@@ -973,9 +2897,19 @@ const file_elasticsearch_service_proto_rawDesc = "" +
 	"\x10total_amount_lte\x18\x06 \x01(\tR\x0etotalAmountLte\x12\x16\n" +
 	"\x06status\x18\a \x01(\tR\x06status\x12$\n" +
 	"\x0ecreated_at_gte\x18\b \x01(\tR\fcreatedAtGte\x12$\n" +
-	"\x0ecreated_at_lte\x18\t \x01(\tR\fcreatedAtLte\"R\n" +
+	"\x0ecreated_at_lte\x18\t \x01(\tR\fcreatedAtLte\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\n" +
+	" \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\v \x01(\tR\vproductName\x12\x1f\n" +
+	"\vcategory_id\x18\f \x01(\tR\n" +
+	"categoryId\x12#\n" +
+	"\rcategory_name\x18\r \x01(\tR\fcategoryName\x12\x19\n" +
+	"\bbrand_id\x18\x0e \x01(\tR\abrandId\x12\x1d\n" +
+	"\n" +
+	"brand_name\x18\x0f \x01(\tR\tbrandName\"R\n" +
 	"\x13GetInvoicesResponse\x12;\n" +
-	"\binvoices\x18\x01 \x03(\v2\x1f.elasticsearchservicepb.InvoiceR\binvoices\"\xe3\x01\n" +
+	"\binvoices\x18\x01 \x03(\v2\x1f.elasticsearchservicepb.InvoiceR\binvoices\"\xb3\x02\n" +
 	"\aInvoice\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
@@ -984,11 +2918,84 @@ const file_elasticsearch_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xc9\x02\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12N\n" +
+	"\x0finvoice_details\x18\a \x03(\v2%.elasticsearchservicepb.InvoiceDetailR\x0einvoiceDetails\"\xad\x04\n" +
+	"\rInvoiceDetail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12/\n" +
+	"\x13discount_percentage\x18\x05 \x01(\x05R\x12discountPercentage\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vtotal_price\x18\a \x01(\x03R\n" +
+	"totalPrice\x12!\n" +
+	"\fproduct_name\x18\b \x01(\tR\vproductName\x12.\n" +
+	"\x13product_category_id\x18\t \x01(\tR\x11productCategoryId\x122\n" +
+	"\x15product_category_name\x18\n" +
+	" \x01(\tR\x13productCategoryName\x12(\n" +
+	"\x10product_brand_id\x18\v \x01(\tR\x0eproductBrandId\x12,\n" +
+	"\x12product_brand_name\x18\f \x01(\tR\x10productBrandName\x12!\n" +
+	"\fwarehouse_id\x18\r \x01(\tR\vwarehouseId\x12%\n" +
+	"\x0ewarehouse_name\x18\x0e \x01(\tR\rwarehouseName\x12!\n" +
+	"\fpromotion_id\x18\x0f \x01(\tR\vpromotionId\"\xbb\x01\n" +
+	"\x15GetSalesReportRequest\x12#\n" +
+	"\rtime_interval\x18\x01 \x01(\tR\ftimeInterval\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12$\n" +
+	"\x0ecreated_at_gte\x18\x03 \x01(\tR\fcreatedAtGte\x12$\n" +
+	"\x0ecreated_at_lte\x18\x04 \x01(\tR\fcreatedAtLte\x12\x19\n" +
+	"\bgroup_by\x18\x05 \x01(\tR\agroupBy\"`\n" +
+	"\x16GetSalesReportResponse\x12F\n" +
+	"\fsales_report\x18\x01 \x01(\v2#.elasticsearchservicepb.SalesReportR\vsalesReport\"\xca\x02\n" +
+	"\vSalesReport\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\tR\aendTime\x12#\n" +
+	"\rtime_interval\x18\x03 \x01(\tR\ftimeInterval\x12#\n" +
+	"\rtotal_revenue\x18\x04 \x01(\x03R\ftotalRevenue\x12!\n" +
+	"\ftotal_orders\x18\x05 \x01(\x03R\vtotalOrders\x12.\n" +
+	"\x13average_order_value\x18\x06 \x01(\x01R\x11averageOrderValue\x12\x1f\n" +
+	"\vtotal_units\x18\a \x01(\x03R\n" +
+	"totalUnits\x12C\n" +
+	"\adetails\x18\b \x03(\v2).elasticsearchservicepb.SalesReportDetailR\adetails\"\x87\x02\n" +
+	"\x11SalesReportDetail\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\tR\aendTime\x12\x18\n" +
+	"\arevenue\x18\x03 \x01(\x03R\arevenue\x12\x16\n" +
+	"\x06orders\x18\x04 \x01(\x03R\x06orders\x12.\n" +
+	"\x13average_order_value\x18\x05 \x01(\x01R\x11averageOrderValue\x12\x14\n" +
+	"\x05units\x18\x06 \x01(\x03R\x05units\x12@\n" +
+	"\x06groups\x18\a \x03(\v2(.elasticsearchservicepb.SalesReportGroupR\x06groups\"f\n" +
+	"\x10SalesReportGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05units\x18\x03 \x01(\x03R\x05units\x12\x18\n" +
+	"\arevenue\x18\x04 \x01(\x03R\arevenue\"W\n" +
+	" GetProductSalesVelocitiesRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"\x8b\x01\n" +
+	"!GetProductSalesVelocitiesResponse\x12f\n" +
+	"\x18product_sales_velocities\x18\x01 \x03(\v2,.elasticsearchservicepb.ProductSalesVelocityR\x16productSalesVelocities\"u\n" +
+	"\x14ProductSalesVelocity\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x02 \x01(\x03R\tunitsSold\x12\x1f\n" +
+	"\vdaily_units\x18\x03 \x01(\x01R\n" +
+	"dailyUnits2\xc5\b\n" +
 	"\x18ElasticsearchServiceGRPC\x12]\n" +
 	"\bGetUsers\x12'.elasticsearchservicepb.GetUsersRequest\x1a(.elasticsearchservicepb.GetUsersResponse\x12f\n" +
-	"\vGetProducts\x12*.elasticsearchservicepb.GetProductsRequest\x1a+.elasticsearchservicepb.GetProductsResponse\x12f\n" +
-	"\vGetInvoices\x12*.elasticsearchservicepb.GetInvoicesRequest\x1a+.elasticsearchservicepb.GetInvoicesResponseB\x19Z\x17elasticsearchservicepb/b\x06proto3"
+	"\vGetProducts\x12*.elasticsearchservicepb.GetProductsRequest\x1a+.elasticsearchservicepb.GetProductsResponse\x12r\n" +
+	"\x0fGetSearchReport\x12..elasticsearchservicepb.GetSearchReportRequest\x1a/.elasticsearchservicepb.GetSearchReportResponse\x12\x90\x01\n" +
+	"\x19GetProductRecommendations\x128.elasticsearchservicepb.GetProductRecommendationsRequest\x1a9.elasticsearchservicepb.GetProductRecommendationsResponse\x12o\n" +
+	"\x0eGetTopProducts\x12-.elasticsearchservicepb.GetTopProductsRequest\x1a..elasticsearchservicepb.GetTopProductsResponse\x12~\n" +
+	"\x13GetTrendingProducts\x122.elasticsearchservicepb.GetTrendingProductsRequest\x1a3.elasticsearchservicepb.GetTrendingProductsResponse\x12f\n" +
+	"\vGetInvoices\x12*.elasticsearchservicepb.GetInvoicesRequest\x1a+.elasticsearchservicepb.GetInvoicesResponse\x12o\n" +
+	"\x0eGetSalesReport\x12-.elasticsearchservicepb.GetSalesReportRequest\x1a..elasticsearchservicepb.GetSalesReportResponse\x12\x90\x01\n" +
+	"\x19GetProductSalesVelocities\x128.elasticsearchservicepb.GetProductSalesVelocitiesRequest\x1a9.elasticsearchservicepb.GetProductSalesVelocitiesResponseB\x19Z\x17elasticsearchservicepb/b\x06proto3"
 
 var (
 	file_elasticsearch_service_proto_rawDescOnce sync.Once
@@ -1002,40 +3009,92 @@ func file_elasticsearch_service_proto_rawDescGZIP() []byte {
 	return file_elasticsearch_service_proto_rawDescData
 }
 
-var file_elasticsearch_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_elasticsearch_service_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_elasticsearch_service_proto_goTypes = []any{
-	(*GetUsersRequest)(nil),       // 0: elasticsearchservicepb.GetUsersRequest
-	(*GetUsersResponse)(nil),      // 1: elasticsearchservicepb.GetUsersResponse
-	(*User)(nil),                  // 2: elasticsearchservicepb.User
-	(*GetProductsRequest)(nil),    // 3: elasticsearchservicepb.GetProductsRequest
-	(*GetProductsResponse)(nil),   // 4: elasticsearchservicepb.GetProductsResponse
-	(*Product)(nil),               // 5: elasticsearchservicepb.Product
-	(*GetInvoicesRequest)(nil),    // 6: elasticsearchservicepb.GetInvoicesRequest
-	(*GetInvoicesResponse)(nil),   // 7: elasticsearchservicepb.GetInvoicesResponse
-	(*Invoice)(nil),               // 8: elasticsearchservicepb.Invoice
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*GetUsersRequest)(nil),                   // 0: elasticsearchservicepb.GetUsersRequest
+	(*GetUsersResponse)(nil),                  // 1: elasticsearchservicepb.GetUsersResponse
+	(*User)(nil),                              // 2: elasticsearchservicepb.User
+	(*GetProductsRequest)(nil),                // 3: elasticsearchservicepb.GetProductsRequest
+	(*GetProductsResponse)(nil),               // 4: elasticsearchservicepb.GetProductsResponse
+	(*ProductFacet)(nil),                      // 5: elasticsearchservicepb.ProductFacet
+	(*ProductFacetBucket)(nil),                // 6: elasticsearchservicepb.ProductFacetBucket
+	(*GetSearchReportRequest)(nil),            // 7: elasticsearchservicepb.GetSearchReportRequest
+	(*GetSearchReportResponse)(nil),           // 8: elasticsearchservicepb.GetSearchReportResponse
+	(*SearchReport)(nil),                      // 9: elasticsearchservicepb.SearchReport
+	(*SearchQueryStat)(nil),                   // 10: elasticsearchservicepb.SearchQueryStat
+	(*Product)(nil),                           // 11: elasticsearchservicepb.Product
+	(*ProductAttribute)(nil),                  // 12: elasticsearchservicepb.ProductAttribute
+	(*CategoryBreadcrumb)(nil),                // 13: elasticsearchservicepb.CategoryBreadcrumb
+	(*GetProductRecommendationsRequest)(nil),  // 14: elasticsearchservicepb.GetProductRecommendationsRequest
+	(*GetProductRecommendationsResponse)(nil), // 15: elasticsearchservicepb.GetProductRecommendationsResponse
+	(*GetTopProductsRequest)(nil),             // 16: elasticsearchservicepb.GetTopProductsRequest
+	(*GetTopProductsResponse)(nil),            // 17: elasticsearchservicepb.GetTopProductsResponse
+	(*GetTrendingProductsRequest)(nil),        // 18: elasticsearchservicepb.GetTrendingProductsRequest
+	(*GetTrendingProductsResponse)(nil),       // 19: elasticsearchservicepb.GetTrendingProductsResponse
+	(*RankedProduct)(nil),                     // 20: elasticsearchservicepb.RankedProduct
+	(*GetInvoicesRequest)(nil),                // 21: elasticsearchservicepb.GetInvoicesRequest
+	(*GetInvoicesResponse)(nil),               // 22: elasticsearchservicepb.GetInvoicesResponse
+	(*Invoice)(nil),                           // 23: elasticsearchservicepb.Invoice
+	(*InvoiceDetail)(nil),                     // 24: elasticsearchservicepb.InvoiceDetail
+	(*GetSalesReportRequest)(nil),             // 25: elasticsearchservicepb.GetSalesReportRequest
+	(*GetSalesReportResponse)(nil),            // 26: elasticsearchservicepb.GetSalesReportResponse
+	(*SalesReport)(nil),                       // 27: elasticsearchservicepb.SalesReport
+	(*SalesReportDetail)(nil),                 // 28: elasticsearchservicepb.SalesReportDetail
+	(*SalesReportGroup)(nil),                  // 29: elasticsearchservicepb.SalesReportGroup
+	(*GetProductSalesVelocitiesRequest)(nil),  // 30: elasticsearchservicepb.GetProductSalesVelocitiesRequest
+	(*GetProductSalesVelocitiesResponse)(nil), // 31: elasticsearchservicepb.GetProductSalesVelocitiesResponse
+	(*ProductSalesVelocity)(nil),              // 32: elasticsearchservicepb.ProductSalesVelocity
+	(*timestamppb.Timestamp)(nil),             // 33: google.protobuf.Timestamp
 }
 var file_elasticsearch_service_proto_depIdxs = []int32{
 	2,  // 0: elasticsearchservicepb.GetUsersResponse.users:type_name -> elasticsearchservicepb.User
-	9,  // 1: elasticsearchservicepb.User.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: elasticsearchservicepb.User.updated_at:type_name -> google.protobuf.Timestamp
-	5,  // 3: elasticsearchservicepb.GetProductsResponse.products:type_name -> elasticsearchservicepb.Product
-	9,  // 4: elasticsearchservicepb.Product.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 5: elasticsearchservicepb.Product.updated_at:type_name -> google.protobuf.Timestamp
-	8,  // 6: elasticsearchservicepb.GetInvoicesResponse.invoices:type_name -> elasticsearchservicepb.Invoice
-	9,  // 7: elasticsearchservicepb.Invoice.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 8: elasticsearchservicepb.Invoice.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 9: elasticsearchservicepb.ElasticsearchServiceGRPC.GetUsers:input_type -> elasticsearchservicepb.GetUsersRequest
-	3,  // 10: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProducts:input_type -> elasticsearchservicepb.GetProductsRequest
-	6,  // 11: elasticsearchservicepb.ElasticsearchServiceGRPC.GetInvoices:input_type -> elasticsearchservicepb.GetInvoicesRequest
-	1,  // 12: elasticsearchservicepb.ElasticsearchServiceGRPC.GetUsers:output_type -> elasticsearchservicepb.GetUsersResponse
-	4,  // 13: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProducts:output_type -> elasticsearchservicepb.GetProductsResponse
-	7,  // 14: elasticsearchservicepb.ElasticsearchServiceGRPC.GetInvoices:output_type -> elasticsearchservicepb.GetInvoicesResponse
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	33, // 1: elasticsearchservicepb.User.created_at:type_name -> google.protobuf.Timestamp
+	33, // 2: elasticsearchservicepb.User.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 3: elasticsearchservicepb.GetProductsResponse.products:type_name -> elasticsearchservicepb.Product
+	5,  // 4: elasticsearchservicepb.GetProductsResponse.facets:type_name -> elasticsearchservicepb.ProductFacet
+	6,  // 5: elasticsearchservicepb.ProductFacet.buckets:type_name -> elasticsearchservicepb.ProductFacetBucket
+	9,  // 6: elasticsearchservicepb.GetSearchReportResponse.search_report:type_name -> elasticsearchservicepb.SearchReport
+	10, // 7: elasticsearchservicepb.SearchReport.queries:type_name -> elasticsearchservicepb.SearchQueryStat
+	33, // 8: elasticsearchservicepb.Product.created_at:type_name -> google.protobuf.Timestamp
+	33, // 9: elasticsearchservicepb.Product.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 10: elasticsearchservicepb.Product.category_breadcrumb:type_name -> elasticsearchservicepb.CategoryBreadcrumb
+	12, // 11: elasticsearchservicepb.Product.attributes:type_name -> elasticsearchservicepb.ProductAttribute
+	11, // 12: elasticsearchservicepb.GetProductRecommendationsResponse.similar_products:type_name -> elasticsearchservicepb.Product
+	11, // 13: elasticsearchservicepb.GetProductRecommendationsResponse.frequently_bought_together:type_name -> elasticsearchservicepb.Product
+	20, // 14: elasticsearchservicepb.GetTopProductsResponse.products:type_name -> elasticsearchservicepb.RankedProduct
+	20, // 15: elasticsearchservicepb.GetTrendingProductsResponse.products:type_name -> elasticsearchservicepb.RankedProduct
+	11, // 16: elasticsearchservicepb.RankedProduct.product:type_name -> elasticsearchservicepb.Product
+	23, // 17: elasticsearchservicepb.GetInvoicesResponse.invoices:type_name -> elasticsearchservicepb.Invoice
+	33, // 18: elasticsearchservicepb.Invoice.created_at:type_name -> google.protobuf.Timestamp
+	33, // 19: elasticsearchservicepb.Invoice.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 20: elasticsearchservicepb.Invoice.invoice_details:type_name -> elasticsearchservicepb.InvoiceDetail
+	27, // 21: elasticsearchservicepb.GetSalesReportResponse.sales_report:type_name -> elasticsearchservicepb.SalesReport
+	28, // 22: elasticsearchservicepb.SalesReport.details:type_name -> elasticsearchservicepb.SalesReportDetail
+	29, // 23: elasticsearchservicepb.SalesReportDetail.groups:type_name -> elasticsearchservicepb.SalesReportGroup
+	32, // 24: elasticsearchservicepb.GetProductSalesVelocitiesResponse.product_sales_velocities:type_name -> elasticsearchservicepb.ProductSalesVelocity
+	0,  // 25: elasticsearchservicepb.ElasticsearchServiceGRPC.GetUsers:input_type -> elasticsearchservicepb.GetUsersRequest
+	3,  // 26: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProducts:input_type -> elasticsearchservicepb.GetProductsRequest
+	7,  // 27: elasticsearchservicepb.ElasticsearchServiceGRPC.GetSearchReport:input_type -> elasticsearchservicepb.GetSearchReportRequest
+	14, // 28: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProductRecommendations:input_type -> elasticsearchservicepb.GetProductRecommendationsRequest
+	16, // 29: elasticsearchservicepb.ElasticsearchServiceGRPC.GetTopProducts:input_type -> elasticsearchservicepb.GetTopProductsRequest
+	18, // 30: elasticsearchservicepb.ElasticsearchServiceGRPC.GetTrendingProducts:input_type -> elasticsearchservicepb.GetTrendingProductsRequest
+	21, // 31: elasticsearchservicepb.ElasticsearchServiceGRPC.GetInvoices:input_type -> elasticsearchservicepb.GetInvoicesRequest
+	25, // 32: elasticsearchservicepb.ElasticsearchServiceGRPC.GetSalesReport:input_type -> elasticsearchservicepb.GetSalesReportRequest
+	30, // 33: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProductSalesVelocities:input_type -> elasticsearchservicepb.GetProductSalesVelocitiesRequest
+	1,  // 34: elasticsearchservicepb.ElasticsearchServiceGRPC.GetUsers:output_type -> elasticsearchservicepb.GetUsersResponse
+	4,  // 35: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProducts:output_type -> elasticsearchservicepb.GetProductsResponse
+	8,  // 36: elasticsearchservicepb.ElasticsearchServiceGRPC.GetSearchReport:output_type -> elasticsearchservicepb.GetSearchReportResponse
+	15, // 37: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProductRecommendations:output_type -> elasticsearchservicepb.GetProductRecommendationsResponse
+	17, // 38: elasticsearchservicepb.ElasticsearchServiceGRPC.GetTopProducts:output_type -> elasticsearchservicepb.GetTopProductsResponse
+	19, // 39: elasticsearchservicepb.ElasticsearchServiceGRPC.GetTrendingProducts:output_type -> elasticsearchservicepb.GetTrendingProductsResponse
+	22, // 40: elasticsearchservicepb.ElasticsearchServiceGRPC.GetInvoices:output_type -> elasticsearchservicepb.GetInvoicesResponse
+	26, // 41: elasticsearchservicepb.ElasticsearchServiceGRPC.GetSalesReport:output_type -> elasticsearchservicepb.GetSalesReportResponse
+	31, // 42: elasticsearchservicepb.ElasticsearchServiceGRPC.GetProductSalesVelocities:output_type -> elasticsearchservicepb.GetProductSalesVelocitiesResponse
+	34, // [34:43] is the sub-list for method output_type
+	25, // [25:34] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_elasticsearch_service_proto_init() }
@@ -1049,7 +3108,7 @@ func file_elasticsearch_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_elasticsearch_service_proto_rawDesc), len(file_elasticsearch_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,13 +11,18 @@ type ElasticsearchServiceGRPCImpl struct {
 	userService    service.UserService
 	catalogService service.CatalogService
 	orderService   service.OrderService
+
+	leaderboardService     service.LeaderboardService
+	searchAnalyticsService service.SearchAnalyticsService
 }
 
-func NewElasticsearchServiceGRPCImpl(userService service.UserService, catalogService service.CatalogService, orderService service.OrderService) *ElasticsearchServiceGRPCImpl {
+func NewElasticsearchServiceGRPCImpl(userService service.UserService, catalogService service.CatalogService, orderService service.OrderService, leaderboardService service.LeaderboardService, searchAnalyticsService service.SearchAnalyticsService) *ElasticsearchServiceGRPCImpl {
 	return &ElasticsearchServiceGRPCImpl{
-		userService:    userService,
-		catalogService: catalogService,
-		orderService:   orderService,
+		userService:            userService,
+		catalogService:         catalogService,
+		orderService:           orderService,
+		leaderboardService:     leaderboardService,
+		searchAnalyticsService: searchAnalyticsService,
 	}
 }
 
@@ -33,16 +38,32 @@ func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetUsers(ctx c
 }
 
 func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductsRequest) (*elasticsearchservicepb.GetProductsResponse, error) {
-	productProtos, err := elasticsearchServiceGRPCImpl.catalogService.GetProducts(ctx, reqDTO)
+	productProtos, productFacetProtos, err := elasticsearchServiceGRPCImpl.catalogService.GetProducts(ctx, reqDTO)
 	if err != nil {
 		return nil, err
 	}
 
 	res := &elasticsearchservicepb.GetProductsResponse{}
 	res.Products = productProtos
+	res.Facets = productFacetProtos
+	return res, nil
+}
+
+func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetSearchReport(ctx context.Context, reqDTO *elasticsearchservicepb.GetSearchReportRequest) (*elasticsearchservicepb.GetSearchReportResponse, error) {
+	searchReportProto, err := elasticsearchServiceGRPCImpl.searchAnalyticsService.GetSearchReport(ctx, reqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &elasticsearchservicepb.GetSearchReportResponse{}
+	res.SearchReport = searchReportProto
 	return res, nil
 }
 
+func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetProductRecommendations(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductRecommendationsRequest) (*elasticsearchservicepb.GetProductRecommendationsResponse, error) {
+	return elasticsearchServiceGRPCImpl.catalogService.GetProductRecommendations(ctx, reqDTO)
+}
+
 func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetInvoices(ctx context.Context, reqDTO *elasticsearchservicepb.GetInvoicesRequest) (*elasticsearchservicepb.GetInvoicesResponse, error) {
 	invoiceProtos, err := elasticsearchServiceGRPCImpl.orderService.GetInvoices(ctx, reqDTO)
 	if err != nil {
@@ -53,3 +74,47 @@ func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetInvoices(ct
 	res.Invoices = invoiceProtos
 	return res, nil
 }
+
+func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetSalesReport(ctx context.Context, reqDTO *elasticsearchservicepb.GetSalesReportRequest) (*elasticsearchservicepb.GetSalesReportResponse, error) {
+	salesReportProto, err := elasticsearchServiceGRPCImpl.orderService.GetSalesReport(ctx, reqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &elasticsearchservicepb.GetSalesReportResponse{}
+	res.SalesReport = salesReportProto
+	return res, nil
+}
+
+func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetProductSalesVelocities(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductSalesVelocitiesRequest) (*elasticsearchservicepb.GetProductSalesVelocitiesResponse, error) {
+	productSalesVelocityProtos, err := elasticsearchServiceGRPCImpl.orderService.GetProductSalesVelocities(ctx, reqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &elasticsearchservicepb.GetProductSalesVelocitiesResponse{}
+	res.ProductSalesVelocities = productSalesVelocityProtos
+	return res, nil
+}
+
+func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetTopProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetTopProductsRequest) (*elasticsearchservicepb.GetTopProductsResponse, error) {
+	rankedProductProtos, err := elasticsearchServiceGRPCImpl.leaderboardService.GetTopProducts(ctx, reqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &elasticsearchservicepb.GetTopProductsResponse{}
+	res.Products = rankedProductProtos
+	return res, nil
+}
+
+func (elasticsearchServiceGRPCImpl *ElasticsearchServiceGRPCImpl) GetTrendingProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetTrendingProductsRequest) (*elasticsearchservicepb.GetTrendingProductsResponse, error) {
+	rankedProductProtos, err := elasticsearchServiceGRPCImpl.leaderboardService.GetTrendingProducts(ctx, reqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &elasticsearchservicepb.GetTrendingProductsResponse{}
+	res.Products = rankedProductProtos
+	return res, nil
+}
@@ -26,6 +26,66 @@ var Invoice = `
             "keyword": { "type": "keyword" }
           }
         },
+      "invoice_details": {
+        "type": "nested",
+        "properties": {
+          "product_id": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          },
+          "price": { "type": "long" },
+          "discount_percentage": { "type": "integer" },
+          "quantity": { "type": "integer" },
+          "total_price": { "type": "long" },
+          "product_name": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          },
+          "product_category_id": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          },
+          "product_category_name": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          },
+          "product_brand_id": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          },
+          "product_brand_name": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          },
+          "warehouse_id": { "type": "keyword" },
+          "promotion_id": { "type": "keyword" },
+          "warehouse_name": {
+            "type": "text",
+            "analyzer": "standard",
+            "fields": {
+              "keyword": { "type": "keyword" }
+            }
+          }
+        }
+      },
       "created_at": { "type": "date" },
       "updated_at": { "type": "date" }
     }
@@ -40,3 +100,19 @@ var InvoiceStandardizeSortFieldMap = map[string]string{
 	"created_at":   "created_at",
 	"updated_at":   "updated_at",
 }
+
+type GroupField struct {
+	IdField   string
+	NameField string
+}
+
+var InvoiceDetailStandardizeGroupFieldMap = map[string]GroupField{
+	"category": {
+		IdField:   "invoice_details.product_category_id.keyword",
+		NameField: "invoice_details.product_category_name.keyword",
+	},
+	"brand": {
+		IdField:   "invoice_details.product_brand_id.keyword",
+		NameField: "invoice_details.product_brand_name.keyword",
+	},
+}
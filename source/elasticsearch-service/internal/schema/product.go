@@ -64,6 +64,37 @@ var Product = `
             "keyword": { "type": "keyword" }
           }
         },
+      "average_rating": { "type": "float" },
+      "rating_count": { "type": "integer" },
+      "final_price": { "type": "long" },
+      "lowest_price_30d": { "type": "long" },
+      "status": { "type": "keyword" },
+      "slug": { "type": "keyword" },
+      "category_breadcrumb": {
+          "properties": {
+            "id": { "type": "keyword" },
+            "name": {
+              "type": "text",
+              "analyzer": "standard",
+              "fields": {
+                "keyword": { "type": "keyword" }
+              }
+            },
+            "slug": { "type": "keyword" }
+          }
+        },
+      "attributes": {
+          "type": "nested",
+          "properties": {
+            "code": { "type": "keyword" },
+            "name": { "type": "keyword" },
+            "type": { "type": "keyword" },
+            "value": { "type": "keyword" },
+            "unit": { "type": "keyword" },
+            "number_value": { "type": "double" }
+          }
+        },
+      "tags": { "type": "keyword" },
       "created_at": { "type": "date" },
       "updated_at": { "type": "date" }
     }
@@ -76,12 +107,15 @@ var ProductStandardizeSortFieldMap = map[string]string{
 	"description":         "description.keyword",
 	"sex":                 "sex.keyword",
 	"price":               "price",
+	"final_price":         "final_price",
 	"discount_percentage": "discount_percentage",
 	"stock":               "stock",
 	"category_id":         "category_id.keyword",
 	"category_name":       "category_name.keyword",
 	"brand_id":            "brand_id.keyword",
 	"brand_name":          "brand_name.keyword",
+	"average_rating":      "average_rating",
+	"rating_count":        "rating_count",
 	"created_at":          "created_at",
 	"updated_at":          "updated_at",
 }
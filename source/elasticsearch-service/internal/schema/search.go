@@ -0,0 +1,44 @@
+package schema
+
+var SearchQuery = `
+{
+  "mappings": {
+    "properties": {
+      "id": { "type": "keyword" },
+      "query": {
+          "type": "text",
+          "analyzer": "standard",
+          "fields": {
+            "keyword": { "type": "keyword" }
+          }
+        },
+      "filters": { "type": "flattened" },
+      "sort_by": { "type": "keyword" },
+      "offset": { "type": "integer" },
+      "limit": { "type": "integer" },
+      "result_count": { "type": "long" },
+      "latency_ms": { "type": "long" },
+      "user_id": { "type": "keyword" },
+      "created_at": { "type": "date" }
+    }
+  }
+}`
+
+var SearchClick = `
+{
+  "mappings": {
+    "properties": {
+      "search_id": { "type": "keyword" },
+      "query": {
+          "type": "text",
+          "analyzer": "standard",
+          "fields": {
+            "keyword": { "type": "keyword" }
+          }
+        },
+      "product_id": { "type": "keyword" },
+      "user_id": { "type": "keyword" },
+      "created_at": { "type": "date" }
+    }
+  }
+}`
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"thanhldt060802/infrastructure"
@@ -14,24 +18,32 @@ import (
 	"thanhldt060802/internal/grpc/service/elasticsearchservicepb"
 	"thanhldt060802/internal/schema"
 	"thanhldt060802/utils"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8/esutil"
 )
 
+// Recommendations of each kind when limit is not given, same as default of catalog-service endpoint
+const recommendationDefaultLimit = 5
+
 type catalogService struct {
+	searchAnalyticsService SearchAnalyticsService
 }
 
 type CatalogService interface {
 	SyncAllAvailableProducts() error
 
-	GetProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductsRequest) ([]*elasticsearchservicepb.Product, error)
+	GetProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductsRequest) ([]*elasticsearchservicepb.Product, []*elasticsearchservicepb.ProductFacet, error)
+	GetProductRecommendations(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductRecommendationsRequest) (*elasticsearchservicepb.GetProductRecommendationsResponse, error)
 	syncCreatingProductLoop()
 	syncUpdatingProductLoop()
 	syncDeletingProductLoop()
 }
 
-func NewCatalogService(sync string) CatalogService {
-	catalogService := &catalogService{}
+func NewCatalogService(sync string, searchAnalyticsService SearchAnalyticsService) CatalogService {
+	catalogService := &catalogService{
+		searchAnalyticsService: searchAnalyticsService,
+	}
 
 	go func() {
 		if sync == "true" {
@@ -91,11 +103,10 @@ func (catalogService *catalogService) SyncAllAvailableProducts() error {
 		return fmt.Errorf("create products index on elasticsearch failed: %s", res.String())
 	}
 
-	grpcRes, err := infrastructure.CatalogServiceGRPCClient.GetAllProducts(context.Background(), &catalogservicepb.GetAllProductsRequest{})
+	stream, err := infrastructure.CatalogServiceGRPCClient.StreamAllProducts(context.Background(), &catalogservicepb.StreamAllProductsRequest{})
 	if err != nil {
 		return fmt.Errorf("get all products from catalog-service failed: %s", err.Error())
 	}
-	products := grpcRes.Products
 
 	hasFailure := false
 	var closeBulkIndexer error
@@ -114,30 +125,40 @@ func (catalogService *catalogService) SyncAllAvailableProducts() error {
 		}
 	}()
 
-	// Add all available data on PostgreSQL to BulkIndexer
-	for _, product := range products {
-		// Convert data to JSON data
-		productJSON, err := json.Marshal(dto.FromProductProtoToProductView(product))
+	// Add all available data on PostgreSQL to BulkIndexer, page by page as they are streamed
+	for {
+		grpcRes, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
 		if err != nil {
-			return err
+			return fmt.Errorf("get all products from catalog-service failed: %s", err.Error())
 		}
 
-		// Add data to BulkIndexer
-		err = indexer.Add(context.Background(), esutil.BulkIndexerItem{
-			Action:     "index",
-			DocumentID: product.Id,
-			Body:       bytes.NewReader(productJSON),
-			OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, resp esutil.BulkIndexerResponseItem, err error) {
-				if err != nil {
-					log.Printf("Bulk index failed: %s", err.Error())
-				} else {
-					log.Printf("Index product with id = %s failed: %s", item.DocumentID, resp.Error.Reason)
-				}
-				hasFailure = true
-			},
-		})
-		if err != nil {
-			return err
+		for _, product := range grpcRes.Products {
+			// Convert data to JSON data
+			productJSON, err := json.Marshal(dto.FromProductProtoToProductView(product))
+			if err != nil {
+				return err
+			}
+
+			// Add data to BulkIndexer
+			err = indexer.Add(context.Background(), esutil.BulkIndexerItem{
+				Action:     "index",
+				DocumentID: product.Id,
+				Body:       bytes.NewReader(productJSON),
+				OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, resp esutil.BulkIndexerResponseItem, err error) {
+					if err != nil {
+						log.Printf("Bulk index failed: %s", err.Error())
+					} else {
+						log.Printf("Index product with id = %s failed: %s", item.DocumentID, resp.Error.Reason)
+					}
+					hasFailure = true
+				},
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -164,6 +185,12 @@ func (catalogService *catalogService) syncCreatingProductLoop() {
 			continue
 		}
 
+		// Only published products are searchable, a draft product is indexed once it is published
+		if newProductView.Status != "PUBLISHED" {
+			deleteProductDocument(newProductView.Id, "creating")
+			continue
+		}
+
 		func() {
 			res, err := infrastructure.ElasticsearchClient.Index(
 				"products",
@@ -198,6 +225,12 @@ func (catalogService *catalogService) syncUpdatingProductLoop() {
 			continue
 		}
 
+		// Product which is unpublished or archived leaves search results
+		if updatedProductView.Status != "PUBLISHED" {
+			deleteProductDocument(updatedProductView.Id, "updating")
+			continue
+		}
+
 		func() {
 			res, err := infrastructure.ElasticsearchClient.Index(
 				"products",
@@ -226,36 +259,52 @@ func (catalogService *catalogService) syncDeletingProductLoop() {
 	ch := subscribe.Channel()
 
 	for msg := range ch {
-		productIdStr := msg.Payload
+		deleteProductDocument(msg.Payload, "deleting")
+	}
+}
 
-		func() {
-			res, err := infrastructure.ElasticsearchClient.Delete(
-				"products",
-				productIdStr,
-				infrastructure.ElasticsearchClient.Delete.WithRefresh("true"),
-			)
-			if err != nil {
-				log.Printf("Delete product from Elasticsearch failed: %s", err.Error())
-			}
-			defer res.Body.Close()
+// Remove product from index, product which was never indexed (e.g. a draft) is already in the wanted state
+func deleteProductDocument(productId string, action string) {
+	res, err := infrastructure.ElasticsearchClient.Delete(
+		"products",
+		productId,
+		infrastructure.ElasticsearchClient.Delete.WithRefresh("true"),
+	)
+	if err != nil {
+		log.Printf("Delete product from Elasticsearch failed: %s", err.Error())
+		return
+	}
+	defer res.Body.Close()
 
-			if res.IsError() {
-				log.Printf("Sync deleting product failed: %s", res.String())
-			} else {
-				log.Printf("Sync deleting product successful")
-			}
-		}()
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		log.Printf("Sync %s product failed: %s", action, res.String())
+	} else {
+		log.Printf("Sync %s product successful", action)
 	}
 }
 
-func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductsRequest) ([]*elasticsearchservicepb.Product, error) {
+func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductsRequest) ([]*elasticsearchservicepb.Product, []*elasticsearchservicepb.ProductFacet, error) {
+	startTime := time.Now()
+
 	mustConditions := []map[string]interface{}{}
 
-	// If filtering by category_id
+	// If filtering by category_id, products of descendant categories carry it in their breadcrumb
 	if reqDTO.CategoryId != "" {
 		mustConditions = append(mustConditions, map[string]interface{}{
-			"term": map[string]interface{}{
-				"category_id.keyword": reqDTO.CategoryId,
+			"bool": map[string]interface{}{
+				"should": []map[string]interface{}{
+					{
+						"term": map[string]interface{}{
+							"category_id.keyword": reqDTO.CategoryId,
+						},
+					},
+					{
+						"term": map[string]interface{}{
+							"category_breadcrumb.id": reqDTO.CategoryId,
+						},
+					},
+				},
+				"minimum_should_match": 1,
 			},
 		})
 	}
@@ -309,7 +358,25 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 	if len(priceRange) > 0 {
 		mustConditions = append(mustConditions, map[string]interface{}{
 			"range": map[string]interface{}{
-				"price_range": priceRange,
+				"price": priceRange,
+			},
+		})
+	}
+
+	// If searching by final price (after discount) in range or partial range
+	finalPriceRange := map[string]interface{}{}
+	if reqDTO.FinalPriceGte != "" {
+		value, _ := strconv.ParseInt(reqDTO.FinalPriceGte, 10, 64)
+		finalPriceRange["gte"] = value
+	}
+	if reqDTO.FinalPriceLte != "" {
+		value, _ := strconv.ParseInt(reqDTO.FinalPriceLte, 10, 64)
+		finalPriceRange["lte"] = value
+	}
+	if len(finalPriceRange) > 0 {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"final_price": finalPriceRange,
 			},
 		})
 	}
@@ -327,7 +394,7 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 	if len(discountPercentageRange) > 0 {
 		mustConditions = append(mustConditions, map[string]interface{}{
 			"range": map[string]interface{}{
-				"discount_percentage_range": discountPercentageRange,
+				"discount_percentage": discountPercentageRange,
 			},
 		})
 	}
@@ -345,7 +412,31 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 	if len(stockRange) > 0 {
 		mustConditions = append(mustConditions, map[string]interface{}{
 			"range": map[string]interface{}{
-				"stock_range": stockRange,
+				"stock": stockRange,
+			},
+		})
+	}
+
+	// If filtering by average_rating greater than or equals
+	if reqDTO.AverageRatingGte != "" {
+		value, _ := strconv.ParseFloat(reqDTO.AverageRatingGte, 64)
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"average_rating": map[string]interface{}{
+					"gte": value,
+				},
+			},
+		})
+	}
+
+	// If filtering by rating_count greater than or equals
+	if reqDTO.RatingCountGte != "" {
+		value, _ := strconv.ParseInt(reqDTO.RatingCountGte, 10, 64)
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"rating_count": map[string]interface{}{
+					"gte": value,
+				},
 			},
 		})
 	}
@@ -385,6 +476,111 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 		})
 	}
 
+	// Attribute and tag filters are applied as post filter so that each facet is counted without its own filter, selecting a
+	// value does not collapse its facet to that value
+	type facetCondition struct {
+		code      string
+		condition map[string]interface{}
+	}
+	facetConditions := []facetCondition{}
+	selectedAttributeCodes := []string{}
+
+	// If filtering by attributes, each attribute must match one of its values, or its number range for NUMBER attributes
+	for _, attributeFilter := range strings.Split(reqDTO.Attributes, ",") {
+		code, values, ok := strings.Cut(attributeFilter, ":")
+		if !ok {
+			continue
+		}
+
+		// Values are matched as they are too, so that ".." in value of other attributes is not taken for a range
+		valueConditions := []map[string]interface{}{
+			{
+				"terms": map[string]interface{}{
+					"attributes.value": strings.Split(values, "|"),
+				},
+			},
+		}
+		if gte, lte, isRange := strings.Cut(values, ".."); isRange {
+			numberValueRange := map[string]interface{}{}
+			if value, err := strconv.ParseFloat(gte, 64); err == nil {
+				numberValueRange["gte"] = value
+			}
+			if value, err := strconv.ParseFloat(lte, 64); err == nil {
+				numberValueRange["lte"] = value
+			}
+			if len(numberValueRange) > 0 {
+				valueConditions = append(valueConditions, map[string]interface{}{
+					"bool": map[string]interface{}{
+						"must": []map[string]interface{}{
+							{
+								"term": map[string]interface{}{
+									"attributes.type": "NUMBER",
+								},
+							},
+							{
+								"range": map[string]interface{}{
+									"attributes.number_value": numberValueRange,
+								},
+							},
+						},
+					},
+				})
+			}
+		}
+
+		if !slices.Contains(selectedAttributeCodes, code) {
+			selectedAttributeCodes = append(selectedAttributeCodes, code)
+		}
+		facetConditions = append(facetConditions, facetCondition{
+			code: code,
+			condition: map[string]interface{}{
+				"nested": map[string]interface{}{
+					"path": "attributes",
+					"query": map[string]interface{}{
+						"bool": map[string]interface{}{
+							"must": []map[string]interface{}{
+								{
+									"term": map[string]interface{}{
+										"attributes.code": code,
+									},
+								},
+							},
+							"should":               valueConditions,
+							"minimum_should_match": 1,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// If filtering by tags, products having any of them match
+	if reqDTO.Tags != "" {
+		facetConditions = append(facetConditions, facetCondition{
+			code: "tags",
+			condition: map[string]interface{}{
+				"terms": map[string]interface{}{
+					"tags": strings.Split(strings.ToLower(reqDTO.Tags), ","),
+				},
+			},
+		})
+	}
+
+	// Facet filters except the ones of given facet code
+	facetFilter := func(excludedCode string) map[string]interface{} {
+		conditions := []map[string]interface{}{}
+		for _, facetCondition := range facetConditions {
+			if facetCondition.code != excludedCode {
+				conditions = append(conditions, facetCondition.condition)
+			}
+		}
+		return map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": conditions,
+			},
+		}
+	}
+
 	// If not searching -> get all
 	if len(mustConditions) == 0 {
 		mustConditions = append(mustConditions, map[string]interface{}{
@@ -392,15 +588,90 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 		})
 	}
 
+	attributeFacetAggs := map[string]interface{}{
+		"names": map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": "attributes.name",
+				"size":  1,
+			},
+		},
+		"values": map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": "attributes.value",
+				"size":  20,
+			},
+		},
+	}
+	// Facets of attributes and tags are counted over all matched products, facet of a selected attribute or of tags is
+	// counted without its own filter
+	aggs := map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"filter": facetFilter(""),
+			"aggs": map[string]interface{}{
+				"attributes": map[string]interface{}{
+					"nested": map[string]interface{}{
+						"path": "attributes",
+					},
+					"aggs": map[string]interface{}{
+						"codes": map[string]interface{}{
+							"terms": map[string]interface{}{
+								"field": "attributes.code",
+								"size":  50,
+							},
+							"aggs": attributeFacetAggs,
+						},
+					},
+				},
+			},
+		},
+		"tags": map[string]interface{}{
+			"filter": facetFilter("tags"),
+			"aggs": map[string]interface{}{
+				"tags": map[string]interface{}{
+					"terms": map[string]interface{}{
+						"field": "tags",
+						"size":  20,
+					},
+				},
+			},
+		},
+	}
+	for i, code := range selectedAttributeCodes {
+		aggs[fmt.Sprintf("attribute_%d", i)] = map[string]interface{}{
+			"filter": facetFilter(code),
+			"aggs": map[string]interface{}{
+				"attributes": map[string]interface{}{
+					"nested": map[string]interface{}{
+						"path": "attributes",
+					},
+					"aggs": map[string]interface{}{
+						"code": map[string]interface{}{
+							"filter": map[string]interface{}{
+								"term": map[string]interface{}{
+									"attributes.code": code,
+								},
+							},
+							"aggs": attributeFacetAggs,
+						},
+					},
+				},
+			},
+		}
+	}
+
 	// Setup query
 	query := map[string]interface{}{
 		"from": reqDTO.Offset,
 		"size": reqDTO.Limit,
+		// Total hits are needed for search logging
+		"track_total_hits": true,
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
 				"must": mustConditions,
 			},
 		},
+		"post_filter": facetFilter(""),
+		"aggs":        aggs,
 	}
 
 	// Apply sorting to query
@@ -413,6 +684,319 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 	}
 	query["sort"] = _sortFields
 
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Search(
+		infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Search.WithIndex("products"),
+		infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, nil, fmt.Errorf("some thing wrong when querying products on elasticsearch")
+	}
+
+	// Declare Elasticsearch response
+	type valueBuckets struct {
+		Buckets []struct {
+			Key      string `json:"key"`
+			DocCount int64  `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	type attributeFacetBucket struct {
+		Key    string       `json:"key"`
+		Names  valueBuckets `json:"names"`
+		Values valueBuckets `json:"values"`
+	}
+	var elasticsearchResponse struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source dto.ProductView `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+		Aggregations map[string]json.RawMessage `json:"aggregations"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, nil, err
+	}
+	var attributesAgg struct {
+		Attributes struct {
+			Codes struct {
+				Buckets []attributeFacetBucket `json:"buckets"`
+			} `json:"codes"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(elasticsearchResponse.Aggregations["attributes"], &attributesAgg); err != nil {
+		return nil, nil, err
+	}
+	var tagsAgg struct {
+		Tags valueBuckets `json:"tags"`
+	}
+	if err := json.Unmarshal(elasticsearchResponse.Aggregations["tags"], &tagsAgg); err != nil {
+		return nil, nil, err
+	}
+	selectedAttributeBuckets := map[string]attributeFacetBucket{}
+	for i, code := range selectedAttributeCodes {
+		var attributeAgg struct {
+			Attributes struct {
+				Code attributeFacetBucket `json:"code"`
+			} `json:"attributes"`
+		}
+		if err := json.Unmarshal(elasticsearchResponse.Aggregations[fmt.Sprintf("attribute_%d", i)], &attributeAgg); err != nil {
+			return nil, nil, err
+		}
+		attributeAgg.Attributes.Code.Key = code
+		selectedAttributeBuckets[code] = attributeAgg.Attributes.Code
+	}
+
+	// Extract data from Elasticsearch response
+	products := make([]dto.ProductView, len(elasticsearchResponse.Hits.Hits))
+	for i, hit := range elasticsearchResponse.Hits.Hits {
+		products[i] = hit.Source
+	}
+
+	// Extract facets from Elasticsearch response, selected attributes come first
+	toFacetBuckets := func(buckets valueBuckets) []*elasticsearchservicepb.ProductFacetBucket {
+		facetBuckets := make([]*elasticsearchservicepb.ProductFacetBucket, len(buckets.Buckets))
+		for i, bucket := range buckets.Buckets {
+			facetBuckets[i] = &elasticsearchservicepb.ProductFacetBucket{
+				Value: bucket.Key,
+				Count: bucket.DocCount,
+			}
+		}
+		return facetBuckets
+	}
+	codeBuckets := []attributeFacetBucket{}
+	for _, code := range selectedAttributeCodes {
+		if len(selectedAttributeBuckets[code].Values.Buckets) > 0 {
+			codeBuckets = append(codeBuckets, selectedAttributeBuckets[code])
+		}
+	}
+	for _, codeBucket := range attributesAgg.Attributes.Codes.Buckets {
+		if _, ok := selectedAttributeBuckets[codeBucket.Key]; !ok {
+			codeBuckets = append(codeBuckets, codeBucket)
+		}
+	}
+	facets := []*elasticsearchservicepb.ProductFacet{}
+	for _, codeBucket := range codeBuckets {
+		facet := &elasticsearchservicepb.ProductFacet{
+			Code:    codeBucket.Key,
+			Name:    codeBucket.Key,
+			Buckets: toFacetBuckets(codeBucket.Values),
+		}
+		if len(codeBucket.Names.Buckets) > 0 {
+			facet.Name = codeBucket.Names.Buckets[0].Key
+		}
+		facets = append(facets, facet)
+	}
+	if len(tagsAgg.Tags.Buckets) > 0 {
+		facets = append(facets, &elasticsearchservicepb.ProductFacet{
+			Code:    "tags",
+			Name:    "Tags",
+			Buckets: toFacetBuckets(tagsAgg.Tags),
+		})
+	}
+
+	// Log search query for search reports, lookups without search id (such as collection rules) are not searches of customers
+	if reqDTO.SearchId != "" {
+		catalogService.searchAnalyticsService.LogSearchQuery(&dto.SearchQueryView{
+			Id:          reqDTO.SearchId,
+			Query:       utils.NormalizeSearchQuery(reqDTO.Name, reqDTO.Description),
+			Filters:     productSearchFilters(reqDTO),
+			SortBy:      reqDTO.SortBy,
+			Offset:      reqDTO.Offset,
+			Limit:       reqDTO.Limit,
+			ResultCount: elasticsearchResponse.Hits.Total.Value,
+			LatencyMs:   time.Since(startTime).Milliseconds(),
+			UserId:      reqDTO.UserId,
+			CreatedAt:   startTime,
+		})
+	}
+
+	return dto.FromListProductViewToListProductProto(products), facets, nil
+}
+
+func productSearchFilters(reqDTO *elasticsearchservicepb.GetProductsRequest) map[string]string {
+	filters := map[string]string{}
+	for key, value := range map[string]string{
+		"category_id":             reqDTO.CategoryId,
+		"brand_id":                reqDTO.BrandId,
+		"sex":                     reqDTO.Sex,
+		"price_gte":               reqDTO.PriceGte,
+		"price_lte":               reqDTO.PriceLte,
+		"final_price_gte":         reqDTO.FinalPriceGte,
+		"final_price_lte":         reqDTO.FinalPriceLte,
+		"discount_percentage_gte": reqDTO.DiscountPercentageGte,
+		"discount_percentage_lte": reqDTO.DiscountPercentageLte,
+		"stock_gte":               reqDTO.StockGte,
+		"stock_lte":               reqDTO.StockLte,
+		"average_rating_gte":      reqDTO.AverageRatingGte,
+		"rating_count_gte":        reqDTO.RatingCountGte,
+		"category_name":           reqDTO.CategoryName,
+		"brand_name":              reqDTO.BrandName,
+		"created_at_gte":          reqDTO.CreatedAtGte,
+		"created_at_lte":          reqDTO.CreatedAtLte,
+		"attributes":              reqDTO.Attributes,
+		"tags":                    reqDTO.Tags,
+	} {
+		if value != "" {
+			filters[key] = value
+		}
+	}
+
+	return filters
+}
+
+func (catalogService *catalogService) GetProductRecommendations(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductRecommendationsRequest) (*elasticsearchservicepb.GetProductRecommendationsResponse, error) {
+	productViewMap, err := getProductViewsByIds(ctx, []string{reqDTO.ProductId})
+	if err != nil {
+		return nil, err
+	}
+	productView, ok := productViewMap[reqDTO.ProductId]
+	if !ok {
+		return nil, fmt.Errorf("id of product is not valid")
+	}
+
+	// Limit left out on gRPC is 0
+	limit := reqDTO.Limit
+	if limit <= 0 {
+		limit = recommendationDefaultLimit
+	}
+
+	res := &elasticsearchservicepb.GetProductRecommendationsResponse{}
+
+	if reqDTO.Type == "" || reqDTO.Type == "all" || reqDTO.Type == "similar" {
+		similarProductViews, err := catalogService.getSimilarProductViews(ctx, &productView, limit)
+		if err != nil {
+			return nil, err
+		}
+		res.SimilarProducts = dto.FromListProductViewToListProductProto(similarProductViews)
+	}
+
+	if reqDTO.Type == "" || reqDTO.Type == "all" || reqDTO.Type == "bought_together" {
+		boughtTogetherProductViews, err := catalogService.getBoughtTogetherProductViews(ctx, &productView, limit)
+		if err != nil {
+			return nil, err
+		}
+		res.FrequentlyBoughtTogether = dto.FromListProductViewToListProductProto(boughtTogetherProductViews)
+	}
+
+	return res, nil
+}
+
+func (catalogService *catalogService) getSimilarProductViews(ctx context.Context, productView *dto.ProductView, limit int32) ([]dto.ProductView, error) {
+	// Similar products share category, brand or an attribute value of the product
+	relatedConditions := []map[string]interface{}{
+		{
+			"term": map[string]interface{}{
+				"category_id.keyword": map[string]interface{}{
+					"value": productView.CategoryId,
+					"boost": 2,
+				},
+			},
+		},
+		{
+			"term": map[string]interface{}{
+				"brand_id.keyword": productView.BrandId,
+			},
+		},
+	}
+	for _, attribute := range productView.Attributes {
+		relatedConditions = append(relatedConditions, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": "attributes",
+				"query": map[string]interface{}{
+					"bool": map[string]interface{}{
+						"must": []map[string]interface{}{
+							{
+								"term": map[string]interface{}{
+									"attributes.code": attribute.Code,
+								},
+							},
+							{
+								"term": map[string]interface{}{
+									"attributes.value": attribute.Value,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	filters := []map[string]interface{}{
+		{
+			"range": map[string]interface{}{
+				"stock": map[string]interface{}{
+					"gt": 0,
+				},
+			},
+		},
+		{
+			"bool": map[string]interface{}{
+				"should":               relatedConditions,
+				"minimum_should_match": 1,
+			},
+		},
+	}
+	// Products of the other sex are never similar, unisex products suit everyone
+	if productView.Sex != "UNISEX" {
+		filters = append(filters, map[string]interface{}{
+			"terms": map[string]interface{}{
+				"sex.keyword": []string{productView.Sex, "UNISEX"},
+			},
+		})
+	}
+
+	// Setup query (text similarity on name/description, boosted by same category/brand/attributes, only in stock)
+	query := map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": append([]map[string]interface{}{
+					{
+						"more_like_this": map[string]interface{}{
+							"fields": []string{"name", "description"},
+							"like": []map[string]interface{}{
+								{
+									"_index": "products",
+									"_id":    productView.Id,
+								},
+							},
+							"min_term_freq": 1,
+							"min_doc_freq":  1,
+						},
+					},
+				}, relatedConditions...),
+				"filter": filters,
+				"must_not": []map[string]interface{}{
+					{
+						"ids": map[string]interface{}{
+							"values": []string{productView.Id},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	// Convert query to JSON query
 	queryJSON, err := json.Marshal(query)
 	if err != nil {
@@ -456,5 +1040,175 @@ func (catalogService *catalogService) GetProducts(ctx context.Context, reqDTO *e
 		products[i] = hit.Source
 	}
 
-	return dto.FromListProductViewToListProductProto(products), nil
+	return products, nil
+}
+
+func (catalogService *catalogService) getBoughtTogetherProductViews(ctx context.Context, productView *dto.ProductView, limit int32) ([]dto.ProductView, error) {
+	// Setup query (count other products on invoices which contain this product, cancelled invoices excluded)
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []map[string]interface{}{
+					{
+						"nested": map[string]interface{}{
+							"path": "invoice_details",
+							"query": map[string]interface{}{
+								"term": map[string]interface{}{
+									"invoice_details.product_id.keyword": productView.Id,
+								},
+							},
+						},
+					},
+				},
+				"must_not": []map[string]interface{}{
+					{
+						"match": map[string]interface{}{
+							"status": "CANCEL",
+						},
+					},
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"invoice_details": map[string]interface{}{
+				"nested": map[string]interface{}{
+					"path": "invoice_details",
+				},
+				"aggs": map[string]interface{}{
+					"products": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field":   "invoice_details.product_id.keyword",
+							"exclude": []string{productView.Id},
+							// Over-fetch since out of stock products are dropped afterward
+							"size": limit * 3,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Search(
+		infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Search.WithIndex("invoices"),
+		infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, fmt.Errorf("some thing wrong when aggregating invoices on elasticsearch")
+	}
+
+	// Declare Elasticsearch response
+	var elasticsearchResponse struct {
+		Aggregations struct {
+			InvoiceDetails struct {
+				Products struct {
+					Buckets []struct {
+						Key string `json:"key"`
+					} `json:"buckets"`
+				} `json:"products"`
+			} `json:"invoice_details"`
+		} `json:"aggregations"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, err
+	}
+
+	// Extract data from Elasticsearch response (most co-purchased first)
+	productIds := []string{}
+	for _, bucket := range elasticsearchResponse.Aggregations.InvoiceDetails.Products.Buckets {
+		productIds = append(productIds, bucket.Key)
+	}
+
+	productViewMap, err := getProductViewsByIds(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	products := []dto.ProductView{}
+	for _, productId := range productIds {
+		if int32(len(products)) == limit {
+			break
+		}
+		if product, ok := productViewMap[productId]; ok && product.Stock > 0 {
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+// Fetch products by list id from Elasticsearch, missing products are not included in result
+func getProductViewsByIds(ctx context.Context, productIds []string) (map[string]dto.ProductView, error) {
+	productViewMap := map[string]dto.ProductView{}
+	if len(productIds) == 0 {
+		return productViewMap, nil
+	}
+
+	// Setup query
+	query := map[string]interface{}{
+		"ids": productIds,
+	}
+
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Mget(
+		bytes.NewReader(queryJSON),
+		infrastructure.ElasticsearchClient.Mget.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Mget.WithIndex("products"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, fmt.Errorf("some thing wrong when querying products on elasticsearch")
+	}
+
+	// Declare Elasticsearch response
+	var elasticsearchResponse struct {
+		Docs []struct {
+			Id     string          `json:"_id"`
+			Found  bool            `json:"found"`
+			Source dto.ProductView `json:"_source"`
+		} `json:"docs"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, err
+	}
+
+	// Extract data from Elasticsearch response
+	for _, doc := range elasticsearchResponse.Docs {
+		if doc.Found {
+			productViewMap[doc.Id] = doc.Source
+		}
+	}
+
+	return productViewMap, nil
 }
@@ -0,0 +1,766 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/service/elasticsearchservicepb"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	leaderboardDailyKeyRetention    = 31 * 24 * time.Hour
+	leaderboardCacheTTL             = 5 * time.Minute
+	leaderboardTrendingDays         = 7
+	leaderboardTrendingHalfLifeDays = 2.0
+	leaderboardDefaultLimit         = 10
+	leaderboardRebuildTTL           = 10 * time.Minute
+	leaderboardCancelledTTL         = leaderboardDailyKeyRetention
+	leaderboardCountedTTL           = leaderboardRebuildTTL
+	leaderboardRebuildIndexLag      = time.Minute
+	leaderboardRebuildPageSize      = 1000
+)
+
+type leaderboardService struct {
+	// Incremental writes hold it for reading, rebuild holds it for writing while swapping new leaderboard in
+	rebuildMutex sync.RWMutex
+}
+
+type LeaderboardService interface {
+	RebuildLeaderboard() error
+
+	GetTopProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetTopProductsRequest) ([]*elasticsearchservicepb.RankedProduct, error)
+	GetTrendingProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetTrendingProductsRequest) ([]*elasticsearchservicepb.RankedProduct, error)
+	syncCreatingInvoiceLoop()
+	syncUpdatingInvoiceLoop()
+	syncRebuildingLeaderboardLoop()
+}
+
+func NewLeaderboardService() LeaderboardService {
+	leaderboardService := &leaderboardService{}
+
+	go leaderboardService.syncCreatingInvoiceLoop()
+	go leaderboardService.syncUpdatingInvoiceLoop()
+	go leaderboardService.syncRebuildingLeaderboardLoop()
+
+	go func() {
+		if err := leaderboardService.RebuildLeaderboard(); err != nil {
+			log.Printf("Rebuild leaderboard the first time failed: %s", err.Error())
+		} else {
+			log.Printf("Rebuild leaderboard the first time successful")
+		}
+	}()
+
+	return leaderboardService
+}
+
+// Redis layout of leaderboard:
+//   - leaderboard:units:{scope}:all          units sold all-time per product
+//   - leaderboard:units:{scope}:day:{date}   units sold on one day (UTC) per product, kept for 31 days
+//   - leaderboard:units:{scope}:last:{n}d    cached union of the last n daily keys
+//   - leaderboard:trending:{scope}           cached decay-weighted union of the last 7 daily keys
+//   - leaderboard-cancelled-invoice:{id}     marker of cancelled invoice whose units were taken back
+//   - leaderboard-counted-invoice:{id}       marker of invoice whose units were added, kept for a while after a rebuild
+//   - leaderboard-rebuild:{n}:leaderboard:*  new leaderboard being rebuilt, renamed over the old one when complete
+// Where scope is "all", "category:{id}" or "brand:{id}".
+
+func leaderboardScope(categoryId string, brandId string) (string, error) {
+	if categoryId != "" && brandId != "" {
+		return "", fmt.Errorf("filter by both category id and brand id is not supported")
+	}
+	if categoryId != "" {
+		return "category:" + categoryId, nil
+	}
+	if brandId != "" {
+		return "brand:" + brandId, nil
+	}
+	return "all", nil
+}
+
+func leaderboardScopesOfInvoiceDetail(invoiceDetail *dto.InvoiceDetailView) []string {
+	scopes := []string{"all"}
+	if invoiceDetail.ProductCategoryId != "" {
+		scopes = append(scopes, "category:"+invoiceDetail.ProductCategoryId)
+	}
+	if invoiceDetail.ProductBrandId != "" {
+		scopes = append(scopes, "brand:"+invoiceDetail.ProductBrandId)
+	}
+	return scopes
+}
+
+func leaderboardAllTimeKey(scope string) string {
+	return fmt.Sprintf("leaderboard:units:%s:all", scope)
+}
+
+func leaderboardDailyKey(scope string, day time.Time) string {
+	return fmt.Sprintf("leaderboard:units:%s:day:%s", scope, day.UTC().Format("2006-01-02"))
+}
+
+func leaderboardLastDaysKey(scope string, days int) string {
+	return fmt.Sprintf("leaderboard:units:%s:last:%dd", scope, days)
+}
+
+func leaderboardTrendingKey(scope string) string {
+	return fmt.Sprintf("leaderboard:trending:%s", scope)
+}
+
+// Outside of leaderboard:* so that rebuilding leaderboard keeps it
+func leaderboardCancelledInvoiceKey(invoiceId string) string {
+	return fmt.Sprintf("leaderboard-cancelled-invoice:%s", invoiceId)
+}
+
+// Outside of leaderboard:* so that rebuilding leaderboard keeps it
+func leaderboardCountedInvoiceKey(invoiceId string) string {
+	return fmt.Sprintf("leaderboard-counted-invoice:%s", invoiceId)
+}
+
+func (leaderboardService *leaderboardService) RebuildLeaderboard() error {
+	ctx := context.Background()
+
+	// Invoices created up to high-water mark are aggregated, those created after it are replayed once new leaderboard is
+	// swapped in. It lags behind now so that invoices aggregated are surely indexed already.
+	highWaterMark := time.Now().UTC().Add(-leaderboardRebuildIndexLag).Truncate(time.Millisecond)
+
+	// Make sure recently indexed invoices are visible to the aggregation
+	refreshRes, err := infrastructure.ElasticsearchClient.Indices.Refresh(
+		infrastructure.ElasticsearchClient.Indices.Refresh.WithIndex("invoices"),
+	)
+	if err != nil {
+		return err
+	}
+	refreshRes.Body.Close()
+
+	// Write new leaderboard under a temporary prefix, temporary keys expire by themselves if the swap below never happens
+	rebuildPrefix := fmt.Sprintf("leaderboard-rebuild:%d:", time.Now().UnixNano())
+	newKeys := map[string]*time.Time{}
+	pipe := infrastructure.RedisClient.Pipeline()
+	addProductUnits := func(keyOf func(scope string) string, day *time.Time) func(invoiceDetail *dto.InvoiceDetailView, units float64) {
+		return func(invoiceDetail *dto.InvoiceDetailView, units float64) {
+			for _, scope := range leaderboardScopesOfInvoiceDetail(invoiceDetail) {
+				key := keyOf(scope)
+				newKeys[key] = day
+				pipe.ZIncrBy(ctx, rebuildPrefix+key, units, invoiceDetail.ProductId)
+				pipe.Expire(ctx, rebuildPrefix+key, leaderboardRebuildTTL)
+			}
+		}
+	}
+
+	// All-time units
+	if err := leaderboardService.aggregateProductUnits(ctx, map[string]interface{}{
+		"lte": highWaterMark.Format(time.RFC3339Nano),
+	}, addProductUnits(leaderboardAllTimeKey, nil)); err != nil {
+		return err
+	}
+
+	// Daily units of the retention window
+	today := highWaterMark.Truncate(24 * time.Hour)
+	for i := 0; i <= int(leaderboardDailyKeyRetention.Hours()/24); i++ {
+		day := today.AddDate(0, 0, -i)
+		createdAtRange := map[string]interface{}{
+			"gte": day.Format(time.RFC3339Nano),
+			"lt":  day.Add(24 * time.Hour).Format(time.RFC3339Nano),
+		}
+		if i == 0 {
+			delete(createdAtRange, "lt")
+			createdAtRange["lte"] = highWaterMark.Format(time.RFC3339Nano)
+		}
+		if err := leaderboardService.aggregateProductUnits(ctx, createdAtRange, addProductUnits(func(scope string) string {
+			return leaderboardDailyKey(scope, day)
+		}, &day)); err != nil {
+			return err
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	// Incremental writes wait from here until invoices past high-water mark are replayed, anything they wrote before is
+	// either renamed over or dropped below and replayed again
+	leaderboardService.rebuildMutex.Lock()
+	defer leaderboardService.rebuildMutex.Unlock()
+
+	// Old keys missing from new leaderboard (and cached unions) are dropped
+	oldKeys := []string{}
+	var cursor uint64
+	for {
+		keys, nextCursor, err := infrastructure.RedisClient.Scan(ctx, cursor, "leaderboard:*", 1000).Result()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if _, ok := newKeys[key]; !ok {
+				oldKeys = append(oldKeys, key)
+			}
+		}
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// Swap new leaderboard in at once, readers see either the old one or the new one but never an empty one
+	swapPipe := infrastructure.RedisClient.TxPipeline()
+	for key, day := range newKeys {
+		swapPipe.Rename(ctx, rebuildPrefix+key, key)
+		if day != nil {
+			swapPipe.ExpireAt(ctx, key, day.Add(leaderboardDailyKeyRetention))
+		} else {
+			swapPipe.Persist(ctx, key)
+		}
+	}
+	if len(oldKeys) > 0 {
+		swapPipe.Del(ctx, oldKeys...)
+	}
+	if _, err := swapPipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return leaderboardService.replayInvoicesAfter(ctx, highWaterMark)
+}
+
+// Units sold per product of invoices created in range (cancelled invoices excluded), paged with a composite aggregation
+// so that every product sold is counted
+func (leaderboardService *leaderboardService) aggregateProductUnits(ctx context.Context, createdAtRange map[string]interface{}, handle func(invoiceDetail *dto.InvoiceDetailView, units float64)) error {
+	var afterKey map[string]interface{}
+	for {
+		composite := map[string]interface{}{
+			"size": leaderboardRebuildPageSize,
+			"sources": []map[string]interface{}{
+				{
+					"product_id": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": "invoice_details.product_id.keyword",
+						},
+					},
+				},
+			},
+		}
+		if afterKey != nil {
+			composite["after"] = afterKey
+		}
+
+		// Setup query
+		query := map[string]interface{}{
+			"size": 0,
+			"query": map[string]interface{}{
+				"bool": map[string]interface{}{
+					"must": []map[string]interface{}{
+						{
+							"range": map[string]interface{}{
+								"created_at": createdAtRange,
+							},
+						},
+					},
+					"must_not": []map[string]interface{}{
+						{
+							"match": map[string]interface{}{
+								"status": "CANCEL",
+							},
+						},
+					},
+				},
+			},
+			"aggs": map[string]interface{}{
+				"invoice_details": map[string]interface{}{
+					"nested": map[string]interface{}{
+						"path": "invoice_details",
+					},
+					"aggs": map[string]interface{}{
+						"products": map[string]interface{}{
+							"composite": composite,
+							"aggs": map[string]interface{}{
+								"units": map[string]interface{}{
+									"sum": map[string]interface{}{
+										"field": "invoice_details.quantity",
+									},
+								},
+								"category": map[string]interface{}{
+									"terms": map[string]interface{}{
+										"field": "invoice_details.product_category_id.keyword",
+										"size":  1,
+									},
+								},
+								"brand": map[string]interface{}{
+									"terms": map[string]interface{}{
+										"field": "invoice_details.product_brand_id.keyword",
+										"size":  1,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		// Convert query to JSON query
+		queryJSON, err := json.Marshal(query)
+		if err != nil {
+			return err
+		}
+
+		// Send request to Elasticsearch
+		res, err := infrastructure.ElasticsearchClient.Search(
+			infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+			infrastructure.ElasticsearchClient.Search.WithIndex("invoices"),
+			infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Parse Elasticsearch response
+		if res.IsError() {
+			res.Body.Close()
+			return fmt.Errorf("some thing wrong when aggregating invoices on elasticsearch: %s", res.String())
+		}
+
+		// Declare Elasticsearch response
+		type keyBuckets struct {
+			Buckets []struct {
+				Key string `json:"key"`
+			} `json:"buckets"`
+		}
+		var elasticsearchResponse struct {
+			Aggregations struct {
+				InvoiceDetails struct {
+					Products struct {
+						AfterKey map[string]interface{} `json:"after_key"`
+						Buckets  []struct {
+							Key struct {
+								ProductId string `json:"product_id"`
+							} `json:"key"`
+							Units struct {
+								Value float64 `json:"value"`
+							} `json:"units"`
+							Category keyBuckets `json:"category"`
+							Brand    keyBuckets `json:"brand"`
+						} `json:"buckets"`
+					} `json:"products"`
+				} `json:"invoice_details"`
+			} `json:"aggregations"`
+		}
+
+		// Unmarshal Elasticsearch response body to Elasticsearch response
+		err = json.NewDecoder(res.Body).Decode(&elasticsearchResponse)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		products := elasticsearchResponse.Aggregations.InvoiceDetails.Products
+		for _, bucket := range products.Buckets {
+			invoiceDetail := &dto.InvoiceDetailView{ProductId: bucket.Key.ProductId}
+			if len(bucket.Category.Buckets) > 0 {
+				invoiceDetail.ProductCategoryId = bucket.Category.Buckets[0].Key
+			}
+			if len(bucket.Brand.Buckets) > 0 {
+				invoiceDetail.ProductBrandId = bucket.Brand.Buckets[0].Key
+			}
+			handle(invoiceDetail, bucket.Units.Value)
+		}
+
+		if len(products.Buckets) < leaderboardRebuildPageSize || products.AfterKey == nil {
+			return nil
+		}
+		afterKey = products.AfterKey
+	}
+}
+
+// Count again invoices created after high-water mark of a rebuild, they are marked as counted so that their creating
+// events delivered afterwards are skipped. Invoice not indexed yet is counted by its creating event.
+func (leaderboardService *leaderboardService) replayInvoicesAfter(ctx context.Context, highWaterMark time.Time) error {
+	refreshRes, err := infrastructure.ElasticsearchClient.Indices.Refresh(
+		infrastructure.ElasticsearchClient.Indices.Refresh.WithIndex("invoices"),
+	)
+	if err != nil {
+		return err
+	}
+	refreshRes.Body.Close()
+
+	var searchAfter []interface{}
+	for {
+		// Setup query
+		query := map[string]interface{}{
+			"size": leaderboardRebuildPageSize,
+			"query": map[string]interface{}{
+				"bool": map[string]interface{}{
+					"must": []map[string]interface{}{
+						{
+							"range": map[string]interface{}{
+								"created_at": map[string]interface{}{
+									"gt": highWaterMark.Format(time.RFC3339Nano),
+								},
+							},
+						},
+					},
+					"must_not": []map[string]interface{}{
+						{
+							"match": map[string]interface{}{
+								"status": "CANCEL",
+							},
+						},
+					},
+				},
+			},
+			"sort": []map[string]interface{}{
+				{"created_at": "asc"},
+				{"id.keyword": "asc"},
+			},
+		}
+		if searchAfter != nil {
+			query["search_after"] = searchAfter
+		}
+
+		// Convert query to JSON query
+		queryJSON, err := json.Marshal(query)
+		if err != nil {
+			return err
+		}
+
+		// Send request to Elasticsearch
+		res, err := infrastructure.ElasticsearchClient.Search(
+			infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+			infrastructure.ElasticsearchClient.Search.WithIndex("invoices"),
+			infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Parse Elasticsearch response
+		if res.IsError() {
+			res.Body.Close()
+			return fmt.Errorf("some thing wrong when querying invoices on elasticsearch: %s", res.String())
+		}
+
+		// Declare Elasticsearch response
+		var elasticsearchResponse struct {
+			Hits struct {
+				Hits []struct {
+					Source dto.InvoiceView `json:"_source"`
+					Sort   []interface{}   `json:"sort"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+
+		// Unmarshal Elasticsearch response body to Elasticsearch response
+		err = json.NewDecoder(res.Body).Decode(&elasticsearchResponse)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		hits := elasticsearchResponse.Hits.Hits
+		if len(hits) == 0 {
+			return nil
+		}
+		pipe := infrastructure.RedisClient.TxPipeline()
+		for i := range hits {
+			pipe.Set(ctx, leaderboardCountedInvoiceKey(hits[i].Source.Id), 1, leaderboardCountedTTL)
+			addInvoiceToLeaderboard(ctx, pipe, &hits[i].Source)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(hits) < leaderboardRebuildPageSize {
+			return nil
+		}
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+// Add units of invoice to all-time and daily keys, cached unions of its scopes are dropped
+func addInvoiceToLeaderboard(ctx context.Context, pipe redis.Pipeliner, invoiceView *dto.InvoiceView) {
+	day := invoiceView.CreatedAt
+	if day.IsZero() {
+		day = time.Now()
+	}
+
+	for i := range invoiceView.InvoiceDetails {
+		invoiceDetail := &invoiceView.InvoiceDetails[i]
+		for _, scope := range leaderboardScopesOfInvoiceDetail(invoiceDetail) {
+			dailyKey := leaderboardDailyKey(scope, day)
+			pipe.ZIncrBy(ctx, leaderboardAllTimeKey(scope), float64(invoiceDetail.Quantity), invoiceDetail.ProductId)
+			pipe.ZIncrBy(ctx, dailyKey, float64(invoiceDetail.Quantity), invoiceDetail.ProductId)
+			pipe.ExpireAt(ctx, dailyKey, day.UTC().Truncate(24*time.Hour).Add(leaderboardDailyKeyRetention))
+			pipe.Del(ctx, leaderboardLastDaysKey(scope, 7), leaderboardLastDaysKey(scope, 30), leaderboardTrendingKey(scope))
+		}
+	}
+}
+
+func (leaderboardService *leaderboardService) syncCreatingInvoiceLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "order-service.created-invoice")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var newInvoiceView dto.InvoiceView
+		if err := json.Unmarshal([]byte(msg.Payload), &newInvoiceView); err != nil {
+			log.Printf("Parse payload from event order-service.created-invoice failed: %s", err.Error())
+			continue
+		}
+
+		ctx := context.Background()
+		// Invoice created cancelled is never counted, so it is never taken back either
+		if newInvoiceView.Status == "CANCEL" {
+			infrastructure.RedisClient.Set(ctx, leaderboardCancelledInvoiceKey(newInvoiceView.Id), 1, leaderboardCancelledTTL)
+			continue
+		}
+
+		leaderboardService.rebuildMutex.RLock()
+		// Invoice replayed by a rebuild is counted already
+		firstCount, err := infrastructure.RedisClient.SetNX(ctx, leaderboardCountedInvoiceKey(newInvoiceView.Id), 1, leaderboardCountedTTL).Result()
+		if err != nil {
+			leaderboardService.rebuildMutex.RUnlock()
+			log.Printf("Sync creating invoice to leaderboard failed: %s", err.Error())
+			continue
+		}
+		if !firstCount {
+			leaderboardService.rebuildMutex.RUnlock()
+			continue
+		}
+
+		pipe := infrastructure.RedisClient.TxPipeline()
+		addInvoiceToLeaderboard(ctx, pipe, &newInvoiceView)
+		_, err = pipe.Exec(ctx)
+		leaderboardService.rebuildMutex.RUnlock()
+		if err != nil {
+			infrastructure.RedisClient.Del(ctx, leaderboardCountedInvoiceKey(newInvoiceView.Id))
+			log.Printf("Sync creating invoice to leaderboard failed: %s", err.Error())
+		} else {
+			log.Printf("Sync creating invoice to leaderboard successful")
+		}
+	}
+}
+
+// Units of a cancelled invoice are taken back once, marker of cancelled invoice is kept as long as daily keys so that the
+// same cancel delivered again is not counted twice
+func (leaderboardService *leaderboardService) syncUpdatingInvoiceLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "order-service.updated-invoice")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var updatedInvoiceView dto.InvoiceView
+		if err := json.Unmarshal([]byte(msg.Payload), &updatedInvoiceView); err != nil {
+			log.Printf("Parse payload from event order-service.updated-invoice failed: %s", err.Error())
+			continue
+		}
+
+		if updatedInvoiceView.Status != "CANCEL" {
+			continue
+		}
+
+		ctx := context.Background()
+		firstCancel, err := infrastructure.RedisClient.SetNX(ctx, leaderboardCancelledInvoiceKey(updatedInvoiceView.Id), 1, leaderboardCancelledTTL).Result()
+		if err != nil {
+			log.Printf("Sync cancelling invoice to leaderboard failed: %s", err.Error())
+			continue
+		}
+		if !firstCancel {
+			continue
+		}
+
+		day := updatedInvoiceView.CreatedAt
+		countedDaily := time.Since(day) < leaderboardDailyKeyRetention
+
+		leaderboardService.rebuildMutex.RLock()
+		pipe := infrastructure.RedisClient.TxPipeline()
+		for i := range updatedInvoiceView.InvoiceDetails {
+			invoiceDetail := &updatedInvoiceView.InvoiceDetails[i]
+			for _, scope := range leaderboardScopesOfInvoiceDetail(invoiceDetail) {
+				keys := []string{leaderboardAllTimeKey(scope)}
+				if countedDaily {
+					keys = append(keys, leaderboardDailyKey(scope, day))
+				}
+				for _, key := range keys {
+					pipe.ZIncrBy(ctx, key, -float64(invoiceDetail.Quantity), invoiceDetail.ProductId)
+					// Rebuild may have left invoice out already, products are never ranked by zero or negative units
+					pipe.ZRemRangeByScore(ctx, key, "-inf", "0")
+				}
+				pipe.Del(ctx, leaderboardLastDaysKey(scope, 7), leaderboardLastDaysKey(scope, 30), leaderboardTrendingKey(scope))
+			}
+		}
+		_, err = pipe.Exec(ctx)
+		leaderboardService.rebuildMutex.RUnlock()
+		if err != nil {
+			// Cancel is taken back again on next delivery or by next rebuild
+			infrastructure.RedisClient.Del(ctx, leaderboardCancelledInvoiceKey(updatedInvoiceView.Id))
+			log.Printf("Sync cancelling invoice to leaderboard failed: %s", err.Error())
+		} else {
+			log.Printf("Sync cancelling invoice to leaderboard successful")
+		}
+	}
+}
+
+func (leaderboardService *leaderboardService) syncRebuildingLeaderboardLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "elasticsearch-service.synced-invoices")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for range ch {
+		if err := leaderboardService.RebuildLeaderboard(); err != nil {
+			log.Printf("Rebuild leaderboard failed: %s", err.Error())
+		} else {
+			log.Printf("Rebuild leaderboard successful")
+		}
+	}
+}
+
+// Union of the daily keys of the last given days into a short-lived cached key, weighted by weightOf(daysAgo)
+func (leaderboardService *leaderboardService) unionDailyKeys(ctx context.Context, destination string, scope string, days int, weightOf func(daysAgo int) float64) error {
+	exists, err := infrastructure.RedisClient.Exists(ctx, destination).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 1 {
+		return nil
+	}
+
+	now := time.Now()
+	keys := make([]string, days)
+	weights := make([]float64, days)
+	for i := range days {
+		keys[i] = leaderboardDailyKey(scope, now.AddDate(0, 0, -i))
+		weights[i] = weightOf(i)
+	}
+
+	pipe := infrastructure.RedisClient.TxPipeline()
+	pipe.ZUnionStore(ctx, destination, &redis.ZStore{
+		Keys:    keys,
+		Weights: weights,
+	})
+	pipe.Expire(ctx, destination, leaderboardCacheTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (leaderboardService *leaderboardService) GetTopProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetTopProductsRequest) ([]*elasticsearchservicepb.RankedProduct, error) {
+	scope, err := leaderboardScope(reqDTO.CategoryId, reqDTO.BrandId)
+	if err != nil {
+		return nil, err
+	}
+
+	var key string
+	switch reqDTO.Period {
+	case "", "all":
+		key = leaderboardAllTimeKey(scope)
+	case "7d", "30d":
+		days := 7
+		if reqDTO.Period == "30d" {
+			days = 30
+		}
+		key = leaderboardLastDaysKey(scope, days)
+		if err := leaderboardService.unionDailyKeys(ctx, key, scope, days, func(int) float64 { return 1 }); err != nil {
+			return nil, fmt.Errorf("union daily leaderboard on redis failed: %s", err.Error())
+		}
+	default:
+		return nil, fmt.Errorf("period is not valid")
+	}
+
+	limit := int64(reqDTO.Limit)
+	if limit <= 0 {
+		limit = leaderboardDefaultLimit
+	}
+
+	members, err := infrastructure.RedisClient.ZRevRangeWithScores(ctx, key, 0, limit-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("query leaderboard from redis failed: %s", err.Error())
+	}
+
+	unitsSold := make([]int64, len(members))
+	for i, member := range members {
+		unitsSold[i] = int64(member.Score)
+	}
+
+	return leaderboardService.toRankedProducts(ctx, members, unitsSold)
+}
+
+func (leaderboardService *leaderboardService) GetTrendingProducts(ctx context.Context, reqDTO *elasticsearchservicepb.GetTrendingProductsRequest) ([]*elasticsearchservicepb.RankedProduct, error) {
+	scope, err := leaderboardScope(reqDTO.CategoryId, reqDTO.BrandId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trending score = units sold of each of the last 7 days weighted by an exponential decay,
+	// so a product selling fast right now outranks one that sold the same amount a week ago
+	trendingKey := leaderboardTrendingKey(scope)
+	if err := leaderboardService.unionDailyKeys(ctx, trendingKey, scope, leaderboardTrendingDays, func(daysAgo int) float64 {
+		return math.Pow(0.5, float64(daysAgo)/leaderboardTrendingHalfLifeDays)
+	}); err != nil {
+		return nil, fmt.Errorf("union daily leaderboard on redis failed: %s", err.Error())
+	}
+	lastDaysKey := leaderboardLastDaysKey(scope, leaderboardTrendingDays)
+	if err := leaderboardService.unionDailyKeys(ctx, lastDaysKey, scope, leaderboardTrendingDays, func(int) float64 { return 1 }); err != nil {
+		return nil, fmt.Errorf("union daily leaderboard on redis failed: %s", err.Error())
+	}
+
+	limit := int64(reqDTO.Limit)
+	if limit <= 0 {
+		limit = leaderboardDefaultLimit
+	}
+
+	members, err := infrastructure.RedisClient.ZRevRangeWithScores(ctx, trendingKey, 0, limit-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("query leaderboard from redis failed: %s", err.Error())
+	}
+
+	unitsSold := make([]int64, len(members))
+	if len(members) > 0 {
+		productIds := make([]string, len(members))
+		for i, member := range members {
+			productIds[i] = member.Member.(string)
+		}
+		scores, err := infrastructure.RedisClient.ZMScore(ctx, lastDaysKey, productIds...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("query leaderboard from redis failed: %s", err.Error())
+		}
+		for i, score := range scores {
+			unitsSold[i] = int64(score)
+		}
+	}
+
+	return leaderboardService.toRankedProducts(ctx, members, unitsSold)
+}
+
+func (leaderboardService *leaderboardService) toRankedProducts(ctx context.Context, members []redis.Z, unitsSold []int64) ([]*elasticsearchservicepb.RankedProduct, error) {
+	productIds := make([]string, len(members))
+	for i, member := range members {
+		productIds[i] = member.Member.(string)
+	}
+
+	productViewMap, err := getProductViewsByIds(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Products deleted since they were sold are skipped
+	rankedProducts := []dto.RankedProductView{}
+	for i, member := range members {
+		productView, ok := productViewMap[productIds[i]]
+		if !ok {
+			continue
+		}
+		rankedProducts = append(rankedProducts, dto.RankedProductView{
+			Product:   productView,
+			Rank:      int32(len(rankedProducts) + 1),
+			UnitsSold: unitsSold[i],
+			Score:     member.Score,
+		})
+	}
+
+	return dto.FromListRankedProductViewToListRankedProductProto(rankedProducts), nil
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"strconv"
 	"strings"
@@ -25,6 +27,8 @@ type OrderService interface {
 	SyncAllAvailableInvoices() error
 
 	GetInvoices(ctx context.Context, reqDTO *elasticsearchservicepb.GetInvoicesRequest) ([]*elasticsearchservicepb.Invoice, error)
+	GetSalesReport(ctx context.Context, reqDTO *elasticsearchservicepb.GetSalesReportRequest) (*elasticsearchservicepb.SalesReport, error)
+	GetProductSalesVelocities(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductSalesVelocitiesRequest) ([]*elasticsearchservicepb.ProductSalesVelocity, error)
 	syncCreatingInvoiceLoop()
 	syncUpdatingInvoiceLoop()
 	syncDeletingInvoiceLoop()
@@ -44,6 +48,9 @@ func NewOrderService(sync string) OrderService {
 				log.Printf("Sync all available users the first time failed: %s", err.Error())
 			} else {
 				log.Printf("Sync all available users the first time successful")
+
+				// Let leaderboard rebuild from synced invoices
+				infrastructure.RedisClient.Publish(context.Background(), "elasticsearch-service.synced-invoices", "")
 			}
 
 			infrastructure.OrderServiceGRPCConnection.Close()
@@ -91,11 +98,10 @@ func (orderService *orderService) SyncAllAvailableInvoices() error {
 		return fmt.Errorf("create invoices index on elasticsearch failed: %s", res.String())
 	}
 
-	grpcRes, err := infrastructure.OrderServiceGRPCClient.GetAllInvoices(context.Background(), &orderservicepb.GetAllInvoicesRequest{})
+	stream, err := infrastructure.OrderServiceGRPCClient.StreamAllInvoices(context.Background(), &orderservicepb.StreamAllInvoicesRequest{})
 	if err != nil {
 		return fmt.Errorf("get all invoices from order-service failed: %s", err.Error())
 	}
-	invoices := grpcRes.Invoices
 
 	hasFailure := false
 	var closeBulkIndexer error
@@ -114,30 +120,40 @@ func (orderService *orderService) SyncAllAvailableInvoices() error {
 		}
 	}()
 
-	// Add all available data on PostgreSQL to BulkIndexer
-	for _, invoice := range invoices {
-		// Convert data to JSON data
-		invoiceJSON, err := json.Marshal(dto.FromInvoiceProtoToInvoiceView(invoice))
+	// Add all available data on PostgreSQL to BulkIndexer, page by page as they are streamed
+	for {
+		grpcRes, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
 		if err != nil {
-			return err
+			return fmt.Errorf("get all invoices from order-service failed: %s", err.Error())
 		}
 
-		// Add data to BulkIndexer
-		err = indexer.Add(context.Background(), esutil.BulkIndexerItem{
-			Action:     "index",
-			DocumentID: invoice.Id,
-			Body:       bytes.NewReader(invoiceJSON),
-			OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, resp esutil.BulkIndexerResponseItem, err error) {
-				if err != nil {
-					log.Printf("Bulk index failed: %s", err.Error())
-				} else {
-					log.Printf("Index invoice with id = %s failed: %s", item.DocumentID, resp.Error.Reason)
-				}
-				hasFailure = true
-			},
-		})
-		if err != nil {
-			return err
+		for _, invoice := range grpcRes.Invoices {
+			// Convert data to JSON data
+			invoiceJSON, err := json.Marshal(dto.FromInvoiceProtoToInvoiceView(invoice))
+			if err != nil {
+				return err
+			}
+
+			// Add data to BulkIndexer
+			err = indexer.Add(context.Background(), esutil.BulkIndexerItem{
+				Action:     "index",
+				DocumentID: invoice.Id,
+				Body:       bytes.NewReader(invoiceJSON),
+				OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, resp esutil.BulkIndexerResponseItem, err error) {
+					if err != nil {
+						log.Printf("Bulk index failed: %s", err.Error())
+					} else {
+						log.Printf("Index invoice with id = %s failed: %s", item.DocumentID, resp.Error.Reason)
+					}
+					hasFailure = true
+				},
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -273,7 +289,7 @@ func (orderService *orderService) GetInvoices(ctx context.Context, reqDTO *elast
 	if len(totalAmountRange) > 0 {
 		mustConditions = append(mustConditions, map[string]interface{}{
 			"range": map[string]interface{}{
-				"total_amount_range": totalAmountRange,
+				"total_amount": totalAmountRange,
 			},
 		})
 	}
@@ -304,6 +320,76 @@ func (orderService *orderService) GetInvoices(ctx context.Context, reqDTO *elast
 		})
 	}
 
+	// Conditions on line items (nested, the same invoice detail must match all of them)
+	invoiceDetailConditions := []map[string]interface{}{}
+
+	// If filtering by product_id
+	if reqDTO.ProductId != "" {
+		invoiceDetailConditions = append(invoiceDetailConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"invoice_details.product_id.keyword": reqDTO.ProductId,
+			},
+		})
+	}
+
+	// If filtering by category_id
+	if reqDTO.CategoryId != "" {
+		invoiceDetailConditions = append(invoiceDetailConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"invoice_details.product_category_id.keyword": reqDTO.CategoryId,
+			},
+		})
+	}
+
+	// If filtering by brand_id
+	if reqDTO.BrandId != "" {
+		invoiceDetailConditions = append(invoiceDetailConditions, map[string]interface{}{
+			"term": map[string]interface{}{
+				"invoice_details.product_brand_id.keyword": reqDTO.BrandId,
+			},
+		})
+	}
+
+	// If searching by product_name
+	if reqDTO.ProductName != "" {
+		invoiceDetailConditions = append(invoiceDetailConditions, map[string]interface{}{
+			"match": map[string]interface{}{
+				"invoice_details.product_name": reqDTO.ProductName,
+			},
+		})
+	}
+
+	// If searching by category_name
+	if reqDTO.CategoryName != "" {
+		invoiceDetailConditions = append(invoiceDetailConditions, map[string]interface{}{
+			"match": map[string]interface{}{
+				"invoice_details.product_category_name": reqDTO.CategoryName,
+			},
+		})
+	}
+
+	// If searching by brand_name
+	if reqDTO.BrandName != "" {
+		invoiceDetailConditions = append(invoiceDetailConditions, map[string]interface{}{
+			"match": map[string]interface{}{
+				"invoice_details.product_brand_name": reqDTO.BrandName,
+			},
+		})
+	}
+
+	if len(invoiceDetailConditions) > 0 {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": "invoice_details",
+				"query": map[string]interface{}{
+					"bool": map[string]interface{}{
+						"must": invoiceDetailConditions,
+					},
+				},
+			},
+		})
+	}
+
 	// If not searching -> get all
 	if len(mustConditions) == 0 {
 		mustConditions = append(mustConditions, map[string]interface{}{
@@ -327,7 +413,7 @@ func (orderService *orderService) GetInvoices(ctx context.Context, reqDTO *elast
 	_sortFields := []map[string]interface{}{}
 	for _, sortField := range sortFields {
 		_sortFields = append(_sortFields, map[string]interface{}{
-			schema.UserStandardizeSortFieldMap[sortField.Field]: strings.ToLower(sortField.Direction),
+			schema.InvoiceStandardizeSortFieldMap[sortField.Field]: strings.ToLower(sortField.Direction),
 		})
 	}
 	query["sort"] = _sortFields
@@ -377,3 +463,374 @@ func (orderService *orderService) GetInvoices(ctx context.Context, reqDTO *elast
 
 	return dto.FromListInvoiceViewToListInvoiceProto(invoices), nil
 }
+
+func (orderService *orderService) GetSalesReport(ctx context.Context, reqDTO *elasticsearchservicepb.GetSalesReportRequest) (*elasticsearchservicepb.SalesReport, error) {
+	mustConditions := []map[string]interface{}{}
+	mustNotConditions := []map[string]interface{}{}
+
+	// If searching by status, otherwise cancelled invoices are left out since they were never paid
+	if reqDTO.Status != "" {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"match": map[string]interface{}{
+				"status": reqDTO.Status,
+			},
+		})
+	} else {
+		mustNotConditions = append(mustNotConditions, map[string]interface{}{
+			"match": map[string]interface{}{
+				"status": "CANCEL",
+			},
+		})
+	}
+
+	// If searching by created_at in range or partial range
+	createdAtRange := map[string]interface{}{}
+	extendedBounds := map[string]interface{}{}
+	if reqDTO.CreatedAtGte != "" {
+		createdAtRange["gte"] = reqDTO.CreatedAtGte
+		extendedBounds["min"] = reqDTO.CreatedAtGte
+	}
+	if reqDTO.CreatedAtLte != "" {
+		createdAtRange["lte"] = reqDTO.CreatedAtLte
+		extendedBounds["max"] = reqDTO.CreatedAtLte
+	}
+	if len(createdAtRange) > 0 {
+		createdAtRange["format"] = "strict_date_optional_time" // For format YYYY-MM-ddTHH:mm:ss
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"created_at": createdAtRange,
+			},
+		})
+	}
+
+	// If not searching -> get all
+	if len(mustConditions) == 0 {
+		mustConditions = append(mustConditions, map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		})
+	}
+
+	// Aggregations on line items of each bucket (units sold, optionally grouped by category or brand)
+	invoiceDetailAggs := map[string]interface{}{
+		"units": map[string]interface{}{
+			"sum": map[string]interface{}{
+				"field": "invoice_details.quantity",
+			},
+		},
+	}
+	if reqDTO.GroupBy != "" {
+		groupField, ok := schema.InvoiceDetailStandardizeGroupFieldMap[reqDTO.GroupBy]
+		if !ok {
+			return nil, fmt.Errorf("group by field is not valid")
+		}
+		invoiceDetailAggs["groups"] = map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": groupField.IdField,
+				"size":  100,
+			},
+			"aggs": map[string]interface{}{
+				"name": map[string]interface{}{
+					"terms": map[string]interface{}{
+						"field": groupField.NameField,
+						"size":  1,
+					},
+				},
+				"units": map[string]interface{}{
+					"sum": map[string]interface{}{
+						"field": "invoice_details.quantity",
+					},
+				},
+				"revenue": map[string]interface{}{
+					"sum": map[string]interface{}{
+						"field": "invoice_details.total_price",
+					},
+				},
+			},
+		}
+	}
+
+	// Setup date histogram over created_at
+	dateHistogram := map[string]interface{}{
+		"field":             "created_at",
+		"calendar_interval": reqDTO.TimeInterval,
+		"format":            "yyyy-MM-dd'T'HH:mm:ss",
+		"min_doc_count":     0,
+	}
+	if len(extendedBounds) > 0 {
+		dateHistogram["extended_bounds"] = extendedBounds
+	}
+
+	// Setup query
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":     mustConditions,
+				"must_not": mustNotConditions,
+			},
+		},
+		"aggs": map[string]interface{}{
+			"sales_over_time": map[string]interface{}{
+				"date_histogram": dateHistogram,
+				"aggs": map[string]interface{}{
+					"revenue": map[string]interface{}{
+						"sum": map[string]interface{}{
+							"field": "total_amount",
+						},
+					},
+					"invoice_details": map[string]interface{}{
+						"nested": map[string]interface{}{
+							"path": "invoice_details",
+						},
+						"aggs": invoiceDetailAggs,
+					},
+				},
+			},
+		},
+	}
+
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Search(
+		infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Search.WithIndex("invoices"),
+		infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, fmt.Errorf("some thing wrong when aggregating invoices on elasticsearch")
+	}
+
+	// Declare Elasticsearch response
+	type valueAgg struct {
+		Value float64 `json:"value"`
+	}
+	var elasticsearchResponse struct {
+		Aggregations struct {
+			SalesOverTime struct {
+				Buckets []struct {
+					KeyAsString    string   `json:"key_as_string"`
+					DocCount       int64    `json:"doc_count"`
+					Revenue        valueAgg `json:"revenue"`
+					InvoiceDetails struct {
+						Units  valueAgg `json:"units"`
+						Groups struct {
+							Buckets []struct {
+								Key  string `json:"key"`
+								Name struct {
+									Buckets []struct {
+										Key string `json:"key"`
+									} `json:"buckets"`
+								} `json:"name"`
+								Units   valueAgg `json:"units"`
+								Revenue valueAgg `json:"revenue"`
+							} `json:"buckets"`
+						} `json:"groups"`
+					} `json:"invoice_details"`
+				} `json:"buckets"`
+			} `json:"sales_over_time"`
+		} `json:"aggregations"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, err
+	}
+
+	// Extract data from Elasticsearch response
+	salesReport := &dto.SalesReport{
+		StartTime:    reqDTO.CreatedAtGte,
+		EndTime:      reqDTO.CreatedAtLte,
+		TimeInterval: reqDTO.TimeInterval,
+		Details:      []*dto.SalesReportDetail{},
+	}
+	for _, bucket := range elasticsearchResponse.Aggregations.SalesOverTime.Buckets {
+		endTime, err := utils.GenerateEndTimeString(bucket.KeyAsString, reqDTO.TimeInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		detail := &dto.SalesReportDetail{
+			StartTime: bucket.KeyAsString,
+			EndTime:   endTime,
+			Revenue:   int64(bucket.Revenue.Value),
+			Orders:    bucket.DocCount,
+			Units:     int64(bucket.InvoiceDetails.Units.Value),
+			Groups:    []*dto.SalesReportGroup{},
+		}
+		if detail.Orders > 0 {
+			detail.AverageOrderValue = float64(detail.Revenue) / float64(detail.Orders)
+		}
+		for _, groupBucket := range bucket.InvoiceDetails.Groups.Buckets {
+			group := &dto.SalesReportGroup{
+				Id:      groupBucket.Key,
+				Units:   int64(groupBucket.Units.Value),
+				Revenue: int64(groupBucket.Revenue.Value),
+			}
+			if len(groupBucket.Name.Buckets) > 0 {
+				group.Name = groupBucket.Name.Buckets[0].Key
+			}
+			detail.Groups = append(detail.Groups, group)
+		}
+
+		salesReport.TotalRevenue += detail.Revenue
+		salesReport.TotalOrders += detail.Orders
+		salesReport.TotalUnits += detail.Units
+		salesReport.Details = append(salesReport.Details, detail)
+	}
+	if salesReport.TotalOrders > 0 {
+		salesReport.AverageOrderValue = float64(salesReport.TotalRevenue) / float64(salesReport.TotalOrders)
+	}
+	if len(salesReport.Details) > 0 {
+		if salesReport.StartTime == "" {
+			salesReport.StartTime = salesReport.Details[0].StartTime
+		}
+		if salesReport.EndTime == "" {
+			salesReport.EndTime = salesReport.Details[len(salesReport.Details)-1].EndTime
+		}
+	}
+
+	return dto.FromSalesReportToSalesReportProto(salesReport), nil
+}
+
+// Units sold of products in the last days (cancelled invoices excluded), products without sales have zero velocity
+func (orderService *orderService) GetProductSalesVelocities(ctx context.Context, reqDTO *elasticsearchservicepb.GetProductSalesVelocitiesRequest) ([]*elasticsearchservicepb.ProductSalesVelocity, error) {
+	if reqDTO.Days <= 0 {
+		return nil, fmt.Errorf("days of sales velocity must be positive")
+	}
+
+	productSalesVelocities := make([]*elasticsearchservicepb.ProductSalesVelocity, len(reqDTO.ProductIds))
+	for i, productId := range reqDTO.ProductIds {
+		productSalesVelocities[i] = &elasticsearchservicepb.ProductSalesVelocity{
+			ProductId: productId,
+		}
+	}
+	if len(reqDTO.ProductIds) == 0 {
+		return productSalesVelocities, nil
+	}
+
+	// Setup query
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []map[string]interface{}{
+					{
+						"range": map[string]interface{}{
+							"created_at": map[string]interface{}{
+								"gte": fmt.Sprintf("now-%dd/d", reqDTO.Days),
+							},
+						},
+					},
+				},
+				"must_not": []map[string]interface{}{
+					{
+						"match": map[string]interface{}{
+							"status": "CANCEL",
+						},
+					},
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"invoice_details": map[string]interface{}{
+				"nested": map[string]interface{}{
+					"path": "invoice_details",
+				},
+				"aggs": map[string]interface{}{
+					"products": map[string]interface{}{
+						"filter": map[string]interface{}{
+							"terms": map[string]interface{}{
+								"invoice_details.product_id.keyword": reqDTO.ProductIds,
+							},
+						},
+						"aggs": map[string]interface{}{
+							"ids": map[string]interface{}{
+								"terms": map[string]interface{}{
+									"field": "invoice_details.product_id.keyword",
+									"size":  len(reqDTO.ProductIds),
+								},
+								"aggs": map[string]interface{}{
+									"units": map[string]interface{}{
+										"sum": map[string]interface{}{
+											"field": "invoice_details.quantity",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Search(
+		infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Search.WithIndex("invoices"),
+		infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, fmt.Errorf("some thing wrong when aggregating invoices on elasticsearch")
+	}
+
+	// Declare Elasticsearch response
+	var elasticsearchResponse struct {
+		Aggregations struct {
+			InvoiceDetails struct {
+				Products struct {
+					Ids struct {
+						Buckets []struct {
+							Key   string `json:"key"`
+							Units struct {
+								Value float64 `json:"value"`
+							} `json:"units"`
+						} `json:"buckets"`
+					} `json:"ids"`
+				} `json:"products"`
+			} `json:"invoice_details"`
+		} `json:"aggregations"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, err
+	}
+
+	// Extract data from Elasticsearch response
+	unitsSoldMap := map[string]int64{}
+	for _, bucket := range elasticsearchResponse.Aggregations.InvoiceDetails.Products.Ids.Buckets {
+		unitsSoldMap[bucket.Key] = int64(bucket.Units.Value)
+	}
+	for _, productSalesVelocity := range productSalesVelocities {
+		productSalesVelocity.UnitsSold = unitsSoldMap[productSalesVelocity.ProductId]
+		productSalesVelocity.DailyUnits = float64(productSalesVelocity.UnitsSold) / float64(reqDTO.Days)
+	}
+
+	return productSalesVelocities, nil
+}
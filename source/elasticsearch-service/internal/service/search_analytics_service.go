@@ -0,0 +1,451 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/service/elasticsearchservicepb"
+	"thanhldt060802/internal/schema"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+)
+
+type searchAnalyticsService struct {
+}
+
+type SearchAnalyticsService interface {
+	LogSearchQuery(searchQueryView *dto.SearchQueryView)
+
+	GetSearchReport(ctx context.Context, reqDTO *elasticsearchservicepb.GetSearchReportRequest) (*elasticsearchservicepb.SearchReport, error)
+	syncClickingProductLoop()
+}
+
+func NewSearchAnalyticsService() SearchAnalyticsService {
+	searchAnalyticsService := &searchAnalyticsService{}
+
+	// Search logs are not synced from any service, so indices are only created when missing and never dropped
+	if err := createIndexIfNotExists("search_queries", schema.SearchQuery); err != nil {
+		log.Printf("Create search_queries index failed: %s", err.Error())
+	}
+	if err := createIndexIfNotExists("search_clicks", schema.SearchClick); err != nil {
+		log.Printf("Create search_clicks index failed: %s", err.Error())
+	}
+
+	go searchAnalyticsService.syncClickingProductLoop()
+
+	return searchAnalyticsService
+}
+
+func createIndexIfNotExists(index string, mapping string) error {
+	// Check if index already exists on Elasticsearch
+	existsRes, err := infrastructure.ElasticsearchClient.Indices.Exists([]string{index})
+	if err != nil {
+		return err
+	}
+	defer existsRes.Body.Close()
+
+	if existsRes.StatusCode == 200 {
+		return nil
+	}
+
+	// Create index on Elasticsearch using custom schema
+	res, err := infrastructure.ElasticsearchClient.Indices.Create(index,
+		infrastructure.ElasticsearchClient.Indices.Create.WithBody(bytes.NewReader([]byte(mapping))))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("create %s index on elasticsearch failed: %s", index, res.String())
+	}
+
+	return nil
+}
+
+// Index search query in background, logging must never slow down or fail the search itself
+func (searchAnalyticsService *searchAnalyticsService) LogSearchQuery(searchQueryView *dto.SearchQueryView) {
+	go func() {
+		res, err := infrastructure.ElasticsearchClient.Index(
+			"search_queries",
+			esutil.NewJSONReader(searchQueryView),
+			infrastructure.ElasticsearchClient.Index.WithDocumentID(searchQueryView.Id),
+		)
+		if err != nil {
+			log.Printf("Log search query on Elasticsearch failed: %s", err.Error())
+			return
+		}
+		defer res.Body.Close()
+
+		if res.IsError() {
+			log.Printf("Log search query failed: %s", res.String())
+		}
+	}()
+}
+
+func (searchAnalyticsService *searchAnalyticsService) syncClickingProductLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.clicked-product")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var searchClickView dto.SearchClickView
+		if err := json.Unmarshal([]byte(msg.Payload), &searchClickView); err != nil {
+			log.Printf("Parse payload from event catalog-service.clicked-product failed: %s", err.Error())
+			continue
+		}
+
+		func() {
+			// Attribute click to the query of the search it came from, search id not logged (or logged for another user) is
+			// dropped so that click is not counted as a click of a search
+			if searchClickView.SearchId != "" {
+				getRes, err := infrastructure.ElasticsearchClient.Get("search_queries", searchClickView.SearchId)
+				if err != nil {
+					log.Printf("Get search query on Elasticsearch failed: %s", err.Error())
+					return
+				}
+				defer getRes.Body.Close()
+
+				var elasticsearchResponse struct {
+					Source dto.SearchQueryView `json:"_source"`
+				}
+				if getRes.IsError() || json.NewDecoder(getRes.Body).Decode(&elasticsearchResponse) != nil {
+					log.Printf("Search id %s of click is not logged, click is kept without search", searchClickView.SearchId)
+					searchClickView.SearchId = ""
+				} else if elasticsearchResponse.Source.UserId != searchClickView.UserId {
+					log.Printf("Search id %s of click belongs to another user, click is kept without search", searchClickView.SearchId)
+					searchClickView.SearchId = ""
+				} else {
+					searchClickView.Query = elasticsearchResponse.Source.Query
+				}
+			}
+
+			// Click of a search is stored under id of search so that one search counts at most one click
+			options := []func(*esapi.IndexRequest){}
+			if searchClickView.SearchId != "" {
+				options = append(options,
+					infrastructure.ElasticsearchClient.Index.WithDocumentID(searchClickView.SearchId),
+					infrastructure.ElasticsearchClient.Index.WithOpType("create"),
+				)
+			}
+			res, err := infrastructure.ElasticsearchClient.Index(
+				"search_clicks",
+				esutil.NewJSONReader(searchClickView),
+				options...,
+			)
+			if err != nil {
+				log.Printf("Index search click on Elasticsearch failed: %s", err.Error())
+				return
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode == http.StatusConflict {
+				log.Printf("Search %s already has a click, click is skipped", searchClickView.SearchId)
+			} else if res.IsError() {
+				log.Printf("Sync clicking product failed: %s", res.String())
+			} else {
+				log.Printf("Sync clicking product successful")
+			}
+		}()
+	}
+}
+
+func (searchAnalyticsService *searchAnalyticsService) GetSearchReport(ctx context.Context, reqDTO *elasticsearchservicepb.GetSearchReportRequest) (*elasticsearchservicepb.SearchReport, error) {
+	if reqDTO.Type != "top" && reqDTO.Type != "zero_result" && reqDTO.Type != "click_through" {
+		return nil, fmt.Errorf("type of search report is not valid")
+	}
+
+	limit := reqDTO.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	filterConditions := []map[string]interface{}{}
+
+	// If searching by created_at in range or partial range
+	createdAtRange := map[string]interface{}{}
+	if reqDTO.CreatedAtGte != "" {
+		createdAtRange["gte"] = reqDTO.CreatedAtGte
+	}
+	if reqDTO.CreatedAtLte != "" {
+		createdAtRange["lte"] = reqDTO.CreatedAtLte
+	}
+	if len(createdAtRange) > 0 {
+		createdAtRange["format"] = "strict_date_optional_time" // For format YYYY-MM-ddTHH:mm:ss
+		filterConditions = append(filterConditions, map[string]interface{}{
+			"range": map[string]interface{}{
+				"created_at": createdAtRange,
+			},
+		})
+	}
+	clickFilterConditions := filterConditions
+
+	// If only counting searches without any result
+	zeroResultCondition := map[string]interface{}{
+		"term": map[string]interface{}{
+			"result_count": 0,
+		},
+	}
+	if reqDTO.Type == "zero_result" {
+		filterConditions = append(filterConditions, zeroResultCondition)
+	}
+
+	// Setup query
+	query := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": filterConditions,
+			},
+		},
+		"aggs": map[string]interface{}{
+			"zero_result": map[string]interface{}{
+				"filter": zeroResultCondition,
+			},
+			"queries": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field":   "query.keyword",
+					"size":    limit,
+					"exclude": []string{""}, // Filter-only searches have no terms
+				},
+				"aggs": map[string]interface{}{
+					"zero_result": map[string]interface{}{
+						"filter": zeroResultCondition,
+					},
+					"average_result_count": map[string]interface{}{
+						"avg": map[string]interface{}{
+							"field": "result_count",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Search(
+		infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Search.WithIndex("search_queries"),
+		infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, fmt.Errorf("some thing wrong when aggregating search queries on elasticsearch: %s", res.String())
+	}
+
+	// Declare Elasticsearch response
+	var elasticsearchResponse struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+		Aggregations struct {
+			ZeroResult struct {
+				DocCount int64 `json:"doc_count"`
+			} `json:"zero_result"`
+			Queries struct {
+				Buckets []struct {
+					Key        string `json:"key"`
+					DocCount   int64  `json:"doc_count"`
+					ZeroResult struct {
+						DocCount int64 `json:"doc_count"`
+					} `json:"zero_result"`
+					AverageResultCount struct {
+						Value float64 `json:"value"`
+					} `json:"average_result_count"`
+				} `json:"buckets"`
+			} `json:"queries"`
+		} `json:"aggregations"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, err
+	}
+
+	// Extract data from Elasticsearch response
+	searchReport := &dto.SearchReport{
+		Type:               reqDTO.Type,
+		TotalSearches:      elasticsearchResponse.Hits.Total.Value,
+		ZeroResultSearches: elasticsearchResponse.Aggregations.ZeroResult.DocCount,
+		Queries:            []*dto.SearchQueryStat{},
+	}
+	queries := []string{}
+	searchQueryStatMap := map[string]*dto.SearchQueryStat{}
+	for _, bucket := range elasticsearchResponse.Aggregations.Queries.Buckets {
+		searchQueryStat := &dto.SearchQueryStat{
+			Query:              bucket.Key,
+			Searches:           bucket.DocCount,
+			ZeroResultSearches: bucket.ZeroResult.DocCount,
+			AverageResultCount: bucket.AverageResultCount.Value,
+		}
+		searchReport.Queries = append(searchReport.Queries, searchQueryStat)
+		queries = append(queries, bucket.Key)
+		searchQueryStatMap[bucket.Key] = searchQueryStat
+	}
+
+	// Click-through of the whole period and of each reported query
+	clickStats, err := searchAnalyticsService.getClickStats(ctx, clickFilterConditions, queries)
+	if err != nil {
+		return nil, err
+	}
+	searchReport.ClickedSearches = clickStats.clickedSearches
+	if searchReport.TotalSearches > 0 {
+		searchReport.ClickThroughRate = float64(searchReport.ClickedSearches) / float64(searchReport.TotalSearches)
+	}
+	for query, queryClickStats := range clickStats.queries {
+		searchQueryStat, ok := searchQueryStatMap[query]
+		if !ok {
+			continue
+		}
+		searchQueryStat.Clicks = queryClickStats.clicks
+		searchQueryStat.ClickedSearches = queryClickStats.clickedSearches
+		if searchQueryStat.Searches > 0 {
+			searchQueryStat.ClickThroughRate = float64(searchQueryStat.ClickedSearches) / float64(searchQueryStat.Searches)
+		}
+	}
+
+	// Lowest click-through first among the most searched queries, these are the ones needing tuning
+	if reqDTO.Type == "click_through" {
+		sort.SliceStable(searchReport.Queries, func(i, j int) bool {
+			return searchReport.Queries[i].ClickThroughRate < searchReport.Queries[j].ClickThroughRate
+		})
+	}
+
+	return dto.FromSearchReportToSearchReportProto(searchReport), nil
+}
+
+type queryClickStats struct {
+	clicks          int64
+	clickedSearches int64
+}
+
+type clickStats struct {
+	clickedSearches int64
+	queries         map[string]queryClickStats
+}
+
+func (searchAnalyticsService *searchAnalyticsService) getClickStats(ctx context.Context, filterConditions []map[string]interface{}, queries []string) (*clickStats, error) {
+	// Setup query
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": filterConditions,
+			},
+		},
+		"aggs": map[string]interface{}{
+			"clicked_searches": map[string]interface{}{
+				"cardinality": map[string]interface{}{
+					"field": "search_id",
+				},
+			},
+			"queries": map[string]interface{}{
+				"filter": map[string]interface{}{
+					"terms": map[string]interface{}{
+						"query.keyword": queries,
+					},
+				},
+				"aggs": map[string]interface{}{
+					"queries": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": "query.keyword",
+							"size":  len(queries) + 1,
+						},
+						"aggs": map[string]interface{}{
+							"clicked_searches": map[string]interface{}{
+								"cardinality": map[string]interface{}{
+									"field": "search_id",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Convert query to JSON query
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send request to Elasticsearch
+	res, err := infrastructure.ElasticsearchClient.Search(
+		infrastructure.ElasticsearchClient.Search.WithContext(ctx),
+		infrastructure.ElasticsearchClient.Search.WithIndex("search_clicks"),
+		infrastructure.ElasticsearchClient.Search.WithBody(bytes.NewReader(queryJSON)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Parse Elasticsearch response
+	if res.IsError() {
+		return nil, fmt.Errorf("some thing wrong when aggregating search clicks on elasticsearch: %s", res.String())
+	}
+
+	// Declare Elasticsearch response
+	var elasticsearchResponse struct {
+		Aggregations struct {
+			ClickedSearches struct {
+				Value int64 `json:"value"`
+			} `json:"clicked_searches"`
+			Queries struct {
+				Queries struct {
+					Buckets []struct {
+						Key             string `json:"key"`
+						DocCount        int64  `json:"doc_count"`
+						ClickedSearches struct {
+							Value int64 `json:"value"`
+						} `json:"clicked_searches"`
+					} `json:"buckets"`
+				} `json:"queries"`
+			} `json:"queries"`
+		} `json:"aggregations"`
+	}
+
+	// Unmarshal Elasticsearch response body to Elasticsearch response
+	elasticsearchResponseBody := json.NewDecoder(res.Body)
+	if err := elasticsearchResponseBody.Decode(&elasticsearchResponse); err != nil {
+		return nil, err
+	}
+
+	// Extract data from Elasticsearch response
+	result := &clickStats{
+		clickedSearches: elasticsearchResponse.Aggregations.ClickedSearches.Value,
+		queries:         map[string]queryClickStats{},
+	}
+	for _, bucket := range elasticsearchResponse.Aggregations.Queries.Queries.Buckets {
+		result.queries[bucket.Key] = queryClickStats{
+			clicks:          bucket.DocCount,
+			clickedSearches: bucket.ClickedSearches.Value,
+		}
+	}
+
+	return result, nil
+}
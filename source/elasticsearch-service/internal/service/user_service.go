@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"strings"
 	"thanhldt060802/infrastructure"
@@ -90,11 +92,10 @@ func (userService *userService) SyncAllAvailableUsers() error {
 		return fmt.Errorf("create users index on elasticsearch failed: %s", res.String())
 	}
 
-	grpcRes, err := infrastructure.UserServiceGRPCClient.GetAllUsers(context.Background(), &userservicepb.GetAllUsersRequest{})
+	stream, err := infrastructure.UserServiceGRPCClient.StreamAllUsers(context.Background(), &userservicepb.StreamAllUsersRequest{})
 	if err != nil {
 		return fmt.Errorf("get all users from user-service failed: %s", err.Error())
 	}
-	users := grpcRes.Users
 
 	hasFailure := false
 	var closeBulkIndexer error
@@ -113,30 +114,40 @@ func (userService *userService) SyncAllAvailableUsers() error {
 		}
 	}()
 
-	// Add all available data on PostgreSQL to BulkIndexer
-	for _, user := range users {
-		// Convert data to JSON data
-		userJSON, err := json.Marshal(dto.FromUserProtoToUserView(user))
+	// Add all available data on PostgreSQL to BulkIndexer, page by page as they are streamed
+	for {
+		grpcRes, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
 		if err != nil {
-			return err
+			return fmt.Errorf("get all users from user-service failed: %s", err.Error())
 		}
 
-		// Add data to BulkIndexer
-		err = indexer.Add(context.Background(), esutil.BulkIndexerItem{
-			Action:     "index",
-			DocumentID: user.Id,
-			Body:       bytes.NewReader(userJSON),
-			OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, resp esutil.BulkIndexerResponseItem, err error) {
-				if err != nil {
-					log.Printf("Bulk index failed: %s", err.Error())
-				} else {
-					log.Printf("Index user with id = %s failed: %s", item.DocumentID, resp.Error.Reason)
-				}
-				hasFailure = true
-			},
-		})
-		if err != nil {
-			return err
+		for _, user := range grpcRes.Users {
+			// Convert data to JSON data
+			userJSON, err := json.Marshal(dto.FromUserProtoToUserView(user))
+			if err != nil {
+				return err
+			}
+
+			// Add data to BulkIndexer
+			err = indexer.Add(context.Background(), esutil.BulkIndexerItem{
+				Action:     "index",
+				DocumentID: user.Id,
+				Body:       bytes.NewReader(userJSON),
+				OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, resp esutil.BulkIndexerResponseItem, err error) {
+					if err != nil {
+						log.Printf("Bulk index failed: %s", err.Error())
+					} else {
+						log.Printf("Index user with id = %s failed: %s", item.DocumentID, resp.Error.Reason)
+					}
+					hasFailure = true
+				},
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
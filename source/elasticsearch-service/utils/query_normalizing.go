@@ -0,0 +1,13 @@
+package utils
+
+import "strings"
+
+// Lowercase and collapse whitespaces of search terms, so "  Quần   A1" and "quần a1" are counted as one query
+func NormalizeSearchQuery(terms ...string) string {
+	words := []string{}
+	for _, term := range terms {
+		words = append(words, strings.Fields(strings.ToLower(term))...)
+	}
+
+	return strings.Join(words, " ")
+}
@@ -5,6 +5,7 @@ import (
 	"thanhldt060802/config"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/service/grpcimpl"
 	"thanhldt060802/internal/handler"
 	"thanhldt060802/internal/middleware"
 	"thanhldt060802/internal/repository"
@@ -39,8 +40,10 @@ func main() {
 	repository.InitTableCartItem()
 	repository.InitTableInvoice()
 	repository.InitTableInvoiceDetail()
+	repository.InitTableWishlistItem()
 	infrastructure.InitRedisClient()
 	defer infrastructure.RedisClient.Close()
+	infrastructure.InitNotifier()
 	infrastructure.InitAllServiceGRPCClients()
 	defer infrastructure.ServiceGRPCConnectionManager.CloseAll()
 
@@ -80,10 +83,19 @@ func main() {
 	jwtAuthMiddleware := middleware.NewAuthMiddleware()
 
 	cartItemRepository := repository.NewCartItemRepository()
+	invoiceRepository := repository.NewInvoiceRepository()
+	wishlistItemRepository := repository.NewWishlistItemRepository()
 
 	cartItemService := service.NewCartItemService(cartItemRepository)
+	invoiceService := service.NewInvoiceService(invoiceRepository, cartItemRepository)
+	wishlistItemService := service.NewWishlistItemService(wishlistItemRepository, cartItemRepository)
+	service.NewLowStockAlertService()
+
+	grpcimpl.StartGRPCServer(grpcimpl.NewOrderServiceGRPCImpl(invoiceService))
 
 	handler.NewCartItemHandler(api, cartItemService, jwtAuthMiddleware)
+	handler.NewInvoiceHandler(api, invoiceService, jwtAuthMiddleware)
+	handler.NewWishlistItemHandler(api, wishlistItemService, jwtAuthMiddleware)
 
 	r.Run(":" + config.AppConfig.AppPort)
 
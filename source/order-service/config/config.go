@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -28,6 +29,11 @@ type Config struct {
 	CatalogServiceGRPCPort       string
 	ElasticsearchServiceGRPCHost string
 	ElasticsearchServiceGRPCPort string
+
+	Notifier         string
+	NotifierFilePath string
+
+	BackorderReconcileInterval string
 }
 
 var AppConfig *Config
@@ -58,11 +64,28 @@ func InitConfig() {
 		CatalogServiceGRPCPort:       GetEnv("CATALOG_SERVICE_GRPC_PORT", "50050"),
 		ElasticsearchServiceGRPCHost: GetEnv("ELASTICSEARCH_SERVICE_GRPC_HOST", "localhost"),
 		ElasticsearchServiceGRPCPort: GetEnv("ELASTICSEARCH_SERVICE_GRPC_PORT", "50050"),
+
+		Notifier:         GetEnv("NOTIFIER", "log"),
+		NotifierFilePath: GetEnv("NOTIFIER_FILE_PATH", "./notifications.log"),
+
+		BackorderReconcileInterval: GetEnv("BACKORDER_RECONCILE_INTERVAL", "1m"),
+	}
+
+	if AppConfig.Notifier != "log" && AppConfig.Notifier != "file" {
+		log.Fatalf("Notifier %s is not supported (must be log or file)", AppConfig.Notifier)
+	}
+	if interval, err := time.ParseDuration(AppConfig.BackorderReconcileInterval); err != nil || interval <= 0 {
+		log.Fatalf("Evironment variable BACKORDER_RECONCILE_INTERVAL is not valid positive duration (e.g. 1m): %s", AppConfig.BackorderReconcileInterval)
 	}
 
 	log.Println("Load .env file successful")
 }
 
+func (config *Config) BackorderReconcileIntervalValue() time.Duration {
+	backorderReconcileInterval, _ := time.ParseDuration(config.BackorderReconcileInterval)
+	return backorderReconcileInterval
+}
+
 func GetEnv(key string, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
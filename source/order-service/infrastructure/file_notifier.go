@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// Append notifications as JSON lines to file
+type fileNotifier struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileNotifier(filePath string) (NotifierBackend, error) {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileNotifier{
+		file: file,
+	}, nil
+}
+
+func (fileNotifier *fileNotifier) Notify(ctx context.Context, notification *Notification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	fileNotifier.mu.Lock()
+	defer fileNotifier.mu.Unlock()
+
+	_, err = fileNotifier.file.Write(append(line, '\n'))
+	return err
+}
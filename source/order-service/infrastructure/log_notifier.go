@@ -0,0 +1,18 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+)
+
+type logNotifier struct {
+}
+
+func NewLogNotifier() NotifierBackend {
+	return &logNotifier{}
+}
+
+func (logNotifier *logNotifier) Notify(ctx context.Context, notification *Notification) error {
+	log.Printf("Notify %s to user %s: %s - %s %v", notification.Type, notification.UserId, notification.Title, notification.Message, notification.Data)
+	return nil
+}
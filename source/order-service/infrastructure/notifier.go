@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"thanhldt060802/config"
+	"time"
+)
+
+// Notification sent to user, e.g. price drop or back in stock of wishlisted product
+type Notification struct {
+	Type      string            `json:"type"`
+	UserId    string            `json:"user_id"`
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	Data      map[string]string `json:"data,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Backend which delivers notifications to users (email, push, ...), log and file backends are stand-ins
+type NotifierBackend interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+var Notifier NotifierBackend
+
+func InitNotifier() {
+	switch config.AppConfig.Notifier {
+	case "log":
+		Notifier = NewLogNotifier()
+	case "file":
+		fileNotifier, err := NewFileNotifier(config.AppConfig.NotifierFilePath)
+		if err != nil {
+			log.Fatal("Init file notifier failed: ", err)
+		}
+		Notifier = fileNotifier
+	}
+
+	log.Printf("Init %s notifier successful", config.AppConfig.Notifier)
+}
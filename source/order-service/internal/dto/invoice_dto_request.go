@@ -5,13 +5,19 @@ type GetInvoicesRequest struct {
 	Limit  int32  `query:"limit" default:"5" minimum:"1" maximum:"10" example:"10" doc:"Limit item from offset."`
 	SortBy string `query:"sort_by" default:"created_at:asc" example:"name:desc,created_at" doc:"Sort by one or more fields separated by commas. For example: sort_by=name:desc,created_at will sort by name in descending order, then by created_at in ascending order."`
 	// Filter
-	UserId string `query:"user_id" doc:"Filter by user id."`
+	UserId     string `query:"user_id" doc:"Filter by user id."`
+	ProductId  string `query:"product_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by invoices containing product id."`
+	CategoryId string `query:"category_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by invoices containing product of category id."`
+	BrandId    string `query:"brand_id" example:"aaaaaaaa-bbbb-cccc-dddddddd" doc:"Filter by invoices containing product of brand id."`
 	// Search
 	TotalAmountGTE string `query:"total_amount_gte" pattern:"^[0-9]+$" example:"100000" doc:"Search by total amount greater than or equals."`
 	TotalAmountLTE string `query:"total_amount_lte" pattern:"^[0-9]+$" example:"200000" doc:"Search by total amount less than or equals."`
 	Status         string `query:"status" example:"CREATED" enum:"CREATED,PAID,CANCEL" doc:"Search by status."`
 	CreatedAtGTE   string `query:"created_at_gte" example:"2024-01-15T00:00:00" doc:"Search by created_at greater than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
 	CreatedAtLTE   string `query:"created_at_lte" example:"2024-02-05T23:59:59" doc:"Search by created_at less than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+	ProductName    string `query:"product_name" example:"Quần A1" doc:"Search by invoices containing product name."`
+	CategoryName   string `query:"category_name" example:"Quần" doc:"Search by invoices containing product of category name."`
+	BrandName      string `query:"brand_name" example:"Gucci" doc:"Search by invoices containing product of brand name."`
 }
 
 type GetInvoiceByIdRequest struct {
@@ -22,16 +28,18 @@ type GetInvoiceByIdRequest struct {
 
 type CreateInvoiceRequest struct {
 	Body struct {
-		UserId         string          `json:"user_id" required:"true" minimum:"1" doc:"User id of invoice."`
-		InvoiceDetails []InvoiceDetail `json:"invoice_details" required:"true" doc:"Invoice details."`
+		UserId            string          `json:"user_id" required:"true" minimum:"1" doc:"User id of invoice."`
+		InvoiceDetails    []InvoiceDetail `json:"invoice_details" required:"true" doc:"Invoice details."`
+		ShippingLatitude  *float64        `json:"shipping_latitude,omitempty" minimum:"-90" maximum:"90" doc:"Latitude of shipping address, used to ship from nearest warehouse."`
+		ShippingLongitude *float64        `json:"shipping_longitude,omitempty" minimum:"-180" maximum:"180" doc:"Longitude of shipping address, used to ship from nearest warehouse."`
 	}
 }
 type InvoiceDetail struct {
 	ProductId          string `json:"product_id" required:"true" minimum:"1" doc:"Product id of invoice detail."`
-	Price              int64  `json:"product_price" required:"true" minimum:"0" doc:"Price of product of invoice detail."`
-	DiscountPercentage int32  `json:"discount_percentage" required:"true" minimum:"0" doc:"Discount percentage of product of invoice detail."`
+	Price              int64  `json:"product_price,omitempty" required:"false" minimum:"0" doc:"Ignored, price of product is set by catalog-service at checkout."`
+	DiscountPercentage int32  `json:"discount_percentage,omitempty" required:"false" minimum:"0" doc:"Ignored, discount percentage of product is set by catalog-service at checkout."`
 	Quantity           int32  `json:"quantity" required:"true" minimum:"1" doc:"Quantity of product of invoice detail."`
-	TotalPrice         int64  `json:"total_price" required:"true" minimum:"0" doc:"Total price of product of invoice detail."`
+	TotalPrice         int64  `json:"total_price,omitempty" required:"false" minimum:"0" doc:"Ignored, total price of invoice detail is computed from price set by catalog-service."`
 }
 
 type UpdateInvoiceByIdRequest struct {
@@ -60,6 +68,50 @@ type GetMyInvoicesRequest struct {
 	CreatedAtLTE   string `query:"created_at_lte" example:"2024-02-05T23:59:59" doc:"Search by created_at less than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
 }
 
+type CreateMyInvoiceRequest struct {
+	Body *struct {
+		ShippingLatitude  *float64 `json:"shipping_latitude,omitempty" minimum:"-90" maximum:"90" doc:"Latitude of shipping address, used to ship from nearest warehouse."`
+		ShippingLongitude *float64 `json:"shipping_longitude,omitempty" minimum:"-180" maximum:"180" doc:"Longitude of shipping address, used to ship from nearest warehouse."`
+	}
+}
+
 type GetMyInvoiceByIdRequest struct {
 	Id string `path:"id" required:"true" doc:"Id of invoice item."`
 }
+
+type GetSalesReportRequest struct {
+	TimeInterval string `query:"time_interval" required:"true" enum:"hour,day,week,month" example:"day" doc:"Time interval of each bucket of report."`
+	// Filter
+	GroupBy string `query:"group_by" enum:"category,brand" example:"category" doc:"Group units sold of each bucket by category or brand."`
+	// Search
+	Status       string `query:"status" example:"DONE" enum:"CREATED,PENDING,CANCEL,DONE" doc:"Search by status, all but CANCEL if empty."`
+	CreatedAtGTE string `query:"created_at_gte" example:"2024-01-15T00:00:00" doc:"Search by created_at greater than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+	CreatedAtLTE string `query:"created_at_lte" example:"2024-02-05T23:59:59" doc:"Search by created_at less than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+}
+
+type GetUnitsSoldReportRequest struct {
+	TimeInterval string `query:"time_interval" required:"true" enum:"hour,day,week,month" example:"day" doc:"Time interval of each bucket of report."`
+	GroupBy      string `query:"group_by" required:"true" enum:"category,brand" example:"category" doc:"Group units sold of each bucket by category or brand."`
+	// Search
+	Status       string `query:"status" example:"DONE" enum:"CREATED,PENDING,CANCEL,DONE" doc:"Search by status, all but CANCEL if empty."`
+	CreatedAtGTE string `query:"created_at_gte" example:"2024-01-15T00:00:00" doc:"Search by created_at greater than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+	CreatedAtLTE string `query:"created_at_lte" example:"2024-02-05T23:59:59" doc:"Search by created_at less than or equal, with format is YYYY-MM-ddTHH:mm:ss."`
+}
+
+type CheckPurchasedProductRequest struct {
+	UserId    string
+	ProductId string
+}
+
+type AllocatedBackorderEventPayload struct {
+	Id               string                                 `json:"id"`
+	InvoiceId        string                                 `json:"invoice_id"`
+	ProductId        string                                 `json:"product_id"`
+	Quantity         int32                                  `json:"quantity"`
+	StockAllocations []*AllocatedBackorderStockEventPayload `json:"stock_allocations"`
+}
+
+type AllocatedBackorderStockEventPayload struct {
+	WarehouseId string `json:"warehouse_id"`
+	Quantity    int32  `json:"quantity"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+type GetMyWishlistItemsRequest struct {
+	Offset int    `query:"offset" default:"0" minimum:"0" example:"0" doc:"Skip item by offset."`
+	Limit  int    `query:"limit" default:"10" minimum:"1" maximum:"50" example:"10" doc:"Limit item from offset."`
+	SortBy string `query:"sort_by" default:"created_at:desc" pattern:"^(created_at|id)(:(asc|desc))?(,(created_at|id)(:(asc|desc))?)*$" example:"created_at:desc" doc:"Sort by one or more fields (created_at, id) separated by commas."`
+}
+
+type CreateMyWishlistItemRequest struct {
+	Body struct {
+		ProductId string `json:"product_id" required:"true" minLength:"1" doc:"Product id of wishlist item."`
+	}
+}
+
+type DeleteMyWishlistItemByIdRequest struct {
+	Id string `path:"id" doc:"Id of wishlist item."`
+}
+
+type MoveMyWishlistItemToCartRequest struct {
+	Id string `path:"id" doc:"Id of wishlist item."`
+}
+
+// Product payload of events from catalog-service, only fields used by order-service
+type ProductEventPayload struct {
+	Id                 string `json:"id"`
+	Name               string `json:"name"`
+	Price              int64  `json:"price"`
+	DiscountPercentage int32  `json:"discount_percentage"`
+	Stock              int32  `json:"stock"`
+	ImageURL           string `json:"image_url"`
+}
+
+// Product payload of event catalog-service.low-stock, threshold is the effective one of product
+type LowStockProductEventPayload struct {
+	Id                string `json:"id"`
+	Sku               string `json:"sku"`
+	Name              string `json:"name"`
+	Stock             int32  `json:"stock"`
+	LowStockThreshold int32  `json:"low_stock_threshold"`
+}
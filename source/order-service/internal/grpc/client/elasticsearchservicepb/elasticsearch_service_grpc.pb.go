@@ -19,9 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ElasticsearchServiceGRPC_GetUsers_FullMethodName    = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetUsers"
-	ElasticsearchServiceGRPC_GetProducts_FullMethodName = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetProducts"
-	ElasticsearchServiceGRPC_GetInvoices_FullMethodName = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetInvoices"
+	ElasticsearchServiceGRPC_GetUsers_FullMethodName                  = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetUsers"
+	ElasticsearchServiceGRPC_GetProducts_FullMethodName               = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetProducts"
+	ElasticsearchServiceGRPC_GetSearchReport_FullMethodName           = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetSearchReport"
+	ElasticsearchServiceGRPC_GetProductRecommendations_FullMethodName = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetProductRecommendations"
+	ElasticsearchServiceGRPC_GetTopProducts_FullMethodName            = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetTopProducts"
+	ElasticsearchServiceGRPC_GetTrendingProducts_FullMethodName       = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetTrendingProducts"
+	ElasticsearchServiceGRPC_GetInvoices_FullMethodName               = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetInvoices"
+	ElasticsearchServiceGRPC_GetSalesReport_FullMethodName            = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetSalesReport"
+	ElasticsearchServiceGRPC_GetProductSalesVelocities_FullMethodName = "/elasticsearchservicepb.ElasticsearchServiceGRPC/GetProductSalesVelocities"
 )
 
 // ElasticsearchServiceGRPCClient is the client API for ElasticsearchServiceGRPC service.
@@ -30,7 +36,13 @@ const (
 type ElasticsearchServiceGRPCClient interface {
 	GetUsers(ctx context.Context, in *GetUsersRequest, opts ...grpc.CallOption) (*GetUsersResponse, error)
 	GetProducts(ctx context.Context, in *GetProductsRequest, opts ...grpc.CallOption) (*GetProductsResponse, error)
+	GetSearchReport(ctx context.Context, in *GetSearchReportRequest, opts ...grpc.CallOption) (*GetSearchReportResponse, error)
+	GetProductRecommendations(ctx context.Context, in *GetProductRecommendationsRequest, opts ...grpc.CallOption) (*GetProductRecommendationsResponse, error)
+	GetTopProducts(ctx context.Context, in *GetTopProductsRequest, opts ...grpc.CallOption) (*GetTopProductsResponse, error)
+	GetTrendingProducts(ctx context.Context, in *GetTrendingProductsRequest, opts ...grpc.CallOption) (*GetTrendingProductsResponse, error)
 	GetInvoices(ctx context.Context, in *GetInvoicesRequest, opts ...grpc.CallOption) (*GetInvoicesResponse, error)
+	GetSalesReport(ctx context.Context, in *GetSalesReportRequest, opts ...grpc.CallOption) (*GetSalesReportResponse, error)
+	GetProductSalesVelocities(ctx context.Context, in *GetProductSalesVelocitiesRequest, opts ...grpc.CallOption) (*GetProductSalesVelocitiesResponse, error)
 }
 
 type elasticsearchServiceGRPCClient struct {
@@ -61,6 +73,46 @@ func (c *elasticsearchServiceGRPCClient) GetProducts(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *elasticsearchServiceGRPCClient) GetSearchReport(ctx context.Context, in *GetSearchReportRequest, opts ...grpc.CallOption) (*GetSearchReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSearchReportResponse)
+	err := c.cc.Invoke(ctx, ElasticsearchServiceGRPC_GetSearchReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elasticsearchServiceGRPCClient) GetProductRecommendations(ctx context.Context, in *GetProductRecommendationsRequest, opts ...grpc.CallOption) (*GetProductRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductRecommendationsResponse)
+	err := c.cc.Invoke(ctx, ElasticsearchServiceGRPC_GetProductRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elasticsearchServiceGRPCClient) GetTopProducts(ctx context.Context, in *GetTopProductsRequest, opts ...grpc.CallOption) (*GetTopProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopProductsResponse)
+	err := c.cc.Invoke(ctx, ElasticsearchServiceGRPC_GetTopProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elasticsearchServiceGRPCClient) GetTrendingProducts(ctx context.Context, in *GetTrendingProductsRequest, opts ...grpc.CallOption) (*GetTrendingProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendingProductsResponse)
+	err := c.cc.Invoke(ctx, ElasticsearchServiceGRPC_GetTrendingProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *elasticsearchServiceGRPCClient) GetInvoices(ctx context.Context, in *GetInvoicesRequest, opts ...grpc.CallOption) (*GetInvoicesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetInvoicesResponse)
@@ -71,13 +123,39 @@ func (c *elasticsearchServiceGRPCClient) GetInvoices(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *elasticsearchServiceGRPCClient) GetSalesReport(ctx context.Context, in *GetSalesReportRequest, opts ...grpc.CallOption) (*GetSalesReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSalesReportResponse)
+	err := c.cc.Invoke(ctx, ElasticsearchServiceGRPC_GetSalesReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elasticsearchServiceGRPCClient) GetProductSalesVelocities(ctx context.Context, in *GetProductSalesVelocitiesRequest, opts ...grpc.CallOption) (*GetProductSalesVelocitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductSalesVelocitiesResponse)
+	err := c.cc.Invoke(ctx, ElasticsearchServiceGRPC_GetProductSalesVelocities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ElasticsearchServiceGRPCServer is the server API for ElasticsearchServiceGRPC service.
 // All implementations must embed UnimplementedElasticsearchServiceGRPCServer
 // for forward compatibility.
 type ElasticsearchServiceGRPCServer interface {
 	GetUsers(context.Context, *GetUsersRequest) (*GetUsersResponse, error)
 	GetProducts(context.Context, *GetProductsRequest) (*GetProductsResponse, error)
+	GetSearchReport(context.Context, *GetSearchReportRequest) (*GetSearchReportResponse, error)
+	GetProductRecommendations(context.Context, *GetProductRecommendationsRequest) (*GetProductRecommendationsResponse, error)
+	GetTopProducts(context.Context, *GetTopProductsRequest) (*GetTopProductsResponse, error)
+	GetTrendingProducts(context.Context, *GetTrendingProductsRequest) (*GetTrendingProductsResponse, error)
 	GetInvoices(context.Context, *GetInvoicesRequest) (*GetInvoicesResponse, error)
+	GetSalesReport(context.Context, *GetSalesReportRequest) (*GetSalesReportResponse, error)
+	GetProductSalesVelocities(context.Context, *GetProductSalesVelocitiesRequest) (*GetProductSalesVelocitiesResponse, error)
 	mustEmbedUnimplementedElasticsearchServiceGRPCServer()
 }
 
@@ -94,9 +172,27 @@ func (UnimplementedElasticsearchServiceGRPCServer) GetUsers(context.Context, *Ge
 func (UnimplementedElasticsearchServiceGRPCServer) GetProducts(context.Context, *GetProductsRequest) (*GetProductsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProducts not implemented")
 }
+func (UnimplementedElasticsearchServiceGRPCServer) GetSearchReport(context.Context, *GetSearchReportRequest) (*GetSearchReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSearchReport not implemented")
+}
+func (UnimplementedElasticsearchServiceGRPCServer) GetProductRecommendations(context.Context, *GetProductRecommendationsRequest) (*GetProductRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductRecommendations not implemented")
+}
+func (UnimplementedElasticsearchServiceGRPCServer) GetTopProducts(context.Context, *GetTopProductsRequest) (*GetTopProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopProducts not implemented")
+}
+func (UnimplementedElasticsearchServiceGRPCServer) GetTrendingProducts(context.Context, *GetTrendingProductsRequest) (*GetTrendingProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingProducts not implemented")
+}
 func (UnimplementedElasticsearchServiceGRPCServer) GetInvoices(context.Context, *GetInvoicesRequest) (*GetInvoicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInvoices not implemented")
 }
+func (UnimplementedElasticsearchServiceGRPCServer) GetSalesReport(context.Context, *GetSalesReportRequest) (*GetSalesReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSalesReport not implemented")
+}
+func (UnimplementedElasticsearchServiceGRPCServer) GetProductSalesVelocities(context.Context, *GetProductSalesVelocitiesRequest) (*GetProductSalesVelocitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductSalesVelocities not implemented")
+}
 func (UnimplementedElasticsearchServiceGRPCServer) mustEmbedUnimplementedElasticsearchServiceGRPCServer() {
 }
 func (UnimplementedElasticsearchServiceGRPCServer) testEmbeddedByValue() {}
@@ -155,6 +251,78 @@ func _ElasticsearchServiceGRPC_GetProducts_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ElasticsearchServiceGRPC_GetSearchReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSearchReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElasticsearchServiceGRPCServer).GetSearchReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElasticsearchServiceGRPC_GetSearchReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElasticsearchServiceGRPCServer).GetSearchReport(ctx, req.(*GetSearchReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElasticsearchServiceGRPC_GetProductRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElasticsearchServiceGRPCServer).GetProductRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElasticsearchServiceGRPC_GetProductRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElasticsearchServiceGRPCServer).GetProductRecommendations(ctx, req.(*GetProductRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElasticsearchServiceGRPC_GetTopProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElasticsearchServiceGRPCServer).GetTopProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElasticsearchServiceGRPC_GetTopProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElasticsearchServiceGRPCServer).GetTopProducts(ctx, req.(*GetTopProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElasticsearchServiceGRPC_GetTrendingProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElasticsearchServiceGRPCServer).GetTrendingProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElasticsearchServiceGRPC_GetTrendingProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElasticsearchServiceGRPCServer).GetTrendingProducts(ctx, req.(*GetTrendingProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ElasticsearchServiceGRPC_GetInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInvoicesRequest)
 	if err := dec(in); err != nil {
@@ -173,6 +341,42 @@ func _ElasticsearchServiceGRPC_GetInvoices_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ElasticsearchServiceGRPC_GetSalesReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSalesReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElasticsearchServiceGRPCServer).GetSalesReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElasticsearchServiceGRPC_GetSalesReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElasticsearchServiceGRPCServer).GetSalesReport(ctx, req.(*GetSalesReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElasticsearchServiceGRPC_GetProductSalesVelocities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductSalesVelocitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElasticsearchServiceGRPCServer).GetProductSalesVelocities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElasticsearchServiceGRPC_GetProductSalesVelocities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElasticsearchServiceGRPCServer).GetProductSalesVelocities(ctx, req.(*GetProductSalesVelocitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ElasticsearchServiceGRPC_ServiceDesc is the grpc.ServiceDesc for ElasticsearchServiceGRPC service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -188,10 +392,34 @@ var ElasticsearchServiceGRPC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProducts",
 			Handler:    _ElasticsearchServiceGRPC_GetProducts_Handler,
 		},
+		{
+			MethodName: "GetSearchReport",
+			Handler:    _ElasticsearchServiceGRPC_GetSearchReport_Handler,
+		},
+		{
+			MethodName: "GetProductRecommendations",
+			Handler:    _ElasticsearchServiceGRPC_GetProductRecommendations_Handler,
+		},
+		{
+			MethodName: "GetTopProducts",
+			Handler:    _ElasticsearchServiceGRPC_GetTopProducts_Handler,
+		},
+		{
+			MethodName: "GetTrendingProducts",
+			Handler:    _ElasticsearchServiceGRPC_GetTrendingProducts_Handler,
+		},
 		{
 			MethodName: "GetInvoices",
 			Handler:    _ElasticsearchServiceGRPC_GetInvoices_Handler,
 		},
+		{
+			MethodName: "GetSalesReport",
+			Handler:    _ElasticsearchServiceGRPC_GetSalesReport_Handler,
+		},
+		{
+			MethodName: "GetProductSalesVelocities",
+			Handler:    _ElasticsearchServiceGRPC_GetProductSalesVelocities_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "elasticsearch_service.proto",
@@ -22,26 +22,26 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type GetAllUsersRequest struct {
+type StreamAllUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllUsersRequest) Reset() {
-	*x = GetAllUsersRequest{}
+func (x *StreamAllUsersRequest) Reset() {
+	*x = StreamAllUsersRequest{}
 	mi := &file_user_service_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllUsersRequest) String() string {
+func (x *StreamAllUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllUsersRequest) ProtoMessage() {}
+func (*StreamAllUsersRequest) ProtoMessage() {}
 
-func (x *GetAllUsersRequest) ProtoReflect() protoreflect.Message {
+func (x *StreamAllUsersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -53,8 +53,8 @@ func (x *GetAllUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllUsersRequest.ProtoReflect.Descriptor instead.
-func (*GetAllUsersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamAllUsersRequest.ProtoReflect.Descriptor instead.
+func (*StreamAllUsersRequest) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{0}
 }
 
@@ -102,27 +102,27 @@ func (x *GetUserByIdRequest) GetId() string {
 	return ""
 }
 
-type GetAllUsersResponse struct {
+type GetBodyMeasurementsByUserIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllUsersResponse) Reset() {
-	*x = GetAllUsersResponse{}
+func (x *GetBodyMeasurementsByUserIdRequest) Reset() {
+	*x = GetBodyMeasurementsByUserIdRequest{}
 	mi := &file_user_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllUsersResponse) String() string {
+func (x *GetBodyMeasurementsByUserIdRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllUsersResponse) ProtoMessage() {}
+func (*GetBodyMeasurementsByUserIdRequest) ProtoMessage() {}
 
-func (x *GetAllUsersResponse) ProtoReflect() protoreflect.Message {
+func (x *GetBodyMeasurementsByUserIdRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -134,12 +134,101 @@ func (x *GetAllUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllUsersResponse.ProtoReflect.Descriptor instead.
-func (*GetAllUsersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetBodyMeasurementsByUserIdRequest.ProtoReflect.Descriptor instead.
+func (*GetBodyMeasurementsByUserIdRequest) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetAllUsersResponse) GetUsers() []*User {
+func (x *GetBodyMeasurementsByUserIdRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUsersByRoleNamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleNames     []string               `protobuf:"bytes,1,rep,name=role_names,json=roleNames,proto3" json:"role_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByRoleNamesRequest) Reset() {
+	*x = GetUsersByRoleNamesRequest{}
+	mi := &file_user_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByRoleNamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByRoleNamesRequest) ProtoMessage() {}
+
+func (x *GetUsersByRoleNamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByRoleNamesRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByRoleNamesRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUsersByRoleNamesRequest) GetRoleNames() []string {
+	if x != nil {
+		return x.RoleNames
+	}
+	return nil
+}
+
+// One page of users, pages are streamed in order of user id
+type StreamAllUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAllUsersResponse) Reset() {
+	*x = StreamAllUsersResponse{}
+	mi := &file_user_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAllUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAllUsersResponse) ProtoMessage() {}
+
+func (x *StreamAllUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAllUsersResponse.ProtoReflect.Descriptor instead.
+func (*StreamAllUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamAllUsersResponse) GetUsers() []*User {
 	if x != nil {
 		return x.Users
 	}
@@ -155,7 +244,7 @@ type GetUserByIdResponse struct {
 
 func (x *GetUserByIdResponse) Reset() {
 	*x = GetUserByIdResponse{}
-	mi := &file_user_service_proto_msgTypes[3]
+	mi := &file_user_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -167,7 +256,7 @@ func (x *GetUserByIdResponse) String() string {
 func (*GetUserByIdResponse) ProtoMessage() {}
 
 func (x *GetUserByIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[3]
+	mi := &file_user_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -180,7 +269,7 @@ func (x *GetUserByIdResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserByIdResponse.ProtoReflect.Descriptor instead.
 func (*GetUserByIdResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{3}
+	return file_user_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetUserByIdResponse) GetUser() *User {
@@ -190,6 +279,95 @@ func (x *GetUserByIdResponse) GetUser() *User {
 	return nil
 }
 
+// Body measurements are empty when user has not given any
+type GetBodyMeasurementsByUserIdResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BodyMeasurements *BodyMeasurements      `protobuf:"bytes,1,opt,name=body_measurements,json=bodyMeasurements,proto3" json:"body_measurements,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetBodyMeasurementsByUserIdResponse) Reset() {
+	*x = GetBodyMeasurementsByUserIdResponse{}
+	mi := &file_user_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBodyMeasurementsByUserIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBodyMeasurementsByUserIdResponse) ProtoMessage() {}
+
+func (x *GetBodyMeasurementsByUserIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBodyMeasurementsByUserIdResponse.ProtoReflect.Descriptor instead.
+func (*GetBodyMeasurementsByUserIdResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBodyMeasurementsByUserIdResponse) GetBodyMeasurements() *BodyMeasurements {
+	if x != nil {
+		return x.BodyMeasurements
+	}
+	return nil
+}
+
+type GetUsersByRoleNamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByRoleNamesResponse) Reset() {
+	*x = GetUsersByRoleNamesResponse{}
+	mi := &file_user_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByRoleNamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByRoleNamesResponse) ProtoMessage() {}
+
+func (x *GetUsersByRoleNamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByRoleNamesResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersByRoleNamesResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUsersByRoleNamesResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -206,7 +384,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_user_service_proto_msgTypes[4]
+	mi := &file_user_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -218,7 +396,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[4]
+	mi := &file_user_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +409,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{4}
+	return file_user_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *User) GetId() string {
@@ -290,18 +468,112 @@ func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+// Body measurements in cm, 0 is not given
+type BodyMeasurements struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        float64                `protobuf:"fixed64,1,opt,name=height,proto3" json:"height,omitempty"`
+	Chest         float64                `protobuf:"fixed64,2,opt,name=chest,proto3" json:"chest,omitempty"`
+	Waist         float64                `protobuf:"fixed64,3,opt,name=waist,proto3" json:"waist,omitempty"`
+	Hip           float64                `protobuf:"fixed64,4,opt,name=hip,proto3" json:"hip,omitempty"`
+	Inseam        float64                `protobuf:"fixed64,5,opt,name=inseam,proto3" json:"inseam,omitempty"`
+	FootLength    float64                `protobuf:"fixed64,6,opt,name=foot_length,json=footLength,proto3" json:"foot_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BodyMeasurements) Reset() {
+	*x = BodyMeasurements{}
+	mi := &file_user_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BodyMeasurements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyMeasurements) ProtoMessage() {}
+
+func (x *BodyMeasurements) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyMeasurements.ProtoReflect.Descriptor instead.
+func (*BodyMeasurements) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BodyMeasurements) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BodyMeasurements) GetChest() float64 {
+	if x != nil {
+		return x.Chest
+	}
+	return 0
+}
+
+func (x *BodyMeasurements) GetWaist() float64 {
+	if x != nil {
+		return x.Waist
+	}
+	return 0
+}
+
+func (x *BodyMeasurements) GetHip() float64 {
+	if x != nil {
+		return x.Hip
+	}
+	return 0
+}
+
+func (x *BodyMeasurements) GetInseam() float64 {
+	if x != nil {
+		return x.Inseam
+	}
+	return 0
+}
+
+func (x *BodyMeasurements) GetFootLength() float64 {
+	if x != nil {
+		return x.FootLength
+	}
+	return 0
+}
+
 var File_user_service_proto protoreflect.FileDescriptor
 
 const file_user_service_proto_rawDesc = "" +
 	"\n" +
-	"\x12user_service.proto\x12\ruserservicepb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x14\n" +
-	"\x12GetAllUsersRequest\"$\n" +
+	"\x12user_service.proto\x12\ruserservicepb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x17\n" +
+	"\x15StreamAllUsersRequest\"$\n" +
 	"\x12GetUserByIdRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
-	"\x13GetAllUsersResponse\x12)\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\"GetBodyMeasurementsByUserIdRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\";\n" +
+	"\x1aGetUsersByRoleNamesRequest\x12\x1d\n" +
+	"\n" +
+	"role_names\x18\x01 \x03(\tR\troleNames\"C\n" +
+	"\x16StreamAllUsersResponse\x12)\n" +
 	"\x05users\x18\x01 \x03(\v2\x13.userservicepb.UserR\x05users\">\n" +
 	"\x13GetUserByIdResponse\x12'\n" +
-	"\x04user\x18\x01 \x01(\v2\x13.userservicepb.UserR\x04user\"\x92\x02\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.userservicepb.UserR\x04user\"s\n" +
+	"#GetBodyMeasurementsByUserIdResponse\x12L\n" +
+	"\x11body_measurements\x18\x01 \x01(\v2\x1f.userservicepb.BodyMeasurementsR\x10bodyMeasurements\"H\n" +
+	"\x1bGetUsersByRoleNamesResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.userservicepb.UserR\x05users\"\x92\x02\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x14\n" +
@@ -312,10 +584,20 @@ const file_user_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xbd\x01\n" +
-	"\x0fUserServiceGRPC\x12T\n" +
-	"\vGetAllUsers\x12!.userservicepb.GetAllUsersRequest\x1a\".userservicepb.GetAllUsersResponse\x12T\n" +
-	"\vGetUserById\x12!.userservicepb.GetUserByIdRequest\x1a\".userservicepb.GetUserByIdResponseB\x10Z\x0euserservicepb/b\x06proto3"
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa1\x01\n" +
+	"\x10BodyMeasurements\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x01R\x06height\x12\x14\n" +
+	"\x05chest\x18\x02 \x01(\x01R\x05chest\x12\x14\n" +
+	"\x05waist\x18\x03 \x01(\x01R\x05waist\x12\x10\n" +
+	"\x03hip\x18\x04 \x01(\x01R\x03hip\x12\x16\n" +
+	"\x06inseam\x18\x05 \x01(\x01R\x06inseam\x12\x1f\n" +
+	"\vfoot_length\x18\x06 \x01(\x01R\n" +
+	"footLength2\xbd\x03\n" +
+	"\x0fUserServiceGRPC\x12_\n" +
+	"\x0eStreamAllUsers\x12$.userservicepb.StreamAllUsersRequest\x1a%.userservicepb.StreamAllUsersResponse0\x01\x12T\n" +
+	"\vGetUserById\x12!.userservicepb.GetUserByIdRequest\x1a\".userservicepb.GetUserByIdResponse\x12\x84\x01\n" +
+	"\x1bGetBodyMeasurementsByUserId\x121.userservicepb.GetBodyMeasurementsByUserIdRequest\x1a2.userservicepb.GetBodyMeasurementsByUserIdResponse\x12l\n" +
+	"\x13GetUsersByRoleNames\x12).userservicepb.GetUsersByRoleNamesRequest\x1a*.userservicepb.GetUsersByRoleNamesResponseB\x10Z\x0euserservicepb/b\x06proto3"
 
 var (
 	file_user_service_proto_rawDescOnce sync.Once
@@ -329,29 +611,40 @@ func file_user_service_proto_rawDescGZIP() []byte {
 	return file_user_service_proto_rawDescData
 }
 
-var file_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_user_service_proto_goTypes = []any{
-	(*GetAllUsersRequest)(nil),    // 0: userservicepb.GetAllUsersRequest
-	(*GetUserByIdRequest)(nil),    // 1: userservicepb.GetUserByIdRequest
-	(*GetAllUsersResponse)(nil),   // 2: userservicepb.GetAllUsersResponse
-	(*GetUserByIdResponse)(nil),   // 3: userservicepb.GetUserByIdResponse
-	(*User)(nil),                  // 4: userservicepb.User
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*StreamAllUsersRequest)(nil),               // 0: userservicepb.StreamAllUsersRequest
+	(*GetUserByIdRequest)(nil),                  // 1: userservicepb.GetUserByIdRequest
+	(*GetBodyMeasurementsByUserIdRequest)(nil),  // 2: userservicepb.GetBodyMeasurementsByUserIdRequest
+	(*GetUsersByRoleNamesRequest)(nil),          // 3: userservicepb.GetUsersByRoleNamesRequest
+	(*StreamAllUsersResponse)(nil),              // 4: userservicepb.StreamAllUsersResponse
+	(*GetUserByIdResponse)(nil),                 // 5: userservicepb.GetUserByIdResponse
+	(*GetBodyMeasurementsByUserIdResponse)(nil), // 6: userservicepb.GetBodyMeasurementsByUserIdResponse
+	(*GetUsersByRoleNamesResponse)(nil),         // 7: userservicepb.GetUsersByRoleNamesResponse
+	(*User)(nil),                                // 8: userservicepb.User
+	(*BodyMeasurements)(nil),                    // 9: userservicepb.BodyMeasurements
+	(*timestamppb.Timestamp)(nil),               // 10: google.protobuf.Timestamp
 }
 var file_user_service_proto_depIdxs = []int32{
-	4, // 0: userservicepb.GetAllUsersResponse.users:type_name -> userservicepb.User
-	4, // 1: userservicepb.GetUserByIdResponse.user:type_name -> userservicepb.User
-	5, // 2: userservicepb.User.created_at:type_name -> google.protobuf.Timestamp
-	5, // 3: userservicepb.User.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 4: userservicepb.UserServiceGRPC.GetAllUsers:input_type -> userservicepb.GetAllUsersRequest
-	1, // 5: userservicepb.UserServiceGRPC.GetUserById:input_type -> userservicepb.GetUserByIdRequest
-	2, // 6: userservicepb.UserServiceGRPC.GetAllUsers:output_type -> userservicepb.GetAllUsersResponse
-	3, // 7: userservicepb.UserServiceGRPC.GetUserById:output_type -> userservicepb.GetUserByIdResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	8,  // 0: userservicepb.StreamAllUsersResponse.users:type_name -> userservicepb.User
+	8,  // 1: userservicepb.GetUserByIdResponse.user:type_name -> userservicepb.User
+	9,  // 2: userservicepb.GetBodyMeasurementsByUserIdResponse.body_measurements:type_name -> userservicepb.BodyMeasurements
+	8,  // 3: userservicepb.GetUsersByRoleNamesResponse.users:type_name -> userservicepb.User
+	10, // 4: userservicepb.User.created_at:type_name -> google.protobuf.Timestamp
+	10, // 5: userservicepb.User.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: userservicepb.UserServiceGRPC.StreamAllUsers:input_type -> userservicepb.StreamAllUsersRequest
+	1,  // 7: userservicepb.UserServiceGRPC.GetUserById:input_type -> userservicepb.GetUserByIdRequest
+	2,  // 8: userservicepb.UserServiceGRPC.GetBodyMeasurementsByUserId:input_type -> userservicepb.GetBodyMeasurementsByUserIdRequest
+	3,  // 9: userservicepb.UserServiceGRPC.GetUsersByRoleNames:input_type -> userservicepb.GetUsersByRoleNamesRequest
+	4,  // 10: userservicepb.UserServiceGRPC.StreamAllUsers:output_type -> userservicepb.StreamAllUsersResponse
+	5,  // 11: userservicepb.UserServiceGRPC.GetUserById:output_type -> userservicepb.GetUserByIdResponse
+	6,  // 12: userservicepb.UserServiceGRPC.GetBodyMeasurementsByUserId:output_type -> userservicepb.GetBodyMeasurementsByUserIdResponse
+	7,  // 13: userservicepb.UserServiceGRPC.GetUsersByRoleNames:output_type -> userservicepb.GetUsersByRoleNamesResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_user_service_proto_init() }
@@ -365,7 +658,7 @@ func file_user_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_service_proto_rawDesc), len(file_user_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
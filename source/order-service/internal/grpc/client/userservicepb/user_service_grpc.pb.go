@@ -19,16 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserServiceGRPC_GetAllUsers_FullMethodName = "/userservicepb.UserServiceGRPC/GetAllUsers"
-	UserServiceGRPC_GetUserById_FullMethodName = "/userservicepb.UserServiceGRPC/GetUserById"
+	UserServiceGRPC_StreamAllUsers_FullMethodName              = "/userservicepb.UserServiceGRPC/StreamAllUsers"
+	UserServiceGRPC_GetUserById_FullMethodName                 = "/userservicepb.UserServiceGRPC/GetUserById"
+	UserServiceGRPC_GetBodyMeasurementsByUserId_FullMethodName = "/userservicepb.UserServiceGRPC/GetBodyMeasurementsByUserId"
+	UserServiceGRPC_GetUsersByRoleNames_FullMethodName         = "/userservicepb.UserServiceGRPC/GetUsersByRoleNames"
 )
 
 // UserServiceGRPCClient is the client API for UserServiceGRPC service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceGRPCClient interface {
-	GetAllUsers(ctx context.Context, in *GetAllUsersRequest, opts ...grpc.CallOption) (*GetAllUsersResponse, error)
+	StreamAllUsers(ctx context.Context, in *StreamAllUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllUsersResponse], error)
 	GetUserById(ctx context.Context, in *GetUserByIdRequest, opts ...grpc.CallOption) (*GetUserByIdResponse, error)
+	GetBodyMeasurementsByUserId(ctx context.Context, in *GetBodyMeasurementsByUserIdRequest, opts ...grpc.CallOption) (*GetBodyMeasurementsByUserIdResponse, error)
+	GetUsersByRoleNames(ctx context.Context, in *GetUsersByRoleNamesRequest, opts ...grpc.CallOption) (*GetUsersByRoleNamesResponse, error)
 }
 
 type userServiceGRPCClient struct {
@@ -39,16 +43,25 @@ func NewUserServiceGRPCClient(cc grpc.ClientConnInterface) UserServiceGRPCClient
 	return &userServiceGRPCClient{cc}
 }
 
-func (c *userServiceGRPCClient) GetAllUsers(ctx context.Context, in *GetAllUsersRequest, opts ...grpc.CallOption) (*GetAllUsersResponse, error) {
+func (c *userServiceGRPCClient) StreamAllUsers(ctx context.Context, in *StreamAllUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAllUsersResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetAllUsersResponse)
-	err := c.cc.Invoke(ctx, UserServiceGRPC_GetAllUsers_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &UserServiceGRPC_ServiceDesc.Streams[0], UserServiceGRPC_StreamAllUsers_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[StreamAllUsersRequest, StreamAllUsersResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserServiceGRPC_StreamAllUsersClient = grpc.ServerStreamingClient[StreamAllUsersResponse]
+
 func (c *userServiceGRPCClient) GetUserById(ctx context.Context, in *GetUserByIdRequest, opts ...grpc.CallOption) (*GetUserByIdResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserByIdResponse)
@@ -59,12 +72,34 @@ func (c *userServiceGRPCClient) GetUserById(ctx context.Context, in *GetUserById
 	return out, nil
 }
 
+func (c *userServiceGRPCClient) GetBodyMeasurementsByUserId(ctx context.Context, in *GetBodyMeasurementsByUserIdRequest, opts ...grpc.CallOption) (*GetBodyMeasurementsByUserIdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBodyMeasurementsByUserIdResponse)
+	err := c.cc.Invoke(ctx, UserServiceGRPC_GetBodyMeasurementsByUserId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceGRPCClient) GetUsersByRoleNames(ctx context.Context, in *GetUsersByRoleNamesRequest, opts ...grpc.CallOption) (*GetUsersByRoleNamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsersByRoleNamesResponse)
+	err := c.cc.Invoke(ctx, UserServiceGRPC_GetUsersByRoleNames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceGRPCServer is the server API for UserServiceGRPC service.
 // All implementations must embed UnimplementedUserServiceGRPCServer
 // for forward compatibility.
 type UserServiceGRPCServer interface {
-	GetAllUsers(context.Context, *GetAllUsersRequest) (*GetAllUsersResponse, error)
+	StreamAllUsers(*StreamAllUsersRequest, grpc.ServerStreamingServer[StreamAllUsersResponse]) error
 	GetUserById(context.Context, *GetUserByIdRequest) (*GetUserByIdResponse, error)
+	GetBodyMeasurementsByUserId(context.Context, *GetBodyMeasurementsByUserIdRequest) (*GetBodyMeasurementsByUserIdResponse, error)
+	GetUsersByRoleNames(context.Context, *GetUsersByRoleNamesRequest) (*GetUsersByRoleNamesResponse, error)
 	mustEmbedUnimplementedUserServiceGRPCServer()
 }
 
@@ -75,12 +110,18 @@ type UserServiceGRPCServer interface {
 // pointer dereference when methods are called.
 type UnimplementedUserServiceGRPCServer struct{}
 
-func (UnimplementedUserServiceGRPCServer) GetAllUsers(context.Context, *GetAllUsersRequest) (*GetAllUsersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllUsers not implemented")
+func (UnimplementedUserServiceGRPCServer) StreamAllUsers(*StreamAllUsersRequest, grpc.ServerStreamingServer[StreamAllUsersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAllUsers not implemented")
 }
 func (UnimplementedUserServiceGRPCServer) GetUserById(context.Context, *GetUserByIdRequest) (*GetUserByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserById not implemented")
 }
+func (UnimplementedUserServiceGRPCServer) GetBodyMeasurementsByUserId(context.Context, *GetBodyMeasurementsByUserIdRequest) (*GetBodyMeasurementsByUserIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBodyMeasurementsByUserId not implemented")
+}
+func (UnimplementedUserServiceGRPCServer) GetUsersByRoleNames(context.Context, *GetUsersByRoleNamesRequest) (*GetUsersByRoleNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByRoleNames not implemented")
+}
 func (UnimplementedUserServiceGRPCServer) mustEmbedUnimplementedUserServiceGRPCServer() {}
 func (UnimplementedUserServiceGRPCServer) testEmbeddedByValue()                         {}
 
@@ -102,38 +143,67 @@ func RegisterUserServiceGRPCServer(s grpc.ServiceRegistrar, srv UserServiceGRPCS
 	s.RegisterService(&UserServiceGRPC_ServiceDesc, srv)
 }
 
-func _UserServiceGRPC_GetAllUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllUsersRequest)
+func _UserServiceGRPC_StreamAllUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAllUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceGRPCServer).StreamAllUsers(m, &grpc.GenericServerStream[StreamAllUsersRequest, StreamAllUsersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserServiceGRPC_StreamAllUsersServer = grpc.ServerStreamingServer[StreamAllUsersResponse]
+
+func _UserServiceGRPC_GetUserById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceGRPCServer).GetUserById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserServiceGRPC_GetUserById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceGRPCServer).GetUserById(ctx, req.(*GetUserByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserServiceGRPC_GetBodyMeasurementsByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBodyMeasurementsByUserIdRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceGRPCServer).GetAllUsers(ctx, in)
+		return srv.(UserServiceGRPCServer).GetBodyMeasurementsByUserId(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserServiceGRPC_GetAllUsers_FullMethodName,
+		FullMethod: UserServiceGRPC_GetBodyMeasurementsByUserId_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceGRPCServer).GetAllUsers(ctx, req.(*GetAllUsersRequest))
+		return srv.(UserServiceGRPCServer).GetBodyMeasurementsByUserId(ctx, req.(*GetBodyMeasurementsByUserIdRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserServiceGRPC_GetUserById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserByIdRequest)
+func _UserServiceGRPC_GetUsersByRoleNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersByRoleNamesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceGRPCServer).GetUserById(ctx, in)
+		return srv.(UserServiceGRPCServer).GetUsersByRoleNames(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserServiceGRPC_GetUserById_FullMethodName,
+		FullMethod: UserServiceGRPC_GetUsersByRoleNames_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceGRPCServer).GetUserById(ctx, req.(*GetUserByIdRequest))
+		return srv.(UserServiceGRPCServer).GetUsersByRoleNames(ctx, req.(*GetUsersByRoleNamesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -145,15 +215,25 @@ var UserServiceGRPC_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "userservicepb.UserServiceGRPC",
 	HandlerType: (*UserServiceGRPCServer)(nil),
 	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetAllUsers",
-			Handler:    _UserServiceGRPC_GetAllUsers_Handler,
-		},
 		{
 			MethodName: "GetUserById",
 			Handler:    _UserServiceGRPC_GetUserById_Handler,
 		},
+		{
+			MethodName: "GetBodyMeasurementsByUserId",
+			Handler:    _UserServiceGRPC_GetBodyMeasurementsByUserId_Handler,
+		},
+		{
+			MethodName: "GetUsersByRoleNames",
+			Handler:    _UserServiceGRPC_GetUsersByRoleNames_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAllUsers",
+			Handler:       _UserServiceGRPC_StreamAllUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user_service.proto",
 }
@@ -2,9 +2,12 @@ package grpcimpl
 
 import (
 	"context"
+	"thanhldt060802/internal/dto"
 	"thanhldt060802/internal/grpc/service/orderservicepb"
 	"thanhldt060802/internal/model"
 	"thanhldt060802/internal/service"
+
+	"google.golang.org/grpc"
 )
 
 type OrderServiceGRPCImpl struct {
@@ -16,13 +19,27 @@ func NewOrderServiceGRPCImpl(invoiceService service.InvoiceService) *OrderServic
 	return &OrderServiceGRPCImpl{invoiceService: invoiceService}
 }
 
-func (orderServiceGRPC *OrderServiceGRPCImpl) GetAllInvoices(ctx context.Context, req *orderservicepb.GetAllInvoicesRequest) (*orderservicepb.GetAllInvoicesResponse, error) {
-	invoices, err := orderServiceGRPC.invoiceService.GetAllInvoices(ctx)
+func (orderServiceGRPC *OrderServiceGRPCImpl) StreamAllInvoices(req *orderservicepb.StreamAllInvoicesRequest, stream grpc.ServerStreamingServer[orderservicepb.StreamAllInvoicesResponse]) error {
+	return orderServiceGRPC.invoiceService.StreamAllInvoices(stream.Context(), func(invoices []*model.InvoiceView) error {
+		res := &orderservicepb.StreamAllInvoicesResponse{}
+		res.Invoices = model.FromListInvoiceViewToListInvoiceProto(invoices)
+		return stream.Send(res)
+	})
+}
+
+func (orderServiceGRPC *OrderServiceGRPCImpl) CheckPurchasedProduct(ctx context.Context, req *orderservicepb.CheckPurchasedProductRequest) (*orderservicepb.CheckPurchasedProductResponse, error) {
+	convertReqDTO := &dto.CheckPurchasedProductRequest{
+		UserId:    req.UserId,
+		ProductId: req.ProductId,
+	}
+
+	invoiceId, err := orderServiceGRPC.invoiceService.CheckPurchasedProduct(ctx, convertReqDTO)
 	if err != nil {
 		return nil, err
 	}
 
-	res := &orderservicepb.GetAllInvoicesResponse{}
-	res.Invoices = model.FromListInvoiceViewToListInvoiceProto(invoices)
+	res := &orderservicepb.CheckPurchasedProductResponse{}
+	res.Purchased = invoiceId != ""
+	res.InvoiceId = invoiceId
 	return res, nil
 }
@@ -85,8 +85,8 @@ func NewCartItemHandler(api huma.API, cartItemService service.CartItemService, j
 	// Update my cart item by id
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodPut,
-		Path:        "/cart-items/id/{id}",
-		Summary:     "/cart-items/id/{id}",
+		Path:        "/my-cart-items/id/{id}",
+		Summary:     "/my-cart-items/id/{id}",
 		Description: "Update my cart item by id.",
 		Tags:        []string{"Cart Item"},
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
@@ -72,6 +72,26 @@ func NewInvoiceHandler(api huma.API, invoiceService service.InvoiceService, jwtA
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
 	}, invoiceHandler.DeleteInvoiceById)
 
+	// Get sales report
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/invoices/reports/sales",
+		Summary:     "/invoices/reports/sales",
+		Description: "Get revenue, order count and average order value over time buckets, cancelled invoices are left out unless searching by status CANCEL.",
+		Tags:        []string{"Invoice"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, invoiceHandler.GetSalesReport)
+
+	// Get units sold report
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/invoices/reports/units-sold",
+		Summary:     "/invoices/reports/units-sold",
+		Description: "Get units sold per category or brand over time buckets, cancelled invoices are left out unless searching by status CANCEL.",
+		Tags:        []string{"Invoice"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication, jwtAuthMiddleware.RequireAdmin},
+	}, invoiceHandler.GetUnitsSoldReport)
+
 	// Get my invoices
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodGet,
@@ -217,6 +237,49 @@ func (invoiceHandler *InvoiceHandler) DeleteInvoiceById(ctx context.Context, req
 	return res, nil
 }
 
+func (invoiceHandler *InvoiceHandler) GetSalesReport(ctx context.Context, reqDTO *dto.GetSalesReportRequest) (*dto.BodyResponse[*model.SalesReportView], error) {
+	salesReport, err := invoiceHandler.invoiceService.GetSalesReport(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get sales report failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SalesReportView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get sales report successful"
+	res.Body.Data = salesReport
+	return res, nil
+}
+
+func (invoiceHandler *InvoiceHandler) GetUnitsSoldReport(ctx context.Context, reqDTO *dto.GetUnitsSoldReportRequest) (*dto.BodyResponse[*model.SalesReportView], error) {
+	convertReqDTO := &dto.GetSalesReportRequest{}
+	convertReqDTO.TimeInterval = reqDTO.TimeInterval
+	convertReqDTO.GroupBy = reqDTO.GroupBy
+	convertReqDTO.Status = reqDTO.Status
+	convertReqDTO.CreatedAtGTE = reqDTO.CreatedAtGTE
+	convertReqDTO.CreatedAtLTE = reqDTO.CreatedAtLTE
+
+	salesReport, err := invoiceHandler.invoiceService.GetSalesReport(ctx, convertReqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get units sold report failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.SalesReportView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get units sold report successful"
+	res.Body.Data = salesReport
+	return res, nil
+}
+
 func (invoiceHandler *InvoiceHandler) GetMyInvoices(ctx context.Context, reqDTO *dto.GetMyInvoicesRequest) (*dto.PaginationBodyResponseList[*model.InvoiceView], error) {
 	convertReqDTO := &dto.GetInvoicesRequest{}
 	convertReqDTO.Offset = reqDTO.Offset
@@ -278,10 +341,14 @@ func (invoiceHandler *InvoiceHandler) GetMyInvoiceById(ctx context.Context, reqD
 	return res, nil
 }
 
-func (invoiceHandler *InvoiceHandler) CreateMyInvoice(ctx context.Context, _ *struct{}) (*dto.SuccessResponse, error) {
+func (invoiceHandler *InvoiceHandler) CreateMyInvoice(ctx context.Context, reqDTO *dto.CreateMyInvoiceRequest) (*dto.SuccessResponse, error) {
 	convertReqDTO := &dto.CreateInvoiceRequest{}
 	convertReqDTO.Body.UserId = ctx.Value("user_id").(string)
 	convertReqDTO.Body.InvoiceDetails = []dto.InvoiceDetail{}
+	if reqDTO.Body != nil {
+		convertReqDTO.Body.ShippingLatitude = reqDTO.Body.ShippingLatitude
+		convertReqDTO.Body.ShippingLongitude = reqDTO.Body.ShippingLongitude
+	}
 
 	if err := invoiceHandler.invoiceService.CreateInvoice(ctx, convertReqDTO); err != nil {
 		res := &dto.ErrorResponse{}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/middleware"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/service"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type WishlistItemHandler struct {
+	wishlistItemService service.WishlistItemService
+	jwtAuthMiddleware   *middleware.JWTAuthMiddleware
+}
+
+func NewWishlistItemHandler(api huma.API, wishlistItemService service.WishlistItemService, jwtAuthMiddleware *middleware.JWTAuthMiddleware) *WishlistItemHandler {
+	wishlistItemHandler := &WishlistItemHandler{
+		wishlistItemService: wishlistItemService,
+		jwtAuthMiddleware:   jwtAuthMiddleware,
+	}
+
+	// Get my wishlist items
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/my-wishlist",
+		Summary:     "/my-wishlist",
+		Description: "Get my wishlist items with live product data.",
+		Tags:        []string{"Wishlist"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, wishlistItemHandler.GetMyWishlistItems)
+
+	// Create my wishlist item
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/my-wishlist",
+		Summary:     "/my-wishlist",
+		Description: "Add product to my wishlist.",
+		Tags:        []string{"Wishlist"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, wishlistItemHandler.CreateMyWishlistItem)
+
+	// Delete my wishlist item by id
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/my-wishlist/id/{id}",
+		Summary:     "/my-wishlist/id/{id}",
+		Description: "Remove product from my wishlist.",
+		Tags:        []string{"Wishlist"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, wishlistItemHandler.DeleteMyWishlistItemById)
+
+	// Move my wishlist item to cart
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		Path:        "/my-wishlist/id/{id}/move-to-cart",
+		Summary:     "/my-wishlist/id/{id}/move-to-cart",
+		Description: "Move product from my wishlist to my cart.",
+		Tags:        []string{"Wishlist"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, wishlistItemHandler.MoveMyWishlistItemToCart)
+
+	return wishlistItemHandler
+}
+
+func (wishlistItemHandler *WishlistItemHandler) GetMyWishlistItems(ctx context.Context, reqDTO *dto.GetMyWishlistItemsRequest) (*dto.PaginationBodyResponseList[*model.WishlistItemView], error) {
+	wishlistItems, err := wishlistItemHandler.wishlistItemService.GetMyWishlistItems(ctx, reqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusInternalServerError
+		res.Code = "ERR_INTERNAL_SERVER"
+		res.Message = "Get my wishlist items failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.PaginationBodyResponseList[*model.WishlistItemView]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get my wishlist items successful"
+	res.Body.Data = wishlistItems
+	res.Body.Total = len(wishlistItems)
+	return res, nil
+}
+
+func (wishlistItemHandler *WishlistItemHandler) CreateMyWishlistItem(ctx context.Context, reqDTO *dto.CreateMyWishlistItemRequest) (*dto.SuccessResponse, error) {
+	if err := wishlistItemHandler.wishlistItemService.CreateMyWishlistItem(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Create my wishlist item failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Create my wishlist item successful"
+	return res, nil
+}
+
+func (wishlistItemHandler *WishlistItemHandler) DeleteMyWishlistItemById(ctx context.Context, reqDTO *dto.DeleteMyWishlistItemByIdRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete my wishlist item by id failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := wishlistItemHandler.wishlistItemService.DeleteMyWishlistItemById(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Delete my wishlist item by id failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Delete my wishlist item by id successful"
+	return res, nil
+}
+
+func (wishlistItemHandler *WishlistItemHandler) MoveMyWishlistItemToCart(ctx context.Context, reqDTO *dto.MoveMyWishlistItemToCartRequest) (*dto.SuccessResponse, error) {
+	if reqDTO.Id == "{id}" {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Move my wishlist item to cart failed"
+		res.Details = []string{"missing path parameters: id"}
+		return nil, res
+	}
+
+	if err := wishlistItemHandler.wishlistItemService.MoveMyWishlistItemToCart(ctx, reqDTO); err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Move my wishlist item to cart failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.SuccessResponse{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Move my wishlist item to cart successful"
+	return res, nil
+}
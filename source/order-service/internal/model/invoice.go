@@ -30,6 +30,12 @@ type InvoiceDetail struct {
 	DiscountPercentage int32  `bun:"discount_percentage,notnull"`
 	Quantity           int32  `bun:"quantity,notnull"`
 	TotalPrice         int64  `bun:"total_price,notnull"`
+	WarehouseId        string `bun:"warehouse_id,nullzero"`
+	PromotionId        string `bun:"promotion_id,nullzero"`
+	AllocationStatus   string `bun:"allocation_status,notnull,default:'ALLOCATED'"` // PENDING while pre-order or backorder units wait for stock
+	IsBundle           bool   `bun:"is_bundle,notnull,default:false"`               // Priced unit of bundle, stock is taken by details of its components
+	BundleId           string `bun:"bundle_id,nullzero"`                            // Bundle which the component is shipped for, it is not priced on its own
+	BackorderId        string `bun:"backorder_id,nullzero"`                         // Backorder which allocated the detail, catalog-service gives its stock back on cancel
 }
 
 type InvoiceView struct {
@@ -55,6 +61,12 @@ type InvoiceDetailView struct {
 	DiscountPercentage int32  `json:"discount_percentage" bun:"discount_percentage"`
 	Quantity           int32  `json:"quantity" bun:"quantity"`
 	TotalPrice         int64  `json:"total_price" bun:"total_price"`
+	WarehouseId        string `json:"warehouse_id,omitempty" bun:"warehouse_id"`
+	PromotionId        string `json:"promotion_id,omitempty" bun:"promotion_id"`
+	AllocationStatus   string `json:"allocation_status" bun:"allocation_status"`
+	IsBundle           bool   `json:"is_bundle" bun:"is_bundle"`
+	BundleId           string `json:"bundle_id,omitempty" bun:"bundle_id"`
+	BackorderId        string `json:"backorder_id,omitempty" bun:"backorder_id"`
 
 	ProductName         string `json:"product_name" bun:"product_name"`
 	ProductSex          string `json:"product_sex" bun:"product_sex"`
@@ -63,18 +75,47 @@ type InvoiceDetailView struct {
 	ProductCategoryName string `json:"product_category_name" bun:"product_category_name"`
 	ProductBrandId      string `json:"product_brand_id" bun:"product_brand_id"`
 	ProductBrandName    string `json:"product_brand_name" bun:"product_brand_name"`
+	WarehouseName       string `json:"warehouse_name,omitempty" bun:"warehouse_name"`
 }
 
 // View -> Proto
 
+// Price of one unit after discount, rounded down the same way as final price of products in catalog-service so that invoice
+// lines match prices shown in catalog
+func FinalPrice(price int64, discountPercentage int32) int64 {
+	return price * int64(100-discountPercentage) / 100
+}
+
 func FromInvoiceViewToInvoiceProto(invoiceView *InvoiceView) *orderservicepb.Invoice {
+	invoiceDetailProtos := make([]*orderservicepb.InvoiceDetail, len(invoiceView.InvoiceDetails))
+	for i, invoiceDetailView := range invoiceView.InvoiceDetails {
+		invoiceDetailProtos[i] = &orderservicepb.InvoiceDetail{
+			Id:                  invoiceDetailView.Id,
+			InvoiceId:           invoiceDetailView.InvoiceId,
+			ProductId:           invoiceDetailView.ProductId,
+			Price:               invoiceDetailView.Price,
+			DiscountPercentage:  invoiceDetailView.DiscountPercentage,
+			Quantity:            invoiceDetailView.Quantity,
+			TotalPrice:          invoiceDetailView.TotalPrice,
+			ProductName:         invoiceDetailView.ProductName,
+			ProductCategoryId:   invoiceDetailView.ProductCategoryId,
+			ProductCategoryName: invoiceDetailView.ProductCategoryName,
+			ProductBrandId:      invoiceDetailView.ProductBrandId,
+			ProductBrandName:    invoiceDetailView.ProductBrandName,
+			WarehouseId:         invoiceDetailView.WarehouseId,
+			WarehouseName:       invoiceDetailView.WarehouseName,
+			PromotionId:         invoiceDetailView.PromotionId,
+		}
+	}
+
 	return &orderservicepb.Invoice{
-		Id:          invoiceView.Id,
-		UserId:      invoiceView.UserId,
-		TotalAmount: invoiceView.TotalAmount,
-		Status:      invoiceView.Status,
-		CreatedAt:   timestamppb.New(invoiceView.CreatedAt),
-		UpdatedAt:   timestamppb.New(invoiceView.UpdatedAt),
+		Id:             invoiceView.Id,
+		UserId:         invoiceView.UserId,
+		TotalAmount:    invoiceView.TotalAmount,
+		Status:         invoiceView.Status,
+		CreatedAt:      timestamppb.New(invoiceView.CreatedAt),
+		UpdatedAt:      timestamppb.New(invoiceView.UpdatedAt),
+		InvoiceDetails: invoiceDetailProtos,
 	}
 }
 
@@ -90,13 +131,35 @@ func FromListInvoiceViewToListInvoiceProto(invoiceViews []*InvoiceView) []*order
 // Proto -> View
 
 func FromInvoiceProtoToInvoiceView(invoiceProto *elasticsearchservicepb.Invoice) *InvoiceView {
+	invoiceDetailViews := make([]*InvoiceDetailView, len(invoiceProto.InvoiceDetails))
+	for i, invoiceDetailProto := range invoiceProto.InvoiceDetails {
+		invoiceDetailViews[i] = &InvoiceDetailView{
+			Id:                  invoiceDetailProto.Id,
+			InvoiceId:           invoiceDetailProto.InvoiceId,
+			ProductId:           invoiceDetailProto.ProductId,
+			Price:               invoiceDetailProto.Price,
+			DiscountPercentage:  invoiceDetailProto.DiscountPercentage,
+			Quantity:            invoiceDetailProto.Quantity,
+			TotalPrice:          invoiceDetailProto.TotalPrice,
+			ProductName:         invoiceDetailProto.ProductName,
+			ProductCategoryId:   invoiceDetailProto.ProductCategoryId,
+			ProductCategoryName: invoiceDetailProto.ProductCategoryName,
+			ProductBrandId:      invoiceDetailProto.ProductBrandId,
+			ProductBrandName:    invoiceDetailProto.ProductBrandName,
+			WarehouseId:         invoiceDetailProto.WarehouseId,
+			WarehouseName:       invoiceDetailProto.WarehouseName,
+			PromotionId:         invoiceDetailProto.PromotionId,
+		}
+	}
+
 	return &InvoiceView{
-		Id:          invoiceProto.Id,
-		UserId:      invoiceProto.UserId,
-		TotalAmount: invoiceProto.TotalAmount,
-		Status:      invoiceProto.Status,
-		CreatedAt:   invoiceProto.CreatedAt.AsTime(),
-		UpdatedAt:   invoiceProto.UpdatedAt.AsTime(),
+		Id:             invoiceProto.Id,
+		UserId:         invoiceProto.UserId,
+		TotalAmount:    invoiceProto.TotalAmount,
+		Status:         invoiceProto.Status,
+		CreatedAt:      invoiceProto.CreatedAt.AsTime(),
+		UpdatedAt:      invoiceProto.UpdatedAt.AsTime(),
+		InvoiceDetails: invoiceDetailViews,
 	}
 }
 
@@ -0,0 +1,69 @@
+package model
+
+import "thanhldt060802/internal/grpc/client/elasticsearchservicepb"
+
+type SalesReportView struct {
+	StartTime         string                   `json:"start_time"`
+	EndTime           string                   `json:"end_time"`
+	TimeInterval      string                   `json:"time_interval"`
+	TotalRevenue      int64                    `json:"total_revenue"`
+	TotalOrders       int64                    `json:"total_orders"`
+	AverageOrderValue float64                  `json:"average_order_value"`
+	TotalUnits        int64                    `json:"total_units"`
+	Details           []*SalesReportDetailView `json:"details"`
+}
+
+type SalesReportDetailView struct {
+	StartTime         string                  `json:"start_time"`
+	EndTime           string                  `json:"end_time"`
+	Revenue           int64                   `json:"revenue"`
+	Orders            int64                   `json:"orders"`
+	AverageOrderValue float64                 `json:"average_order_value"`
+	Units             int64                   `json:"units"`
+	Groups            []*SalesReportGroupView `json:"groups,omitempty"`
+}
+
+type SalesReportGroupView struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Units   int64  `json:"units"`
+	Revenue int64  `json:"revenue"`
+}
+
+// Proto -> View
+
+func FromSalesReportProtoToSalesReportView(salesReportProto *elasticsearchservicepb.SalesReport) *SalesReportView {
+	detailViews := make([]*SalesReportDetailView, len(salesReportProto.Details))
+	for i, detailProto := range salesReportProto.Details {
+		groupViews := make([]*SalesReportGroupView, len(detailProto.Groups))
+		for j, groupProto := range detailProto.Groups {
+			groupViews[j] = &SalesReportGroupView{
+				Id:      groupProto.Id,
+				Name:    groupProto.Name,
+				Units:   groupProto.Units,
+				Revenue: groupProto.Revenue,
+			}
+		}
+
+		detailViews[i] = &SalesReportDetailView{
+			StartTime:         detailProto.StartTime,
+			EndTime:           detailProto.EndTime,
+			Revenue:           detailProto.Revenue,
+			Orders:            detailProto.Orders,
+			AverageOrderValue: detailProto.AverageOrderValue,
+			Units:             detailProto.Units,
+			Groups:            groupViews,
+		}
+	}
+
+	return &SalesReportView{
+		StartTime:         salesReportProto.StartTime,
+		EndTime:           salesReportProto.EndTime,
+		TimeInterval:      salesReportProto.TimeInterval,
+		TotalRevenue:      salesReportProto.TotalRevenue,
+		TotalOrders:       salesReportProto.TotalOrders,
+		AverageOrderValue: salesReportProto.AverageOrderValue,
+		TotalUnits:        salesReportProto.TotalUnits,
+		Details:           detailViews,
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type WishlistItem struct {
+	bun.BaseModel `bun:"tb_wishlist_item"`
+
+	Id        string `bun:"id,pk"`
+	UserId    string `bun:"user_id,notnull"`
+	ProductId string `bun:"product_id,notnull"`
+	// Last known final price and stock of product, used to detect price drop and back in stock
+	LastKnownPrice int64      `bun:"last_known_price,notnull"`
+	LastKnownStock int32      `bun:"last_known_stock,notnull"`
+	CreatedAt      *time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+type WishlistItemView struct {
+	bun.BaseModel `bun:"tb_wishlist_item,alias:_wishlist_item"`
+
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	ProductId string    `json:"product_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProductName               string `json:"product_name" bun:"product_name"`
+	ProductSex                string `json:"product_sex" bun:"product_sex"`
+	ProductPrice              int64  `json:"product_price" bun:"product_price"`
+	ProductDiscountPercentage int32  `json:"product_discount_percentage" bun:"product_discount_percentage"`
+	ProductStock              int32  `json:"product_stock" bun:"product_stock"`
+	ProductImageURL           string `json:"product_image_url" bun:"product_image_url"`
+	ProductCategoryId         string `json:"product_category_id" bun:"product_category_id"`
+	ProductCategoryName       string `json:"product_category_name" bun:"product_category_name"`
+	ProductBrandId            string `json:"product_brand_id" bun:"product_brand_id"`
+	ProductBrandName          string `json:"product_brand_name" bun:"product_brand_name"`
+}
@@ -19,6 +19,7 @@ type CartItemRepository interface {
 	GetViewsByUserId(ctx context.Context, userId string, offset int, limit int, sortFields []*utils.SortField) ([]*model.CartItemView, error)
 
 	GetById(ctx context.Context, id string) (*model.CartItem, error)
+	GetByUserIdAndProductId(ctx context.Context, userId string, productId string) (*model.CartItem, error)
 	Create(ctx context.Context, newCartItem *model.CartItem) error
 	Update(ctx context.Context, updatedCartItem *model.CartItem) error
 	DeleteByUserId(ctx context.Context, userId string) error
@@ -33,7 +34,6 @@ func (cartItemRepository *cartItemRepository) GetViews(ctx context.Context, offs
 	var cartItems []*model.CartItemView
 
 	query := infrastructure.PostgresDB.NewSelect().Model(&cartItems).
-		TableExpr("tb_cart_item AS _cart_item").
 		Column("_cart_item.*").
 		ColumnExpr("_product.name AS product_name").
 		ColumnExpr("_product.sex AS product_sex").
@@ -44,7 +44,7 @@ func (cartItemRepository *cartItemRepository) GetViews(ctx context.Context, offs
 		ColumnExpr("_product.brand_id AS product_brand_id").
 		ColumnExpr("_category.name AS product_category_name").
 		ColumnExpr("_brand.name AS product_brand_name").
-		Join("JOIN tb_product AS _product ON _product.id = _cart_item.product_id").
+		Join("JOIN tb_product AS _product ON _product.id = _cart_item.product_id AND _product.status = 'PUBLISHED'").
 		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
 		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
 		Offset(offset).
@@ -65,7 +65,6 @@ func (cartItemRepository *cartItemRepository) GetAllViewsByUserId(ctx context.Co
 	var cartItems []*model.CartItemView
 
 	query := infrastructure.PostgresDB.NewSelect().Model(&cartItems).
-		TableExpr("tb_cart_item AS _cart_item").
 		Column("_cart_item.*").
 		ColumnExpr("_product.name AS product_name").
 		ColumnExpr("_product.sex AS product_sex").
@@ -76,7 +75,7 @@ func (cartItemRepository *cartItemRepository) GetAllViewsByUserId(ctx context.Co
 		ColumnExpr("_product.brand_id AS product_brand_id").
 		ColumnExpr("_category.name AS product_category_name").
 		ColumnExpr("_brand.name AS product_brand_name").
-		Join("JOIN tb_product AS _product ON _product.id = _cart_item.product_id").
+		Join("JOIN tb_product AS _product ON _product.id = _cart_item.product_id AND _product.status = 'PUBLISHED'").
 		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
 		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
 		Where("_cart_item.user_id = ?", userId)
@@ -92,7 +91,6 @@ func (cartItemRepository *cartItemRepository) GetViewsByUserId(ctx context.Conte
 	var cartItems []*model.CartItemView
 
 	query := infrastructure.PostgresDB.NewSelect().Model(&cartItems).
-		TableExpr("tb_cart_item AS _cart_item").
 		Column("_cart_item.*").
 		ColumnExpr("_product.name AS product_name").
 		ColumnExpr("_product.sex AS product_sex").
@@ -103,7 +101,7 @@ func (cartItemRepository *cartItemRepository) GetViewsByUserId(ctx context.Conte
 		ColumnExpr("_product.brand_id AS product_brand_id").
 		ColumnExpr("_category.name AS product_category_name").
 		ColumnExpr("_brand.name AS product_brand_name").
-		Join("JOIN tb_product AS _product ON _product.id = _cart_item.product_id").
+		Join("JOIN tb_product AS _product ON _product.id = _cart_item.product_id AND _product.status = 'PUBLISHED'").
 		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
 		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
 		Where("_cart_item.user_id = ?", userId).
@@ -133,6 +131,18 @@ func (cartItemRepository *cartItemRepository) GetById(ctx context.Context, id st
 	return cartItem, nil
 }
 
+func (cartItemRepository *cartItemRepository) GetByUserIdAndProductId(ctx context.Context, userId string, productId string) (*model.CartItem, error) {
+	cartItem := new(model.CartItem)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(cartItem).Where("user_id = ?", userId).Where("product_id = ?", productId)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return cartItem, nil
+}
+
 func (cartItemRepository *cartItemRepository) Create(ctx context.Context, newCartItem *model.CartItem) error {
 	newCartItem.Id = uuid.New().String()
 	_, err := infrastructure.PostgresDB.NewInsert().Model(newCartItem).Returning("*").Exec(ctx)
@@ -70,5 +70,56 @@ func InitTableInvoiceDetail() {
 		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.InvoiceDetail{}).Exec(ctx); err != nil {
 			log.Fatal("Create table tb_invoice_detail on PostgreSQL failed: ", err)
 		}
+	} else {
+		upgradeTableInvoiceDetail(ctx)
+	}
+}
+
+// Upgrade table tb_invoice_detail created before invoice details recorded their fulfilling warehouse, promotion, allocation
+// status, bundle and backorder
+func upgradeTableInvoiceDetail(ctx context.Context) {
+	queries := []string{
+		`ALTER TABLE tb_invoice_detail ADD COLUMN IF NOT EXISTS warehouse_id VARCHAR`,
+		`ALTER TABLE tb_invoice_detail ADD COLUMN IF NOT EXISTS promotion_id VARCHAR`,
+		`ALTER TABLE tb_invoice_detail ADD COLUMN IF NOT EXISTS allocation_status VARCHAR NOT NULL DEFAULT 'ALLOCATED'`,
+		`ALTER TABLE tb_invoice_detail ADD COLUMN IF NOT EXISTS is_bundle BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE tb_invoice_detail ADD COLUMN IF NOT EXISTS bundle_id VARCHAR`,
+		`ALTER TABLE tb_invoice_detail ADD COLUMN IF NOT EXISTS backorder_id VARCHAR`,
+	}
+	for _, query := range queries {
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Upgrade table tb_invoice_detail on PostgreSQL failed: ", err)
+		}
+	}
+}
+
+func InitTableWishlistItem() {
+	ctx := context.Background()
+
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' AND table_name = ?
+		)
+	`
+	if err := infrastructure.PostgresDB.QueryRowContext(ctx, query, "tb_wishlist_item").Scan(&exists); err != nil {
+		log.Fatal("Check table tb_wishlist_item on PostgreSQL failed: ", err)
+	}
+
+	if !exists {
+		if _, err := infrastructure.PostgresDB.NewCreateTable().Model(&model.WishlistItem{}).Exec(ctx); err != nil {
+			log.Fatal("Create table tb_wishlist_item on PostgreSQL failed: ", err)
+		}
+
+		// Each product is wishlisted by a user at most once
+		query := `
+			CREATE UNIQUE INDEX IF NOT EXISTS tb_wishlist_item_user_id_product_id_key ON tb_wishlist_item (user_id, product_id);
+			CREATE INDEX IF NOT EXISTS tb_wishlist_item_product_id_idx ON tb_wishlist_item (product_id);
+		`
+		if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+			log.Fatal("Create index for table tb_wishlist_item on PostgreSQL failed: ", err)
+		}
 	}
 }
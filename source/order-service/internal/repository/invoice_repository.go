@@ -2,13 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/model"
+	"time"
+
+	"github.com/uptrace/bun"
 )
 
 type invoiceRepository struct {
 }
 
+// Returned by ReplacePendingDetailsById when invoice details were allocated or removed meanwhile
+var ErrInvoiceDetailsNotPending = errors.New("invoice details are no longer pending")
+
 type InvoiceRepository interface {
 	GetViewById(ctx context.Context, id string, dataExpansion bool) (*model.InvoiceView, error)
 
@@ -17,8 +24,13 @@ type InvoiceRepository interface {
 	Update(ctx context.Context, updatedInvoice *model.Invoice) error
 	DeleteById(ctx context.Context, id string) error
 
-	// Elasticsearch integration (init data for elasticsearch-service)
-	GetAllViews(ctx context.Context, dataExpansion bool) ([]*model.InvoiceView, error)
+	// Elasticsearch integration (init data for elasticsearch-service), invoices are read page by page ordered by id
+	GetViewsAfterId(ctx context.Context, afterId string, limit int, dataExpansion bool) ([]*model.InvoiceView, error)
+
+	// Catalog integration (extra features for catalog-service)
+	GetDoneIdByUserIdAndProductId(ctx context.Context, userId string, productId string) (string, error)
+	GetPendingDetailsByIdAndProductId(ctx context.Context, id string, productId string) ([]*model.InvoiceDetail, error)
+	ReplacePendingDetailsById(ctx context.Context, id string, pendingInvoiceDetails []*model.InvoiceDetail, newInvoiceDetails []*model.InvoiceDetail) error
 }
 
 func NewInvoiceRepository() InvoiceRepository {
@@ -38,7 +50,6 @@ func (invoiceRepository *invoiceRepository) GetViewById(ctx context.Context, id
 		var invoiceDetails []*model.InvoiceDetailView
 
 		query := infrastructure.PostgresDB.NewSelect().Model(&invoiceDetails).
-			TableExpr("tb_invoice_detail AS _invoice_detail").
 			Column("_invoice_detail.*").
 			ColumnExpr("_product.name AS product_name").
 			ColumnExpr("_product.sex AS product_sex").
@@ -47,9 +58,11 @@ func (invoiceRepository *invoiceRepository) GetViewById(ctx context.Context, id
 			ColumnExpr("_product.brand_id AS product_brand_id").
 			ColumnExpr("_category.name AS product_category_name").
 			ColumnExpr("_brand.name AS product_brand_name").
+			ColumnExpr("_warehouse.name AS warehouse_name").
 			Join("JOIN tb_product AS _product ON _product.id = _invoice_detail.product_id").
 			Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
 			Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+			Join("LEFT JOIN tb_warehouse AS _warehouse ON _warehouse.id = _invoice_detail.warehouse_id").
 			Where("_invoice_detail.invoice_id = ?", id)
 
 		if err := query.Scan(ctx); err != nil {
@@ -112,44 +125,128 @@ func (invoiceRepository *invoiceRepository) DeleteById(ctx context.Context, id s
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (invoiceRepository *invoiceRepository) GetAllViews(ctx context.Context, dataExpansion bool) ([]*model.InvoiceView, error) {
+func (invoiceRepository *invoiceRepository) GetViewsAfterId(ctx context.Context, afterId string, limit int, dataExpansion bool) ([]*model.InvoiceView, error) {
 	var invoices []*model.InvoiceView
 
-	query := infrastructure.PostgresDB.NewSelect().Model(&invoices)
+	query := infrastructure.PostgresDB.NewSelect().Model(&invoices).
+		Where("_invoice.id > ?", afterId).
+		Order("_invoice.id ASC").
+		Limit(limit)
 
 	if err := query.Scan(ctx); err != nil {
 		return nil, err
 	}
 
-	if dataExpansion {
-		for i := range invoices {
-			var invoiceDetails []*model.InvoiceDetailView
-
-			query := infrastructure.PostgresDB.NewSelect().Model(&invoiceDetails).
-				TableExpr("tb_invoice_detail AS _invoice_detail").
-				Column("_invoice_detail.*").
-				ColumnExpr("_product.name AS product_name").
-				ColumnExpr("_product.sex AS product_sex").
-				ColumnExpr("_product.image_url AS product_image_url").
-				ColumnExpr("_product.category_id AS product_category_id").
-				ColumnExpr("_product.brand_id AS product_brand_id").
-				ColumnExpr("_category.name AS product_category_name").
-				ColumnExpr("_brand.name AS product_brand_name").
-				Join("JOIN tb_product AS _product ON _product.id = _invoice_detail.product_id").
-				Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
-				Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
-				Where("_invoice_detail.invoice_id = ?", invoices[i].Id)
-
-			if err := query.Scan(ctx); err != nil {
-				return nil, err
-			}
-
-			invoices[i].InvoiceDetails = invoiceDetails
+	if dataExpansion && len(invoices) != 0 {
+		ids := make([]string, len(invoices))
+		for i, invoice := range invoices {
+			ids[i] = invoice.Id
+		}
+
+		// Details of the whole page are read at once
+		var invoiceDetails []*model.InvoiceDetailView
+
+		query := infrastructure.PostgresDB.NewSelect().Model(&invoiceDetails).
+			Column("_invoice_detail.*").
+			ColumnExpr("_product.name AS product_name").
+			ColumnExpr("_product.sex AS product_sex").
+			ColumnExpr("_product.image_url AS product_image_url").
+			ColumnExpr("_product.category_id AS product_category_id").
+			ColumnExpr("_product.brand_id AS product_brand_id").
+			ColumnExpr("_category.name AS product_category_name").
+			ColumnExpr("_brand.name AS product_brand_name").
+			ColumnExpr("_warehouse.name AS warehouse_name").
+			Join("JOIN tb_product AS _product ON _product.id = _invoice_detail.product_id").
+			Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+			Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+			Join("LEFT JOIN tb_warehouse AS _warehouse ON _warehouse.id = _invoice_detail.warehouse_id").
+			Where("_invoice_detail.invoice_id IN (?)", bun.In(ids))
+
+		if err := query.Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		invoiceDetailsMap := map[string][]*model.InvoiceDetailView{}
+		for _, invoiceDetail := range invoiceDetails {
+			invoiceDetailsMap[invoiceDetail.InvoiceId] = append(invoiceDetailsMap[invoiceDetail.InvoiceId], invoiceDetail)
+		}
+		for _, invoice := range invoices {
+			invoice.InvoiceDetails = invoiceDetailsMap[invoice.Id]
 		}
 	}
 
 	return invoices, nil
 }
+
+func (invoiceRepository *invoiceRepository) GetDoneIdByUserIdAndProductId(ctx context.Context, userId string, productId string) (string, error) {
+	var invoiceId string
+
+	query := infrastructure.PostgresDB.NewSelect().Model((*model.InvoiceView)(nil)).
+		Column("_invoice.id").
+		Join("JOIN tb_invoice_detail AS _invoice_detail ON _invoice_detail.invoice_id = _invoice.id").
+		Where("_invoice.user_id = ?", userId).
+		Where("_invoice.status = ?", "DONE").
+		Where("_invoice_detail.product_id = ?", productId).
+		Order("_invoice.created_at DESC").
+		Limit(1)
+
+	if err := query.Scan(ctx, &invoiceId); err != nil {
+		return "", err
+	}
+
+	return invoiceId, nil
+}
+
+// Invoice details of product waiting for pre-order or backorder stock
+func (invoiceRepository *invoiceRepository) GetPendingDetailsByIdAndProductId(ctx context.Context, id string, productId string) ([]*model.InvoiceDetail, error) {
+	var invoiceDetails []*model.InvoiceDetail
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&invoiceDetails).
+		Where("invoice_id = ?", id).
+		Where("product_id = ?", productId).
+		Where("allocation_status = 'PENDING'")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return invoiceDetails, nil
+}
+
+// Pending invoice details are replaced by allocated ones in one transaction, it fails when any of them is no longer pending
+func (invoiceRepository *invoiceRepository) ReplacePendingDetailsById(ctx context.Context, id string, pendingInvoiceDetails []*model.InvoiceDetail, newInvoiceDetails []*model.InvoiceDetail) error {
+	tx, err := infrastructure.PostgresDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	pendingIds := make([]string, len(pendingInvoiceDetails))
+	for i, pendingInvoiceDetail := range pendingInvoiceDetails {
+		pendingIds[i] = pendingInvoiceDetail.Id
+	}
+	result, err := tx.NewDelete().Model((*model.InvoiceDetail)(nil)).
+		Where("invoice_id = ?", id).
+		Where("id IN (?)", bun.In(pendingIds)).
+		Where("allocation_status = 'PENDING'").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected != int64(len(pendingIds)) {
+		return ErrInvoiceDetailsNotPending
+	}
+
+	if _, err = tx.NewInsert().Model(&newInvoiceDetails).Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err = tx.NewUpdate().Model((*model.Invoice)(nil)).Set("updated_at = ?", time.Now().UTC()).Where("id = ?", id).Exec(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
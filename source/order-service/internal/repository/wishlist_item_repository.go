@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/utils"
+
+	"github.com/google/uuid"
+)
+
+type wishlistItemRepository struct {
+}
+
+type WishlistItemRepository interface {
+	GetViewsByUserId(ctx context.Context, userId string, offset int, limit int, sortFields []*utils.SortField) ([]*model.WishlistItemView, error)
+
+	GetById(ctx context.Context, id string) (*model.WishlistItem, error)
+	GetByUserIdAndProductId(ctx context.Context, userId string, productId string) (*model.WishlistItem, error)
+	GetByProductId(ctx context.Context, productId string) ([]*model.WishlistItem, error)
+	Create(ctx context.Context, newWishlistItem *model.WishlistItem) error
+	UpdateLastKnownByProductId(ctx context.Context, productId string, lastKnownPrice int64, lastKnownStock int32) error
+	DeleteById(ctx context.Context, id string) error
+}
+
+func NewWishlistItemRepository() WishlistItemRepository {
+	return &wishlistItemRepository{}
+}
+
+func (wishlistItemRepository *wishlistItemRepository) GetViewsByUserId(ctx context.Context, userId string, offset int, limit int, sortFields []*utils.SortField) ([]*model.WishlistItemView, error) {
+	var wishlistItems []*model.WishlistItemView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&wishlistItems).
+		Column("_wishlist_item.id", "_wishlist_item.user_id", "_wishlist_item.product_id", "_wishlist_item.created_at").
+		ColumnExpr("_product.name AS product_name").
+		ColumnExpr("_product.sex AS product_sex").
+		ColumnExpr("_product.price AS product_price").
+		ColumnExpr("_product.discount_percentage AS product_discount_percentage").
+		ColumnExpr("_product.stock AS product_stock").
+		ColumnExpr("_product.image_url AS product_image_url").
+		ColumnExpr("_product.category_id AS product_category_id").
+		ColumnExpr("_product.brand_id AS product_brand_id").
+		ColumnExpr("_category.name AS product_category_name").
+		ColumnExpr("_brand.name AS product_brand_name").
+		Join("JOIN tb_product AS _product ON _product.id = _wishlist_item.product_id").
+		Join("JOIN tb_category AS _category ON _category.id = _product.category_id").
+		Join("JOIN tb_brand AS _brand ON _brand.id = _product.brand_id").
+		Where("_wishlist_item.user_id = ?", userId).
+		Offset(offset).
+		Limit(limit)
+
+	for _, sortField := range sortFields {
+		query = query.Order(fmt.Sprintf("_wishlist_item.%s %s", sortField.Field, sortField.Direction))
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return wishlistItems, nil
+}
+
+func (wishlistItemRepository *wishlistItemRepository) GetById(ctx context.Context, id string) (*model.WishlistItem, error) {
+	wishlistItem := new(model.WishlistItem)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(wishlistItem).Where("id = ?", id)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return wishlistItem, nil
+}
+
+func (wishlistItemRepository *wishlistItemRepository) GetByUserIdAndProductId(ctx context.Context, userId string, productId string) (*model.WishlistItem, error) {
+	wishlistItem := new(model.WishlistItem)
+
+	query := infrastructure.PostgresDB.NewSelect().Model(wishlistItem).Where("user_id = ?", userId).Where("product_id = ?", productId)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return wishlistItem, nil
+}
+
+func (wishlistItemRepository *wishlistItemRepository) GetByProductId(ctx context.Context, productId string) ([]*model.WishlistItem, error) {
+	var wishlistItems []*model.WishlistItem
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&wishlistItems).Where("product_id = ?", productId)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return wishlistItems, nil
+}
+
+func (wishlistItemRepository *wishlistItemRepository) Create(ctx context.Context, newWishlistItem *model.WishlistItem) error {
+	newWishlistItem.Id = uuid.New().String()
+	_, err := infrastructure.PostgresDB.NewInsert().Model(newWishlistItem).Returning("*").Exec(ctx)
+	return err
+}
+
+func (wishlistItemRepository *wishlistItemRepository) UpdateLastKnownByProductId(ctx context.Context, productId string, lastKnownPrice int64, lastKnownStock int32) error {
+	_, err := infrastructure.PostgresDB.NewUpdate().Model(&model.WishlistItem{}).
+		Set("last_known_price = ?", lastKnownPrice).
+		Set("last_known_stock = ?", lastKnownStock).
+		Where("product_id = ?", productId).
+		Exec(ctx)
+	return err
+}
+
+func (wishlistItemRepository *wishlistItemRepository) DeleteById(ctx context.Context, id string) error {
+	_, err := infrastructure.PostgresDB.NewDelete().Model(&model.WishlistItem{}).Where("id = ?", id).Exec(ctx)
+	return err
+}
@@ -48,6 +48,24 @@ func (cartItemService *cartItemService) GetCartItems(ctx context.Context, reqDTO
 			foundCartItems = cartItems
 		}
 
+		// Prices in cart follow catalog-service, which includes promotions in effect, in one lookup for the whole page
+		productIds := make([]string, len(foundCartItems))
+		for i, foundCartItem := range foundCartItems {
+			productIds[i] = foundCartItem.ProductId
+		}
+		productMap, err := getProductsByIds(ctx, productIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, foundCartItem := range foundCartItems {
+			if product, ok := productMap[foundCartItem.ProductId]; ok {
+				foundCartItem.ProductName = product.Name
+				foundCartItem.ProductPrice = product.Price
+				foundCartItem.ProductDiscountPercentage = product.DiscountPercentage
+				foundCartItem.ProductImageURL = product.ImageUrl
+			}
+		}
+
 		return foundCartItems, nil
 	} else {
 		return nil, fmt.Errorf("catalog-service is not running")
@@ -66,11 +84,12 @@ func (cartItemService *cartItemService) CreateCartItem(ctx context.Context, reqD
 		}
 
 		{
-			convertReqDTO := &catalogservicepb.GetProductByIdRequest{}
-			convertReqDTO.Id = reqDTO.Body.ProductId
-			_, err := infrastructure.CatalogServiceGRPCClient.GetProductById(ctx, convertReqDTO)
+			productMap, err := getProductsByIds(ctx, []string{reqDTO.Body.ProductId})
 			if err != nil {
-				return fmt.Errorf("get product from catalog-service failed: %s", err.Error())
+				return err
+			}
+			if _, ok := productMap[reqDTO.Body.ProductId]; !ok {
+				return fmt.Errorf("id of product is not valid")
 			}
 		}
 
@@ -126,3 +145,25 @@ func (cartItemService *cartItemService) DeleteCartItemById(ctx context.Context,
 
 	return nil
 }
+
+// Products by id from catalog-service in one batch lookup, products catalog-service does not return (unknown or not
+// published) are missing from map
+func getProductsByIds(ctx context.Context, productIds []string) (map[string]*catalogservicepb.Product, error) {
+	productMap := map[string]*catalogservicepb.Product{}
+	if len(productIds) == 0 {
+		return productMap, nil
+	}
+
+	convertReqDTO := &catalogservicepb.GetProductsByIdsRequest{}
+	convertReqDTO.Ids = productIds
+	grpcRes, err := infrastructure.CatalogServiceGRPCClient.GetProductsByIds(ctx, convertReqDTO)
+	if err != nil {
+		return nil, fmt.Errorf("get products from catalog-service failed: %s", err.Error())
+	}
+
+	for _, product := range grpcRes.Products {
+		productMap[product.Id] = product
+	}
+
+	return productMap, nil
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"thanhldt060802/config"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/dto"
 	"thanhldt060802/internal/grpc/client/catalogservicepb"
@@ -15,6 +19,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// Invoices per message of invoice stream
+const invoiceStreamBatchSize = 200
+
 type invoiceService struct {
 	invoiceRepository  repository.InvoiceRepository
 	cartItemRepository repository.CartItemRepository
@@ -27,17 +34,29 @@ type InvoiceService interface {
 	DeleteInvoiceById(ctx context.Context, reqDTO *dto.DeleteInvoiceByIdRequest) error
 
 	// Elasticsearch integration (init data for elasticsearch-service)
-	GetAllInvoices(ctx context.Context) ([]*model.InvoiceView, error)
+	StreamAllInvoices(ctx context.Context, send func(invoices []*model.InvoiceView) error) error
+
+	// Catalog integration (extra features for catalog-service)
+	CheckPurchasedProduct(ctx context.Context, reqDTO *dto.CheckPurchasedProductRequest) (string, error)
 
 	// Elasticsearch integration features
 	GetInvoices(ctx context.Context, reqDTO *dto.GetInvoicesRequest) ([]*model.InvoiceView, error)
+	GetSalesReport(ctx context.Context, reqDTO *dto.GetSalesReportRequest) (*model.SalesReportView, error)
+
+	watchAllocatedBackorderLoop()
+	reconcileAllocatedBackorderLoop()
 }
 
 func NewInvoiceService(invoiceRepository repository.InvoiceRepository, cartItemRepository repository.CartItemRepository) InvoiceService {
-	return &invoiceService{
+	invoiceService := &invoiceService{
 		invoiceRepository:  invoiceRepository,
 		cartItemRepository: cartItemRepository,
 	}
+
+	go invoiceService.watchAllocatedBackorderLoop()
+	go invoiceService.reconcileAllocatedBackorderLoop()
+
+	return invoiceService
 }
 
 func (invoiceService *invoiceService) GetInvoiceById(ctx context.Context, reqDTO *dto.GetInvoiceByIdRequest) (*model.InvoiceView, error) {
@@ -50,6 +69,10 @@ func (invoiceService *invoiceService) GetInvoiceById(ctx context.Context, reqDTO
 }
 
 func (invoiceService *invoiceService) CreateInvoice(ctx context.Context, reqDTO *dto.CreateInvoiceRequest) error {
+	if infrastructure.CatalogServiceGRPCClient == nil {
+		return fmt.Errorf("catalog-service is not running")
+	}
+
 	newInvoice := &model.Invoice{
 		Id:          uuid.New().String(),
 		UserId:      reqDTO.Body.UserId,
@@ -57,26 +80,8 @@ func (invoiceService *invoiceService) CreateInvoice(ctx context.Context, reqDTO
 		Status:      "CREATED",
 	}
 
-	if len(reqDTO.Body.InvoiceDetails) != 0 {
-		newInvoiceDetails := []*model.InvoiceDetail{}
-		for _, invoiceDetail := range reqDTO.Body.InvoiceDetails {
-			newInvoiceDetails = append(newInvoiceDetails, &model.InvoiceDetail{
-				Id:                 uuid.New().String(),
-				InvoiceId:          newInvoice.Id,
-				ProductId:          invoiceDetail.ProductId,
-				Price:              invoiceDetail.Price,
-				DiscountPercentage: invoiceDetail.DiscountPercentage,
-				Quantity:           invoiceDetail.Quantity,
-				TotalPrice:         invoiceDetail.TotalPrice,
-			})
-
-			newInvoice.TotalAmount += invoiceDetail.TotalPrice
-		}
-
-		if err := invoiceService.invoiceRepository.Create(ctx, newInvoice, newInvoiceDetails); err != nil {
-			return fmt.Errorf("insert invoice to postgresql failed: %s", err.Error())
-		}
-	} else {
+	fromCart := len(reqDTO.Body.InvoiceDetails) == 0
+	if fromCart {
 		cartItems, err := invoiceService.cartItemRepository.GetAllViewsByUserId(ctx, reqDTO.Body.UserId)
 		if err != nil {
 			return fmt.Errorf("query cart items from postgresql failed: %s", err.Error())
@@ -85,54 +90,72 @@ func (invoiceService *invoiceService) CreateInvoice(ctx context.Context, reqDTO
 			return fmt.Errorf("cart items is empty")
 		}
 		for _, cartItem := range cartItems {
-			totalPrice := int64(float64(cartItem.ProductPrice) * float64(100-cartItem.ProductDiscountPercentage) / 100 * float64(cartItem.Quantity))
 			reqDTO.Body.InvoiceDetails = append(reqDTO.Body.InvoiceDetails, dto.InvoiceDetail{
-				ProductId:          cartItem.ProductId,
-				Price:              cartItem.ProductPrice,
-				DiscountPercentage: cartItem.ProductDiscountPercentage,
-				Quantity:           cartItem.Quantity,
-				TotalPrice:         totalPrice,
+				ProductId: cartItem.ProductId,
+				Quantity:  cartItem.Quantity,
 			})
 		}
+	}
 
-		newInvoiceDetails := []*model.InvoiceDetail{}
-		for _, invoiceDetail := range reqDTO.Body.InvoiceDetails {
-			newInvoiceDetails = append(newInvoiceDetails, &model.InvoiceDetail{
-				Id:                 uuid.New().String(),
-				InvoiceId:          newInvoice.Id,
-				ProductId:          invoiceDetail.ProductId,
-				Price:              invoiceDetail.Price,
-				DiscountPercentage: invoiceDetail.DiscountPercentage,
-				Quantity:           invoiceDetail.Quantity,
-				TotalPrice:         invoiceDetail.TotalPrice,
-			})
-
-			newInvoice.TotalAmount += invoiceDetail.TotalPrice
+	// Stock is taken before invoice is stored so that catalog-service decides which warehouses fulfill it
+	convertReqDTO := &catalogservicepb.UpdateProductStocksByListInvoiceDetailRequest{}
+	convertReqDTO.InvoiceDetails = make([]*catalogservicepb.InvoiceDetail, len(reqDTO.Body.InvoiceDetails))
+	for i := range reqDTO.Body.InvoiceDetails {
+		convertReqDTO.InvoiceDetails[i] = &catalogservicepb.InvoiceDetail{
+			ProductId: reqDTO.Body.InvoiceDetails[i].ProductId,
+			Quantity:  reqDTO.Body.InvoiceDetails[i].Quantity,
 		}
-
-		if err := invoiceService.invoiceRepository.Create(ctx, newInvoice, newInvoiceDetails); err != nil {
-			return fmt.Errorf("insert invoice to postgresql failed: %s", err.Error())
+	}
+	convertReqDTO.InvoiceId = newInvoice.Id
+	convertReqDTO.ActorId = newInvoice.UserId
+	if reqDTO.Body.ShippingLatitude != nil && reqDTO.Body.ShippingLongitude != nil {
+		convertReqDTO.ShippingLocation = &catalogservicepb.Location{
+			Latitude:  *reqDTO.Body.ShippingLatitude,
+			Longitude: *reqDTO.Body.ShippingLongitude,
 		}
+	}
+	grpcRes, err := infrastructure.CatalogServiceGRPCClient.UpdateProductStocksByListInvoiceDetail(ctx, convertReqDTO)
+	if err != nil {
+		return fmt.Errorf("update products from catalog-service failed: %s", err.Error())
+	}
 
-		if err := invoiceService.cartItemRepository.DeleteByUserId(ctx, reqDTO.Body.UserId); err != nil {
-			return fmt.Errorf("delete cart items from postgresql failed: %s", err.Error())
-		}
+	// Every item is priced by catalog-service at checkout, which applies promotions in effect, prices given by client are
+	// never recorded
+	newInvoiceDetails := splitInvoiceDetailsByStockAllocation(newInvoice.Id, reqDTO.Body.InvoiceDetails, grpcRes.StockAllocations, true)
+	for _, newInvoiceDetail := range newInvoiceDetails {
+		newInvoice.TotalAmount += newInvoiceDetail.TotalPrice
 	}
 
-	{
-		convertReqDTO := &catalogservicepb.UpdateProductStocksByListInvoiceDetailRequest{}
-		convertReqDTO.InvoiceDetails = make([]*catalogservicepb.InvoiceDetail, len(reqDTO.Body.InvoiceDetails))
-		for i := range reqDTO.Body.InvoiceDetails {
-			convertReqDTO.InvoiceDetails[i].ProductId = reqDTO.Body.InvoiceDetails[i].ProductId
-			convertReqDTO.InvoiceDetails[i].Quantity = reqDTO.Body.InvoiceDetails[i].Quantity
+	if err := invoiceService.invoiceRepository.Create(ctx, newInvoice, newInvoiceDetails); err != nil {
+		// Give taken stock back since the invoice does not exist, catalog-service cancels its pending backorders by itself and
+		// stock of bundles is given back through their components
+		restoreReqDTO := &catalogservicepb.RestoreProductStocksByListInvoiceDetailRequest{}
+		for _, newInvoiceDetail := range newInvoiceDetails {
+			if newInvoiceDetail.AllocationStatus == "PENDING" || newInvoiceDetail.IsBundle {
+				continue
+			}
+			restoreReqDTO.InvoiceDetails = append(restoreReqDTO.InvoiceDetails, &catalogservicepb.InvoiceDetail{
+				ProductId:   newInvoiceDetail.ProductId,
+				Quantity:    newInvoiceDetail.Quantity,
+				WarehouseId: newInvoiceDetail.WarehouseId,
+			})
 		}
-		_, err := infrastructure.CatalogServiceGRPCClient.UpdateProductStocksByListInvoiceDetail(ctx, convertReqDTO)
-		if err != nil {
-			return fmt.Errorf("update products from catalog-service failed: %s", err.Error())
+		restoreReqDTO.InvoiceId = newInvoice.Id
+		restoreReqDTO.ActorId = newInvoice.UserId
+		restoreReqDTO.Reason = "CANCEL_RESTORE"
+		if _, restoreErr := infrastructure.CatalogServiceGRPCClient.RestoreProductStocksByListInvoiceDetail(ctx, restoreReqDTO); restoreErr != nil {
+			return fmt.Errorf("insert invoice to postgresql failed: %s, restore stock of products from catalog-service failed: %s", err.Error(), restoreErr.Error())
+		}
+		return fmt.Errorf("insert invoice to postgresql failed: %s", err.Error())
+	}
+
+	if fromCart {
+		if err := invoiceService.cartItemRepository.DeleteByUserId(ctx, reqDTO.Body.UserId); err != nil {
+			return fmt.Errorf("delete cart items from postgresql failed: %s", err.Error())
 		}
 	}
 
-	newInvoiceView, _ := invoiceService.invoiceRepository.GetViewById(ctx, newInvoice.Id, false)
+	newInvoiceView, _ := invoiceService.invoiceRepository.GetViewById(ctx, newInvoice.Id, true)
 	payload, _ := json.Marshal(newInvoiceView)
 	if err := infrastructure.RedisClient.Publish(ctx, "order-service.created-invoice", payload).Err(); err != nil {
 		return fmt.Errorf("pulish event order-service.created-invoice failed: %s", err.Error())
@@ -151,17 +174,56 @@ func (invoiceService *invoiceService) UpdateInvoiceById(ctx context.Context, req
 		return fmt.Errorf("id of invoice is not valid: no permission")
 	}
 
+	oldStatus := foundInvoice.Status
 	if reqDTO.Body.Status != nil {
 		foundInvoice.Status = *reqDTO.Body.Status
 	}
+	if oldStatus == "CANCEL" && foundInvoice.Status != "CANCEL" {
+		return fmt.Errorf("status of cancelled invoice can not be changed")
+	}
 	timeUpdate := time.Now().UTC()
 	foundInvoice.UpdatedAt = &timeUpdate
 
+	// Sold stock goes back to catalog when invoice is cancelled, before the cancel is stored so that a failed restore leaves
+	// invoice as it was to be cancelled again. Catalog-service gives stock of an invoice back only once, so a cancel whose
+	// update fails after the restore can be retried too.
+	if oldStatus != "CANCEL" && foundInvoice.Status == "CANCEL" {
+		if infrastructure.CatalogServiceGRPCClient == nil {
+			return fmt.Errorf("catalog-service is not running")
+		}
+
+		invoiceView, err := invoiceService.invoiceRepository.GetViewById(ctx, foundInvoice.Id, true)
+		if err != nil {
+			return fmt.Errorf("query invoice from postgresql failed: %s", err.Error())
+		}
+
+		// Pending invoice details hold no stock, catalog-service cancels their backorders by id of invoice and gives back stock
+		// of the allocated ones itself, whether their allocation reached the invoice or not. Bundle details hold no stock
+		// either, details of their components do.
+		convertReqDTO := &catalogservicepb.RestoreProductStocksByListInvoiceDetailRequest{}
+		for _, invoiceDetail := range invoiceView.InvoiceDetails {
+			if invoiceDetail.AllocationStatus == "PENDING" || invoiceDetail.BackorderId != "" || invoiceDetail.IsBundle {
+				continue
+			}
+			convertReqDTO.InvoiceDetails = append(convertReqDTO.InvoiceDetails, &catalogservicepb.InvoiceDetail{
+				ProductId:   invoiceDetail.ProductId,
+				Quantity:    invoiceDetail.Quantity,
+				WarehouseId: invoiceDetail.WarehouseId,
+			})
+		}
+		convertReqDTO.InvoiceId = foundInvoice.Id
+		convertReqDTO.ActorId, _ = ctx.Value("user_id").(string)
+		convertReqDTO.Reason = "CANCEL_RESTORE"
+		if _, err := infrastructure.CatalogServiceGRPCClient.RestoreProductStocksByListInvoiceDetail(ctx, convertReqDTO); err != nil {
+			return fmt.Errorf("restore stock of products from catalog-service failed: %s", err.Error())
+		}
+	}
+
 	if err := invoiceService.invoiceRepository.Update(ctx, foundInvoice); err != nil {
 		return fmt.Errorf("update invoice on postgresql failed: %s", err.Error())
 	}
 
-	updatedInvoiceView, _ := invoiceService.invoiceRepository.GetViewById(ctx, foundInvoice.Id, false)
+	updatedInvoiceView, _ := invoiceService.invoiceRepository.GetViewById(ctx, foundInvoice.Id, true)
 	payload, _ := json.Marshal(updatedInvoiceView)
 	if err := infrastructure.RedisClient.Publish(ctx, "order-service.updated-invoice", payload).Err(); err != nil {
 		return fmt.Errorf("pulish event order-service.updated-invoice failed: %s", err.Error())
@@ -191,13 +253,40 @@ func (invoiceService *invoiceService) DeleteInvoiceById(ctx context.Context, req
 	return nil
 }
 
-func (invoiceService *invoiceService) GetAllInvoices(ctx context.Context) ([]*model.InvoiceView, error) {
-	foundInvoices, err := invoiceService.invoiceRepository.GetAllViews(ctx, false)
+// Invoices with their details are sent page by page, so that no message grows with invoices
+func (invoiceService *invoiceService) StreamAllInvoices(ctx context.Context, send func(invoices []*model.InvoiceView) error) error {
+	afterId := ""
+	for {
+		invoices, err := invoiceService.invoiceRepository.GetViewsAfterId(ctx, afterId, invoiceStreamBatchSize, true)
+		if err != nil {
+			return fmt.Errorf("query invoices from postgresql failed: %s", err.Error())
+		}
+		if len(invoices) == 0 {
+			return nil
+		}
+
+		if err := send(invoices); err != nil {
+			return fmt.Errorf("send invoices failed: %s", err.Error())
+		}
+
+		if len(invoices) < invoiceStreamBatchSize {
+			return nil
+		}
+		afterId = invoices[len(invoices)-1].Id
+	}
+}
+
+// Return id of latest DONE invoice of user containing product, empty when user never received product
+func (invoiceService *invoiceService) CheckPurchasedProduct(ctx context.Context, reqDTO *dto.CheckPurchasedProductRequest) (string, error) {
+	invoiceId, err := invoiceService.invoiceRepository.GetDoneIdByUserIdAndProductId(ctx, reqDTO.UserId, reqDTO.ProductId)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("query invoices from postgresql failed: %s", err.Error())
+		return "", fmt.Errorf("query invoices from postgresql failed: %s", err.Error())
 	}
 
-	return foundInvoices, nil
+	return invoiceId, nil
 }
 
 func (invoiceService *invoiceService) GetInvoices(ctx context.Context, reqDTO *dto.GetInvoicesRequest) ([]*model.InvoiceView, error) {
@@ -212,6 +301,12 @@ func (invoiceService *invoiceService) GetInvoices(ctx context.Context, reqDTO *d
 		convertReqDTO.Status = reqDTO.Status
 		convertReqDTO.CreatedAtGte = reqDTO.CreatedAtGTE
 		convertReqDTO.CreatedAtLte = reqDTO.CreatedAtLTE
+		convertReqDTO.ProductId = reqDTO.ProductId
+		convertReqDTO.ProductName = reqDTO.ProductName
+		convertReqDTO.CategoryId = reqDTO.CategoryId
+		convertReqDTO.CategoryName = reqDTO.CategoryName
+		convertReqDTO.BrandId = reqDTO.BrandId
+		convertReqDTO.BrandName = reqDTO.BrandName
 
 		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetInvoices(ctx, convertReqDTO)
 		if err != nil {
@@ -223,3 +318,259 @@ func (invoiceService *invoiceService) GetInvoices(ctx context.Context, reqDTO *d
 		return nil, fmt.Errorf("elasticsearch-service is not running")
 	}
 }
+
+func (invoiceService *invoiceService) GetSalesReport(ctx context.Context, reqDTO *dto.GetSalesReportRequest) (*model.SalesReportView, error) {
+	if infrastructure.ElasticsearchServiceGRPCClient != nil {
+		convertReqDTO := &elasticsearchservicepb.GetSalesReportRequest{}
+		convertReqDTO.TimeInterval = reqDTO.TimeInterval
+		convertReqDTO.Status = reqDTO.Status
+		convertReqDTO.CreatedAtGte = reqDTO.CreatedAtGTE
+		convertReqDTO.CreatedAtLte = reqDTO.CreatedAtLTE
+		convertReqDTO.GroupBy = reqDTO.GroupBy
+
+		grpcRes, err := infrastructure.ElasticsearchServiceGRPCClient.GetSalesReport(ctx, convertReqDTO)
+		if err != nil {
+			return nil, fmt.Errorf("get sales report from elasticsearch-service failed: %s", err.Error())
+		}
+
+		return model.FromSalesReportProtoToSalesReportView(grpcRes.SalesReport), nil
+	} else {
+		return nil, fmt.Errorf("elasticsearch-service is not running")
+	}
+}
+
+// Pending invoice details of backorder are split into warehouse ones once catalog-service allocates stock to it. Stock allocated
+// to an invoice cancelled in the meantime is given back to catalog.
+func (invoiceService *invoiceService) watchAllocatedBackorderLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.allocated-backorder")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var allocatedBackorder dto.AllocatedBackorderEventPayload
+		if err := json.Unmarshal([]byte(msg.Payload), &allocatedBackorder); err != nil {
+			log.Printf("Parse payload from event catalog-service.allocated-backorder failed: %s", err.Error())
+			continue
+		}
+
+		if err := invoiceService.allocatePendingInvoiceDetails(context.Background(), &allocatedBackorder); err != nil {
+			log.Printf("Allocate backorder %s of invoice %s failed: %s", allocatedBackorder.Id, allocatedBackorder.InvoiceId, err.Error())
+		}
+	}
+}
+
+// Allocations missed by watchAllocatedBackorderLoop (order-service down, event lost, failed update) stay unconfirmed on
+// catalog-service and are pulled again until they are applied
+func (invoiceService *invoiceService) reconcileAllocatedBackorderLoop() {
+	ticker := time.NewTicker(config.AppConfig.BackorderReconcileIntervalValue())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if infrastructure.CatalogServiceGRPCClient == nil {
+			continue
+		}
+
+		ctx := context.Background()
+		res, err := infrastructure.CatalogServiceGRPCClient.GetUnconfirmedAllocatedBackorders(ctx, &catalogservicepb.GetUnconfirmedAllocatedBackordersRequest{Limit: 100})
+		if err != nil {
+			log.Printf("Query allocated backorders from catalog-service failed: %s", err.Error())
+			continue
+		}
+
+		for _, allocatedBackorderProto := range res.AllocatedBackorders {
+			allocatedBackorder := &dto.AllocatedBackorderEventPayload{
+				Id:               allocatedBackorderProto.Id,
+				InvoiceId:        allocatedBackorderProto.InvoiceId,
+				ProductId:        allocatedBackorderProto.ProductId,
+				Quantity:         allocatedBackorderProto.Quantity,
+				StockAllocations: make([]*dto.AllocatedBackorderStockEventPayload, len(allocatedBackorderProto.StockAllocations)),
+			}
+			for i, stockAllocationProto := range allocatedBackorderProto.StockAllocations {
+				allocatedBackorder.StockAllocations[i] = &dto.AllocatedBackorderStockEventPayload{
+					WarehouseId: stockAllocationProto.WarehouseId,
+					Quantity:    stockAllocationProto.Quantity,
+				}
+			}
+
+			if err := invoiceService.allocatePendingInvoiceDetails(ctx, allocatedBackorder); err != nil {
+				log.Printf("Allocate backorder %s of invoice %s failed: %s", allocatedBackorder.Id, allocatedBackorder.InvoiceId, err.Error())
+			}
+		}
+	}
+}
+
+// Pending invoice details of product are replaced by details of its allocation, then allocation is confirmed to
+// catalog-service. Applying an allocation again (event and pull, several replicas) finds no pending details and only
+// confirms it.
+func (invoiceService *invoiceService) allocatePendingInvoiceDetails(ctx context.Context, allocatedBackorder *dto.AllocatedBackorderEventPayload) error {
+	foundInvoice, err := invoiceService.invoiceRepository.GetById(ctx, allocatedBackorder.InvoiceId)
+	if err != nil {
+		return fmt.Errorf("id of invoice is not valid: %s", err.Error())
+	}
+
+	// Stock of backorder of cancelled invoice is given back by catalog-service when the cancel reaches it
+	if foundInvoice.Status == "CANCEL" {
+		return invoiceService.confirmAllocatedBackorder(ctx, allocatedBackorder.Id)
+	}
+
+	pendingInvoiceDetails, err := invoiceService.invoiceRepository.GetPendingDetailsByIdAndProductId(ctx, foundInvoice.Id, allocatedBackorder.ProductId)
+	if err != nil {
+		return fmt.Errorf("query pending invoice details from postgresql failed: %s", err.Error())
+	}
+	if len(pendingInvoiceDetails) == 0 {
+		return invoiceService.confirmAllocatedBackorder(ctx, allocatedBackorder.Id)
+	}
+
+	// Price of pending invoice details is kept, only their quantity is split by fulfilling warehouse
+	invoiceDetails := make([]dto.InvoiceDetail, len(pendingInvoiceDetails))
+	for i, pendingInvoiceDetail := range pendingInvoiceDetails {
+		invoiceDetails[i] = dto.InvoiceDetail{
+			ProductId:          pendingInvoiceDetail.ProductId,
+			Price:              pendingInvoiceDetail.Price,
+			DiscountPercentage: pendingInvoiceDetail.DiscountPercentage,
+			Quantity:           pendingInvoiceDetail.Quantity,
+			TotalPrice:         pendingInvoiceDetail.TotalPrice,
+		}
+	}
+	stockAllocations := make([]*catalogservicepb.StockAllocation, len(allocatedBackorder.StockAllocations))
+	for i, stockAllocation := range allocatedBackorder.StockAllocations {
+		stockAllocations[i] = &catalogservicepb.StockAllocation{
+			ProductId:   allocatedBackorder.ProductId,
+			WarehouseId: stockAllocation.WarehouseId,
+			Quantity:    stockAllocation.Quantity,
+			PromotionId: pendingInvoiceDetails[0].PromotionId,
+		}
+	}
+	newInvoiceDetails := splitInvoiceDetailsByStockAllocation(foundInvoice.Id, invoiceDetails, stockAllocations, false)
+	for _, newInvoiceDetail := range newInvoiceDetails {
+		newInvoiceDetail.BackorderId = allocatedBackorder.Id
+	}
+
+	if err := invoiceService.invoiceRepository.ReplacePendingDetailsById(ctx, foundInvoice.Id, pendingInvoiceDetails, newInvoiceDetails); errors.Is(err, repository.ErrInvoiceDetailsNotPending) {
+		// Another replica applied it meanwhile
+		return invoiceService.confirmAllocatedBackorder(ctx, allocatedBackorder.Id)
+	} else if err != nil {
+		return fmt.Errorf("update invoice details on postgresql failed: %s", err.Error())
+	}
+
+	if err := invoiceService.confirmAllocatedBackorder(ctx, allocatedBackorder.Id); err != nil {
+		return err
+	}
+
+	updatedInvoiceView, err := invoiceService.invoiceRepository.GetViewById(ctx, foundInvoice.Id, true)
+	if err != nil {
+		return fmt.Errorf("query invoice from postgresql failed: %s", err.Error())
+	}
+	payload, _ := json.Marshal(updatedInvoiceView)
+	if err := infrastructure.RedisClient.Publish(ctx, "order-service.updated-invoice", payload).Err(); err != nil {
+		return fmt.Errorf("pulish event order-service.updated-invoice failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Allocation left unconfirmed (catalog-service down) is pulled again and only confirmed then
+func (invoiceService *invoiceService) confirmAllocatedBackorder(ctx context.Context, backorderId string) error {
+	if infrastructure.CatalogServiceGRPCClient == nil {
+		return fmt.Errorf("catalog-service is not running")
+	}
+
+	if _, err := infrastructure.CatalogServiceGRPCClient.ConfirmAllocatedBackorder(ctx, &catalogservicepb.ConfirmAllocatedBackorderRequest{Id: backorderId}); err != nil {
+		return fmt.Errorf("confirm backorder on catalog-service failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Invoice detail is split into one detail per fulfilling warehouse, total price is split by quantity and last part takes rest
+// of it so that total amount is unchanged. Allocations of a product are consumed by its invoice details in order. When priced
+// by stock allocation, price and discount of each part are the ones of its allocation instead of the given ones. Detail of a
+// bundle is followed by unpriced details of its components, one per fulfilling warehouse.
+func splitInvoiceDetailsByStockAllocation(invoiceId string, invoiceDetails []dto.InvoiceDetail, stockAllocations []*catalogservicepb.StockAllocation, pricedByStockAllocation bool) []*model.InvoiceDetail {
+	stockAllocationsMap := map[string][]*catalogservicepb.StockAllocation{}
+	componentStockAllocationsMap := map[string][]*catalogservicepb.StockAllocation{}
+	for _, stockAllocation := range stockAllocations {
+		if stockAllocation.BundleId != "" {
+			componentStockAllocationsMap[stockAllocation.BundleId] = append(componentStockAllocationsMap[stockAllocation.BundleId], stockAllocation)
+			continue
+		}
+		stockAllocationsMap[stockAllocation.ProductId] = append(stockAllocationsMap[stockAllocation.ProductId], &catalogservicepb.StockAllocation{
+			ProductId:          stockAllocation.ProductId,
+			WarehouseId:        stockAllocation.WarehouseId,
+			Quantity:           stockAllocation.Quantity,
+			Price:              stockAllocation.Price,
+			DiscountPercentage: stockAllocation.DiscountPercentage,
+			PromotionId:        stockAllocation.PromotionId,
+			Pending:            stockAllocation.Pending,
+			Bundle:             stockAllocation.Bundle,
+		})
+	}
+
+	newInvoiceDetails := []*model.InvoiceDetail{}
+	for _, invoiceDetail := range invoiceDetails {
+		remainingQuantity := invoiceDetail.Quantity
+		remainingTotalPrice := invoiceDetail.TotalPrice
+		for remainingQuantity > 0 {
+			newInvoiceDetail := &model.InvoiceDetail{
+				Id:                 uuid.New().String(),
+				InvoiceId:          invoiceId,
+				ProductId:          invoiceDetail.ProductId,
+				Price:              invoiceDetail.Price,
+				DiscountPercentage: invoiceDetail.DiscountPercentage,
+				Quantity:           remainingQuantity,
+				AllocationStatus:   "ALLOCATED",
+			}
+
+			productStockAllocations := stockAllocationsMap[invoiceDetail.ProductId]
+			if len(productStockAllocations) != 0 {
+				stockAllocation := productStockAllocations[0]
+				newInvoiceDetail.WarehouseId = stockAllocation.WarehouseId
+				newInvoiceDetail.PromotionId = stockAllocation.PromotionId
+				if stockAllocation.Pending {
+					newInvoiceDetail.AllocationStatus = "PENDING"
+				}
+				newInvoiceDetail.IsBundle = stockAllocation.Bundle
+				newInvoiceDetail.Quantity = min(stockAllocation.Quantity, remainingQuantity)
+				stockAllocation.Quantity -= newInvoiceDetail.Quantity
+				if stockAllocation.Quantity == 0 {
+					stockAllocationsMap[invoiceDetail.ProductId] = productStockAllocations[1:]
+				}
+
+				if pricedByStockAllocation {
+					newInvoiceDetail.Price = stockAllocation.Price
+					newInvoiceDetail.DiscountPercentage = stockAllocation.DiscountPercentage
+					newInvoiceDetail.TotalPrice = model.FinalPrice(stockAllocation.Price, stockAllocation.DiscountPercentage) * int64(newInvoiceDetail.Quantity)
+					remainingQuantity -= newInvoiceDetail.Quantity
+					newInvoiceDetails = append(newInvoiceDetails, newInvoiceDetail)
+					continue
+				}
+			}
+
+			if newInvoiceDetail.Quantity == remainingQuantity {
+				newInvoiceDetail.TotalPrice = remainingTotalPrice
+			} else {
+				newInvoiceDetail.TotalPrice = invoiceDetail.TotalPrice * int64(newInvoiceDetail.Quantity) / int64(invoiceDetail.Quantity)
+			}
+			remainingQuantity -= newInvoiceDetail.Quantity
+			remainingTotalPrice -= newInvoiceDetail.TotalPrice
+
+			newInvoiceDetails = append(newInvoiceDetails, newInvoiceDetail)
+		}
+
+		for _, componentStockAllocation := range componentStockAllocationsMap[invoiceDetail.ProductId] {
+			newInvoiceDetails = append(newInvoiceDetails, &model.InvoiceDetail{
+				Id:               uuid.New().String(),
+				InvoiceId:        invoiceId,
+				ProductId:        componentStockAllocation.ProductId,
+				Quantity:         componentStockAllocation.Quantity,
+				WarehouseId:      componentStockAllocation.WarehouseId,
+				AllocationStatus: "ALLOCATED",
+				BundleId:         invoiceDetail.ProductId,
+			})
+		}
+		delete(componentStockAllocationsMap, invoiceDetail.ProductId)
+	}
+
+	return newInvoiceDetails
+}
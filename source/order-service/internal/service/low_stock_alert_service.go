@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/grpc/client/userservicepb"
+	"time"
+)
+
+type lowStockAlertService struct {
+	notificationQueue chan *infrastructure.Notification
+}
+
+type LowStockAlertService interface {
+	watchLowStockProductLoop()
+	sendNotificationLoop()
+}
+
+func NewLowStockAlertService() LowStockAlertService {
+	lowStockAlertService := &lowStockAlertService{
+		notificationQueue: make(chan *infrastructure.Notification, notificationQueueSize),
+	}
+
+	go lowStockAlertService.watchLowStockProductLoop()
+	go lowStockAlertService.sendNotificationLoop()
+
+	return lowStockAlertService
+}
+
+// Notify admins and staffs when catalog-service detects a product dropping to or below its low stock threshold
+func (lowStockAlertService *lowStockAlertService) watchLowStockProductLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.low-stock")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var lowStockProduct dto.LowStockProductEventPayload
+		if err := json.Unmarshal([]byte(msg.Payload), &lowStockProduct); err != nil {
+			log.Printf("Parse payload from event catalog-service.low-stock failed: %s", err.Error())
+			continue
+		}
+
+		if infrastructure.UserServiceGRPCClient == nil {
+			log.Printf("Notify low stock of product %s failed: user-service is not running", lowStockProduct.Id)
+			continue
+		}
+
+		users, err := lowStockAlertService.getBackOfficeUsers(context.Background())
+		if err != nil {
+			log.Printf("Get users from user-service failed: %s", err.Error())
+			continue
+		}
+
+		for _, user := range users {
+			lowStockAlertService.queueNotification(&infrastructure.Notification{
+				Type:    "LOW_STOCK",
+				UserId:  user.Id,
+				Title:   "Product is low on stock",
+				Message: fmt.Sprintf("%s (%s) has %d left, threshold is %d", lowStockProduct.Name, lowStockProduct.Sku, lowStockProduct.Stock, lowStockProduct.LowStockThreshold),
+				Data: map[string]string{
+					"product_id": lowStockProduct.Id,
+					"sku":        lowStockProduct.Sku,
+					"stock":      fmt.Sprint(lowStockProduct.Stock),
+					"threshold":  fmt.Sprint(lowStockProduct.LowStockThreshold),
+				},
+			})
+		}
+	}
+}
+
+// Admins and staffs from user-service
+func (lowStockAlertService *lowStockAlertService) getBackOfficeUsers(ctx context.Context) ([]*userservicepb.User, error) {
+	grpcRes, err := infrastructure.UserServiceGRPCClient.GetUsersByRoleNames(ctx, &userservicepb.GetUsersByRoleNamesRequest{
+		RoleNames: []string{"ADMIN", "STAFF"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcRes.Users, nil
+}
+
+// Queue notification without blocking event consumer, notification is dropped when queue is full
+func (lowStockAlertService *lowStockAlertService) queueNotification(notification *infrastructure.Notification) {
+	notification.CreatedAt = time.Now().UTC()
+
+	select {
+	case lowStockAlertService.notificationQueue <- notification:
+	default:
+		log.Printf("Notification queue is full, drop %s notification to user %s", notification.Type, notification.UserId)
+	}
+}
+
+func (lowStockAlertService *lowStockAlertService) sendNotificationLoop() {
+	for notification := range lowStockAlertService.notificationQueue {
+		if err := infrastructure.Notifier.Notify(context.Background(), notification); err != nil {
+			log.Printf("Send %s notification to user %s failed: %s", notification.Type, notification.UserId, err.Error())
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"thanhldt060802/infrastructure"
+	"thanhldt060802/internal/dto"
+	"thanhldt060802/internal/model"
+	"thanhldt060802/internal/repository"
+	"thanhldt060802/utils"
+	"time"
+)
+
+const notificationQueueSize = 1000
+
+type wishlistItemService struct {
+	wishlistItemRepository repository.WishlistItemRepository
+	cartItemRepository     repository.CartItemRepository
+	notificationQueue      chan *infrastructure.Notification
+}
+
+type WishlistItemService interface {
+	GetMyWishlistItems(ctx context.Context, reqDTO *dto.GetMyWishlistItemsRequest) ([]*model.WishlistItemView, error)
+	CreateMyWishlistItem(ctx context.Context, reqDTO *dto.CreateMyWishlistItemRequest) error
+	DeleteMyWishlistItemById(ctx context.Context, reqDTO *dto.DeleteMyWishlistItemByIdRequest) error
+	MoveMyWishlistItemToCart(ctx context.Context, reqDTO *dto.MoveMyWishlistItemToCartRequest) error
+	watchUpdatingProductLoop()
+	sendNotificationLoop()
+}
+
+func NewWishlistItemService(wishlistItemRepository repository.WishlistItemRepository, cartItemRepository repository.CartItemRepository) WishlistItemService {
+	wishlistItemService := &wishlistItemService{
+		wishlistItemRepository: wishlistItemRepository,
+		cartItemRepository:     cartItemRepository,
+		notificationQueue:      make(chan *infrastructure.Notification, notificationQueueSize),
+	}
+
+	go wishlistItemService.watchUpdatingProductLoop()
+	go wishlistItemService.sendNotificationLoop()
+
+	return wishlistItemService
+}
+
+func (wishlistItemService *wishlistItemService) GetMyWishlistItems(ctx context.Context, reqDTO *dto.GetMyWishlistItemsRequest) ([]*model.WishlistItemView, error) {
+	userId, _ := ctx.Value("user_id").(string)
+	sortFields := utils.ParseSorter(reqDTO.SortBy)
+
+	wishlistItems, err := wishlistItemService.wishlistItemRepository.GetViewsByUserId(ctx, userId, reqDTO.Offset, reqDTO.Limit, sortFields)
+	if err != nil {
+		return nil, fmt.Errorf("query wishlist items from postgresql failed: %s", err.Error())
+	}
+
+	// Prices and stock follow catalog-service in one lookup for the whole page, stored ones are kept while it is not running
+	if infrastructure.CatalogServiceGRPCClient == nil {
+		return wishlistItems, nil
+	}
+	productIds := make([]string, len(wishlistItems))
+	for i, wishlistItem := range wishlistItems {
+		productIds[i] = wishlistItem.ProductId
+	}
+	productMap, err := getProductsByIds(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, wishlistItem := range wishlistItems {
+		if product, ok := productMap[wishlistItem.ProductId]; ok {
+			wishlistItem.ProductName = product.Name
+			wishlistItem.ProductPrice = product.Price
+			wishlistItem.ProductDiscountPercentage = product.DiscountPercentage
+			wishlistItem.ProductStock = product.Stock
+			wishlistItem.ProductImageURL = product.ImageUrl
+		}
+	}
+
+	return wishlistItems, nil
+}
+
+func (wishlistItemService *wishlistItemService) CreateMyWishlistItem(ctx context.Context, reqDTO *dto.CreateMyWishlistItemRequest) error {
+	if infrastructure.CatalogServiceGRPCClient != nil {
+		userId, _ := ctx.Value("user_id").(string)
+
+		if _, err := wishlistItemService.wishlistItemRepository.GetByUserIdAndProductId(ctx, userId, reqDTO.Body.ProductId); err == nil {
+			return fmt.Errorf("product is already in wishlist")
+		}
+
+		productMap, err := getProductsByIds(ctx, []string{reqDTO.Body.ProductId})
+		if err != nil {
+			return err
+		}
+		product, ok := productMap[reqDTO.Body.ProductId]
+		if !ok {
+			return fmt.Errorf("id of product is not valid")
+		}
+
+		newWishlistItem := model.WishlistItem{
+			UserId:         userId,
+			ProductId:      reqDTO.Body.ProductId,
+			LastKnownPrice: model.FinalPrice(product.Price, product.DiscountPercentage),
+			LastKnownStock: product.Stock,
+		}
+		if err := wishlistItemService.wishlistItemRepository.Create(ctx, &newWishlistItem); err != nil {
+			return fmt.Errorf("insert wishlist item to postgresql failed: %s", err.Error())
+		}
+
+		return nil
+	} else {
+		return fmt.Errorf("catalog-service is not running")
+	}
+}
+
+func (wishlistItemService *wishlistItemService) DeleteMyWishlistItemById(ctx context.Context, reqDTO *dto.DeleteMyWishlistItemByIdRequest) error {
+	foundWishlistItem, err := wishlistItemService.getMyWishlistItemById(ctx, reqDTO.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := wishlistItemService.wishlistItemRepository.DeleteById(ctx, foundWishlistItem.Id); err != nil {
+		return fmt.Errorf("delete wishlist item from postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (wishlistItemService *wishlistItemService) MoveMyWishlistItemToCart(ctx context.Context, reqDTO *dto.MoveMyWishlistItemToCartRequest) error {
+	foundWishlistItem, err := wishlistItemService.getMyWishlistItemById(ctx, reqDTO.Id)
+	if err != nil {
+		return err
+	}
+
+	// Increase quantity if product is already in cart, otherwise add a new cart item
+	foundCartItem, err := wishlistItemService.cartItemRepository.GetByUserIdAndProductId(ctx, foundWishlistItem.UserId, foundWishlistItem.ProductId)
+	if err == nil {
+		foundCartItem.Quantity++
+		if err := wishlistItemService.cartItemRepository.Update(ctx, foundCartItem); err != nil {
+			return fmt.Errorf("update cart item on postgresql failed: %s", err.Error())
+		}
+	} else if errors.Is(err, sql.ErrNoRows) {
+		newCartItem := model.CartItem{
+			UserId:    foundWishlistItem.UserId,
+			ProductId: foundWishlistItem.ProductId,
+			Quantity:  1,
+		}
+		if err := wishlistItemService.cartItemRepository.Create(ctx, &newCartItem); err != nil {
+			return fmt.Errorf("insert cart item to postgresql failed: %s", err.Error())
+		}
+	} else {
+		return fmt.Errorf("query cart item from postgresql failed: %s", err.Error())
+	}
+
+	if err := wishlistItemService.wishlistItemRepository.DeleteById(ctx, foundWishlistItem.Id); err != nil {
+		return fmt.Errorf("delete wishlist item from postgresql failed: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (wishlistItemService *wishlistItemService) getMyWishlistItemById(ctx context.Context, id string) (*model.WishlistItem, error) {
+	foundWishlistItem, err := wishlistItemService.wishlistItemRepository.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("id of wishlist item is not valid: %s", err.Error())
+	}
+
+	if userId, _ := ctx.Value("user_id").(string); userId != foundWishlistItem.UserId {
+		return nil, fmt.Errorf("id of wishlist item is not valid: no permission")
+	}
+
+	return foundWishlistItem, nil
+}
+
+// Detect price drop and back in stock of wishlisted products from updated products
+func (wishlistItemService *wishlistItemService) watchUpdatingProductLoop() {
+	subscribe := infrastructure.RedisClient.Subscribe(context.Background(), "catalog-service.updated-product")
+	defer subscribe.Close()
+
+	ch := subscribe.Channel()
+
+	for msg := range ch {
+		var updatedProduct dto.ProductEventPayload
+		if err := json.Unmarshal([]byte(msg.Payload), &updatedProduct); err != nil {
+			log.Printf("Parse payload from event catalog-service.updated-product failed: %s", err.Error())
+			continue
+		}
+
+		ctx := context.Background()
+
+		wishlistItems, err := wishlistItemService.wishlistItemRepository.GetByProductId(ctx, updatedProduct.Id)
+		if err != nil {
+			log.Printf("Query wishlist items from postgresql failed: %s", err.Error())
+			continue
+		}
+		if len(wishlistItems) == 0 {
+			continue
+		}
+
+		newPrice := model.FinalPrice(updatedProduct.Price, updatedProduct.DiscountPercentage)
+		for _, wishlistItem := range wishlistItems {
+			if newPrice < wishlistItem.LastKnownPrice {
+				wishlistItemService.queueNotification(&infrastructure.Notification{
+					Type:    "PRICE_DROP",
+					UserId:  wishlistItem.UserId,
+					Title:   "Price drop on your wishlist",
+					Message: fmt.Sprintf("%s is now %d (was %d)", updatedProduct.Name, newPrice, wishlistItem.LastKnownPrice),
+					Data: map[string]string{
+						"product_id": updatedProduct.Id,
+						"old_price":  fmt.Sprint(wishlistItem.LastKnownPrice),
+						"new_price":  fmt.Sprint(newPrice),
+						"image_url":  updatedProduct.ImageURL,
+					},
+				})
+			}
+			if wishlistItem.LastKnownStock <= 0 && updatedProduct.Stock > 0 {
+				wishlistItemService.queueNotification(&infrastructure.Notification{
+					Type:    "BACK_IN_STOCK",
+					UserId:  wishlistItem.UserId,
+					Title:   "Back in stock on your wishlist",
+					Message: fmt.Sprintf("%s is back in stock", updatedProduct.Name),
+					Data: map[string]string{
+						"product_id": updatedProduct.Id,
+						"stock":      fmt.Sprint(updatedProduct.Stock),
+						"image_url":  updatedProduct.ImageURL,
+					},
+				})
+			}
+		}
+
+		if err := wishlistItemService.wishlistItemRepository.UpdateLastKnownByProductId(ctx, updatedProduct.Id, newPrice, updatedProduct.Stock); err != nil {
+			log.Printf("Update wishlist items on postgresql failed: %s", err.Error())
+		}
+	}
+}
+
+// Queue notification without blocking event consumer, notification is dropped when queue is full
+func (wishlistItemService *wishlistItemService) queueNotification(notification *infrastructure.Notification) {
+	notification.CreatedAt = time.Now().UTC()
+
+	select {
+	case wishlistItemService.notificationQueue <- notification:
+	default:
+		log.Printf("Notification queue is full, drop %s notification to user %s", notification.Type, notification.UserId)
+	}
+}
+
+func (wishlistItemService *wishlistItemService) sendNotificationLoop() {
+	for notification := range wishlistItemService.notificationQueue {
+		if err := infrastructure.Notifier.Notify(context.Background(), notification); err != nil {
+			log.Printf("Send %s notification to user %s failed: %s", notification.Type, notification.UserId, err.Error())
+		}
+	}
+}
@@ -18,6 +18,14 @@ type GetUserByIdRequest struct {
 	Id string `path:"id" doc:"Id of user."`
 }
 
+type GetBodyMeasurementsByUserIdRequest struct {
+	UserId string
+}
+
+type GetUsersByRoleNamesRequest struct {
+	RoleNames []string
+}
+
 type CreateUserRequest struct {
 	Body struct {
 		FullName string `json:"full_name" required:"true" minLength:"1" doc:"Full name of user."`
@@ -37,6 +45,9 @@ type UpdateUserByIdRequest struct {
 		Password *string `json:"password,omitempty" minLength:"1" doc:"Password of user."`
 		Address  *string `json:"address,omitempty" minLength:"1" doc:"Address of user."`
 		RoleName *string `json:"role_name,omitempty" enum:"ADMIN,CUSTOMER" doc:"Role name of user."`
+
+		BodyMeasurements       *BodyMeasurementsRequest `json:"body_measurements,omitempty" doc:"Body measurements of user, they replace the current ones."`
+		RemoveBodyMeasurements bool                     `json:"remove_body_measurements,omitempty" doc:"Clear body measurements of user."`
 	}
 }
 
@@ -71,9 +82,22 @@ type UpdateAccountRequest struct {
 		Email    *string `json:"email,omitempty" minLength:"1" format:"email" doc:"Email of user account."`
 		Password *string `json:"password,omitempty" minLength:"1" doc:"Password of user acccount."`
 		Address  *string `json:"address,omitempty" minLength:"1" doc:"Address of user account."`
+
+		BodyMeasurements       *BodyMeasurementsRequest `json:"body_measurements,omitempty" doc:"Body measurements for size recommendation, they replace the current ones."`
+		RemoveBodyMeasurements bool                     `json:"remove_body_measurements,omitempty" doc:"Clear body measurements of user account."`
 	}
 }
 
+// Measurements in cm, measurements left out are unknown
+type BodyMeasurementsRequest struct {
+	Height     *float64 `json:"height,omitempty" exclusiveMinimum:"0" maximum:"300" example:"172" doc:"Height in cm."`
+	Chest      *float64 `json:"chest,omitempty" exclusiveMinimum:"0" maximum:"300" example:"96" doc:"Chest circumference in cm."`
+	Waist      *float64 `json:"waist,omitempty" exclusiveMinimum:"0" maximum:"300" example:"82" doc:"Waist circumference in cm."`
+	Hip        *float64 `json:"hip,omitempty" exclusiveMinimum:"0" maximum:"300" example:"98" doc:"Hip circumference in cm."`
+	Inseam     *float64 `json:"inseam,omitempty" exclusiveMinimum:"0" maximum:"300" example:"80" doc:"Inseam length in cm."`
+	FootLength *float64 `json:"foot_length,omitempty" exclusiveMinimum:"0" maximum:"300" example:"26.5" doc:"Foot length in cm."`
+}
+
 // GetAllLoggedInAccountsRequest
 
 type DeleteLoggedInAccountRequest struct {
@@ -6,6 +6,8 @@ import (
 	"thanhldt060802/internal/grpc/service/userservicepb"
 	"thanhldt060802/internal/model"
 	"thanhldt060802/internal/service"
+
+	"google.golang.org/grpc"
 )
 
 type UserServiceGRPCImpl struct {
@@ -17,15 +19,12 @@ func NewUserServiceGRPCImpl(userService service.UserService) *UserServiceGRPCImp
 	return &UserServiceGRPCImpl{userService: userService}
 }
 
-func (userServiceGRPC *UserServiceGRPCImpl) GetAllUsers(ctx context.Context, req *userservicepb.GetAllUsersRequest) (*userservicepb.GetAllUsersResponse, error) {
-	users, err := userServiceGRPC.userService.GetAllUsers(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res := &userservicepb.GetAllUsersResponse{}
-	res.Users = model.FromListUserViewToListUserProto(users)
-	return res, nil
+func (userServiceGRPC *UserServiceGRPCImpl) StreamAllUsers(req *userservicepb.StreamAllUsersRequest, stream grpc.ServerStreamingServer[userservicepb.StreamAllUsersResponse]) error {
+	return userServiceGRPC.userService.StreamAllUsers(stream.Context(), func(users []*model.UserView) error {
+		res := &userservicepb.StreamAllUsersResponse{}
+		res.Users = model.FromListUserViewToListUserProto(users)
+		return stream.Send(res)
+	})
 }
 
 func (userServiceGRPC *UserServiceGRPCImpl) GetUserById(ctx context.Context, req *userservicepb.GetUserByIdRequest) (*userservicepb.GetUserByIdResponse, error) {
@@ -41,3 +40,31 @@ func (userServiceGRPC *UserServiceGRPCImpl) GetUserById(ctx context.Context, req
 	res.User = model.FromUserViewToUserProto(user)
 	return res, nil
 }
+
+func (userServiceGRPC *UserServiceGRPCImpl) GetBodyMeasurementsByUserId(ctx context.Context, req *userservicepb.GetBodyMeasurementsByUserIdRequest) (*userservicepb.GetBodyMeasurementsByUserIdResponse, error) {
+	convertReqDTO := &dto.GetBodyMeasurementsByUserIdRequest{}
+	convertReqDTO.UserId = req.UserId
+
+	bodyMeasurements, err := userServiceGRPC.userService.GetBodyMeasurementsByUserId(ctx, convertReqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userservicepb.GetBodyMeasurementsByUserIdResponse{}
+	res.BodyMeasurements = model.FromBodyMeasurementsToBodyMeasurementsProto(bodyMeasurements)
+	return res, nil
+}
+
+func (userServiceGRPC *UserServiceGRPCImpl) GetUsersByRoleNames(ctx context.Context, req *userservicepb.GetUsersByRoleNamesRequest) (*userservicepb.GetUsersByRoleNamesResponse, error) {
+	convertReqDTO := &dto.GetUsersByRoleNamesRequest{}
+	convertReqDTO.RoleNames = req.RoleNames
+
+	users, err := userServiceGRPC.userService.GetUsersByRoleNames(ctx, convertReqDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userservicepb.GetUsersByRoleNamesResponse{}
+	res.Users = model.FromListUserViewToListUserProto(users)
+	return res, nil
+}
@@ -110,6 +110,16 @@ func NewUserHandler(api huma.API, userService service.UserService, jwtAuthMiddle
 		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
 	}, userHandler.GetMyAccount)
 
+	// Get my body measurements
+	huma.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		Path:        "/my-account/body-measurements",
+		Summary:     "/my-account/body-measurements",
+		Description: "Get body measurements of account.",
+		Tags:        []string{"Account"},
+		Middlewares: huma.Middlewares{jwtAuthMiddleware.Authentication},
+	}, userHandler.GetMyBodyMeasurements)
+
 	// Update my account
 	huma.Register(api, huma.Operation{
 		Method:      http.MethodPut,
@@ -334,6 +344,27 @@ func (userHandler *UserHandler) GetMyAccount(ctx context.Context, _ *struct{}) (
 	return res, nil
 }
 
+func (userHandler *UserHandler) GetMyBodyMeasurements(ctx context.Context, _ *struct{}) (*dto.BodyResponse[*model.BodyMeasurements], error) {
+	convertReqDTO := &dto.GetBodyMeasurementsByUserIdRequest{}
+	convertReqDTO.UserId = ctx.Value("user_id").(string)
+
+	bodyMeasurements, err := userHandler.userService.GetBodyMeasurementsByUserId(ctx, convertReqDTO)
+	if err != nil {
+		res := &dto.ErrorResponse{}
+		res.Status = http.StatusBadRequest
+		res.Code = "ERR_BAD_REQUEST"
+		res.Message = "Get body measurements of account failed"
+		res.Details = []string{err.Error()}
+		return nil, res
+	}
+
+	res := &dto.BodyResponse[*model.BodyMeasurements]{}
+	res.Body.Code = "OK"
+	res.Body.Message = "Get body measurements of account successful"
+	res.Body.Data = bodyMeasurements
+	return res, nil
+}
+
 func (userHandler *UserHandler) UpdateMyAccount(ctx context.Context, reqDTO *dto.UpdateAccountRequest) (*dto.SuccessResponse, error) {
 	convertReqDTO := &dto.UpdateUserByIdRequest{}
 	convertReqDTO.Id = ctx.Value("user_id").(string)
@@ -341,6 +372,8 @@ func (userHandler *UserHandler) UpdateMyAccount(ctx context.Context, reqDTO *dto
 	convertReqDTO.Body.Email = reqDTO.Body.Email
 	convertReqDTO.Body.Password = reqDTO.Body.Password
 	convertReqDTO.Body.Address = reqDTO.Body.Address
+	convertReqDTO.Body.BodyMeasurements = reqDTO.Body.BodyMeasurements
+	convertReqDTO.Body.RemoveBodyMeasurements = reqDTO.Body.RemoveBodyMeasurements
 
 	if err := userHandler.userService.UpdateUserById(ctx, convertReqDTO); err != nil {
 		res := &dto.ErrorResponse{}
@@ -12,15 +12,26 @@ import (
 type User struct {
 	bun.BaseModel `bun:"tb_user"`
 
-	Id             string     `bun:"id,pk"`
-	FullName       string     `bun:"full_name,notnull"`
-	Email          string     `bun:"email,notnull"`
-	Username       string     `bun:"username,notnull"`
-	HashedPassword string     `bun:"hashed_password,notnull"`
-	Address        string     `bun:"address,notnull"`
-	RoleName       string     `bun:"role_name,notnull"`
-	CreatedAt      *time.Time `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt      *time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	Id               string            `bun:"id,pk"`
+	FullName         string            `bun:"full_name,notnull"`
+	Email            string            `bun:"email,notnull"`
+	Username         string            `bun:"username,notnull"`
+	HashedPassword   string            `bun:"hashed_password,notnull"`
+	Address          string            `bun:"address,notnull"`
+	RoleName         string            `bun:"role_name,notnull"`
+	BodyMeasurements *BodyMeasurements `bun:"body_measurements,type:jsonb,nullzero"`
+	CreatedAt        *time.Time        `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt        *time.Time        `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+// Body measurements of user in cm used for size recommendation, measurements not given are omitted
+type BodyMeasurements struct {
+	Height     *float64 `json:"height,omitempty"`
+	Chest      *float64 `json:"chest,omitempty"`
+	Waist      *float64 `json:"waist,omitempty"`
+	Hip        *float64 `json:"hip,omitempty"`
+	Inseam     *float64 `json:"inseam,omitempty"`
+	FootLength *float64 `json:"foot_length,omitempty"`
 }
 
 type UserView struct {
@@ -51,6 +62,29 @@ func FromUserViewToUserProto(userView *UserView) *userservicepb.User {
 	}
 }
 
+func FromBodyMeasurementsToBodyMeasurementsProto(bodyMeasurements *BodyMeasurements) *userservicepb.BodyMeasurements {
+	if bodyMeasurements == nil {
+		return nil
+	}
+
+	// Measurement not given is 0 on proto
+	value := func(measurement *float64) float64 {
+		if measurement == nil {
+			return 0
+		}
+		return *measurement
+	}
+
+	return &userservicepb.BodyMeasurements{
+		Height:     value(bodyMeasurements.Height),
+		Chest:      value(bodyMeasurements.Chest),
+		Waist:      value(bodyMeasurements.Waist),
+		Hip:        value(bodyMeasurements.Hip),
+		Inseam:     value(bodyMeasurements.Inseam),
+		FootLength: value(bodyMeasurements.FootLength),
+	}
+}
+
 func FromListUserViewToListUserProto(userViews []*UserView) []*userservicepb.User {
 	userProtos := make([]*userservicepb.User, len(userViews))
 	for i, userView := range userViews {
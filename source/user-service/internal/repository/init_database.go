@@ -60,5 +60,18 @@ func InitTableUser() {
 		if _, err := infrastructure.PostgresDB.NewInsert().Model(&userData).Exec(ctx); err != nil {
 			log.Fatal("Create data for table tb_user on PostgreSQL failed: ", err)
 		}
+	} else {
+		upgradeTableUser(ctx)
+	}
+}
+
+// Upgrade table tb_user created before users had body measurements
+func upgradeTableUser(ctx context.Context) {
+	query := `
+		ALTER TABLE tb_user
+			ADD COLUMN IF NOT EXISTS body_measurements JSONB
+	`
+	if _, err := infrastructure.PostgresDB.ExecContext(ctx, query); err != nil {
+		log.Fatal("Upgrade table tb_user on PostgreSQL failed: ", err)
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"thanhldt060802/infrastructure"
 	"thanhldt060802/internal/model"
+
+	"github.com/uptrace/bun"
 )
 
 type userRepository struct {
@@ -19,8 +21,11 @@ type UserRepository interface {
 	Update(ctx context.Context, updatedUser *model.User) error
 	DeleteById(ctx context.Context, id string) error
 
-	// Elasticsearch integrattion (init data for elasticsearch-service)
-	GetAllViews(ctx context.Context) ([]*model.UserView, error)
+	// Order integration (notify back office users)
+	GetViewsByRoleNames(ctx context.Context, roleNames []string) ([]*model.UserView, error)
+
+	// Elasticsearch integrattion (init data for elasticsearch-service), users are read page by page ordered by id
+	GetViewsAfterId(ctx context.Context, afterId string, limit int) ([]*model.UserView, error)
 }
 
 func NewUserRepository() UserRepository {
@@ -90,10 +95,27 @@ func (userRepository *userRepository) DeleteById(ctx context.Context, id string)
 	return err
 }
 
-func (userRepository *userRepository) GetAllViews(ctx context.Context) ([]*model.UserView, error) {
+func (userRepository *userRepository) GetViewsByRoleNames(ctx context.Context, roleNames []string) ([]*model.UserView, error) {
+	var users []*model.UserView
+
+	query := infrastructure.PostgresDB.NewSelect().Model(&users).
+		Where("_user.role_name IN (?)", bun.In(roleNames)).
+		Order("_user.id ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (userRepository *userRepository) GetViewsAfterId(ctx context.Context, afterId string, limit int) ([]*model.UserView, error) {
 	var users []*model.UserView
 
-	query := infrastructure.PostgresDB.NewSelect().Model(&users)
+	query := infrastructure.PostgresDB.NewSelect().Model(&users).
+		Where("_user.id > ?", afterId).
+		Order("_user.id ASC").
+		Limit(limit)
 
 	if err := query.Scan(ctx); err != nil {
 		return nil, err
@@ -17,12 +17,16 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// Users per message of user stream
+const userStreamBatchSize = 500
+
 type userService struct {
 	userRepository repository.UserRepository
 }
 
 type UserService interface {
 	GetUserById(ctx context.Context, reqDTO *dto.GetUserByIdRequest) (*model.UserView, error)
+	GetBodyMeasurementsByUserId(ctx context.Context, reqDTO *dto.GetBodyMeasurementsByUserIdRequest) (*model.BodyMeasurements, error)
 	CreateUser(ctx context.Context, reqDTO *dto.CreateUserRequest) error
 	UpdateUserById(ctx context.Context, reqDTO *dto.UpdateUserByIdRequest) error
 	DeleteUserById(ctx context.Context, reqDTO *dto.DeleteUserByIdRequest) error
@@ -31,8 +35,11 @@ type UserService interface {
 	LogoutAccount(ctx context.Context, id string) error
 	GetAllLoggedInAccounts(ctx context.Context) ([]string, error)
 
+	// Order integration (notify back office users)
+	GetUsersByRoleNames(ctx context.Context, reqDTO *dto.GetUsersByRoleNamesRequest) ([]*model.UserView, error)
+
 	// Elasticsearch integration (init data for elasticsearch-service)
-	GetAllUsers(ctx context.Context) ([]*model.UserView, error)
+	StreamAllUsers(ctx context.Context, send func(users []*model.UserView) error) error
 
 	// Elasticsearch integration features
 	GetUsers(ctx context.Context, reqDTO *dto.GetUsersRequest) ([]*model.UserView, error)
@@ -53,6 +60,20 @@ func (userService *userService) GetUserById(ctx context.Context, reqDTO *dto.Get
 	return foundUser, nil
 }
 
+// Body measurements are kept out of user view so that user listings and search index do not carry them, they are only
+// read by user themselves and for size recommendation
+func (userService *userService) GetBodyMeasurementsByUserId(ctx context.Context, reqDTO *dto.GetBodyMeasurementsByUserIdRequest) (*model.BodyMeasurements, error) {
+	foundUser, err := userService.userRepository.GetById(ctx, reqDTO.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("id of user is not valid: %s", err.Error())
+	}
+	if foundUser.BodyMeasurements == nil {
+		return &model.BodyMeasurements{}, nil
+	}
+
+	return foundUser.BodyMeasurements, nil
+}
+
 func (userService *userService) CreateUser(ctx context.Context, reqDTO *dto.CreateUserRequest) error {
 	if _, err := userService.userRepository.GetByUsername(ctx, reqDTO.Body.Username); err == nil {
 		return fmt.Errorf("username of user is already exists")
@@ -94,6 +115,10 @@ func (userService *userService) UpdateUserById(ctx context.Context, reqDTO *dto.
 		return fmt.Errorf("id of user is not valid: %s", err.Error())
 	}
 
+	if reqDTO.Body.RemoveBodyMeasurements && reqDTO.Body.BodyMeasurements != nil {
+		return fmt.Errorf("body measurements of user can not be removed and changed at the same time")
+	}
+
 	if reqDTO.Body.FullName != nil {
 		foundUser.FullName = *reqDTO.Body.FullName
 	}
@@ -116,6 +141,18 @@ func (userService *userService) UpdateUserById(ctx context.Context, reqDTO *dto.
 	if reqDTO.Body.RoleName != nil {
 		foundUser.RoleName = *reqDTO.Body.RoleName
 	}
+	if reqDTO.Body.RemoveBodyMeasurements {
+		foundUser.BodyMeasurements = nil
+	} else if reqDTO.Body.BodyMeasurements != nil {
+		foundUser.BodyMeasurements = &model.BodyMeasurements{
+			Height:     reqDTO.Body.BodyMeasurements.Height,
+			Chest:      reqDTO.Body.BodyMeasurements.Chest,
+			Waist:      reqDTO.Body.BodyMeasurements.Waist,
+			Hip:        reqDTO.Body.BodyMeasurements.Hip,
+			Inseam:     reqDTO.Body.BodyMeasurements.Inseam,
+			FootLength: reqDTO.Body.BodyMeasurements.FootLength,
+		}
+	}
 	timeUpdate := time.Now().UTC()
 	foundUser.UpdatedAt = &timeUpdate
 
@@ -260,8 +297,13 @@ func (userService *userService) GetAllLoggedInAccounts(ctx context.Context) ([]s
 	return loggedInAccounts, nil
 }
 
-func (userService *userService) GetAllUsers(ctx context.Context) ([]*model.UserView, error) {
-	users, err := userService.userRepository.GetAllViews(ctx)
+// Users of given roles, empty when no role is given
+func (userService *userService) GetUsersByRoleNames(ctx context.Context, reqDTO *dto.GetUsersByRoleNamesRequest) ([]*model.UserView, error) {
+	if len(reqDTO.RoleNames) == 0 {
+		return []*model.UserView{}, nil
+	}
+
+	users, err := userService.userRepository.GetViewsByRoleNames(ctx, reqDTO.RoleNames)
 	if err != nil {
 		return nil, fmt.Errorf("query users from postgresql failed: %s", err.Error())
 	}
@@ -269,6 +311,29 @@ func (userService *userService) GetAllUsers(ctx context.Context) ([]*model.UserV
 	return users, nil
 }
 
+// Users are sent page by page, so that no message grows with users
+func (userService *userService) StreamAllUsers(ctx context.Context, send func(users []*model.UserView) error) error {
+	afterId := ""
+	for {
+		users, err := userService.userRepository.GetViewsAfterId(ctx, afterId, userStreamBatchSize)
+		if err != nil {
+			return fmt.Errorf("query users from postgresql failed: %s", err.Error())
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		if err := send(users); err != nil {
+			return fmt.Errorf("send users failed: %s", err.Error())
+		}
+
+		if len(users) < userStreamBatchSize {
+			return nil
+		}
+		afterId = users[len(users)-1].Id
+	}
+}
+
 func (userService *userService) GetUsers(ctx context.Context, reqDTO *dto.GetUsersRequest) ([]*model.UserView, error) {
 	if infrastructure.ElasticsearchServiceGRPCClient != nil {
 		convertReqDTO := &elasticsearchservicepb.GetUsersRequest{}